@@ -299,6 +299,8 @@ type AllocationMetric struct {
 	AllocationTime    time.Duration
 	CoalescedFailures int
 	ScoreMetaData     []*NodeScoreMeta
+
+	GangPlacementsReverted int
 }
 
 // NodeScoreMeta is used to serialize node scoring metadata
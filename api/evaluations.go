@@ -97,35 +97,36 @@ const (
 
 // Evaluation is used to serialize an evaluation.
 type Evaluation struct {
-	ID                   string
-	Priority             int
-	Type                 string
-	TriggeredBy          string
-	Namespace            string
-	JobID                string
-	JobModifyIndex       uint64
-	NodeID               string
-	NodeModifyIndex      uint64
-	DeploymentID         string
-	Status               string
-	StatusDescription    string
-	Wait                 time.Duration
-	WaitUntil            time.Time
-	NextEval             string
-	PreviousEval         string
-	BlockedEval          string
-	RelatedEvals         []*EvaluationStub
-	FailedTGAllocs       map[string]*AllocationMetric
-	ClassEligibility     map[string]bool
-	EscapedComputedClass bool
-	QuotaLimitReached    string
-	AnnotatePlan         bool
-	QueuedAllocations    map[string]int
-	SnapshotIndex        uint64
-	CreateIndex          uint64
-	ModifyIndex          uint64
-	CreateTime           int64
-	ModifyTime           int64
+	ID                    string
+	Priority              int
+	Type                  string
+	TriggeredBy           string
+	Namespace             string
+	JobID                 string
+	JobModifyIndex        uint64
+	NodeID                string
+	NodeModifyIndex       uint64
+	DeploymentID          string
+	Status                string
+	StatusDescription     string
+	Wait                  time.Duration
+	WaitUntil             time.Time
+	NextEval              string
+	PreviousEval          string
+	BlockedEval           string
+	RelatedEvals          []*EvaluationStub
+	FailedTGAllocs        map[string]*AllocationMetric
+	ClassEligibility      map[string]bool
+	EscapedComputedClass  bool
+	BlockedOnDependencies bool
+	QuotaLimitReached     string
+	AnnotatePlan          bool
+	QueuedAllocations     map[string]int
+	SnapshotIndex         uint64
+	CreateIndex           uint64
+	ModifyIndex           uint64
+	CreateTime            int64
+	ModifyTime            int64
 }
 
 // EvaluationStub is used to serialize parts of an evaluation returned in the
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"errors"
+	"net/url"
+)
+
+const (
+	// EventSinkWebhook is a sink which POSTs events to an HTTP endpoint.
+	EventSinkWebhook = "webhook"
+
+	// EventSinkFile is a sink which appends events as newline delimited JSON
+	// to a file local to the leader.
+	EventSinkFile = "file"
+)
+
+// EventSinks is used to access the event sinks endpoints.
+type EventSinks struct {
+	client *Client
+}
+
+// EventSinks returns a handle on the event sinks endpoints.
+func (c *Client) EventSinks() *EventSinks {
+	return &EventSinks{client: c}
+}
+
+// List is used to list all event sinks.
+func (e *EventSinks) List(q *QueryOptions) ([]*EventSink, *QueryMeta, error) {
+	var resp []*EventSink
+	qm, err := e.client.query("/v1/event/sinks", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Info is used to fetch details of a specific event sink.
+func (e *EventSinks) Info(id string, q *QueryOptions) (*EventSink, *QueryMeta, error) {
+	if id == "" {
+		return nil, nil, errors.New("missing event sink ID")
+	}
+
+	var resp EventSink
+	qm, err := e.client.query("/v1/event/sink/"+url.PathEscape(id), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Register is used to create or update an event sink.
+func (e *EventSinks) Register(sink *EventSink, w *WriteOptions) (*WriteMeta, error) {
+	if sink == nil {
+		return nil, errors.New("missing event sink")
+	}
+	if sink.ID == "" {
+		return nil, errors.New("missing event sink ID")
+	}
+
+	wm, err := e.client.put("/v1/event/sink/"+url.PathEscape(sink.ID), sink, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// Delete is used to delete an event sink.
+func (e *EventSinks) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	if id == "" {
+		return nil, errors.New("missing event sink ID")
+	}
+
+	wm, err := e.client.delete("/v1/event/sink/"+url.PathEscape(id), nil, nil, w)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// EventSink is used to serialize an event sink, which forwards the events of
+// the event stream matching its topics to a webhook or a file.
+type EventSink struct {
+	ID        string             `hcl:"id,label"`
+	Type      string             `hcl:"type"`
+	Topics    map[Topic][]string `hcl:"topics,optional"`
+	Namespace string             `hcl:"namespace,optional"`
+	Address   string             `hcl:"address,optional"`
+	Path      string             `hcl:"path,optional"`
+
+	// LatestIndex is the index of the last events delivered by the sink.
+	LatestIndex uint64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
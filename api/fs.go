@@ -323,6 +323,29 @@ func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin str
 	return frames, errCh
 }
 
+// LogsByTime streams the content of a tasks logs written between since and
+// until. Either may be the zero time to leave that end of the range open.
+// Reading logs by time requires the task to record log timestamps, and until
+// can't be set when following the logs. The other parameters and the return
+// values are the same as Logs.
+func (a *AllocFS) LogsByTime(alloc *Allocation, follow bool, task, logType string,
+	since, until time.Time, cancel <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
+
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	if !since.IsZero() {
+		q.Params["since"] = since.Format(time.RFC3339Nano)
+	}
+	if !until.IsZero() {
+		q.Params["until"] = until.Format(time.RFC3339Nano)
+	}
+	return a.Logs(alloc, follow, task, logType, OriginStart, 0, cancel, q)
+}
+
 // FrameReader is used to convert a stream of frames into a read closer.
 type FrameReader struct {
 	frames   <-chan *StreamFrame
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"net/url"
+	"sort"
+)
+
+const (
+	// HostVolumeStatePending is the state of a host volume that has been
+	// placed on a node but has not yet been created by the client.
+	HostVolumeStatePending = "pending"
+
+	// HostVolumeStateReady is the state of a host volume that has been
+	// created by the client and is available for mounting.
+	HostVolumeStateReady = "ready"
+)
+
+// HostVolume is a dynamic host volume, created on a client via the API rather
+// than being statically configured in the client agent configuration.
+type HostVolume struct {
+	// Namespace is the Nomad namespace for the volume. Only jobs in the same
+	// namespace can mount the volume.
+	Namespace string `hcl:"namespace"`
+
+	// ID is a UUID-like string generated by the server.
+	ID string `hcl:"id"`
+
+	// Name is the name that group.volume will use to identify the volume
+	// source. It must be unique per node.
+	Name string `hcl:"name"`
+
+	// PluginID is the host volume plugin on the client that will be used for
+	// creating the volume. If omitted, the built-in "mkdir" plugin is used.
+	PluginID string `mapstructure:"plugin_id" hcl:"plugin_id"`
+
+	// NodePool is the node pool of the node where the volume is placed. If
+	// the user doesn't provide a node ID, a node will be selected from this
+	// pool using the constraints.
+	NodePool string `mapstructure:"node_pool" hcl:"node_pool"`
+
+	// NodeID is the node where the volume is placed. If omitted, the server
+	// selects a node using the node pool and constraints.
+	NodeID string `mapstructure:"node_id" hcl:"node_id"`
+
+	// Constraints are optional. If the NodeID is not provided, the NodePool
+	// and Constraints are used to select a node.
+	Constraints []*Constraint `hcl:"constraint"`
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are the
+	// optional inputs to the plugin for the size of the volume.
+	RequestedCapacityMinBytes int64 `mapstructure:"capacity_min" hcl:"capacity_min"`
+	RequestedCapacityMaxBytes int64 `mapstructure:"capacity_max" hcl:"capacity_max"`
+
+	// CapacityBytes is the actual size of the volume as reported by the
+	// plugin.
+	CapacityBytes int64
+
+	// Parameters are an opaque map of parameters for the host volume plugin.
+	Parameters map[string]string `hcl:"parameters"`
+
+	// HostPath is the path on disk where the volume's mount point was
+	// created.
+	HostPath string
+
+	// State represents the overall state of the volume.
+	State string
+
+	CreateIndex uint64
+	CreateTime  int64
+
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// HostVolumeStub is used for responses for the list volumes endpoint
+type HostVolumeStub struct {
+	Namespace     string
+	ID            string
+	Name          string
+	PluginID      string
+	NodePool      string
+	NodeID        string
+	CapacityBytes int64
+	State         string
+
+	CreateIndex uint64
+	CreateTime  int64
+
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// HostVolumeCreateRequest is used to create a host volume.
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+}
+
+// HostVolumeCreateResponse is the response to a HostVolumeCreateRequest.
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+}
+
+// HostVolumeListRequest filters the list of host volumes.
+type HostVolumeListRequest struct {
+	NodeID   string
+	NodePool string
+}
+
+// HostVolumes is used to access the dynamic host volume endpoints.
+type HostVolumes struct {
+	client *Client
+}
+
+// HostVolumes returns a handle on the HostVolumes endpoints.
+func (c *Client) HostVolumes() *HostVolumes {
+	return &HostVolumes{client: c}
+}
+
+// Create places a host volume on a node and has the node's client create it.
+// If the volume ID is set, the request updates that existing volume.
+func (hv *HostVolumes) Create(vol *HostVolume, opts *WriteOptions) (*HostVolume, *WriteMeta, error) {
+	req := &HostVolumeCreateRequest{Volume: vol}
+	var out HostVolumeCreateResponse
+	wm, err := hv.client.put("/v1/volume/host/create", req, &out, opts)
+	if err != nil {
+		return nil, wm, err
+	}
+	return out.Volume, wm, nil
+}
+
+// Get returns a single host volume.
+func (hv *HostVolumes) Get(id string, opts *QueryOptions) (*HostVolume, *QueryMeta, error) {
+	var out HostVolume
+	qm, err := hv.client.query("/v1/volume/host/"+url.PathEscape(id), &out, opts)
+	if err != nil {
+		return nil, qm, err
+	}
+	return &out, qm, nil
+}
+
+// List returns the host volumes, optionally filtered by node ID or node pool.
+func (hv *HostVolumes) List(req *HostVolumeListRequest, opts *QueryOptions) ([]*HostVolumeStub, *QueryMeta, error) {
+	qp := url.Values{}
+	qp.Set("type", "host")
+	if req != nil {
+		if req.NodeID != "" {
+			qp.Set("node_id", req.NodeID)
+		}
+		if req.NodePool != "" {
+			qp.Set("node_pool", req.NodePool)
+		}
+	}
+
+	var out []*HostVolumeStub
+	qm, err := hv.client.query("/v1/volumes?"+qp.Encode(), &out, opts)
+	if err != nil {
+		return nil, qm, err
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreateIndex > out[j].CreateIndex
+	})
+	return out, qm, nil
+}
+
+// Delete deletes a host volume from its node and deregisters it.
+func (hv *HostVolumes) Delete(id string, opts *WriteOptions) (*WriteMeta, error) {
+	wm, err := hv.client.delete("/v1/volume/host/"+url.PathEscape(id), nil, nil, opts)
+	return wm, err
+}
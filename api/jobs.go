@@ -37,6 +37,13 @@ const (
 	// PeriodicSpecCron is used for a cron spec.
 	PeriodicSpecCron = "cron"
 
+	// PeriodicCatchupNone, PeriodicCatchupLatest and PeriodicCatchupAll are
+	// the policies for the launches of a periodic job missed while no leader
+	// was running the periodic dispatcher.
+	PeriodicCatchupNone   = "none"
+	PeriodicCatchupLatest = "latest"
+	PeriodicCatchupAll    = "all"
+
 	// DefaultNamespace is the default namespace.
 	DefaultNamespace = "default"
 
@@ -481,12 +488,21 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 
 func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	payload []byte, idPrefixTemplate string, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
+	return j.DispatchArray(jobID, meta, payload, idPrefixTemplate, nil, q)
+}
+
+// DispatchArray is used to dispatch a parameterized job as a single job
+// array, which runs one allocation of each task group per array index. A nil
+// array dispatches a single work item like Dispatch.
+func (j *Jobs) DispatchArray(jobID string, meta map[string]string,
+	payload []byte, idPrefixTemplate string, array *JobArray, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
 	var resp JobDispatchResponse
 	req := &JobDispatchRequest{
 		JobID:            jobID,
 		Meta:             meta,
 		Payload:          payload,
 		IdPrefixTemplate: idPrefixTemplate,
+		Array:            array,
 	}
 	wm, err := j.client.put("/v1/job/"+url.PathEscape(jobID)+"/dispatch", req, &resp, q)
 	if err != nil {
@@ -829,6 +845,29 @@ type PeriodicConfig struct {
 	SpecType        *string
 	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
 	TimeZone        *string `mapstructure:"time_zone" hcl:"time_zone,optional"`
+
+	// Exclude is the list of exclusions during which launches are skipped.
+	Exclude []*PeriodicExclusion `hcl:"exclude,block"`
+
+	// StartAfter and EndBefore bound the launches of the job. They are either
+	// a date, an RFC 3339 timestamp, or a timestamp without offset which is
+	// interpreted in the time zone of the job.
+	StartAfter *string `mapstructure:"start_after" hcl:"start_after,optional"`
+	EndBefore  *string `mapstructure:"end_before" hcl:"end_before,optional"`
+
+	// Catchup is the policy for the launches missed while no leader was
+	// running the periodic dispatcher: "none", "latest" or "all". With "all",
+	// the launches missed within the CatchupWindow are run.
+	Catchup       *string        `hcl:"catchup,optional"`
+	CatchupWindow *time.Duration `mapstructure:"catchup_window" hcl:"catchup_window,optional"`
+}
+
+// PeriodicExclusion excludes launches of a periodic job, either within a
+// range of dates and times or matching a cron expression.
+type PeriodicExclusion struct {
+	Cron  string `hcl:"cron,optional"`
+	Start string `hcl:"start,optional"`
+	End   string `hcl:"end,optional"`
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -850,13 +889,114 @@ func (p *PeriodicConfig) Canonicalize() {
 	if p.TimeZone == nil || *p.TimeZone == "" {
 		p.TimeZone = pointerOf("UTC")
 	}
+	if p.Catchup == nil || *p.Catchup == "" {
+		p.Catchup = pointerOf(PeriodicCatchupLatest)
+	}
 }
 
+const (
+	// periodicDateFormat is the format of dates in periodic exclusions and
+	// bounds, which cover the whole day.
+	periodicDateFormat = "2006-01-02"
+
+	// periodicDateTimeFormat is the format of times in periodic exclusions and
+	// bounds without an explicit offset, interpreted in the job time zone.
+	periodicDateTimeFormat = "2006-01-02T15:04:05"
+
+	// periodicMaxExcludedLaunches bounds the number of excluded launches
+	// skipped while computing the next launch.
+	periodicMaxExcludedLaunches = 10_000
+)
+
 // Next returns the closest time instant matching the spec that is after the
-// passed time. If no matching instance exists, the zero value of time.Time is
-// returned. The `time.Location` of the returned value matches that of the
-// passed time.
+// passed time, within the bounds and not excluded. If no matching instance
+// exists, the zero value of time.Time is returned. The `time.Location` of the
+// returned value matches that of the passed time.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
+	loc, err := p.GetLocation()
+	if err != nil {
+		loc = time.UTC
+	}
+
+	var startAfter, endBefore time.Time
+	if p.StartAfter != nil {
+		if startAfter, _, err = parsePeriodicTime(*p.StartAfter, loc); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if p.EndBefore != nil {
+		if endBefore, _, err = parsePeriodicTime(*p.EndBefore, loc); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if !startAfter.IsZero() && fromTime.Before(startAfter) {
+		fromTime = startAfter.Add(-time.Nanosecond).In(fromTime.Location())
+	}
+
+	var crons []*cronexpr.Expression
+	var ranges [][2]time.Time
+	for _, e := range p.Exclude {
+		if e.Cron != "" {
+			exp, err := cronexpr.Parse(e.Cron)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("failed parsing cron expression: %s: %v", e.Cron, err)
+			}
+			crons = append(crons, exp)
+			continue
+		}
+
+		start, _, err := parsePeriodicTime(e.Start, loc)
+		if err != nil {
+			return time.Time{}, err
+		}
+		end, isDate, err := parsePeriodicTime(e.End, loc)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if isDate {
+			end = end.AddDate(0, 0, 1)
+		}
+		ranges = append(ranges, [2]time.Time{start, end})
+	}
+
+	for i := 0; i < periodicMaxExcludedLaunches; i++ {
+		next, err := p.next(fromTime)
+		if err != nil || next.IsZero() {
+			return next, err
+		}
+		if !endBefore.IsZero() && !next.Before(endBefore) {
+			return time.Time{}, nil
+		}
+
+		resume := next
+		excluded := false
+		for _, exp := range crons {
+			if exp.Next(next.Add(-time.Second)).Equal(next) {
+				excluded = true
+			}
+		}
+		for _, r := range ranges {
+			if !next.Before(r[0]) && next.Before(r[1]) {
+				excluded = true
+				if last := r[1].Add(-time.Nanosecond); last.After(resume) {
+					resume = last
+				}
+			}
+		}
+		if !excluded {
+			return next, nil
+		}
+		fromTime = resume.In(fromTime.Location())
+	}
+
+	return time.Time{}, fmt.Errorf("failed to find a launch which is not excluded after %d attempts", periodicMaxExcludedLaunches)
+}
+
+// next returns the closest time instant matching the spec that is after the
+// passed time, regardless of the bounds and exclusions.
+func (p *PeriodicConfig) next(fromTime time.Time) (time.Time, error) {
 	// Single spec parsing
 	if p != nil && *p.SpecType == PeriodicSpecCron {
 		if p.Spec != nil && *p.Spec != "" {
@@ -878,6 +1018,27 @@ func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 	return nextTime, nil
 }
 
+// parsePeriodicTime parses a date or timestamp in the location, returning
+// whether the value is a date. The zero time is returned for an empty value.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
+func parsePeriodicTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	if t, err := time.ParseInLocation(periodicDateFormat, value, loc); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.ParseInLocation(periodicDateTimeFormat, value, loc); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%q must be a date (YYYY-MM-DD) or a timestamp (YYYY-MM-DDTHH:MM:SS, with an optional offset)", value)
+	}
+	return t, false, nil
+}
+
 // cronParseNext is a helper that parses the next time for the given expression
 // but captures any panic that may occur in the underlying library.
 // ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
@@ -956,6 +1117,26 @@ func (js *JobSubmission) Copy() *JobSubmission {
 	}
 }
 
+const (
+	JobDependencyStatusComplete = "complete"
+	JobDependencyStatusFailed   = "failed"
+	JobDependencyStatusDead     = "dead"
+)
+
+// JobDependency is used to serialize a job which must reach a terminal status
+// before the job depending on it is scheduled.
+type JobDependency struct {
+	JobID     string `mapstructure:"job_id" hcl:",label"`
+	Namespace string `hcl:"namespace,optional"`
+	Status    string `hcl:"status,optional"`
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Status == "" {
+		d.Status = JobDependencyStatusComplete
+	}
+}
+
 // Job is used to serialize a job.
 type Job struct {
 	/* Fields parsed from HCL config */
@@ -967,6 +1148,7 @@ type Job struct {
 	Type             *string                 `hcl:"type,optional"`
 	Priority         *int                    `hcl:"priority,optional"`
 	AllAtOnce        *bool                   `mapstructure:"all_at_once" hcl:"all_at_once,optional"`
+	Rebalance        *bool                   `hcl:"rebalance,optional"`
 	Datacenters      []string                `hcl:"datacenters,optional"`
 	NodePool         *string                 `mapstructure:"node_pool" hcl:"node_pool,optional"`
 	Constraints      []*Constraint           `hcl:"constraint,block"`
@@ -975,6 +1157,7 @@ type Job struct {
 	Update           *UpdateStrategy         `hcl:"update,block"`
 	Multiregion      *Multiregion            `hcl:"multiregion,block"`
 	Spreads          []*Spread               `hcl:"spread,block"`
+	DependsOn        []*JobDependency        `mapstructure:"depends_on" hcl:"depends_on,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
@@ -989,6 +1172,7 @@ type Job struct {
 	ParentID                 *string
 	Dispatched               bool
 	DispatchIdempotencyToken *string
+	Array                    *JobArray
 	Payload                  []byte
 	ConsulNamespace          *string `mapstructure:"consul_namespace"`
 	VaultNamespace           *string `mapstructure:"vault_namespace"`
@@ -1049,6 +1233,9 @@ func (j *Job) Canonicalize() {
 	if j.AllAtOnce == nil {
 		j.AllAtOnce = pointerOf(false)
 	}
+	if j.Rebalance == nil {
+		j.Rebalance = pointerOf(false)
+	}
 	if j.ConsulToken == nil {
 		j.ConsulToken = pointerOf("")
 	}
@@ -1107,6 +1294,9 @@ func (j *Job) Canonicalize() {
 	for _, a := range j.Affinities {
 		a.Canonicalize()
 	}
+	for _, d := range j.DependsOn {
+		d.Canonicalize()
+	}
 }
 
 // LookupTaskGroup finds a task group by name
@@ -1465,6 +1655,24 @@ type JobDispatchRequest struct {
 	Payload          []byte
 	Meta             map[string]string
 	IdPrefixTemplate string
+	Array            *JobArray
+}
+
+// JobArray is the range of work items of a job dispatched as a job array.
+// Each task group of the job has one allocation per array index.
+type JobArray struct {
+	// Start and End are the first and last array indexes, inclusive.
+	Start int
+	End   int
+
+	// MaxParallel is the maximum number of allocations of each task group
+	// which may be running at the same time. Zero means no limit.
+	MaxParallel int
+}
+
+// Size returns the number of indexes of the array.
+func (a *JobArray) Size() int {
+	return a.End - a.Start + 1
 }
 
 type JobDispatchResponse struct {
@@ -286,6 +286,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -315,6 +316,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:                    pointerOf(""),
 						Count:                   pointerOf(1),
 						PreventRescheduleOnLost: pointerOf(false),
+						Gang:                    pointerOf(false),
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  pointerOf(false),
 							Migrate: pointerOf(false),
@@ -385,6 +387,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -403,6 +406,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:                    pointerOf(""),
 						Count:                   pointerOf(1),
 						PreventRescheduleOnLost: pointerOf(false),
+						Gang:                    pointerOf(false),
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  pointerOf(false),
 							Migrate: pointerOf(false),
@@ -467,6 +471,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -495,6 +500,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					{
 						Name:                    pointerOf("bar"),
 						PreventRescheduleOnLost: pointerOf(false),
+						Gang:                    pointerOf(false),
 						Count:                   pointerOf(1),
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  pointerOf(false),
@@ -643,6 +649,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Region:            pointerOf("global"),
 				Type:              pointerOf("service"),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -673,6 +680,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:                    pointerOf("cache"),
 						Count:                   pointerOf(1),
 						PreventRescheduleOnLost: pointerOf(true),
+						Gang:                    pointerOf(false),
 						RestartPolicy: &RestartPolicy{
 							Interval:        pointerOf(5 * time.Minute),
 							Attempts:        pointerOf(10),
@@ -816,6 +824,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -847,6 +856,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					SpecType:        pointerOf(PeriodicSpecCron),
 					ProhibitOverlap: pointerOf(false),
 					TimeZone:        pointerOf("UTC"),
+					Catchup:         pointerOf(PeriodicCatchupLatest),
 				},
 			},
 		},
@@ -910,6 +920,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -939,6 +950,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:                    pointerOf("bar"),
 						Count:                   pointerOf(1),
 						PreventRescheduleOnLost: pointerOf(true),
+						Gang:                    pointerOf(false),
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  pointerOf(false),
 							Migrate: pointerOf(false),
@@ -988,6 +1000,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					{
 						Name:                    pointerOf("baz"),
 						PreventRescheduleOnLost: pointerOf(false),
+						Gang:                    pointerOf(false),
 						Count:                   pointerOf(1),
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  pointerOf(false),
@@ -1094,6 +1107,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				NodePool:          pointerOf(""),
 				Priority:          pointerOf(JobDefaultPriority),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -1122,6 +1136,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					{
 						Name:                    pointerOf("bar"),
 						PreventRescheduleOnLost: pointerOf(true),
+						Gang:                    pointerOf(false),
 						Count:                   pointerOf(1),
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  pointerOf(false),
@@ -1178,6 +1193,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					{
 						Name:                    pointerOf("baz"),
 						PreventRescheduleOnLost: pointerOf(false),
+						Gang:                    pointerOf(false),
 						Count:                   pointerOf(1),
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  pointerOf(false),
@@ -1273,6 +1289,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Rebalance:         pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -2071,6 +2088,38 @@ func TestJobs_PeriodicForce(t *testing.T) {
 	must.Eq(t, eval.ID, evalID)
 }
 
+func TestPeriodicConfig_Next_Exclude(t *testing.T) {
+	testutil.Parallel(t)
+
+	p := &PeriodicConfig{
+		Spec:     pointerOf("0 9 * * *"),
+		TimeZone: pointerOf("America/New_York"),
+		Exclude: []*PeriodicExclusion{
+			{Cron: "* * * * 0,6"},
+			{Start: "2024-12-24", End: "2024-12-25"},
+		},
+		StartAfter: pointerOf("2024-12-20"),
+		EndBefore:  pointerOf("2024-12-28"),
+	}
+	p.Canonicalize()
+
+	loc, err := p.GetLocation()
+	must.NoError(t, err)
+	from := time.Date(2024, time.December, 1, 0, 0, 0, 0, loc)
+
+	var launches []string
+	for {
+		next, err := p.Next(from)
+		must.NoError(t, err)
+		if next.IsZero() {
+			break
+		}
+		launches = append(launches, next.Format("2006-01-02"))
+		from = next
+	}
+	must.Eq(t, []string{"2024-12-20", "2024-12-23", "2024-12-26", "2024-12-27"}, launches)
+}
+
 func TestJobs_Plan(t *testing.T) {
 	testutil.Parallel(t)
 
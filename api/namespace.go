@@ -78,6 +78,7 @@ type Namespace struct {
 	VaultConfiguration    *NamespaceVaultConfiguration    `hcl:"vault,block"`
 	ConsulConfiguration   *NamespaceConsulConfiguration   `hcl:"consul,block"`
 	Meta                  map[string]string
+	Weight                int
 	CreateIndex           uint64
 	ModifyIndex           uint64
 }
@@ -180,6 +180,10 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// NodeWeights are the weighted node attributes used to score nodes when
+	// the weighted scheduler algorithm is selected.
+	NodeWeights []*SchedulerNodeWeight
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -193,6 +197,26 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// RebalanceMove is a running allocation which the rebalancer migrates because
+// its placement has become a poor fit.
+type RebalanceMove struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+	Reason    string
+	Score     float64
+}
+
+// SchedulerRebalanceReportResponse is the response object used to report the
+// allocations the rebalancer would migrate in its next cycle.
+type SchedulerRebalanceReportResponse struct {
+	Moves []*RebalanceMove
+
+	QueryMeta
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -209,10 +233,30 @@ type SchedulerSetConfigurationResponse struct {
 type SchedulerAlgorithm string
 
 const (
-	SchedulerAlgorithmBinpack SchedulerAlgorithm = "binpack"
-	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmBinpack  SchedulerAlgorithm = "binpack"
+	SchedulerAlgorithmSpread   SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmWeighted SchedulerAlgorithm = "weighted"
 )
 
+// SchedulerNodeWeight is a node attribute or metadata value that the weighted
+// scheduler algorithm uses to score nodes.
+type SchedulerNodeWeight struct {
+	// Attribute is the node attribute or metadata to match, such as
+	// "${meta.cost_tier}".
+	Attribute string
+
+	// Value is the value the attribute is compared to.
+	Value string
+
+	// Operand is the comparison operator, using the same operators as
+	// affinities. Defaults to "=".
+	Operand string
+
+	// Weight is the score in the range [-100, 100] given to the nodes which
+	// match.
+	Weight int8
+}
+
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
 type PreemptionConfig struct {
 	SystemSchedulerEnabled   bool
@@ -254,6 +298,17 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 	return &out, wm, nil
 }
 
+// SchedulerRebalanceReport is used to query the allocations the rebalancer
+// would migrate in its next cycle, without migrating them.
+func (op *Operator) SchedulerRebalanceReport(q *QueryOptions) (*SchedulerRebalanceReportResponse, *QueryMeta, error) {
+	var resp SchedulerRebalanceReportResponse
+	qm, err := op.c.query("/v1/operator/scheduler/rebalance", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Snapshot is used to capture a snapshot state of a running cluster.
 // The returned reader that must be consumed fully
 func (op *Operator) Snapshot(q *QueryOptions) (io.ReadCloser, error) {
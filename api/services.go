@@ -59,6 +59,10 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// ChecksFailing indicates one or more of the Nomad checks defined on the
+	// service are failing.
+	ChecksFailing bool
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -74,9 +74,17 @@ type TaskResourceUsage struct {
 type AllocResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Tasks         map[string]*TaskResourceUsage
+	DiskStats     *AllocDiskStats
 	Timestamp     int64
 }
 
+// AllocDiskStats holds the disk usage of the ephemeral disk of an allocation.
+type AllocDiskStats struct {
+	Used      uint64
+	Size      uint64
+	Timestamp int64
+}
+
 // AllocCheckStatus contains the current status of a nomad service discovery check.
 type AllocCheckStatus struct {
 	ID         string
@@ -332,6 +340,8 @@ type Spread struct {
 	Attribute    string          `hcl:"attribute,optional"`
 	Weight       *int8           `hcl:"weight,optional"`
 	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	MaxSkew      int             `mapstructure:"max_skew" hcl:"max_skew,optional"`
+	Hard         bool            `hcl:"hard,optional"`
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -449,6 +459,31 @@ func (m *MigrateStrategy) Copy() *MigrateStrategy {
 	return nm
 }
 
+// DisruptionBudget limits how many allocations of a task group can be
+// unavailable at once before allocations of the group are stopped for
+// voluntary reasons, such as node drains, preemption and destructive updates.
+type DisruptionBudget struct {
+	MaxUnavailable *int `mapstructure:"max_unavailable" hcl:"max_unavailable,optional"`
+}
+
+func (d *DisruptionBudget) Canonicalize() {
+	if d == nil {
+		return
+	}
+	if d.MaxUnavailable == nil {
+		d.MaxUnavailable = pointerOf(1)
+	}
+}
+
+func (d *DisruptionBudget) Copy() *DisruptionBudget {
+	if d == nil {
+		return nil
+	}
+	nd := new(DisruptionBudget)
+	*nd = *d
+	return nd
+}
+
 // VolumeRequest is a representation of a storage volume that a TaskGroup wishes to use.
 type VolumeRequest struct {
 	Name           string           `hcl:"name,label"`
@@ -507,10 +542,12 @@ type TaskGroup struct {
 	EphemeralDisk    *EphemeralDisk            `hcl:"ephemeral_disk,block"`
 	Update           *UpdateStrategy           `hcl:"update,block"`
 	Migrate          *MigrateStrategy          `hcl:"migrate,block"`
+	DisruptionBudget *DisruptionBudget         `hcl:"disruption_budget,block"`
 	Networks         []*NetworkResource        `hcl:"network,block"`
 	Meta             map[string]string         `hcl:"meta,block"`
 	Services         []*Service                `hcl:"service,block"`
 	ShutdownDelay    *time.Duration            `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
+	MaxRuntime       *time.Duration            `mapstructure:"max_runtime" hcl:"max_runtime,optional"`
 	// Deprecated: StopAfterClientDisconnect is deprecated in Nomad 1.8. Use Disconnect.StopOnClientAfter instead.
 	StopAfterClientDisconnect *time.Duration `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	// To be deprecated after 1.8.0 infavour of Disconnect.LostAfter
@@ -519,6 +556,7 @@ type TaskGroup struct {
 	Consul              *Consul        `hcl:"consul,block"`
 	// To be deprecated after 1.8.0 infavour of Disconnect.Replace
 	PreventRescheduleOnLost *bool `hcl:"prevent_reschedule_on_lost,optional"`
+	Gang                    *bool `hcl:"gang,optional"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -643,9 +681,17 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.PreventRescheduleOnLost = pointerOf(false)
 	}
 
+	if g.Gang == nil {
+		g.Gang = pointerOf(false)
+	}
+
 	if g.Disconnect != nil {
 		g.Disconnect.Canonicalize()
 	}
+
+	if g.DisruptionBudget != nil {
+		g.DisruptionBudget.Canonicalize()
+	}
 }
 
 // These needs to be in sync with DefaultServiceJobRestartPolicy in
@@ -721,6 +767,12 @@ type LogConfig struct {
 	Enabled *bool `mapstructure:"enabled" hcl:"enabled,optional"`
 
 	Disabled *bool `mapstructure:"disabled" hcl:"disabled,optional"`
+
+	Compression    *string        `mapstructure:"compression" hcl:"compression,optional"`
+	RotateInterval *time.Duration `mapstructure:"rotate_interval" hcl:"rotate_interval,optional"`
+	Timestamps     *bool          `mapstructure:"timestamps" hcl:"timestamps,optional"`
+
+	Sinks []*LogSink `mapstructure:"sink" hcl:"sink,block"`
 }
 
 func DefaultLogConfig() *LogConfig {
@@ -741,6 +793,42 @@ func (l *LogConfig) Canonicalize() {
 	if l.Disabled == nil {
 		l.Disabled = pointerOf(false)
 	}
+	for _, sink := range l.Sinks {
+		sink.Canonicalize()
+	}
+}
+
+const (
+	LogSinkTypeSyslog = "syslog"
+	LogSinkTypeSocket = "socket"
+	LogSinkTypeHTTP   = "http"
+)
+
+// LogSink is an external destination task logs are shipped to, in addition
+// to being written to the rotated log files.
+type LogSink struct {
+	Type          string            `mapstructure:"type" hcl:"type"`
+	Address       string            `mapstructure:"address" hcl:"address"`
+	Tag           string            `mapstructure:"tag" hcl:"tag,optional"`
+	Headers       map[string]string `mapstructure:"headers" hcl:"headers,block"`
+	BufferSize    *int              `mapstructure:"buffer_size" hcl:"buffer_size,optional"`
+	BatchSize     *int              `mapstructure:"batch_size" hcl:"batch_size,optional"`
+	BatchInterval *time.Duration    `mapstructure:"batch_interval" hcl:"batch_interval,optional"`
+}
+
+func (s *LogSink) Canonicalize() {
+	if s.BufferSize == nil {
+		s.BufferSize = pointerOf(1024)
+	}
+	if s.Type != LogSinkTypeHTTP {
+		return
+	}
+	if s.BatchSize == nil {
+		s.BatchSize = pointerOf(100)
+	}
+	if s.BatchInterval == nil {
+		s.BatchInterval = pointerOf(time.Second)
+	}
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
@@ -789,6 +877,7 @@ type Task struct {
 	CSIPluginConfig *TaskCSIPluginConfig   `mapstructure:"csi_plugin" json:",omitempty" hcl:"csi_plugin,block"`
 	Leader          bool                   `hcl:"leader,optional"`
 	ShutdownDelay   time.Duration          `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
+	MaxRuntime      time.Duration          `mapstructure:"max_runtime" hcl:"max_runtime,optional"`
 	KillSignal      string                 `mapstructure:"kill_signal" hcl:"kill_signal,optional"`
 	Kind            string                 `hcl:"kind,optional"`
 	ScalingPolicies []*ScalingPolicy       `hcl:"scaling,block"`
@@ -479,6 +479,43 @@ func TestTask_Canonicalize_Vault(t *testing.T) {
 	}
 }
 
+func TestTask_Canonicalize_LogSinks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    *LogSink
+		expected *LogSink
+	}{
+		{
+			name:  "syslog",
+			input: &LogSink{Type: LogSinkTypeSyslog, Address: "udp://127.0.0.1:514"},
+			expected: &LogSink{
+				Type:       LogSinkTypeSyslog,
+				Address:    "udp://127.0.0.1:514",
+				BufferSize: pointerOf(1024),
+			},
+		},
+		{
+			name:  "http",
+			input: &LogSink{Type: LogSinkTypeHTTP, Address: "http://127.0.0.1", BatchSize: pointerOf(10)},
+			expected: &LogSink{
+				Type:          LogSinkTypeHTTP,
+				Address:       "http://127.0.0.1",
+				BufferSize:    pointerOf(1024),
+				BatchSize:     pointerOf(10),
+				BatchInterval: pointerOf(time.Second),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logs := &LogConfig{Sinks: []*LogSink{tc.input}}
+			logs.Canonicalize()
+			must.Eq(t, tc.expected, logs.Sinks[0])
+		})
+	}
+}
+
 // Ensures no regression on https://github.com/hashicorp/nomad/issues/3132
 func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 	testutil.Parallel(t)
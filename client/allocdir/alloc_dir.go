@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -42,6 +43,13 @@ const (
 	fileMode666 = os.FileMode(0o666)
 )
 
+// fileID uniquely identifies a file on the host by its device and inode
+// numbers.
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
 var (
 	// SnapshotErrorTime is the sentinel time that will be used on the
 	// error file written by Snapshot when it encounters as error.
@@ -99,6 +107,7 @@ type Interface interface {
 	Build() error
 	Destroy() error
 	Move(Interface, []*structs.Task) error
+	DiskUsage() (uint64, error)
 }
 
 // AllocDir allows creating, destroying, and accessing an allocation's
@@ -312,6 +321,69 @@ func (d *AllocDir) Move(other Interface, tasks []*structs.Task) error {
 	return nil
 }
 
+// DiskUsage returns the number of bytes used on disk by the ephemeral disk of
+// the allocation: the shared alloc directory and the local and tmp
+// directories of each task. Files linked several times are only counted once
+// and file systems mounted below these directories are not walked.
+func (d *AllocDir) DiskUsage() (uint64, error) {
+	d.mu.RLock()
+	rootPaths := []string{d.SharedDir}
+	for _, taskdir := range d.TaskDirs {
+		rootPaths = append(rootPaths, taskdir.LocalDir, filepath.Join(taskdir.Dir, TmpDirName))
+	}
+	d.mu.RUnlock()
+
+	var used uint64
+	seen := make(map[fileID]struct{})
+	for _, root := range rootPaths {
+		rootInfo, err := os.Lstat(root)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, err
+		}
+		rootID, _, _ := getFileUsage(rootInfo)
+
+		walkFn := func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				// Files may be removed by the tasks while walking.
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			id, size, ok := getFileUsage(info)
+			if ok {
+				if entry.IsDir() && id.dev != rootID.dev {
+					return filepath.SkipDir
+				}
+				if _, ok := seen[id]; ok {
+					return nil
+				}
+				seen[id] = struct{}{}
+			}
+			used += size
+			return nil
+		}
+
+		if err := filepath.WalkDir(root, walkFn); err != nil {
+			return 0, fmt.Errorf("failed to measure disk usage of %s: %w", root, err)
+		}
+	}
+
+	return used, nil
+}
+
 // Destroy tears down previously build directory structure.
 func (d *AllocDir) Destroy() error {
 	// Unmount all mounted shared alloc dirs.
@@ -7,6 +7,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
+	"crypto/rand"
 	"io"
 	"io/fs"
 	"os"
@@ -203,6 +204,51 @@ func TestAllocDir_Snapshot(t *testing.T) {
 	must.SliceLen(t, 2, links)
 }
 
+func TestAllocDir_DiskUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	tmp := t.TempDir()
+
+	d := NewAllocDir(testlog.HCLogger(t), tmp, tmp, "test")
+	defer d.Destroy()
+	must.NoError(t, d.Build())
+
+	td1 := d.NewTaskDir(t1.Name)
+	must.NoError(t, td1.Build(fsisolation.None, nil, "nobody"))
+
+	base, err := d.DiskUsage()
+	must.NoError(t, err)
+
+	// Write a file to the shared dir
+	data := make([]byte, 1024*1024)
+	_, err = rand.Read(data)
+	must.NoError(t, err)
+	file := filepath.Join(d.SharedDir, "data", "bar")
+	must.NoError(t, os.WriteFile(file, data, 0o666))
+
+	used, err := d.DiskUsage()
+	must.NoError(t, err)
+	must.GreaterEq(t, base+uint64(len(data)), used)
+
+	// Hard links are only counted once
+	must.NoError(t, os.Link(file, filepath.Join(td1.LocalDir, "bar")))
+	linked, err := d.DiskUsage()
+	must.NoError(t, err)
+	must.Eq(t, used, linked)
+
+	// Files outside of the ephemeral disk are not counted
+	must.NoError(t, os.WriteFile(filepath.Join(td1.SecretsDir, "secret"), data, 0o666))
+	secret, err := d.DiskUsage()
+	must.NoError(t, err)
+	must.Eq(t, used, secret)
+
+	// Files in the task tmp dir are counted
+	must.NoError(t, os.WriteFile(filepath.Join(td1.Dir, TmpDirName, "foo"), data, 0o666))
+	tmpUsed, err := d.DiskUsage()
+	must.NoError(t, err)
+	must.GreaterEq(t, used+uint64(len(data)), tmpUsed)
+}
+
 func TestAllocDir_Move(t *testing.T) {
 	ci.Parallel(t)
 
@@ -109,3 +109,14 @@ func getOwner(fi os.FileInfo) (int, int) {
 	}
 	return int(stat.Uid), int(stat.Gid)
 }
+
+// getFileUsage returns the identity of the file and the number of bytes
+// allocated to it on disk.
+func getFileUsage(fi os.FileInfo) (fileID, uint64, bool) {
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileID{}, uint64(fi.Size()), false
+	}
+	id := fileID{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}
+	return id, uint64(stat.Blocks) * 512, true
+}
@@ -62,3 +62,9 @@ func MountSpecialDirs(taskDir string) error {
 func getOwner(os.FileInfo) (int, int) {
 	return idUnsupported, idUnsupported
 }
+
+// getFileUsage returns the apparent size of the file as Windows doesn't
+// expose device and inode numbers through os.FileInfo.
+func getFileUsage(fi os.FileInfo) (fileID, uint64, bool) {
+	return fileID{}, uint64(fi.Size()), false
+}
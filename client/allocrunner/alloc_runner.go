@@ -140,6 +140,10 @@ type allocRunner struct {
 	// transitions.
 	runnerHooks []interfaces.RunnerHook
 
+	// diskUsageHook measures the disk usage of the allocation and is
+	// queried for alloc stats
+	diskUsageHook *diskUsageHook
+
 	// hookResources holds the output from allocrunner hooks so that later
 	// allocrunner hooks or task runner hooks can read them
 	hookResources *cstructs.AllocHookResources
@@ -325,6 +329,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			StateUpdater:        ar,
 			DynamicRegistry:     ar.dynamicRegistry,
 			ConsulServices:      ar.consulServicesHandler,
+			CheckStore:          ar.checkStore,
 			ConsulProxiesFunc:   ar.consulProxiesClientFunc,
 			ConsulSI:            ar.sidsClient,
 			VaultFunc:           ar.vaultClientFunc,
@@ -710,6 +715,28 @@ func hasSidecarTasks(tasks map[string]*taskrunner.TaskRunner) bool {
 	return false
 }
 
+// failTasks kills all running task runners with the given event, which is
+// expected to fail them. The remaining tasks are then killed as siblings of a
+// failed task, failing the allocation.
+func (ar *allocRunner) failTasks(event *structs.TaskEvent) {
+	var wg sync.WaitGroup
+	for name, tr := range ar.tasks {
+		if !tr.IsRunning() {
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, tr *taskrunner.TaskRunner) {
+			defer wg.Done()
+			err := tr.Kill(context.TODO(), event.Copy())
+			if err != nil && err != taskrunner.ErrTaskNotRunning {
+				ar.logger.Warn("error failing task", "error", err, "task_name", name)
+			}
+		}(name, tr)
+	}
+	wg.Wait()
+}
+
 // killTasks kills all task runners, leader (if there is one) first. Errors are
 // logged except taskrunner.ErrTaskNotRunning which is ignored. Task states
 // after Kill has been called are returned.
@@ -1294,6 +1321,10 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 		}
 	}
 
+	if ar.diskUsageHook != nil {
+		astat.DiskStats = ar.diskUsageHook.Stats()
+	}
+
 	return astat, nil
 }
 
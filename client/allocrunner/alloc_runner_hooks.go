@@ -122,6 +122,9 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 	// directory path exists for other hooks.
 	alloc := ar.Alloc()
 
+	ar.diskUsageHook = newDiskUsageHook(hookLogger, alloc, ar.allocDir, ar,
+		ar.clientConfig.EnforceEphemeralDisk, ar.clientConfig.DiskUsageInterval)
+
 	ar.runnerHooks = []interfaces.RunnerHook{
 		newIdentityHook(hookLogger, ar.widmgr),
 		newAllocDirHook(hookLogger, ar.allocDir),
@@ -157,6 +160,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 			config.GetConsulConfigs(ar.logger)),
 		newCSIHook(alloc, hookLogger, ar.csiManager, ar.rpcClient, ar, ar.hookResources, ar.clientConfig.Node.SecretID),
 		newChecksHook(hookLogger, alloc, ar.checkStore, ar, builtTaskEnv),
+		ar.diskUsageHook,
 	}
 	if config.ExtraAllocHooks != nil {
 		ar.runnerHooks = append(ar.runnerHooks, config.ExtraAllocHooks...)
@@ -4,6 +4,7 @@
 package allocrunner
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"os"
@@ -1934,6 +1935,81 @@ func TestAllocRunner_TaskFailed_KillTG(t *testing.T) {
 	})
 }
 
+// Test that an alloc exceeding its ephemeral disk size is failed
+func TestAllocRunner_DiskExceeded_Fail(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].EphemeralDisk.SizeMB = 1
+	alloc.Job.TaskGroups[0].RestartPolicy.Attempts = 0
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.RestartPolicy.Attempts = 0
+	task.Driver = "mock_driver"
+	task.KillTimeout = 10 * time.Millisecond
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	conf.ClientConfig.EnforceEphemeralDisk = true
+	conf.ClientConfig.DiskUsageInterval = 10 * time.Millisecond
+	ar, err := NewAllocRunner(conf)
+	must.NoError(t, err)
+	defer destroy(ar)
+
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+		if last.ClientStatus != structs.AllocClientStatusRunning {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusRunning)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+
+	stats, err := ar.StatsReporter().LatestAllocStats("")
+	must.NoError(t, err)
+	must.NotNil(t, stats.DiskStats)
+	must.Eq(t, 1024*1024, stats.DiskStats.Size)
+
+	// Fill the ephemeral disk
+	data := make([]byte, 2*1024*1024)
+	_, err = rand.Read(data)
+	must.NoError(t, err)
+	dataDir := filepath.Join(ar.GetAllocDir().ShareDirPath(), "data")
+	must.NoError(t, os.WriteFile(filepath.Join(dataDir, "foo"), data, 0o666))
+
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last.ClientStatus != structs.AllocClientStatusFailed {
+			return false, fmt.Errorf("got status %v; want %v", last.ClientStatus, structs.AllocClientStatusFailed)
+		}
+
+		state := last.TaskStates[task.Name]
+		if state.State != structs.TaskStateDead {
+			return false, fmt.Errorf("got state %v; want %v", state.State, structs.TaskStateDead)
+		}
+		if !state.Failed {
+			return false, fmt.Errorf("task should have failed")
+		}
+		for _, e := range state.Events {
+			if e.Type == structs.TaskDiskExceeded {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("Did not find event %v", structs.TaskDiskExceeded)
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
 // Test that alloc becoming terminal should destroy the alloc runner
 func TestAllocRunner_TerminalUpdate_Destroy(t *testing.T) {
 	ci.Parallel(t)
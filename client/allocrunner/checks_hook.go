@@ -90,6 +90,7 @@ type checksHook struct {
 	ctx       context.Context
 	stop      func()
 	observers observers
+	scripts   map[structs.CheckID]struct{}
 	alloc     *structs.Allocation
 }
 
@@ -132,6 +133,9 @@ func (h *checksHook) initialize(alloc *structs.Allocation) {
 	// fresh set of observers
 	h.observers = make(observers)
 
+	// fresh set of script checks, which are not observed by this hook
+	h.scripts = make(map[structs.CheckID]struct{})
+
 	// set the initial alloc
 	h.alloc = alloc
 }
@@ -162,6 +166,22 @@ func (h *checksHook) observe(alloc *structs.Allocation, services []*structs.Serv
 				continue
 			}
 
+			// script checks are executed within the task by the script check
+			// hook of the task runner, which stores their results; we only
+			// insert the pending result so they gate allocation health
+			if check.Type == structs.ServiceCheckScript {
+				if _, exists := h.scripts[id]; exists {
+					continue
+				}
+				result := checks.Stub(id, structs.GetCheckMode(check), now, alloc.Name, service.TaskName, service.Name, check.Name)
+				if err := h.shim.Set(h.allocID, result); err != nil {
+					h.logger.Error("failed to set initial check status", "id", h.allocID, "error", err)
+					continue
+				}
+				h.scripts[id] = struct{}{}
+				continue
+			}
+
 			ctx, cancel := context.WithCancel(h.ctx)
 
 			// create the observer for this check
@@ -247,8 +267,11 @@ func (h *checksHook) Update(request *interfaces.RunnerUpdateRequest) error {
 	// stop the observers of the checks we are removing
 	remove := h.shim.Difference(request.Alloc.ID, next)
 	for _, id := range remove {
-		h.observers[id].stop()
-		delete(h.observers, id)
+		if o, exists := h.observers[id]; exists {
+			o.stop()
+			delete(h.observers, id)
+		}
+		delete(h.scripts, id)
 	}
 
 	// remove checks that are no longer part of the allocation
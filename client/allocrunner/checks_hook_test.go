@@ -318,3 +318,51 @@ func TestCheckHook_Checks_UpdateSet(t *testing.T) {
 	results := shim.List(alloc.ID)
 	must.MapEmpty(t, results)
 }
+
+func TestCheckHook_Checks_Script(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	checkStore := makeCheckStore(logger)
+
+	alloc := mock.Alloc()
+	group := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	group.Tasks[0].Services = nil
+	group.Services = []*structs.Service{{
+		Name:     "service-one",
+		TaskName: "web",
+		Provider: "nomad",
+		Checks: []*structs.ServiceCheck{{
+			Name:     "check-script",
+			Type:     "script",
+			Command:  "/bin/true",
+			Interval: 250 * time.Millisecond,
+			Timeout:  1 * time.Second,
+		}},
+	}}
+
+	network := mock.NewNetworkStatus("127.0.0.1")
+	envBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
+
+	h := newChecksHook(logger, alloc, checkStore, network, envBuilder.Build())
+	must.NoError(t, h.Prerun())
+
+	// script checks are not observed by the hook, but have a pending result
+	// until the task runs them
+	must.MapEmpty(t, h.observers)
+	results := checkStore.List(alloc.ID)
+	must.MapLen(t, 1, results)
+	for _, result := range results {
+		must.Eq(t, structs.CheckPending, result.Status)
+		must.Eq(t, "check-script", result.Check)
+	}
+
+	// removing the check on update must not panic without an observer
+	updated := alloc.Copy()
+	updated.Job.LookupTaskGroup(updated.TaskGroup).Services = nil
+	must.NoError(t, h.Update(&interfaces.RunnerUpdateRequest{Alloc: updated}))
+	must.MapEmpty(t, checkStore.List(alloc.ID))
+	must.MapEmpty(t, h.scripts)
+
+	h.PreKill()
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// diskUsageHookName is the name of this hook as appears in logs
+	diskUsageHookName = "disk_usage"
+
+	// bytesPerMB is the number of bytes in the unit of the ephemeral disk
+	// size
+	bytesPerMB = 1024 * 1024
+)
+
+// taskFailer is used by the disk usage hook to fail the tasks of an
+// allocation which exceeds its ephemeral disk size. Implemented by
+// allocRunner.
+type taskFailer interface {
+	failTasks(event *structs.TaskEvent)
+}
+
+// diskUsageHook periodically measures the disk usage of the ephemeral disk of
+// an allocation and warns once it exceeds the ephemeral disk size of the
+// group. If the client enforces the ephemeral disk size, the tasks of the
+// allocation are failed instead.
+type diskUsageHook struct {
+	logger   hclog.Logger
+	allocDir allocdir.Interface
+	failer   taskFailer
+	enforce  bool
+	interval time.Duration
+
+	// lock guards the fields below
+	lock     sync.Mutex
+	sizeMB   int
+	stats    *cstructs.AllocDiskStats
+	exceeded bool
+	stop     context.CancelFunc
+}
+
+func newDiskUsageHook(
+	logger hclog.Logger,
+	alloc *structs.Allocation,
+	allocDir allocdir.Interface,
+	failer taskFailer,
+	enforce bool,
+	interval time.Duration,
+) *diskUsageHook {
+	return &diskUsageHook{
+		logger:   logger.Named(diskUsageHookName),
+		allocDir: allocDir,
+		failer:   failer,
+		enforce:  enforce,
+		interval: interval,
+		sizeMB:   ephemeralDiskSize(alloc),
+	}
+}
+
+// ephemeralDiskSize returns the ephemeral disk size of the group of the
+// allocation in MB, or 0 if it can't be found.
+func ephemeralDiskSize(alloc *structs.Allocation) int {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.EphemeralDisk == nil {
+		return 0
+	}
+	return tg.EphemeralDisk.SizeMB
+}
+
+func (h *diskUsageHook) Name() string {
+	return diskUsageHookName
+}
+
+func (h *diskUsageHook) Prerun() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.stop != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stop = cancel
+	go h.run(ctx)
+	return nil
+}
+
+func (h *diskUsageHook) Update(req *interfaces.RunnerUpdateRequest) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.sizeMB = ephemeralDiskSize(req.Alloc)
+	return nil
+}
+
+func (h *diskUsageHook) Postrun() error {
+	h.shutdown()
+	return nil
+}
+
+func (h *diskUsageHook) Shutdown() {
+	h.shutdown()
+}
+
+func (h *diskUsageHook) shutdown() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.stop != nil {
+		h.stop()
+	}
+}
+
+// Stats returns the last measured disk usage of the allocation, or nil if it
+// hasn't been measured yet.
+func (h *diskUsageHook) Stats() *cstructs.AllocDiskStats {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.stats == nil {
+		return nil
+	}
+	stats := *h.stats
+	return &stats
+}
+
+// run measures the disk usage of the allocation on its interval until ctx is
+// cancelled.
+func (h *diskUsageHook) run(ctx context.Context) {
+	timer, stop := helper.NewSafeTimer(0)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			h.measure()
+			timer.Reset(h.interval)
+		}
+	}
+}
+
+// measure records the disk usage of the allocation and, the first time it
+// exceeds the ephemeral disk size, warns or fails its tasks.
+func (h *diskUsageHook) measure() {
+	used, err := h.allocDir.DiskUsage()
+	if err != nil {
+		h.logger.Warn("failed to measure allocation disk usage", "error", err)
+		return
+	}
+
+	h.lock.Lock()
+	size := uint64(h.sizeMB) * bytesPerMB
+	h.stats = &cstructs.AllocDiskStats{
+		Used:      used,
+		Size:      size,
+		Timestamp: time.Now().UnixNano(),
+	}
+	exceeded := !h.exceeded && size > 0 && used > size
+	if exceeded {
+		h.exceeded = true
+	}
+	sizeMB := h.sizeMB
+	h.lock.Unlock()
+
+	if !exceeded {
+		return
+	}
+
+	usedMB := (used + bytesPerMB - 1) / bytesPerMB
+	if !h.enforce {
+		h.logger.Warn("allocation exceeded its ephemeral disk size",
+			"size_mb", sizeMB, "used_mb", usedMB)
+		return
+	}
+
+	h.logger.Warn("allocation exceeded its ephemeral disk size, killing tasks",
+		"size_mb", sizeMB, "used_mb", usedMB)
+	event := structs.NewTaskEvent(structs.TaskDiskExceeded).
+		SetDiskLimit(int64(sizeMB)).
+		SetKillReason(fmt.Sprintf("Allocation used %d MB of disk, exceeding its ephemeral disk size of %d MB", usedMB, sizeMB)).
+		SetFailsTask()
+	h.failer.failTasks(event)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+var (
+	_ interfaces.RunnerPrerunHook  = (*diskUsageHook)(nil)
+	_ interfaces.RunnerUpdateHook  = (*diskUsageHook)(nil)
+	_ interfaces.RunnerPostrunHook = (*diskUsageHook)(nil)
+	_ interfaces.ShutdownHook      = (*diskUsageHook)(nil)
+)
+
+// mockTaskFailer records the events the tasks were failed with.
+type mockTaskFailer struct {
+	lock   sync.Mutex
+	events []*structs.TaskEvent
+}
+
+func (m *mockTaskFailer) failTasks(event *structs.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *mockTaskFailer) Events() []*structs.TaskEvent {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.events
+}
+
+func TestDiskUsageHook(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].EphemeralDisk.SizeMB = 1
+
+	allocDir := allocdir.NewAllocDir(logger, t.TempDir(), t.TempDir(), alloc.ID)
+	defer allocDir.Destroy()
+	must.NoError(t, allocDir.Build())
+
+	failer := &mockTaskFailer{}
+	h := newDiskUsageHook(logger, alloc, allocDir, failer, true, 10*time.Millisecond)
+	must.Nil(t, h.Stats())
+
+	must.NoError(t, h.Prerun())
+	defer h.Postrun()
+
+	// The usage is measured without failing the tasks
+	testutil.WaitForResult(func() (bool, error) {
+		stats := h.Stats()
+		if stats == nil {
+			return false, fmt.Errorf("expected disk stats")
+		}
+		if stats.Size != 1024*1024 {
+			return false, fmt.Errorf("expected size of 1 MB, got %d", stats.Size)
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+	must.SliceEmpty(t, failer.Events())
+
+	// Exceeding the ephemeral disk size fails the tasks once
+	data := make([]byte, 2*1024*1024)
+	_, err := rand.Read(data)
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(allocDir.SharedDir, "data", "foo"), data, 0o666))
+
+	testutil.WaitForResult(func() (bool, error) {
+		if n := len(failer.Events()); n != 1 {
+			return false, fmt.Errorf("expected tasks to be failed once, got %d", n)
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+
+	event := failer.Events()[0]
+	must.Eq(t, structs.TaskDiskExceeded, event.Type)
+	must.True(t, event.FailsTask)
+	must.Eq(t, 1, event.DiskLimit)
+	must.StrContains(t, event.KillReason, "exceeding its ephemeral disk size of 1 MB")
+
+	stats := h.Stats()
+	must.GreaterEq(t, uint64(len(data)), stats.Used)
+
+	time.Sleep(50 * time.Millisecond)
+	must.Len(t, 1, failer.Events())
+}
+
+func TestDiskUsageHook_NotEnforced(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].EphemeralDisk.SizeMB = 1
+
+	allocDir := allocdir.NewAllocDir(logger, t.TempDir(), t.TempDir(), alloc.ID)
+	defer allocDir.Destroy()
+	must.NoError(t, allocDir.Build())
+
+	data := make([]byte, 2*1024*1024)
+	_, err := rand.Read(data)
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(allocDir.SharedDir, "data", "foo"), data, 0o666))
+
+	failer := &mockTaskFailer{}
+	h := newDiskUsageHook(logger, alloc, allocDir, failer, false, 10*time.Millisecond)
+	must.NoError(t, h.Prerun())
+	defer h.Postrun()
+
+	// Exceeding the ephemeral disk size is measured without failing the tasks
+	testutil.WaitForResult(func() (bool, error) {
+		stats := h.Stats()
+		if stats == nil || stats.Used < uint64(len(data)) {
+			return false, fmt.Errorf("expected disk usage to be measured, got %#v", stats)
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+	must.Eq(t, 1024*1024, h.Stats().Size)
+
+	time.Sleep(50 * time.Millisecond)
+	must.SliceEmpty(t, failer.Events())
+}
+
+func TestDiskUsageHook_Update(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].EphemeralDisk.SizeMB = 1
+
+	allocDir := allocdir.NewAllocDir(logger, t.TempDir(), t.TempDir(), alloc.ID)
+	defer allocDir.Destroy()
+	must.NoError(t, allocDir.Build())
+
+	failer := &mockTaskFailer{}
+	h := newDiskUsageHook(logger, alloc, allocDir, failer, true, 10*time.Millisecond)
+
+	// Growing the ephemeral disk prevents the tasks from being failed
+	update := alloc.Copy()
+	update.Job.TaskGroups[0].EphemeralDisk.SizeMB = 10
+	must.NoError(t, h.Update(&interfaces.RunnerUpdateRequest{Alloc: update}))
+
+	data := make([]byte, 2*1024*1024)
+	_, err := rand.Read(data)
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(allocDir.SharedDir, "data", "foo"), data, 0o666))
+
+	must.NoError(t, h.Prerun())
+	defer h.Postrun()
+
+	testutil.WaitForResult(func() (bool, error) {
+		stats := h.Stats()
+		if stats == nil || stats.Used < uint64(len(data)) {
+			return false, fmt.Errorf("expected disk usage to be measured, got %#v", stats)
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+	must.Eq(t, 10*1024*1024, h.Stats().Size)
+	must.SliceEmpty(t, failer.Events())
+}
@@ -16,6 +16,7 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/logmon"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	bstructs "github.com/hashicorp/nomad/plugins/base/structs"
@@ -40,6 +41,9 @@ type logmonHook struct {
 
 	config *logmonHookConfig
 
+	// sinkAllowlist restricts the destinations of the log sinks of the task
+	sinkAllowlist *logging.SinkAllowlist
+
 	logger hclog.Logger
 }
 
@@ -52,9 +56,14 @@ type logmonHookConfig struct {
 
 func newLogMonHook(tr *TaskRunner, logger hclog.Logger) *logmonHook {
 	hook := &logmonHook{
-		runner: tr,
-		config: tr.logmonHookConfig,
-		logger: logger,
+		runner:        tr,
+		config:        tr.logmonHookConfig,
+		sinkAllowlist: &logging.SinkAllowlist{},
+		logger:        logger,
+	}
+	if tr.clientConfig != nil {
+		hook.sinkAllowlist.Hosts = tr.clientConfig.LogSinkAllowedHosts
+		hook.sinkAllowlist.SocketDirs = tr.clientConfig.LogSinkSocketDirs
 	}
 
 	return hook
@@ -119,6 +128,13 @@ func (h *logmonHook) Prestart(ctx context.Context,
 		return nil
 	}
 
+	// Jobs may only ship logs to the destinations the client allows
+	for _, sink := range logSinks(req.Task) {
+		if err := h.sinkAllowlist.Check(sink); err != nil {
+			return structs.NewRecoverableError(err, false)
+		}
+	}
+
 	attempts := 0
 	for {
 		err := h.prestartOneLoop(ctx, req)
@@ -193,13 +209,17 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 	}
 
 	err := h.logmon.Start(&logmon.LogConfig{
-		LogDir:        h.config.logDir,
-		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
-		StderrLogFile: fmt.Sprintf("%s.stderr", req.Task.Name),
-		StdoutFifo:    h.config.stdoutFifo,
-		StderrFifo:    h.config.stderrFifo,
-		MaxFiles:      req.Task.LogConfig.MaxFiles,
-		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
+		LogDir:         h.config.logDir,
+		StdoutLogFile:  fmt.Sprintf("%s.stdout", req.Task.Name),
+		StderrLogFile:  fmt.Sprintf("%s.stderr", req.Task.Name),
+		StdoutFifo:     h.config.stdoutFifo,
+		StderrFifo:     h.config.stderrFifo,
+		MaxFiles:       req.Task.LogConfig.MaxFiles,
+		MaxFileSizeMB:  req.Task.LogConfig.MaxFileSizeMB,
+		RotateInterval: req.Task.LogConfig.RotateInterval,
+		Compression:    req.Task.LogConfig.Compression,
+		Timestamps:     req.Task.LogConfig.Timestamps,
+		Sinks:          logSinks(req.Task),
 	})
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
@@ -246,3 +266,29 @@ func (h *logmonHook) reattach(req *interfaces.TaskStopRequest) error {
 
 	return h.launchLogMon(reattachConfig)
 }
+
+// logSinks returns the configuration of the sinks the logs of the task are
+// shipped to, tagged with the task name unless a tag is set.
+func logSinks(task *structs.Task) []*logging.SinkConfig {
+	if len(task.LogConfig.Sinks) == 0 {
+		return nil
+	}
+
+	sinks := make([]*logging.SinkConfig, len(task.LogConfig.Sinks))
+	for i, sink := range task.LogConfig.Sinks {
+		tag := sink.Tag
+		if tag == "" {
+			tag = task.Name
+		}
+		sinks[i] = &logging.SinkConfig{
+			Type:          sink.Type,
+			Address:       sink.Address,
+			Tag:           tag,
+			Headers:       sink.Headers,
+			BufferSize:    sink.BufferSize,
+			BatchSize:     sink.BatchSize,
+			BatchInterval: sink.BatchInterval,
+		}
+	}
+	return sinks
+}
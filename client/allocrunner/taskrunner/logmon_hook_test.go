@@ -13,8 +13,10 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
@@ -105,6 +107,38 @@ func TestTaskRunner_LogmonHook_StartStop(t *testing.T) {
 	require.NoError(t, hook.Stop(context.Background(), &stopReq, nil))
 }
 
+// TestTaskRunner_LogmonHook_SinkNotAllowed asserts that tasks shipping logs to
+// a sink the client doesn't allow fail to start.
+func TestTaskRunner_LogmonHook_SinkNotAllowed(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.LogConfig.Sinks = []*structs.LogSink{{
+		Type:    "http",
+		Address: "http://169.254.169.254/latest/meta-data",
+	}}
+
+	dir := t.TempDir()
+
+	hookConf := newLogMonHookConfig(task.Name, task.LogConfig, dir)
+	runner := &TaskRunner{
+		logmonHookConfig: hookConf,
+		clientConfig: &config.Config{
+			LogSinkAllowedHosts: []string{"logs.example.com"},
+		},
+	}
+	hook := newLogMonHook(runner, testlog.HCLogger(t))
+
+	req := interfaces.TaskPrestartRequest{Task: task}
+	resp := interfaces.TaskPrestartResponse{}
+
+	err := hook.Prestart(context.Background(), &req, &resp)
+	must.ErrorContains(t, err, "is not allowed by the client configuration")
+	must.False(t, structs.IsRecoverable(err))
+	must.Nil(t, hook.logmonPluginClient)
+}
+
 // TestTaskRunner_LogmonHook_Disabled asserts that no logmon running or expected
 // by any of the lifecycle hooks.
 func TestTaskRunner_LogmonHook_Disabled(t *testing.T) {
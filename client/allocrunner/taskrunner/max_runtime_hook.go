@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	_ interfaces.TaskPoststartHook = (*maxRuntimeHook)(nil)
+	_ interfaces.TaskUpdateHook    = (*maxRuntimeHook)(nil)
+	_ interfaces.TaskExitedHook    = (*maxRuntimeHook)(nil)
+	_ interfaces.TaskStopHook      = (*maxRuntimeHook)(nil)
+)
+
+type maxRuntimeHookConfig struct {
+	alloc     *structs.Allocation
+	task      string
+	lifecycle ti.TaskLifecycle
+
+	// forceFailure marks the next exit of the task as a failure, even if
+	// the task exits successfully once killed.
+	forceFailure func()
+
+	// clearForcedFailure unmarks the next exit of the task as a failure, if
+	// the task exited on its own before it could be killed.
+	clearForcedFailure func()
+
+	// shutdownCtx is cancelled when the task runner is shutdown, in which
+	// case the task keeps running and must not be restarted.
+	shutdownCtx context.Context
+
+	// startedAt returns the time the running task was started at, which
+	// survives client restarts.
+	startedAt func() time.Time
+
+	logger log.Logger
+}
+
+// maxRuntimeHook restarts tasks which run for longer than their max runtime.
+// The restart counts as a failure, so the task is failed and rescheduled once
+// its restart policy has no attempts left.
+type maxRuntimeHook struct {
+	task               string
+	lifecycle          ti.TaskLifecycle
+	forceFailure       func()
+	clearForcedFailure func()
+	shutdownCtx        context.Context
+	startedAt          func() time.Time
+	logger             log.Logger
+
+	// maxRuntime is the max runtime of the task and may be changed by an
+	// in-place update of the allocation.
+	maxRuntime time.Duration
+
+	// running is whether the task has started and not exited yet.
+	running bool
+
+	// cancel stops the pending max runtime timer.
+	cancel context.CancelFunc
+
+	lock sync.Mutex
+}
+
+func newMaxRuntimeHook(config *maxRuntimeHookConfig) *maxRuntimeHook {
+	h := &maxRuntimeHook{
+		task:               config.task,
+		lifecycle:          config.lifecycle,
+		forceFailure:       config.forceFailure,
+		clearForcedFailure: config.clearForcedFailure,
+		shutdownCtx:        config.shutdownCtx,
+		startedAt:          config.startedAt,
+		cancel:             func() {},
+	}
+	h.maxRuntime = maxRuntimeForTask(config.alloc, config.task)
+	h.logger = config.logger.Named(h.Name())
+	return h
+}
+
+// maxRuntimeForTask returns the max runtime of the task of the allocation.
+func maxRuntimeForTask(alloc *structs.Allocation, taskName string) time.Duration {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return 0
+	}
+	task := tg.LookupTask(taskName)
+	if task == nil {
+		return 0
+	}
+	return task.GetMaxRuntime(tg)
+}
+
+func (*maxRuntimeHook) Name() string {
+	return "max_runtime"
+}
+
+func (h *maxRuntimeHook) Poststart(ctx context.Context, _ *interfaces.TaskPoststartRequest, _ *interfaces.TaskPoststartResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.running = true
+	h.watch()
+	return nil
+}
+
+func (h *maxRuntimeHook) Update(_ context.Context, req *interfaces.TaskUpdateRequest, _ *interfaces.TaskUpdateResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	maxRuntime := maxRuntimeForTask(req.Alloc, h.task)
+	if maxRuntime == h.maxRuntime {
+		return nil
+	}
+	h.maxRuntime = maxRuntime
+	if h.running {
+		h.watch()
+	}
+	return nil
+}
+
+func (h *maxRuntimeHook) Exited(context.Context, *interfaces.TaskExitedRequest, *interfaces.TaskExitedResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.running = false
+	h.cancel()
+	return nil
+}
+
+func (h *maxRuntimeHook) Stop(context.Context, *interfaces.TaskStopRequest, *interfaces.TaskStopResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.running = false
+	h.cancel()
+	return nil
+}
+
+// watch replaces the pending timer with one restarting the task once it
+// reaches its max runtime. Callers must hold the lock.
+func (h *maxRuntimeHook) watch() {
+	h.cancel()
+	if h.maxRuntime <= 0 {
+		h.cancel = func() {}
+		return
+	}
+
+	var ctx context.Context
+	ctx, h.cancel = context.WithCancel(h.shutdownCtx)
+
+	maxRuntime := h.maxRuntime
+	remaining := time.Until(h.startedAt().Add(maxRuntime))
+	go func() {
+		timer, stop := helper.NewSafeTimer(remaining)
+		defer stop()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		h.logger.Info("task exceeded its max runtime, restarting", "max_runtime", maxRuntime)
+		event := structs.NewTaskEvent(structs.TaskMaxRuntimeExceeded).
+			SetKillReason(fmt.Sprintf("Task exceeded its max runtime of %v", maxRuntime))
+		// The failure is forced before restarting, as the exit of the killed
+		// task may be recorded before the restart returns. If the task exited
+		// on its own first, its exit must not be recorded as a failure.
+		h.forceFailure()
+		err := h.lifecycle.Restart(ctx, event, true)
+		if err == ErrTaskNotRunning {
+			h.clearForcedFailure()
+		} else if err != nil {
+			h.logger.Error("failed to restart task which exceeded its max runtime", "error", err)
+		}
+	}()
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func testMaxRuntimeHook(t *testing.T, startedAt time.Time, maxRuntime time.Duration) (*maxRuntimeHook, *trtesting.MockTaskHooks) {
+	alloc := mock.BatchAlloc()
+	alloc.Job.TaskGroups[0].Tasks[0].MaxRuntime = maxRuntime
+
+	lifecycle := trtesting.NewMockTaskHooks()
+	h := newMaxRuntimeHook(&maxRuntimeHookConfig{
+		alloc:              alloc,
+		task:               alloc.Job.TaskGroups[0].Tasks[0].Name,
+		lifecycle:          lifecycle,
+		forceFailure:       func() {},
+		clearForcedFailure: func() {},
+		shutdownCtx:        context.Background(),
+		startedAt:          func() time.Time { return startedAt },
+		logger:             testlog.HCLogger(t),
+	})
+	t.Cleanup(func() {
+		must.NoError(t, h.Stop(context.Background(), nil, nil))
+	})
+	return h, lifecycle
+}
+
+func TestTaskRunner_MaxRuntimeHook_Restarts(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle := testMaxRuntimeHook(t, time.Now(), 50*time.Millisecond)
+	must.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+
+	select {
+	case <-lifecycle.RestartCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for restart")
+	}
+	must.Eq(t, 1, lifecycle.Restarts())
+}
+
+func TestTaskRunner_MaxRuntimeHook_Restored(t *testing.T) {
+	ci.Parallel(t)
+
+	// A task restored after its max runtime has elapsed is restarted at once
+	h, lifecycle := testMaxRuntimeHook(t, time.Now().Add(-time.Hour), time.Minute)
+	must.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+
+	select {
+	case <-lifecycle.RestartCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for restart")
+	}
+}
+
+func TestTaskRunner_MaxRuntimeHook_Exited(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle := testMaxRuntimeHook(t, time.Now(), 100*time.Millisecond)
+	must.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+	must.NoError(t, h.Exited(context.Background(), nil, nil))
+
+	select {
+	case <-lifecycle.RestartCh:
+		t.Fatal("unexpected restart of exited task")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestTaskRunner_MaxRuntimeHook_Update(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle := testMaxRuntimeHook(t, time.Now(), 0)
+	must.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+
+	// The group max runtime applies to tasks without their own
+	alloc := mock.BatchAlloc()
+	alloc.Job.TaskGroups[0].MaxRuntime = pointer.Of(50 * time.Millisecond)
+	must.NoError(t, h.Update(context.Background(), &interfaces.TaskUpdateRequest{Alloc: alloc}, nil))
+
+	select {
+	case <-lifecycle.RestartCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for restart")
+	}
+}
+
+func TestTaskRunner_MaxRuntimeHook_ExitedBeforeRestart(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle := testMaxRuntimeHook(t, time.Now(), 50*time.Millisecond)
+
+	// The task exits on its own between the timer firing and the restart, so
+	// its exit must not be recorded as a failure
+	var lock sync.Mutex
+	forced := false
+	h.forceFailure = func() {
+		lock.Lock()
+		defer lock.Unlock()
+		forced = true
+	}
+	h.clearForcedFailure = func() {
+		lock.Lock()
+		defer lock.Unlock()
+		forced = false
+	}
+	lifecycle.RestartError = ErrTaskNotRunning
+	must.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+
+	select {
+	case <-lifecycle.RestartCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for restart")
+	}
+
+	testutil.WaitForResult(func() (bool, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if forced {
+			return false, fmt.Errorf("expected forced failure to be cleared")
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	cifs "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/proclib"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var _ interfaces.TaskUpdateHook = (*resizeHook)(nil)
+
+type resizeHookConfig struct {
+	alloc     *structs.Allocation
+	task      string
+	cores     bool
+	lifecycle ti.TaskLifecycle
+	events    ti.EventEmitter
+	wranglers cifs.ProcessWranglers
+
+	// resizable is whether the task driver runs tasks in the cgroup managed
+	// by the client, so their limits can be changed while they are running.
+	resizable bool
+
+	logger log.Logger
+}
+
+// resizeHook applies in-place updates of the cpu and memory of a task. The
+// limits of the running task are changed without restarting it if its driver
+// supports it, otherwise the task is restarted to pick up its new resources.
+type resizeHook struct {
+	task      proclib.Task
+	lifecycle ti.TaskLifecycle
+	events    ti.EventEmitter
+	wranglers cifs.ProcessWranglers
+	resizable bool
+	logger    log.Logger
+
+	// resources are the last known resources of the task.
+	resources *structs.AllocatedTaskResources
+
+	lock sync.Mutex
+}
+
+func newResizeHook(config *resizeHookConfig) *resizeHook {
+	h := &resizeHook{
+		lifecycle: config.lifecycle,
+		events:    config.events,
+		wranglers: config.wranglers,
+		resizable: config.resizable,
+		resources: taskResourcesOf(config.alloc, config.task),
+	}
+	h.task = proclib.Task{
+		AllocID: config.alloc.ID,
+		Task:    config.task,
+		Cores:   config.cores,
+	}
+	h.logger = config.logger.Named(h.Name())
+	return h
+}
+
+// taskResourcesOf returns the resources allocated to the task of the
+// allocation, or nil if there are none.
+func taskResourcesOf(alloc *structs.Allocation, taskName string) *structs.AllocatedTaskResources {
+	if alloc.AllocatedResources == nil {
+		return nil
+	}
+	return alloc.AllocatedResources.Tasks[taskName]
+}
+
+func (*resizeHook) Name() string {
+	return "resize"
+}
+
+func (h *resizeHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, _ *interfaces.TaskUpdateResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	resources := taskResourcesOf(req.Alloc, h.task.Task)
+	if resources == nil || !resizeRequired(h.resources, resources) {
+		return nil
+	}
+	h.resources = resources
+
+	// A task which is not running picks up its new resources when started
+	if !h.lifecycle.IsRunning() {
+		return nil
+	}
+
+	if h.resizable {
+		err := h.wranglers.Resize(h.task, limitsOf(resources))
+		if err == nil {
+			h.logger.Debug("resized task resources", "cpu", resources.Cpu.CpuShares,
+				"memory", resources.Memory.MemoryMB, "memory_max", resources.Memory.MemoryMaxMB)
+			h.events.EmitEvent(structs.NewTaskEvent(structs.TaskResourcesResized))
+			return nil
+		}
+		if !errors.Is(err, cgroupslib.ErrResizeUnsupported) {
+			h.logger.Warn("failed to resize task resources, restarting", "error", err)
+		}
+	}
+
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).
+		SetRestartReason("Restarting task to apply updated resources")
+	if err := h.lifecycle.Restart(ctx, event, false); err != nil && err != ErrTaskNotRunning {
+		return err
+	}
+	return nil
+}
+
+// resizeRequired returns whether the cpu or memory of the task has changed.
+func resizeRequired(a, b *structs.AllocatedTaskResources) bool {
+	if a == nil {
+		return true
+	}
+	return a.Cpu.CpuShares != b.Cpu.CpuShares ||
+		a.Memory.MemoryMB != b.Memory.MemoryMB ||
+		a.Memory.MemoryMaxMB != b.Memory.MemoryMaxMB
+}
+
+// limitsOf returns the cgroup limits of the task resources, computing the hard
+// and soft memory limits the same way as the executor does when starting the
+// task.
+func limitsOf(resources *structs.AllocatedTaskResources) cgroupslib.Limits {
+	limits := cgroupslib.Limits{
+		CPUShares: resources.Cpu.CpuShares,
+	}
+
+	mem := resources.Memory
+	switch mem.MemoryMaxMB {
+	case 0:
+		limits.MemoryHardBytes = mem.MemoryMB * 1024 * 1024
+	case -1:
+		limits.MemoryHardBytes = -1
+		limits.MemorySoftBytes = mem.MemoryMB * 1024 * 1024
+	default:
+		limits.MemoryHardBytes = mem.MemoryMaxMB * 1024 * 1024
+		limits.MemorySoftBytes = mem.MemoryMB * 1024 * 1024
+	}
+	return limits
+}
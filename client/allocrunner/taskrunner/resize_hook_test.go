@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	trtesting "github.com/hashicorp/nomad/client/allocrunner/taskrunner/testing"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/proclib"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// mockResizeWranglers records the limits of resized tasks.
+type mockResizeWranglers struct {
+	err    error
+	limits map[proclib.Task]cgroupslib.Limits
+}
+
+func (m *mockResizeWranglers) Setup(proclib.Task) error {
+	return nil
+}
+
+func (m *mockResizeWranglers) Destroy(proclib.Task) error {
+	return nil
+}
+
+func (m *mockResizeWranglers) Resize(task proclib.Task, limits cgroupslib.Limits) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.limits[task] = limits
+	return nil
+}
+
+func testResizeHook(t *testing.T, resizable bool, err error) (*resizeHook, *trtesting.MockTaskHooks, *mockResizeWranglers, *structs.Allocation) {
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	lifecycle := trtesting.NewMockTaskHooks()
+	lifecycle.HasHandle = true
+	wranglers := &mockResizeWranglers{
+		err:    err,
+		limits: make(map[proclib.Task]cgroupslib.Limits),
+	}
+	h := newResizeHook(&resizeHookConfig{
+		alloc:     alloc,
+		task:      task.Name,
+		lifecycle: lifecycle,
+		events:    lifecycle,
+		wranglers: wranglers,
+		resizable: resizable,
+		logger:    testlog.HCLogger(t),
+	})
+	return h, lifecycle, wranglers, alloc
+}
+
+// resizeAlloc returns a copy of the allocation with updated task resources.
+func resizeAlloc(alloc *structs.Allocation, cpu, memory, memoryMax int64) *structs.Allocation {
+	alloc = alloc.Copy()
+	tres := alloc.AllocatedResources.Tasks[alloc.Job.TaskGroups[0].Tasks[0].Name]
+	tres.Cpu.CpuShares = cpu
+	tres.Memory.MemoryMB = memory
+	tres.Memory.MemoryMaxMB = memoryMax
+	return alloc
+}
+
+func TestTaskRunner_ResizeHook_Resize(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle, wranglers, alloc := testResizeHook(t, true, nil)
+
+	update := resizeAlloc(alloc, 1000, 512, 1024)
+	must.NoError(t, h.Update(context.Background(), &interfaces.TaskUpdateRequest{Alloc: update}, nil))
+
+	must.Eq(t, 0, lifecycle.Restarts())
+	must.MapEq(t, map[proclib.Task]cgroupslib.Limits{
+		h.task: {
+			CPUShares:       1000,
+			MemoryHardBytes: 1024 * 1024 * 1024,
+			MemorySoftBytes: 512 * 1024 * 1024,
+		},
+	}, wranglers.limits)
+
+	events := lifecycle.Events()
+	must.Len(t, 1, events)
+	must.Eq(t, structs.TaskResourcesResized, events[0].Type)
+
+	// Updates which do not change the resources are ignored
+	must.NoError(t, h.Update(context.Background(), &interfaces.TaskUpdateRequest{Alloc: update.Copy()}, nil))
+	must.Len(t, 1, lifecycle.Events())
+}
+
+func TestTaskRunner_ResizeHook_Restart(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		resizable bool
+		err       error
+	}{
+		{
+			name: "driver cannot resize",
+		},
+		{
+			name:      "resize unsupported",
+			resizable: true,
+			err:       cgroupslib.ErrResizeUnsupported,
+		},
+		{
+			name:      "resize failed",
+			resizable: true,
+			err:       errors.New("permission denied"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, lifecycle, wranglers, alloc := testResizeHook(t, tc.resizable, tc.err)
+
+			update := resizeAlloc(alloc, 1000, 512, 0)
+			must.NoError(t, h.Update(context.Background(), &interfaces.TaskUpdateRequest{Alloc: update}, nil))
+
+			must.Eq(t, 1, lifecycle.Restarts())
+			must.MapEmpty(t, wranglers.limits)
+		})
+	}
+}
+
+func TestTaskRunner_ResizeHook_NotRunning(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle, wranglers, alloc := testResizeHook(t, true, nil)
+	lifecycle.HasHandle = false
+
+	update := resizeAlloc(alloc, 1000, 512, 0)
+	must.NoError(t, h.Update(context.Background(), &interfaces.TaskUpdateRequest{Alloc: update}, nil))
+
+	must.Eq(t, 0, lifecycle.Restarts())
+	must.MapEmpty(t, wranglers.limits)
+}
+
+func TestTaskRunner_ResizeHook_limitsOf(t *testing.T) {
+	ci.Parallel(t)
+
+	resources := func(memory, memoryMax int64) *structs.AllocatedTaskResources {
+		return &structs.AllocatedTaskResources{
+			Cpu:    structs.AllocatedCpuResources{CpuShares: 500},
+			Memory: structs.AllocatedMemoryResources{MemoryMB: memory, MemoryMaxMB: memoryMax},
+		}
+	}
+
+	must.Eq(t, cgroupslib.Limits{
+		CPUShares:       500,
+		MemoryHardBytes: 256 * 1024 * 1024,
+	}, limitsOf(resources(256, 0)))
+
+	must.Eq(t, cgroupslib.Limits{
+		CPUShares:       500,
+		MemoryHardBytes: -1,
+		MemorySoftBytes: 256 * 1024 * 1024,
+	}, limitsOf(resources(256, -1)))
+
+	must.Eq(t, cgroupslib.Limits{
+		CPUShares:       500,
+		MemoryHardBytes: 512 * 1024 * 1024,
+		MemorySoftBytes: 256 * 1024 * 1024,
+	}, limitsOf(resources(256, 512)))
+}
@@ -60,6 +60,7 @@ type RestartTracker struct {
 	killed           bool      // Whether the task has been killed
 	restartTriggered bool      // Whether the task has been signalled to be restarted
 	failure          bool      // Whether a failure triggered the restart
+	forcedFailure    bool      // Whether the exit is a failure regardless of the exit code
 	count            int       // Current number of attempts.
 	onSuccess        bool      // Whether to restart on successful exit code.
 	startTime        time.Time // When the interval began
@@ -117,6 +118,26 @@ func (r *RestartTracker) SetRestartTriggered(failure bool) *RestartTracker {
 	return r
 }
 
+// SetForcedFailure is used to mark that the next exit of the task is a failure
+// even if the task exits successfully once killed, such as when it is
+// restarted for exceeding its max runtime.
+func (r *RestartTracker) SetForcedFailure() *RestartTracker {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.failure = true
+	r.forcedFailure = true
+	return r
+}
+
+// ClearForcedFailure is used to unmark the next exit of the task as a failure,
+// such as when the task exited on its own before it could be killed.
+func (r *RestartTracker) ClearForcedFailure() *RestartTracker {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.forcedFailure = false
+	return r
+}
+
 // SetKilled is used to mark that the task has been killed.
 func (r *RestartTracker) SetKilled() *RestartTracker {
 	r.lock.Lock()
@@ -160,6 +181,7 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 		r.exitRes = nil
 		r.restartTriggered = false
 		r.failure = false
+		r.forcedFailure = false
 		r.killed = false
 	}()
 
@@ -180,8 +202,9 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 		r.reason = ReasonNoRestartsAllowed
 
 		// If the task does not restart on a successful exit code and
-		// the exit code was successful: terminate.
-		if !r.onSuccess && r.exitRes != nil && r.exitRes.Successful() {
+		// the exit code was successful: terminate. Tasks forced to fail
+		// fail regardless of how they exited once killed.
+		if !r.onSuccess && !r.forcedFailure && r.exitRes != nil && r.exitRes.Successful() {
 			return structs.TaskTerminated, 0
 		}
 
@@ -211,7 +234,7 @@ func (r *RestartTracker) GetState() (string, time.Duration) {
 			r.reason = ReasonUnrecoverableError
 			return structs.TaskNotRestarting, 0
 		}
-	} else if r.exitRes != nil {
+	} else if r.exitRes != nil && !r.forcedFailure {
 		// If the task started successfully and restart on success isn't specified,
 		// don't restart but don't mark as failed.
 		if r.exitRes.Successful() && !r.onSuccess {
@@ -157,6 +157,51 @@ func TestClient_RestartTracker_RestartTriggered_Failure(t *testing.T) {
 	}
 }
 
+func TestClient_RestartTracker_RestartTriggered_FailureSuccessfulExit(t *testing.T) {
+	ci.Parallel(t)
+	p := testPolicy(true, structs.RestartPolicyModeFail)
+	p.Attempts = 1
+	rt := NewRestartTracker(p, structs.JobTypeBatch, nil)
+
+	// A task restarted as a failure by check_restart which exits successfully
+	// once killed terminates rather than counting against its restart policy
+	state, _ := rt.SetRestartTriggered(true).SetExitResult(testExitResult(0)).GetState()
+	if state != structs.TaskTerminated {
+		t.Fatalf("expect terminated got %v", state)
+	}
+	if count := rt.GetCount(); count != 1 {
+		t.Fatalf("expect count 1 got %v", count)
+	}
+}
+
+func TestClient_RestartTracker_ForcedFailureSuccessfulExit(t *testing.T) {
+	ci.Parallel(t)
+	p := testPolicy(true, structs.RestartPolicyModeFail)
+	p.Attempts = 1
+	rt := NewRestartTracker(p, structs.JobTypeBatch, nil)
+
+	// A task forced to fail is restarted even if it exits successfully once
+	// killed, until it has no attempts left
+	state, when := rt.SetForcedFailure().SetRestartTriggered(true).SetExitResult(testExitResult(0)).GetState()
+	if state != structs.TaskRestarting || when == 0 {
+		t.Fatalf("expect restart got %v %v", state, when)
+	}
+	state, _ = rt.SetForcedFailure().SetRestartTriggered(true).SetExitResult(testExitResult(0)).GetState()
+	if state != structs.TaskNotRestarting {
+		t.Fatalf("expect failed got %v", state)
+	}
+
+	// The next successful exit terminates the task
+	if state, _ := rt.SetExitResult(testExitResult(0)).GetState(); state != structs.TaskTerminated {
+		t.Fatalf("expect terminated got %v", state)
+	}
+
+	// A cleared forced failure doesn't fail a successful exit
+	if state, _ := rt.SetForcedFailure().ClearForcedFailure().SetExitResult(testExitResult(0)).GetState(); state != structs.TaskTerminated {
+		t.Fatalf("expect terminated got %v", state)
+	}
+}
+
 func TestClient_RestartTracker_StartError_Recoverable_Fail(t *testing.T) {
 	ci.Parallel(t)
 	p := testPolicy(true, structs.RestartPolicyModeFail)
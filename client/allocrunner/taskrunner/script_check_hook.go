@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	tinterfaces "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/taskenv"
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -30,6 +31,7 @@ type scriptCheckHookConfig struct {
 	alloc        *structs.Allocation
 	task         *structs.Task
 	consul       serviceregistration.Handler
+	checkStore   checkstore.Shim
 	logger       log.Logger
 	shutdownWait time.Duration
 }
@@ -39,6 +41,10 @@ type scriptCheckHookConfig struct {
 type scriptCheckHook struct {
 	consul serviceregistration.Handler
 
+	// checkStore receives the results of script checks of services using the
+	// Nomad provider
+	checkStore checkstore.Shim
+
 	// a script check hook can create checks for both group-level and task-level
 	// services, so we track both possible namespaces we require
 	groupConsulNamespace string
@@ -69,6 +75,7 @@ type scriptCheckHook struct {
 func newScriptCheckHook(c scriptCheckHookConfig) *scriptCheckHook {
 	h := &scriptCheckHook{
 		consul:               c.consul,
+		checkStore:           c.checkStore,
 		groupConsulNamespace: c.alloc.ConsulNamespace(),
 		taskConsulNamespace:  c.alloc.ConsulNamespaceForTask(c.task.Name),
 		alloc:                c.alloc,
@@ -201,7 +208,7 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 				taskName:        h.task.Name,
 				check:           check,
 				serviceID:       serviceID,
-				ttlUpdater:      h.consul,
+				ttlUpdater:      h.ttlUpdater(service, check),
 				driverExec:      h.driverExec,
 				taskEnv:         h.taskEnv,
 				logger:          h.logger,
@@ -241,7 +248,7 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 				taskName:        groupTaskName,
 				check:           check,
 				serviceID:       serviceID,
-				ttlUpdater:      h.consul,
+				ttlUpdater:      h.ttlUpdater(service, check),
 				driverExec:      h.driverExec,
 				taskEnv:         h.taskEnv,
 				logger:          h.logger,
@@ -256,6 +263,24 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	return scriptChecks
 }
 
+// ttlUpdater returns the TTLUpdater that receives the results of the script
+// check, depending on the provider of the service.
+func (h *scriptCheckHook) ttlUpdater(service *structs.Service, check *structs.ServiceCheck) TTLUpdater {
+	if service.Provider != structs.ServiceProviderNomad {
+		return h.consul
+	}
+	return &nomadCheckUpdater{
+		checkStore: h.checkStore,
+		allocID:    h.alloc.ID,
+		id:         structs.NomadCheckID(h.alloc.ID, h.alloc.TaskGroup, check),
+		mode:       structs.GetCheckMode(check),
+		group:      h.alloc.Name,
+		task:       service.TaskName,
+		service:    service.Name,
+		check:      check.Name,
+	}
+}
+
 // associated returns true if the script check is associated with the task. This
 // would be the case if the check.task is the same as task, or if the service.task
 // is the same as the task _and_ check.task is not configured (i.e. the check
@@ -276,6 +301,43 @@ type TTLUpdater interface {
 	UpdateTTL(id, namespace, output, status string) error
 }
 
+// nomadCheckUpdater is the TTLUpdater for script checks of services using the
+// Nomad provider. Rather than heartbeating Consul, it stores the check results
+// in the client check store, where they are used for allocation health and
+// exposed through the allocation checks API.
+type nomadCheckUpdater struct {
+	checkStore checkstore.Shim
+	allocID    string
+
+	// check coordinates
+	id      structs.CheckID
+	mode    structs.CheckMode
+	group   string
+	task    string
+	service string
+	check   string
+}
+
+// UpdateTTL stores the result of the script check. Nomad checks have no
+// warning state, so any status other than passing is a failure.
+func (u *nomadCheckUpdater) UpdateTTL(_, _, output, status string) error {
+	result := &structs.CheckQueryResult{
+		ID:        u.id,
+		Mode:      u.mode,
+		Status:    structs.CheckFailure,
+		Output:    output,
+		Timestamp: time.Now().UTC().Unix(),
+		Group:     u.group,
+		Task:      u.task,
+		Service:   u.service,
+		Check:     u.check,
+	}
+	if status == api.HealthPassing {
+		result.Status = structs.CheckSuccess
+	}
+	return u.checkStore.Set(u.allocID, result)
+}
+
 // scriptCheck runs script checks via a interfaces.ScriptExecutor and updates the
 // appropriate check's TTL when the script succeeds.
 type scriptCheck struct {
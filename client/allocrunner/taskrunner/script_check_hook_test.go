@@ -15,8 +15,10 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	regMock "github.com/hashicorp/nomad/client/serviceregistration/mock"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
+	"github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
@@ -24,6 +26,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/require"
 )
 
@@ -331,3 +334,73 @@ func TestScript_associated(t *testing.T) {
 		require.False(t, new(scriptCheckHook).associated("task1", "task2", "task2"))
 	})
 }
+
+// TestScript_NomadProvider asserts script checks of services using the Nomad
+// provider store their results in the check store.
+func TestScript_NomadProvider(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	checkStore := checkstore.NewStore(logger, state.NewMemDB(logger))
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Services = []*structs.Service{{
+		Name:     "service-one",
+		TaskName: task.Name,
+		Provider: structs.ServiceProviderNomad,
+		Checks: []*structs.ServiceCheck{{
+			Name:     "check-script",
+			Type:     structs.ServiceCheckScript,
+			Command:  "/bin/true",
+			Interval: 50 * time.Millisecond,
+			Timeout:  time.Second,
+		}},
+	}}
+
+	cases := []struct {
+		name      string
+		code      int
+		expStatus structs.CheckStatus
+	}{
+		{name: "passing", code: 0, expStatus: structs.CheckSuccess},
+		{name: "warning", code: 1, expStatus: structs.CheckFailure},
+		{name: "critical", code: 2, expStatus: structs.CheckFailure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newScriptCheckHook(scriptCheckHookConfig{
+				alloc:        alloc,
+				task:         task,
+				checkStore:   checkStore,
+				logger:       logger,
+				shutdownWait: time.Second,
+			})
+			h.taskEnv = taskenv.NewBuilder(mock.Node(), alloc, task, "global").Build()
+			h.driverExec = newSimpleExec(tc.code, nil)
+			must.NoError(t, h.upsertChecks())
+			defer func() {
+				must.NoError(t, h.Stop(context.Background(), nil, nil))
+				must.NoError(t, checkStore.Purge(alloc.ID))
+			}()
+
+			id := structs.NomadCheckID(alloc.ID, alloc.TaskGroup, task.Services[0].Checks[0])
+			must.Wait(t, wait.InitialSuccess(
+				wait.BoolFunc(func() bool {
+					result, ok := checkStore.List(alloc.ID)[id]
+					return ok && result.Status == tc.expStatus
+				}),
+				wait.Timeout(3*time.Second),
+				wait.Gap(50*time.Millisecond),
+			))
+
+			result := checkStore.List(alloc.ID)[id]
+			must.Eq(t, "check-script", result.Check)
+			must.Eq(t, "service-one", result.Service)
+			must.Eq(t, task.Name, result.Task)
+			must.Eq(t, structs.Healthiness, result.Mode)
+			must.StrContains(t, result.Output, fmt.Sprintf("code=%d", tc.code))
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -75,15 +76,18 @@ const (
 )
 
 type TaskRunner struct {
-	// allocID, taskName, taskLeader, and taskResources are immutable so these fields may
-	// be accessed without locks
-	allocID       string
-	taskName      string
-	taskLeader    bool
+	// allocID, taskName, and taskLeader are immutable so these fields may be
+	// accessed without locks
+	allocID    string
+	taskName   string
+	taskLeader bool
+
+	// alloc and taskResources are updated with the allocation and must be
+	// accessed with allocLock held. Task resources may be changed by an
+	// in-place update of the cpu and memory of the task.
+	alloc         *structs.Allocation
 	taskResources *structs.AllocatedTaskResources
-
-	alloc     *structs.Allocation
-	allocLock sync.Mutex
+	allocLock     sync.Mutex
 
 	clientConfig *config.Config
 
@@ -186,6 +190,10 @@ type TaskRunner struct {
 	// registering services and checks
 	consulServiceClient serviceregistration.Handler
 
+	// checkStore is where the results of script checks of services using the
+	// Nomad provider are stored
+	checkStore checkstore.Shim
+
 	// consulProxiesClientFunc gets a client used by the envoy version hook for
 	// asking consul what version of envoy nomad should inject into the connect
 	// sidecar or gateway task.
@@ -286,6 +294,10 @@ type Config struct {
 	// ConsulServices is used for managing Consul service registrations
 	ConsulServices serviceregistration.Handler
 
+	// CheckStore is used to store the results of script checks of services
+	// using the Nomad provider.
+	CheckStore checkstore.Shim
+
 	// ConsulProxiesFunc gets a client to use for looking up supported envoy versions
 	// from Consul.
 	ConsulProxiesFunc consul.SupportedProxiesAPIFunc
@@ -386,6 +398,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		envBuilder:              envBuilder,
 		dynamicRegistry:         config.DynamicRegistry,
 		consulServiceClient:     config.ConsulServices,
+		checkStore:              config.CheckStore,
 		consulProxiesClientFunc: config.ConsulProxiesFunc,
 		siClient:                config.ConsulSI,
 		vaultClientFunc:         config.VaultFunc,
@@ -864,7 +877,7 @@ func (tr *TaskRunner) shouldRestart() (bool, time.Duration) {
 }
 
 func (tr *TaskRunner) assignCgroup(taskConfig *drivers.TaskConfig) {
-	reserveCores := len(tr.taskResources.Cpu.ReservedCores) > 0
+	reserveCores := len(tr.TaskResources().Cpu.ReservedCores) > 0
 	p := cgroupslib.LinuxResourcesPath(taskConfig.AllocID, taskConfig.Name, reserveCores)
 	taskConfig.Resources.LinuxResources.CpusetCgroupPath = p
 }
@@ -1126,7 +1139,7 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	task := tr.Task()
 	alloc := tr.Alloc()
 	invocationid := uuid.Short()
-	taskResources := tr.taskResources
+	taskResources := tr.TaskResources()
 	ports := tr.Alloc().AllocatedResources.Shared.Ports
 	env := tr.envBuilder.Build()
 	tr.networkIsolationLock.Lock()
@@ -1494,7 +1507,7 @@ func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
 
 	// Look up device statistics lazily when fetched, as currently we do not emit any stats for them yet
 	if ru != nil && tr.deviceStatsReporter != nil {
-		deviceResources := tr.taskResources.Devices
+		deviceResources := tr.TaskResources().Devices
 		ru.ResourceUsage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
 	}
 	return ru
@@ -24,6 +24,21 @@ func (tr *TaskRunner) setAlloc(updated *structs.Allocation, task *structs.Task)
 
 	tr.alloc = updated
 	tr.task = task
+
+	// The cpu and memory of the task may be updated in-place
+	if ares := updated.AllocatedResources; ares != nil {
+		if tres, ok := ares.Tasks[tr.taskName]; ok {
+			tr.taskResources = tres
+		}
+	}
+}
+
+// TaskResources returns the resources allocated to the task, which may be
+// changed by an in-place update of the allocation.
+func (tr *TaskRunner) TaskResources() *structs.AllocatedTaskResources {
+	tr.allocLock.Lock()
+	defer tr.allocLock.Unlock()
+	return tr.taskResources
 }
 
 // IsLeader returns true if this task is the leader of its task group.
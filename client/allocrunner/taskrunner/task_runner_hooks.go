@@ -74,6 +74,31 @@ func (tr *TaskRunner) initHooks() {
 		newDeviceHook(tr.devicemanager, hookLogger),
 		newAPIHook(tr.shutdownCtx, tr.clientConfig.APIListenerRegistrar, hookLogger),
 		newWranglerHook(tr.wranglers, task.Name, alloc.ID, task.UsesCores(), hookLogger),
+		newResizeHook(&resizeHookConfig{
+			alloc:     alloc,
+			task:      task.Name,
+			cores:     task.UsesCores(),
+			lifecycle: tr,
+			events:    tr,
+			wranglers: tr.wranglers,
+			resizable: tr.driverCapabilities.ResizeResources,
+			logger:    hookLogger,
+		}),
+	}
+
+	// Only batch tasks may have a max runtime. The hook is added even if the
+	// task doesn't have one, as an in-place update may set it.
+	if alloc.Job.Type == structs.JobTypeBatch || alloc.Job.Type == structs.JobTypeSysBatch {
+		tr.runnerHooks = append(tr.runnerHooks, newMaxRuntimeHook(&maxRuntimeHookConfig{
+			alloc:              alloc,
+			task:               task.Name,
+			lifecycle:          tr,
+			forceFailure:       func() { tr.restartTracker.SetForcedFailure() },
+			clearForcedFailure: func() { tr.restartTracker.ClearForcedFailure() },
+			shutdownCtx:        tr.shutdownCtx,
+			startedAt:          func() time.Time { return tr.TaskState().StartedAt },
+			logger:             hookLogger,
+		}))
 	}
 
 	// If the task has a CSI block, add the hook.
@@ -178,10 +203,11 @@ func (tr *TaskRunner) initHooks() {
 	// initial registration may be updated to include script checks, which must
 	// be handled with this hook.
 	tr.runnerHooks = append(tr.runnerHooks, newScriptCheckHook(scriptCheckHookConfig{
-		alloc:  tr.Alloc(),
-		task:   tr.Task(),
-		consul: tr.consulServiceClient,
-		logger: hookLogger,
+		alloc:      tr.Alloc(),
+		task:       tr.Task(),
+		consul:     tr.consulServiceClient,
+		checkStore: tr.checkStore,
+		logger:     hookLogger,
 	}))
 
 	// If this task driver has remote capabilities, add the remote task
@@ -242,7 +268,7 @@ func (tr *TaskRunner) prestart() error {
 			Task:          tr.Task(),
 			TaskDir:       tr.taskDir,
 			TaskEnv:       tr.envBuilder.Build(),
-			TaskResources: tr.taskResources,
+			TaskResources: tr.TaskResources(),
 		}
 
 		origHookState := tr.hookState(name)
@@ -1881,6 +1881,91 @@ func TestTaskRunner_DeriveToken_Unrecoverable(t *testing.T) {
 	require.True(t, state.Events[2].FailsTask)
 }
 
+// TestTaskRunner_MaxRuntime asserts that a task running for longer than its
+// max runtime is killed and fails once it has no restart attempts left.
+func TestTaskRunner_MaxRuntime(t *testing.T) {
+	ci.Parallel(t)
+
+	// Use a batch job with no restarts
+	alloc := mock.BatchAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.RestartPolicy.Attempts = 0
+	tg.RestartPolicy.Interval = 0
+	tg.RestartPolicy.Delay = 0
+	tg.RestartPolicy.Mode = structs.RestartPolicyModeFail
+	task := tg.Tasks[0]
+	task.MaxRuntime = 100 * time.Millisecond
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	defer tr.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+	go tr.Run()
+
+	testWaitForTaskToDie(t, tr)
+
+	state := tr.TaskState()
+	must.True(t, state.Failed)
+
+	var exceeded *structs.TaskEvent
+	for _, e := range state.Events {
+		if e.Type == structs.TaskMaxRuntimeExceeded {
+			exceeded = e
+		}
+	}
+	must.NotNil(t, exceeded)
+	must.Eq(t, "Task exceeded its max runtime of 100ms", exceeded.DisplayMessage)
+}
+
+// TestTaskRunner_UpdateResources asserts that an in-place update of the cpu
+// and memory of a task restarts it with its new resources when its driver
+// cannot resize them.
+func TestTaskRunner_UpdateResources(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	defer tr.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+	go tr.Run()
+
+	testWaitForTaskToStart(t, tr)
+
+	update := alloc.Copy()
+	update.AllocatedResources.Tasks[task.Name].Cpu.CpuShares = 1000
+	update.AllocatedResources.Tasks[task.Name].Memory.MemoryMB = 512
+	tr.Update(update)
+
+	must.Eq(t, 1000, tr.TaskResources().Cpu.CpuShares)
+	must.Eq(t, 512, tr.TaskResources().Memory.MemoryMB)
+
+	testutil.WaitForResult(func() (bool, error) {
+		for _, e := range tr.TaskState().Events {
+			if e.Type == structs.TaskRestartSignal {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("task not restarted")
+	}, func(err error) {
+		t.Fatal(err)
+	})
+	testWaitForTaskToStart(t, tr)
+}
+
 // TestTaskRunner_Download_RawExec asserts that downloaded artifacts may be
 // executed in a driver without filesystem isolation.
 func TestTaskRunner_Download_RawExec(t *testing.T) {
@@ -35,6 +35,9 @@ type MockTaskHooks struct {
 	RestartCh chan struct{}
 	restarts  int
 
+	// RestartError is returned when Restart is called on the mock hook
+	RestartError error
+
 	SignalCh chan struct{}
 	signals  []string
 
@@ -71,7 +74,7 @@ func (m *MockTaskHooks) Restart(ctx context.Context, event *structs.TaskEvent, f
 	case m.RestartCh <- struct{}{}:
 	default:
 	}
-	return nil
+	return m.RestartError
 }
 
 func (m *MockTaskHooks) Signal(event *structs.TaskEvent, s string) error {
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"maps"
@@ -33,6 +34,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	"github.com/hashicorp/nomad/client/fingerprint"
 	"github.com/hashicorp/nomad/client/hoststats"
+	"github.com/hashicorp/nomad/client/hostvolumemanager"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/numalib"
@@ -282,6 +284,10 @@ type Client struct {
 	// csimanager is responsible for managing csi plugins.
 	csimanager csimanager.Manager
 
+	// hostVolumeManager is responsible for creating and deleting dynamic
+	// host volumes.
+	hostVolumeManager *hostvolumemanager.HostVolumeManager
+
 	// devicemanger is responsible for managing device plugins.
 	devicemanager devicemanager.Manager
 
@@ -503,6 +509,24 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.csimanager = csiManager
 	c.pluginManagers.RegisterAndRun(csiManager.PluginManager())
 
+	// Setup the host volume manager and restore any dynamic host volumes
+	// before the node is registered, so they're fingerprinted with the node.
+	hostVolumesDir := cfg.HostVolumesDir
+	if hostVolumesDir == "" {
+		hostVolumesDir = filepath.Join(cfg.StateDir, "host_volumes")
+	}
+	c.hostVolumeManager = hostvolumemanager.NewHostVolumeManager(c.logger, hostvolumemanager.Config{
+		PluginDir:      cfg.HostVolumePluginDir,
+		SharedMountDir: hostVolumesDir,
+		StateMgr:       c.stateDB,
+		UpdateNodeVols: c.updateNodeFromHostVol,
+	})
+	hvCtx, hvCancel := context.WithTimeout(context.Background(), HostVolumePluginRequestTimeout)
+	if err := c.hostVolumeManager.Restore(hvCtx); err != nil {
+		logger.Error("failed to restore dynamic host volumes", "error", err)
+	}
+	hvCancel()
+
 	// Setup the driver manager
 	driverConfig := &drivermanager.Config{
 		Logger:              c.logger,
@@ -2498,6 +2522,23 @@ OUTER:
 	}
 }
 
+// updateNodeFromHostVol adds or removes a dynamic host volume from the node
+// and signals the client to send the updated node to the server. A nil volume
+// removes the volume with that name.
+func (c *Client) updateNodeFromHostVol(name string, volume *structs.ClientHostVolumeConfig) {
+	c.UpdateNode(func(node *structs.Node) {
+		if node.HostVolumes == nil {
+			node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig)
+		}
+		if volume == nil {
+			delete(node.HostVolumes, name)
+		} else {
+			node.HostVolumes[name] = volume
+		}
+	})
+	c.updateNode()
+}
+
 // updateNode signals the client to send the updated
 // Node to the server.
 func (c *Client) updateNode() {
@@ -2839,6 +2880,7 @@ func (c *Client) VaultClient(cluster string) (vaultclient.VaultClient, error) {
 // setupNomadServiceRegistrationHandler sets up the registration handler to use
 // for native service discovery.
 func (c *Client) setupNomadServiceRegistrationHandler() {
+	statusGetter := nsd.NewStatusGetter(c.checkStore)
 	cfg := nsd.ServiceRegistrationHandlerCfg{
 		Datacenter: c.Datacenter(),
 		Enabled:    c.GetConfig().NomadServiceDiscovery,
@@ -2847,8 +2889,9 @@ func (c *Client) setupNomadServiceRegistrationHandler() {
 		Region:     c.Region(),
 		RPCFn:      c.RPC,
 		CheckWatcher: serviceregistration.NewCheckWatcher(
-			c.logger, nsd.NewStatusGetter(c.checkStore),
+			c.logger, statusGetter,
 		),
+		CheckStatusGetter: statusGetter,
 	}
 	c.nomadService = nsd.NewServiceRegistrationHandler(c.logger, &cfg)
 }
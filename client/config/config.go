@@ -107,6 +107,15 @@ type Config struct {
 	// should be owned  by root with file mode 0o755.
 	AllocMountsDir string
 
+	// HostVolumesDir is the directory in which dynamic host volumes created
+	// by the built-in "mkdir" plugin are placed, and the parent directory
+	// passed to external host volume plugins.
+	HostVolumesDir string
+
+	// HostVolumePluginDir is the directory with the executables for external
+	// host volume plugins, named by plugin ID.
+	HostVolumePluginDir string
+
 	// Logger provides a logger to the client
 	Logger log.InterceptLogger
 
@@ -243,6 +252,22 @@ type Config struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool
 
+	// LogSinkAllowedHosts are the hosts the syslog and http log sinks of
+	// tasks may ship to. An entry without a port allows any port.
+	LogSinkAllowedHosts []string
+
+	// LogSinkSocketDirs are the directories containing the unix sockets the
+	// socket and syslog log sinks of tasks may ship to.
+	LogSinkSocketDirs []string
+
+	// EnforceEphemeralDisk fails the tasks of allocations which use more disk
+	// than their ephemeral disk size, instead of only warning.
+	EnforceEphemeralDisk bool
+
+	// DiskUsageInterval is how often the disk usage of allocations is
+	// measured
+	DiskUsageInterval time.Duration
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *ClientTemplateConfig
 
@@ -835,6 +860,8 @@ func (c *Config) Copy() *Config {
 	nc.ReservableCores = slices.Clone(c.ReservableCores)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Users = c.Users.Copy()
+	nc.LogSinkAllowedHosts = slices.Clone(c.LogSinkAllowedHosts)
+	nc.LogSinkSocketDirs = slices.Clone(c.LogSinkSocketDirs)
 	return &nc
 }
 
@@ -856,6 +883,7 @@ func DefaultConfig() *Config {
 		GCMaxAllocs:             50,
 		NoHostUUID:              true,
 		DisableRemoteExec:       false,
+		DiskUsageInterval:       30 * time.Second,
 		TemplateConfig:          DefaultTemplateConfig(),
 		RPCHoldTimeout:          5 * time.Second,
 		CNIPath:                 "/opt/cni/bin",
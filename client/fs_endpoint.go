@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/client/allocdir"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -36,6 +37,7 @@ var (
 	taskNotPresentErr    = fmt.Errorf("must provide task name")
 	logTypeNotPresentErr = fmt.Errorf("must provide log type (stdout/stderr)")
 	invalidOrigin        = fmt.Errorf("origin must be start or end")
+	untilFollowErr       = fmt.Errorf("until can't be used when following logs")
 )
 
 const (
@@ -60,6 +62,10 @@ const (
 	// directory listing.
 	nextLogCheckRate = 100 * time.Millisecond
 
+	// maxDecompressedLogSize is the most a compressed log file without a
+	// recorded uncompressed size is decompressed to find its size.
+	maxDecompressedLogSize = 1 << 30
+
 	// deleteEvent and truncateEvent are the file events that can be sent in a
 	// StreamFrame
 	deleteEvent   = "file deleted"
@@ -411,6 +417,10 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 		handleStreamResultError(invalidOrigin, pointer.Of(int64(http.StatusBadRequest)), encoder)
 		return
 	}
+	if req.Follow && !req.Until.IsZero() {
+		handleStreamResultError(untilFollowErr, pointer.Of(int64(http.StatusBadRequest)), encoder)
+		return
+	}
 
 	fs, err := f.c.GetAllocFS(req.AllocID)
 	if err != nil {
@@ -461,7 +471,7 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	// Start streaming
 	go func() {
 		if err := f.logsImpl(ctx, req.Follow, req.PlainText,
-			req.Offset, req.Origin, req.Task, req.LogType, fs, frames); err != nil {
+			req.Offset, req.Origin, req.Task, req.LogType, req.Since, req.Until, fs, frames); err != nil {
 			select {
 			case errCh <- err:
 			case <-ctx.Done():
@@ -544,9 +554,11 @@ OUTER:
 
 // logsImpl is used to stream the logs of a the given task. Output is sent on
 // the passed frames channel and the method will return on EOF if follow is not
-// true otherwise when the context is cancelled or on an error.
+// true otherwise when the context is cancelled or on an error. If since is set
+// the stream starts at the first line written at or after it, and if until is
+// set the stream ends before the first line written after it.
 func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset int64,
-	origin, task, logType string,
+	origin, task, logType string, since, until time.Time,
 	fs allocdir.AllocDirFS, frames chan<- *sframer.StreamFrame) error {
 
 	// Create the framer
@@ -569,6 +581,23 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 		return invalidOrigin
 	}
 
+	// sizes caches the decompressed size of compressed log files
+	sizes := make(map[string]int64)
+
+	// Seeking by time requires the timestamp index of the log files
+	if !since.IsZero() || !until.IsZero() {
+		indexes, err := timestampedLogIndexes(fs, logPath, task, logType)
+		if err != nil {
+			return err
+		}
+		if !since.IsZero() {
+			nextIdx, offset, err = seekLogTime(fs, logPath, indexes, since, sizes)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	for {
 		// Logic for picking next file is:
 		// 1) List log files
@@ -592,6 +621,15 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			maxIndex = idx
 		}
 
+		// Offsets are into the decompressed logs, so the size of compressed
+		// log files is needed to find the file the offset falls into.
+		if offset != 0 {
+			entries, err = decompressedLogSizes(fs, logPath, entries, task, logType, sizes)
+			if err != nil {
+				return err
+			}
+		}
+
 		logEntry, idx, openOffset, err := findClosest(entries, nextIdx, offset, task, logType)
 		if err != nil {
 			return err
@@ -611,8 +649,29 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			eofCancelCh = blockUntilNextLog(ctx, fs, logPath, task, logType, idx+1)
 		}
 
+		// Stop at the first line written after until
+		var limit int64
+		if !until.IsZero() {
+			records, err := readLogTimestamps(fs, logPath, logEntry.Name)
+			if err != nil {
+				return err
+			}
+			if end, ok := logging.SearchTimestampIndex(records, until.Add(time.Nanosecond)); ok {
+				if end <= openOffset {
+					return nil
+				}
+				limit = end - openOffset
+				cancelAfterFirstEof = true
+				exitAfter = true
+			}
+		}
+
 		p := filepath.Join(logPath, logEntry.Name)
-		err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		if _, compression := logging.SplitCompressedExt(logEntry.Name); compression != "" {
+			err = f.streamCompressedFile(ctx, openOffset, p, limit, compression, fs, framer)
+		} else {
+			err = f.streamFile(ctx, openOffset, p, limit, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		}
 
 		// Check if the context is cancelled
 		select {
@@ -802,6 +861,213 @@ OUTER:
 	}
 }
 
+// streamCompressedFile streams the decompressed content of a compressed log
+// file, starting at the offset into the decompressed content. If limit is
+// greater than zero, the stream will end once that many bytes have been read.
+// Compressed log files are never written to, so the stream ends at EOF.
+func (f *FileSystem) streamCompressedFile(ctx context.Context, offset int64, path string, limit int64,
+	compression string, fs allocdir.AllocDirFS, framer *sframer.StreamFramer) error {
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := logging.NewDecompressReader(compression, file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if _, err := io.CopyN(io.Discard, reader, offset); err != nil && err != io.EOF {
+		return err
+	}
+
+	var limitReader io.Reader = reader
+	if limit > 0 {
+		limitReader = io.LimitReader(reader, limit)
+	}
+
+	data := make([]byte, streamFrameSize)
+	for {
+		n, readErr := limitReader.Read(data)
+		offset += int64(n)
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+
+		if n != 0 {
+			if err := framer.Send(path, "", data[:n], offset); err != nil {
+				return parseFramerErr(err)
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+
+		select {
+		case <-framer.ExitCh():
+			return nil
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+	}
+}
+
+// decompressedLogSizes returns the entries with the size of the compressed log
+// files of the task replaced by the size of their decompressed content.
+// Compressed log files never change, so their sizes are cached.
+func decompressedLogSizes(fs allocdir.AllocDirFS, logPath string, entries []*cstructs.AllocFileInfo,
+	task, logType string, cache map[string]int64) ([]*cstructs.AllocFileInfo, error) {
+
+	prefix := fmt.Sprintf("%s.%s.", task, logType)
+	out := make([]*cstructs.AllocFileInfo, len(entries))
+	for i, entry := range entries {
+		out[i] = entry
+
+		_, compression := logging.SplitCompressedExt(entry.Name)
+		if entry.IsDir || compression == "" || !strings.HasPrefix(entry.Name, prefix) {
+			continue
+		}
+
+		size, ok := cache[entry.Name]
+		if !ok {
+			var err error
+			size, err = decompressedSize(fs, filepath.Join(logPath, entry.Name), compression)
+			if os.IsNotExist(err) {
+				// Rotated out since the entries were listed
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to read %q: %v", entry.Name, err)
+			}
+			cache[entry.Name] = size
+		}
+
+		decompressed := *entry
+		decompressed.Size = size
+		out[i] = &decompressed
+	}
+	return out, nil
+}
+
+// decompressedSize returns the size of the decompressed content of a file. The
+// size recorded when the file was compressed is used when there is one,
+// otherwise the file is decompressed up to maxDecompressedLogSize bytes.
+func decompressedSize(fs allocdir.AllocDirFS, path, compression string) (int64, error) {
+	dir, name := filepath.Split(path)
+	if sizeFile, err := fs.ReadAt(filepath.Join(dir, logging.UncompressedSizeName(name)), 0); err == nil {
+		size, err := logging.ReadUncompressedSize(sizeFile)
+		sizeFile.Close()
+		if err == nil {
+			return size, nil
+		}
+	}
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader, err := logging.NewDecompressReader(compression, file)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	size, err := io.Copy(io.Discard, io.LimitReader(reader, maxDecompressedLogSize+1))
+	if err != nil {
+		return 0, err
+	}
+	if size > maxDecompressedLogSize {
+		return 0, fmt.Errorf("decompressed size exceeds %d bytes", int64(maxDecompressedLogSize))
+	}
+	return size, nil
+}
+
+// timestampedLogIndexes returns the sorted log indexes of the task, or an error
+// if the task doesn't record the time its log lines were written.
+func timestampedLogIndexes(fs allocdir.AllocDirFS, logPath, task, logType string) (indexTupleArray, error) {
+	entries, err := fs.List(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entries: %v", err)
+	}
+
+	indexes, err := logIndexes(entries, task, logType)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) == 0 {
+		return nil, notFoundErr{taskName: task, logType: logType}
+	}
+	sort.Sort(indexes)
+
+	names := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		names[entry.Name] = struct{}{}
+	}
+	for _, index := range indexes {
+		if _, ok := names[logging.TimestampIndexName(index.entry.Name)]; ok {
+			return indexes, nil
+		}
+	}
+	return nil, timestampsNotRecordedErr{taskName: task, logType: logType}
+}
+
+// seekLogTime returns the log index and the offset into the log file of the
+// first line written at or after t. If all the lines were written before t,
+// the end of the last log file is returned.
+func seekLogTime(fs allocdir.AllocDirFS, logPath string, indexes indexTupleArray, t time.Time,
+	sizes map[string]int64) (int64, int64, error) {
+
+	for _, index := range indexes {
+		records, err := readLogTimestamps(fs, logPath, index.entry.Name)
+		if err != nil {
+			return 0, 0, err
+		}
+		if offset, ok := logging.SearchTimestampIndex(records, t); ok {
+			return index.idx, offset, nil
+		}
+	}
+
+	last := indexes[len(indexes)-1]
+	size := last.entry.Size
+	if _, compression := logging.SplitCompressedExt(last.entry.Name); compression != "" {
+		var ok bool
+		if size, ok = sizes[last.entry.Name]; !ok {
+			var err error
+			size, err = decompressedSize(fs, filepath.Join(logPath, last.entry.Name), compression)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to read %q: %v", last.entry.Name, err)
+			}
+			sizes[last.entry.Name] = size
+		}
+	}
+	return last.idx, size, nil
+}
+
+// readLogTimestamps returns the records of the timestamp index of a log file.
+// Log files without an index, such as those written before the task recorded
+// timestamps, have no records.
+func readLogTimestamps(fs allocdir.AllocDirFS, logPath, name string) ([]logging.TimestampRecord, error) {
+	indexName := logging.TimestampIndexName(name)
+	file, err := fs.ReadAt(filepath.Join(logPath, indexName), 0)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %v", indexName, err)
+	}
+	defer file.Close()
+
+	records, err := logging.ReadTimestampIndex(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %v", indexName, err)
+	}
+	return records, nil
+}
+
 // blockUntilNextLog returns a channel that will have data sent when the next
 // log index or anything greater is created.
 func blockUntilNextLog(ctx context.Context, fs allocdir.AllocDirFS, logPath, task, logType string, nextIndex int64) chan error {
@@ -878,6 +1144,7 @@ func (a indexTupleArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 // error is returned.
 func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexTupleArray, error) {
 	var indexes []indexTuple
+	seen := make(map[int64]int)
 	prefix := fmt.Sprintf("%s.%s.", task, logType)
 	for _, entry := range entries {
 		if entry.IsDir {
@@ -890,12 +1157,25 @@ func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexT
 			continue
 		}
 
+		// Rotated log files may be compressed
+		idxStr, compression := logging.SplitCompressedExt(idxStr)
+
 		// Convert to an int
 		idx, err := strconv.Atoi(idxStr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert %q to a log index: %v", idxStr, err)
 		}
 
+		// A log file exists both compressed and uncompressed while it is
+		// being compressed, in which case the uncompressed file is read.
+		if i, ok := seen[int64(idx)]; ok {
+			if compression == "" {
+				indexes[i].entry = entry
+			}
+			continue
+		}
+		seen[int64(idx)] = len(indexes)
+
 		indexes = append(indexes, indexTuple{idx: int64(idx), entry: entry})
 	}
 
@@ -919,6 +1199,22 @@ func (e notFoundErr) Code() int {
 	return http.StatusNotFound
 }
 
+// timestampsNotRecordedErr is returned when logs are requested by time for a
+// task which doesn't record the time its log lines were written.
+type timestampsNotRecordedErr struct {
+	taskName string
+	logType  string
+}
+
+func (e timestampsNotRecordedErr) Error() string {
+	return fmt.Sprintf("log timestamps are not recorded for task %q and log type %q", e.taskName, e.logType)
+}
+
+// Code returns a 400 as the request can't be satisfied
+func (e timestampsNotRecordedErr) Code() int {
+	return http.StatusBadRequest
+}
+
 // findClosest takes a list of entries, the desired log index and desired log
 // offset (which can be negative, treated as offset from end), task name and log
 // type and returns the log entry, the log index, the offset to read from and a
@@ -4,7 +4,10 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"math"
@@ -14,6 +17,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -25,6 +29,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/config"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -32,6 +37,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/klauspost/compress/zstd"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
@@ -2074,7 +2080,7 @@ func TestFS_logsImpl_NoFollow(t *testing.T) {
 
 	if err := c.endpoints.FileSystem.logsImpl(
 		ctx, false, false, 0,
-		OriginStart, task, logType, ad, frames); err != nil {
+		OriginStart, task, logType, time.Time{}, time.Time{}, ad, frames); err != nil {
 		t.Fatalf("logsImpl failed: %v", err)
 	}
 
@@ -2085,6 +2091,271 @@ func TestFS_logsImpl_NoFollow(t *testing.T) {
 	}
 }
 
+func TestFS_logsImpl_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Create a series of rotated log files, compressed with different
+	// algorithms, followed by the uncompressed current log file
+	writeCompressed := func(name string, content []byte, w func(io.Writer) io.WriteCloser) {
+		var buf bytes.Buffer
+		cw := w(&buf)
+		_, err := cw.Write(content)
+		must.NoError(t, err)
+		must.NoError(t, cw.Close())
+		must.NoError(t, os.WriteFile(filepath.Join(logDir, name), buf.Bytes(), 0777))
+	}
+	writeCompressed("foo.stdout.0.gz", []byte("01"), func(w io.Writer) io.WriteCloser {
+		return gzip.NewWriter(w)
+	})
+	writeCompressed("foo.stdout.1.zst", []byte("23"), func(w io.Writer) io.WriteCloser {
+		zw, err := zstd.NewWriter(w)
+		must.NoError(t, err)
+		return zw
+	})
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.2"), []byte("45"), 0777))
+
+	cases := []struct {
+		name     string
+		origin   string
+		offset   int64
+		expected string
+	}{
+		{
+			name:     "from start",
+			origin:   OriginStart,
+			expected: "012345",
+		},
+		{
+			name:     "offset into compressed file",
+			origin:   OriginStart,
+			offset:   3,
+			expected: "345",
+		},
+		{
+			name:     "offset from end",
+			origin:   OriginEnd,
+			offset:   5,
+			expected: "12345",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make(chan *sframer.StreamFrame, 32)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- c.endpoints.FileSystem.logsImpl(
+					ctx, false, false, tc.offset,
+					tc.origin, "foo", "stdout", time.Time{}, time.Time{}, ad, frames)
+			}()
+
+			var received []byte
+			for frame := range frames {
+				received = append(received, frame.Data...)
+			}
+			must.NoError(t, <-errCh)
+			must.Eq(t, tc.expected, string(received))
+		})
+	}
+}
+
+func TestFS_logsImpl_UncompressedSize(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// The rotated log file can't be decompressed, so the offset can only be
+	// resolved by using the size recorded when it was compressed
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0.gz"), []byte("invalid"), 0777))
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, logging.UncompressedSizeName("foo.stdout.0.gz")), []byte("2"), 0777))
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.1"), []byte("23"), 0777))
+
+	frames := make(chan *sframer.StreamFrame, 32)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.endpoints.FileSystem.logsImpl(
+			ctx, false, false, 3,
+			OriginStart, "foo", "stdout", time.Time{}, time.Time{}, ad, frames)
+	}()
+
+	var received []byte
+	for frame := range frames {
+		received = append(received, frame.Data...)
+	}
+	must.NoError(t, <-errCh)
+	must.Eq(t, "3", string(received))
+}
+
+func TestFS_logsImpl_Timestamps(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Create a compressed rotated log file and the current log file, each
+	// with two lines written a second apart
+	start := time.Now().Truncate(time.Second)
+	writeIndex := func(name string, records ...logging.TimestampRecord) {
+		var buf bytes.Buffer
+		for _, r := range records {
+			binary.Write(&buf, binary.BigEndian, r.Time.UnixNano())
+			binary.Write(&buf, binary.BigEndian, r.Offset)
+		}
+		must.NoError(t, os.WriteFile(filepath.Join(logDir, logging.TimestampIndexName(name)), buf.Bytes(), 0777))
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte("a\nb\n"))
+	must.NoError(t, err)
+	must.NoError(t, gw.Close())
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0.gz"), buf.Bytes(), 0777))
+	writeIndex("foo.stdout.0.gz",
+		logging.TimestampRecord{Time: start, Offset: 0},
+		logging.TimestampRecord{Time: start.Add(time.Second), Offset: 2},
+	)
+
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.1"), []byte("c\nd\n"), 0777))
+	writeIndex("foo.stdout.1",
+		logging.TimestampRecord{Time: start.Add(2 * time.Second), Offset: 0},
+		logging.TimestampRecord{Time: start.Add(3 * time.Second), Offset: 2},
+	)
+
+	// Logs without timestamps can't be read by time
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "bar.stdout.0"), []byte("e\n"), 0777))
+
+	cases := []struct {
+		name     string
+		task     string
+		since    time.Time
+		until    time.Time
+		expected string
+		err      string
+	}{
+		{
+			name:     "since",
+			task:     "foo",
+			since:    start.Add(time.Second),
+			expected: "b\nc\nd\n",
+		},
+		{
+			name:     "since between lines",
+			task:     "foo",
+			since:    start.Add(1500 * time.Millisecond),
+			expected: "c\nd\n",
+		},
+		{
+			name:     "since after all lines",
+			task:     "foo",
+			since:    start.Add(time.Minute),
+			expected: "",
+		},
+		{
+			name:     "until",
+			task:     "foo",
+			until:    start.Add(2 * time.Second),
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "since and until",
+			task:     "foo",
+			since:    start.Add(time.Second),
+			until:    start.Add(time.Second),
+			expected: "b\n",
+		},
+		{
+			name:  "not recorded",
+			task:  "bar",
+			since: start,
+			err:   "log timestamps are not recorded",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make(chan *sframer.StreamFrame, 32)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- c.endpoints.FileSystem.logsImpl(
+					ctx, false, false, 0,
+					OriginStart, tc.task, "stdout", tc.since, tc.until, ad, frames)
+			}()
+
+			var received []byte
+			for frame := range frames {
+				received = append(received, frame.Data...)
+			}
+			err := <-errCh
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, string(received))
+		})
+	}
+}
+
+func TestFS_logIndexes_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	entries := []*cstructs.AllocFileInfo{
+		{Name: "foo.stdout.0.gz"},
+		{Name: "foo.stdout.1.zst"},
+		{Name: "foo.stdout.1"},
+		{Name: "foo.stdout.2"},
+		{Name: ".foo.stdout.2.gz.tmp"},
+	}
+
+	indexes, err := logIndexes(entries, "foo", "stdout")
+	must.NoError(t, err)
+	sort.Sort(indexes)
+
+	// Files being compressed are read uncompressed
+	var names []string
+	for _, index := range indexes {
+		names = append(names, index.entry.Name)
+	}
+	must.Eq(t, []string{"foo.stdout.0.gz", "foo.stdout.1", "foo.stdout.2"}, names)
+}
+
 func TestFS_logsImpl_Follow(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2164,7 +2435,7 @@ func TestFS_logsImpl_Follow(t *testing.T) {
 	// Start streaming logs
 	go c.endpoints.FileSystem.logsImpl(
 		context.Background(), true, false, 0,
-		OriginStart, task, logType, ad, frames)
+		OriginStart, task, logType, time.Time{}, time.Time{}, ad, frames)
 
 	select {
 	case <-firstResultCh:
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// HostVolumePluginRequestTimeout is the timeout for a single host volume
+// plugin operation.
+const HostVolumePluginRequestTimeout = 2 * time.Minute
+
+// HostVolume endpoint is used for managing dynamic host volumes on the client.
+type HostVolume struct {
+	c *Client
+}
+
+func newHostVolumesEndpoint(c *Client) *HostVolume {
+	return &HostVolume{c: c}
+}
+
+// Create runs the requested plugin to create a dynamic host volume and adds
+// the volume to the node.
+func (v *HostVolume) Create(req *cstructs.ClientHostVolumeCreateRequest,
+	resp *cstructs.ClientHostVolumeCreateResponse) error {
+
+	defer metrics.MeasureSince([]string{"client", "host_volume", "create"}, time.Now())
+
+	// Statically configured host volumes own their names on this node.
+	if _, ok := v.c.GetConfig().Node.HostVolumes[req.Name]; ok && !v.isDynamic(req.Name) {
+		return fmt.Errorf("host volume %q is already configured on this node", req.Name)
+	}
+
+	ctx, cancelFn := v.requestContext()
+	defer cancelFn()
+
+	cresp, err := v.c.hostVolumeManager.Create(ctx, req)
+	if err != nil {
+		v.c.logger.Error("failed to create host volume", "name", req.Name, "error", err)
+		return err
+	}
+
+	resp.HostPath = cresp.HostPath
+	resp.CapacityBytes = cresp.CapacityBytes
+
+	v.c.logger.Info("created host volume", "id", req.ID, "path", resp.HostPath)
+	return nil
+}
+
+// Delete runs the requested plugin to delete a dynamic host volume and removes
+// the volume from the node.
+func (v *HostVolume) Delete(req *cstructs.ClientHostVolumeDeleteRequest,
+	resp *cstructs.ClientHostVolumeDeleteResponse) error {
+
+	defer metrics.MeasureSince([]string{"client", "host_volume", "delete"}, time.Now())
+
+	ctx, cancelFn := v.requestContext()
+	defer cancelFn()
+
+	if _, err := v.c.hostVolumeManager.Delete(ctx, req); err != nil {
+		v.c.logger.Error("failed to delete host volume", "ID", req.ID, "error", err)
+		return err
+	}
+
+	v.c.logger.Info("deleted host volume", "id", req.ID, "path", req.HostPath)
+	return nil
+}
+
+// isDynamic returns true if the node's host volume with this name was created
+// via the API rather than in the agent configuration.
+func (v *HostVolume) isDynamic(name string) bool {
+	vol, ok := v.c.GetConfig().Node.HostVolumes[name]
+	return ok && vol.ID != ""
+}
+
+func (v *HostVolume) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), HostVolumePluginRequestTimeout)
+}
@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+)
+
+// HostVolumePlugin is the interface implemented by both the built-in "mkdir"
+// plugin and external plugin executables.
+type HostVolumePlugin interface {
+	Create(context.Context, *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error)
+	Delete(context.Context, *cstructs.ClientHostVolumeDeleteRequest) error
+}
+
+// HostVolumePluginCreateResponse is returned by a plugin after it creates a
+// volume. External plugins write it to stdout as JSON.
+type HostVolumePluginCreateResponse struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"bytes"`
+}
+
+const (
+	// environment variables passed to external host volume plugins
+	EnvOperation   = "DHV_OPERATION"
+	EnvVolumesDir  = "DHV_VOLUMES_DIR"
+	EnvCreatedPath = "DHV_CREATED_PATH"
+	EnvNamespace   = "DHV_NAMESPACE"
+	EnvVolumeName  = "DHV_VOLUME_NAME"
+	EnvVolumeID    = "DHV_VOLUME_ID"
+	EnvNodeID      = "DHV_NODE_ID"
+	EnvCapacityMin = "DHV_CAPACITY_MIN_BYTES"
+	EnvCapacityMax = "DHV_CAPACITY_MAX_BYTES"
+	EnvParameters  = "DHV_PARAMETERS"
+)
+
+// HostVolumePluginMkdir is the built-in plugin, which creates a plain
+// directory under the client's host volumes directory.
+type HostVolumePluginMkdir struct {
+	ID         string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+func (p *HostVolumePluginMkdir) Create(_ context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With(
+		"operation", "create",
+		"volume_id", req.ID,
+		"path", path)
+	log.Debug("running plugin")
+
+	resp := &HostVolumePluginCreateResponse{
+		Path:      path,
+		SizeBytes: 0,
+	}
+
+	// Creating the same volume twice is not an error, so that volumes can be
+	// restored after a client restart.
+	if _, err := os.Stat(path); err == nil {
+		return resp, nil
+	}
+
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		log.Debug("error with plugin", "error", err)
+		return nil, err
+	}
+
+	log.Debug("plugin ran successfully")
+	return resp, nil
+}
+
+func (p *HostVolumePluginMkdir) Delete(_ context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With(
+		"operation", "delete",
+		"volume_id", req.ID,
+		"path", path)
+	log.Debug("running plugin")
+
+	if err := os.RemoveAll(path); err != nil {
+		log.Debug("error with plugin", "error", err)
+		return err
+	}
+
+	log.Debug("plugin ran successfully")
+	return nil
+}
+
+var _ HostVolumePlugin = &HostVolumePluginExternal{}
+
+// HostVolumePluginExternal runs an executable from the client's host volume
+// plugin directory. The executable is called with the operation ("create" or
+// "delete") as its first argument and the target path as its second, and
+// receives the volume request in DHV_* environment variables.
+type HostVolumePluginExternal struct {
+	ID         string
+	Executable string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+// NewHostVolumePluginExternal returns an external plugin if the plugin ID
+// resolves to an executable in the plugin directory.
+func NewHostVolumePluginExternal(log hclog.Logger,
+	id, pluginDir, targetPath string) (*HostVolumePluginExternal, error) {
+
+	if pluginDir == "" {
+		return nil, fmt.Errorf("%w: %q: no host volume plugin directory configured",
+			ErrPluginNotExists, id)
+	}
+
+	executable := filepath.Join(pluginDir, id)
+	if escapingfs.PathEscapesSandbox(pluginDir, executable) {
+		return nil, fmt.Errorf("%w: %q", ErrPluginNotExists, id)
+	}
+
+	f, err := os.Stat(executable)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %q", ErrPluginNotExists, id)
+		}
+		return nil, err
+	}
+	if f.IsDir() || f.Mode().Perm()&0o111 == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrPluginNotExecutable, id)
+	}
+
+	return &HostVolumePluginExternal{
+		ID:         id,
+		Executable: executable,
+		TargetPath: targetPath,
+		log:        log,
+	}, nil
+}
+
+func (p *HostVolumePluginExternal) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+
+	path := filepath.Join(p.TargetPath, req.ID)
+	env := []string{
+		EnvOperation + "=create",
+		EnvVolumesDir + "=" + p.TargetPath,
+		EnvNamespace + "=" + req.Namespace,
+		EnvVolumeName + "=" + req.Name,
+		EnvVolumeID + "=" + req.ID,
+		EnvNodeID + "=" + req.NodeID,
+		EnvCapacityMin + "=" + strconv.FormatInt(req.RequestedCapacityMinBytes, 10),
+		EnvCapacityMax + "=" + strconv.FormatInt(req.RequestedCapacityMaxBytes, 10),
+		EnvParameters + "=" + string(params),
+	}
+
+	stdout, _, err := p.runPlugin(ctx, "create", path, env)
+	if err != nil {
+		return nil, fmt.Errorf("error creating volume %q with plugin %q: %w", req.ID, p.ID, err)
+	}
+
+	var pluginResp HostVolumePluginCreateResponse
+	if err := json.Unmarshal(stdout, &pluginResp); err != nil {
+		return nil, fmt.Errorf("error parsing output of plugin %q: %w", p.ID, err)
+	}
+	if pluginResp.Path == "" {
+		pluginResp.Path = path
+	}
+	return &pluginResp, nil
+}
+
+func (p *HostVolumePluginExternal) Delete(ctx context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+
+	env := []string{
+		EnvOperation + "=delete",
+		EnvVolumesDir + "=" + p.TargetPath,
+		EnvCreatedPath + "=" + req.HostPath,
+		EnvVolumeID + "=" + req.ID,
+		EnvNodeID + "=" + req.NodeID,
+		EnvParameters + "=" + string(params),
+	}
+
+	if _, _, err = p.runPlugin(ctx, "delete", req.HostPath, env); err != nil {
+		return fmt.Errorf("error deleting volume %q with plugin %q: %w", req.ID, p.ID, err)
+	}
+	return nil
+}
+
+func (p *HostVolumePluginExternal) runPlugin(ctx context.Context,
+	op, path string, env []string) (stdout, stderr []byte, err error) {
+
+	log := p.log.With(
+		"operation", op,
+		"path", path)
+	log.Debug("running plugin")
+
+	// set up plugin execution
+	cmd := exec.CommandContext(ctx, p.Executable, op, path)
+	cmd.Env = append(os.Environ(), env...)
+
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+
+	stdout, err = cmd.Output()
+	stderr = errBuf.Bytes()
+
+	log = log.With(
+		"stdout", string(stdout),
+		"stderr", string(stderr),
+	)
+	if err != nil {
+		log.Debug("error with plugin", "error", err)
+		if msg := strings.TrimSpace(string(stderr)); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+		return stdout, stderr, err
+	}
+	log.Debug("plugin ran successfully")
+	return stdout, stderr, nil
+}
+
+var (
+	ErrPluginNotExists     = errors.New("no such plugin")
+	ErrPluginNotExecutable = errors.New("plugin not executable")
+)
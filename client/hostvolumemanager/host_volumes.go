@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// hostvolumemanager is a package that creates and deletes dynamic host
+// volumes on a client, by running either the built-in "mkdir" plugin or an
+// external plugin executable, and keeps the node's host volumes in sync.
+package hostvolumemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	ErrVolumeNameExists = errors.New("volume name already exists on this node")
+)
+
+// HostVolumeStateManager manages the lifecycle of volumes in client state.
+type HostVolumeStateManager interface {
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+	DeleteDynamicHostVolume(string) error
+}
+
+// HostVolumeNodeUpdater is used to add or remove a dynamic host volume from
+// the node. A nil volume removes it.
+type HostVolumeNodeUpdater func(name string, volume *structs.ClientHostVolumeConfig)
+
+// Config is used to configure a HostVolumeManager.
+type Config struct {
+	// PluginDir is where external plugins may be found.
+	PluginDir string
+
+	// SharedMountDir is where plugins should place the directory that will
+	// later become a volume's HostPath
+	SharedMountDir string
+
+	// StateMgr manages client state to restore on agent restarts.
+	StateMgr HostVolumeStateManager
+
+	// UpdateNodeVols is run to update the node when a volume is created or
+	// deleted.
+	UpdateNodeVols HostVolumeNodeUpdater
+}
+
+// HostVolumeManager executes plugins, manages volume metadata in client
+// state, and registers volumes with the client node.
+type HostVolumeManager struct {
+	pluginDir      string
+	sharedMountDir string
+	stateMgr       HostVolumeStateManager
+	updateNodeVols HostVolumeNodeUpdater
+
+	// volNames maps volume names to volume IDs, to prevent two volumes with
+	// the same name from being created on this node.
+	volNames     map[string]string
+	volNamesLock sync.Mutex
+
+	log hclog.Logger
+}
+
+// NewHostVolumeManager includes default builtin plugins.
+func NewHostVolumeManager(logger hclog.Logger, config Config) *HostVolumeManager {
+	logger = logger.Named("host_volume_manager")
+	return &HostVolumeManager{
+		pluginDir:      config.PluginDir,
+		sharedMountDir: config.SharedMountDir,
+		stateMgr:       config.StateMgr,
+		updateNodeVols: config.UpdateNodeVols,
+		volNames:       make(map[string]string),
+		log:            logger,
+	}
+}
+
+// Create runs the appropriate plugin for the given request, saves the request
+// to state, and updates the node with the volume.
+func (hvm *HostVolumeManager) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*cstructs.ClientHostVolumeCreateResponse, error) {
+
+	if err := hvm.claimName(req.Name, req.ID); err != nil {
+		return nil, err
+	}
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		hvm.releaseName(req.Name, req.ID)
+		return nil, err
+	}
+
+	pluginResp, err := plug.Create(ctx, req)
+	if err != nil {
+		hvm.releaseName(req.Name, req.ID)
+		return nil, err
+	}
+
+	volState := &cstructs.HostVolumeState{
+		ID:        req.ID,
+		HostPath:  pluginResp.Path,
+		CreateReq: req,
+	}
+	if err := hvm.stateMgr.PutDynamicHostVolume(volState); err != nil {
+		// if we fail to write to state, delete the volume so it isn't left
+		// lying around without Nomad knowing about it.
+		hvm.log.Error("failed to save volume in state, so deleting", "volume_id", req.ID, "error", err)
+		delErr := plug.Delete(ctx, &cstructs.ClientHostVolumeDeleteRequest{
+			ID:         req.ID,
+			PluginID:   req.PluginID,
+			NodeID:     req.NodeID,
+			HostPath:   pluginResp.Path,
+			Parameters: req.Parameters,
+		})
+		if delErr != nil {
+			hvm.log.Warn("error deleting volume after state store failure", "volume_id", req.ID, "error", delErr)
+			err = errors.Join(err, delErr)
+		}
+		hvm.releaseName(req.Name, req.ID)
+		return nil, err
+	}
+
+	hvm.updateNodeVols(req.Name, genVolConfig(req, pluginResp.Path))
+
+	return &cstructs.ClientHostVolumeCreateResponse{
+		HostPath:      pluginResp.Path,
+		CapacityBytes: pluginResp.SizeBytes,
+	}, nil
+}
+
+// Delete runs the appropriate plugin for the given request, removes it from
+// state, and updates the node to remove the volume.
+func (hvm *HostVolumeManager) Delete(ctx context.Context,
+	req *cstructs.ClientHostVolumeDeleteRequest) (*cstructs.ClientHostVolumeDeleteResponse, error) {
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := plug.Delete(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := hvm.stateMgr.DeleteDynamicHostVolume(req.ID); err != nil {
+		hvm.log.Error("failed to delete volume in state", "volume_id", req.ID, "error", err)
+		return nil, err // bail so a user may retry
+	}
+
+	if name, ok := hvm.nameForID(req.ID); ok {
+		hvm.updateNodeVols(name, nil)
+		hvm.releaseName(name, req.ID)
+	}
+
+	return &cstructs.ClientHostVolumeDeleteResponse{}, nil
+}
+
+// Restore recreates the volumes that were created before the client agent
+// restarted and adds them back to the node. Creation is idempotent for the
+// built-in plugin, and external plugins are expected to behave the same.
+func (hvm *HostVolumeManager) Restore(ctx context.Context) error {
+	vols, err := hvm.stateMgr.GetDynamicHostVolumes()
+	if err != nil {
+		return fmt.Errorf("error reading dynamic host volumes from state: %w", err)
+	}
+
+	var mErr *multierror.Error
+	for _, vol := range vols {
+		if vol.CreateReq == nil {
+			continue
+		}
+		if _, err := hvm.Create(ctx, vol.CreateReq); err != nil {
+			hvm.log.Error("failed to restore host volume", "volume_id", vol.ID, "error", err)
+			mErr = multierror.Append(mErr, fmt.Errorf("volume %q: %w", vol.ID, err))
+			continue
+		}
+		hvm.log.Info("restored host volume", "volume_id", vol.ID, "name", vol.CreateReq.Name)
+	}
+	return mErr.ErrorOrNil()
+}
+
+func (hvm *HostVolumeManager) getPlugin(id string) (HostVolumePlugin, error) {
+	if id == "" || id == structs.HostVolumePluginMkdir {
+		return &HostVolumePluginMkdir{
+			ID:         structs.HostVolumePluginMkdir,
+			TargetPath: hvm.sharedMountDir,
+			log:        hvm.log.With("plugin_id", structs.HostVolumePluginMkdir),
+		}, nil
+	}
+
+	log := hvm.log.With("plugin_id", id)
+	return NewHostVolumePluginExternal(log, id, hvm.pluginDir, hvm.sharedMountDir)
+}
+
+// claimName reserves a volume name for a volume ID. Claiming the same name
+// twice for the same ID is allowed, so that creation is idempotent.
+func (hvm *HostVolumeManager) claimName(name, id string) error {
+	hvm.volNamesLock.Lock()
+	defer hvm.volNamesLock.Unlock()
+
+	if existing, ok := hvm.volNames[name]; ok && existing != id {
+		return fmt.Errorf("%w: %q is used by volume %q", ErrVolumeNameExists, name, existing)
+	}
+	hvm.volNames[name] = id
+	return nil
+}
+
+func (hvm *HostVolumeManager) releaseName(name, id string) {
+	hvm.volNamesLock.Lock()
+	defer hvm.volNamesLock.Unlock()
+
+	if existing, ok := hvm.volNames[name]; ok && existing == id {
+		delete(hvm.volNames, name)
+	}
+}
+
+func (hvm *HostVolumeManager) nameForID(id string) (string, bool) {
+	hvm.volNamesLock.Lock()
+	defer hvm.volNamesLock.Unlock()
+
+	for name, volID := range hvm.volNames {
+		if volID == id {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// genVolConfig returns the configuration for a dynamic volume as it appears
+// in the node's host volumes.
+func genVolConfig(req *cstructs.ClientHostVolumeCreateRequest, hostPath string) *structs.ClientHostVolumeConfig {
+	return &structs.ClientHostVolumeConfig{
+		Name:     req.Name,
+		ID:       req.ID,
+		Path:     hostPath,
+		ReadOnly: false,
+	}
+}
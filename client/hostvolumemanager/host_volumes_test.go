@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHostVolumeManager_Mkdir(t *testing.T) {
+	ci.Parallel(t)
+
+	log := testlog.HCLogger(t)
+	tmp := t.TempDir()
+	memDB := state.NewMemDB(log)
+
+	nodeVols := map[string]*structs.ClientHostVolumeConfig{}
+	updateNodeVols := func(name string, vol *structs.ClientHostVolumeConfig) {
+		if vol == nil {
+			delete(nodeVols, name)
+		} else {
+			nodeVols[name] = vol
+		}
+	}
+
+	hvm := NewHostVolumeManager(log, Config{
+		SharedMountDir: tmp,
+		StateMgr:       memDB,
+		UpdateNodeVols: updateNodeVols,
+	})
+
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:       uuid.Generate(),
+		Name:     "example",
+		PluginID: structs.HostVolumePluginMkdir,
+	}
+	resp, err := hvm.Create(context.Background(), req)
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(tmp, req.ID), resp.HostPath)
+	must.DirExists(t, resp.HostPath)
+	must.MapContainsKey(t, nodeVols, "example")
+	must.Eq(t, req.ID, nodeVols["example"].ID)
+
+	vols, err := memDB.GetDynamicHostVolumes()
+	must.NoError(t, err)
+	must.Len(t, 1, vols)
+
+	// creating the same volume again is idempotent
+	_, err = hvm.Create(context.Background(), req)
+	must.NoError(t, err)
+
+	// but a different volume can't reuse the name
+	_, err = hvm.Create(context.Background(), &cstructs.ClientHostVolumeCreateRequest{
+		ID:       uuid.Generate(),
+		Name:     "example",
+		PluginID: structs.HostVolumePluginMkdir,
+	})
+	must.ErrorIs(t, err, ErrVolumeNameExists)
+
+	// a new manager restores the volume to the node
+	nodeVols = map[string]*structs.ClientHostVolumeConfig{}
+	hvm = NewHostVolumeManager(log, Config{
+		SharedMountDir: tmp,
+		StateMgr:       memDB,
+		UpdateNodeVols: updateNodeVols,
+	})
+	must.NoError(t, hvm.Restore(context.Background()))
+	must.MapContainsKey(t, nodeVols, "example")
+
+	_, err = hvm.Delete(context.Background(), &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       req.ID,
+		PluginID: structs.HostVolumePluginMkdir,
+		HostPath: resp.HostPath,
+	})
+	must.NoError(t, err)
+	must.MapEmpty(t, nodeVols)
+	_, err = os.Stat(resp.HostPath)
+	must.True(t, os.IsNotExist(err))
+
+	vols, err = memDB.GetDynamicHostVolumes()
+	must.NoError(t, err)
+	must.Len(t, 0, vols)
+}
+
+func TestHostVolumeManager_External(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugin is a shell script")
+	}
+
+	log := testlog.HCLogger(t)
+	pluginDir := t.TempDir()
+	mountDir := t.TempDir()
+
+	script := `#!/bin/sh
+set -e
+case "$1" in
+  create)
+    mkdir -p "$2"
+    echo "{\"path\": \"$2\", \"bytes\": $DHV_CAPACITY_MIN_BYTES}"
+    ;;
+  delete)
+    rm -rf "$2"
+    ;;
+esac
+`
+	must.NoError(t, os.WriteFile(filepath.Join(pluginDir, "example-plugin"), []byte(script), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(pluginDir, "not-executable"), []byte(script), 0o644))
+
+	hvm := NewHostVolumeManager(log, Config{
+		PluginDir:      pluginDir,
+		SharedMountDir: mountDir,
+		StateMgr:       state.NewMemDB(log),
+		UpdateNodeVols: func(string, *structs.ClientHostVolumeConfig) {},
+	})
+
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        uuid.Generate(),
+		Name:                      "example",
+		PluginID:                  "example-plugin",
+		RequestedCapacityMinBytes: 1000,
+	}
+	resp, err := hvm.Create(context.Background(), req)
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(mountDir, req.ID), resp.HostPath)
+	must.Eq(t, 1000, resp.CapacityBytes)
+	must.DirExists(t, resp.HostPath)
+
+	_, err = hvm.Delete(context.Background(), &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       req.ID,
+		PluginID: "example-plugin",
+		HostPath: resp.HostPath,
+	})
+	must.NoError(t, err)
+	_, err = os.Stat(resp.HostPath)
+	must.True(t, os.IsNotExist(err))
+
+	_, err = hvm.Create(context.Background(), &cstructs.ClientHostVolumeCreateRequest{
+		ID: uuid.Generate(), Name: "other", PluginID: "not-executable"})
+	must.ErrorIs(t, err, ErrPluginNotExecutable)
+
+	_, err = hvm.Create(context.Background(), &cstructs.ClientHostVolumeCreateRequest{
+		ID: uuid.Generate(), Name: "other", PluginID: "missing"})
+	must.ErrorIs(t, err, ErrPluginNotExists)
+}
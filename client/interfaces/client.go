@@ -4,6 +4,7 @@
 package interfaces
 
 import (
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/idset"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
 	"github.com/hashicorp/nomad/client/lib/proclib"
@@ -48,6 +49,7 @@ type ArtifactGetter interface {
 type ProcessWranglers interface {
 	Setup(proclib.Task) error
 	Destroy(proclib.Task) error
+	Resize(proclib.Task, cgroupslib.Limits) error
 }
 
 // CPUPartitions is an interface satisfied by the cgroupslib package.
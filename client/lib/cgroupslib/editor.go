@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-set/v2"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"golang.org/x/sys/unix"
 )
 
@@ -110,6 +111,11 @@ type Lifecycle interface {
 	Setup() error
 	Kill() error
 	Teardown() error
+
+	// Resize changes the cpu and memory limits of the cgroup while its
+	// processes are running, returning ErrResizeUnsupported if that is not
+	// possible.
+	Resize(Limits) error
 }
 
 // -------- cgroups v1 ---------
@@ -180,6 +186,10 @@ func (l *lifeCG1) Kill() error {
 	return l.thaw()
 }
 
+func (l *lifeCG1) Resize(Limits) error {
+	return ErrResizeUnsupported
+}
+
 func (l *lifeCG1) edit(iface string) *editor {
 	scope := ScopeCG1(l.allocID, l.task)
 	return &editor{
@@ -245,6 +255,24 @@ func (l *lifeCG2) Kill() error {
 	return ed.Write("cgroup.kill", "1")
 }
 
+func (l *lifeCG2) Resize(limits Limits) error {
+	ed := l.edit()
+
+	memHard := "max"
+	if limits.MemoryHardBytes >= 0 {
+		memHard = strconv.FormatInt(limits.MemoryHardBytes, 10)
+	}
+	if err := ed.Write("memory.max", memHard); err != nil {
+		return err
+	}
+	if err := ed.Write("memory.low", strconv.FormatInt(limits.MemorySoftBytes, 10)); err != nil {
+		return err
+	}
+
+	cpuWeight := cgroups.ConvertCPUSharesToCgroupV2Value(uint64(limits.CPUShares))
+	return ed.Write("cpu.weight", strconv.FormatUint(cpuWeight, 10))
+}
+
 // -------- helpers ---------
 
 func getPIDs(file string) (*set.Set[int], error) {
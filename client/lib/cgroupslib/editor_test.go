@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestLifeCG2_Resize(t *testing.T) {
+	cases := []struct {
+		name   string
+		limits Limits
+		max    string
+		low    string
+		weight string
+	}{
+		{
+			name: "hard limit",
+			limits: Limits{
+				CPUShares:       1024,
+				MemoryHardBytes: 256 << 20,
+			},
+			max:    "268435456",
+			low:    "0",
+			weight: "39",
+		},
+		{
+			name: "soft limit",
+			limits: Limits{
+				CPUShares:       2048,
+				MemoryHardBytes: 512 << 20,
+				MemorySoftBytes: 256 << 20,
+			},
+			max:    "536870912",
+			low:    "268435456",
+			weight: "79",
+		},
+		{
+			name: "no limit",
+			limits: Limits{
+				CPUShares:       100,
+				MemoryHardBytes: -1,
+				MemorySoftBytes: 256 << 20,
+			},
+			max:    "max",
+			low:    "268435456",
+			weight: "4",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &lifeCG2{dpath: t.TempDir()}
+			must.NoError(t, l.Resize(tc.limits))
+
+			ed := l.edit()
+			read := func(filename string) string {
+				s, err := ed.Read(filename)
+				must.NoError(t, err)
+				return s
+			}
+			must.Eq(t, tc.max, read("memory.max"))
+			must.Eq(t, tc.low, read("memory.low"))
+			must.Eq(t, tc.weight, read("cpu.weight"))
+		})
+	}
+}
+
+func TestLifeCG1_Resize(t *testing.T) {
+	l := &lifeCG1{allocID: "abc", task: "web"}
+	must.ErrorIs(t, l.Resize(Limits{CPUShares: 100}), ErrResizeUnsupported)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package cgroupslib
+
+import (
+	"errors"
+)
+
+// ErrResizeUnsupported is returned when the limits of a cgroup cannot be
+// changed while its processes are running.
+var ErrResizeUnsupported = errors.New("cgroup limits cannot be resized")
+
+// Limits are the cpu and memory limits of the cgroup of a task.
+type Limits struct {
+	// CPUShares is the cpu shares of the task, converted into the cgroup
+	// cpu weight.
+	CPUShares int64
+
+	// MemoryHardBytes is the hard memory limit, or a negative value if the
+	// memory of the task is not limited.
+	MemoryHardBytes int64
+
+	// MemorySoftBytes is the soft memory limit, or zero if the task has no
+	// soft memory limit.
+	MemorySoftBytes int64
+}
@@ -4,6 +4,7 @@
 package proclib
 
 import (
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/helper/testlog"
 	testing "github.com/mitchellh/go-testing-interface"
 )
@@ -36,3 +37,7 @@ func (m *mock) Kill() error {
 func (m *mock) Cleanup() error {
 	return nil
 }
+
+func (m *mock) Resize(cgroupslib.Limits) error {
+	return nil
+}
@@ -6,6 +6,8 @@ package proclib
 import (
 	"fmt"
 	"sync"
+
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 )
 
 // Task records the unique coordinates of a task from the perspective of a Nomad
@@ -79,6 +81,22 @@ func (w *Wranglers) Destroy(task Task) error {
 	return nil
 }
 
+// Resize the cpu and memory limits of the processes of a running task, without
+// restarting the task. Returns cgroupslib.ErrResizeUnsupported if the limits
+// cannot be changed on this operating system or configuration.
+func (w *Wranglers) Resize(task Task, limits cgroupslib.Limits) error {
+	w.configs.Logger.Trace("resize task process limits", "task", task)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	pw, exists := w.m[task]
+	if !exists {
+		return fmt.Errorf("no process management for task %s", task)
+	}
+	return pw.Resize(limits)
+}
+
 // A ProcessWrangler "owns" a particular Task on a client, enabling the client
 // to kill and cleanup processes created by that Task, without help from the
 // task driver. Currently we have implementations only for Linux (via cgroups).
@@ -86,4 +104,5 @@ type ProcessWrangler interface {
 	Initialize() error
 	Kill() error
 	Cleanup() error
+	Resize(cgroupslib.Limits) error
 }
@@ -75,3 +75,7 @@ func (w *LinuxWranglerCG1) Cleanup() error {
 
 	return nil
 }
+
+func (w *LinuxWranglerCG1) Resize(cgroupslib.Limits) error {
+	return cgroupslib.ErrResizeUnsupported
+}
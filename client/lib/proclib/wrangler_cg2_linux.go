@@ -50,3 +50,8 @@ func (w *LinuxWranglerCG2) Cleanup() error {
 	w.log.Trace("remove cgroup", "task", w.task)
 	return w.cg.Teardown()
 }
+
+func (w *LinuxWranglerCG2) Resize(limits cgroupslib.Limits) error {
+	w.log.Trace("resize cgroup", "task", w.task)
+	return w.cg.Resize(limits)
+}
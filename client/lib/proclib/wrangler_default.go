@@ -5,6 +5,10 @@
 
 package proclib
 
+import (
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+)
+
 // New creates a Wranglers backed by the DefaultWrangler implementation, which
 // does not do anything.
 func New(configs *Configs) (*Wranglers, error) {
@@ -38,3 +42,7 @@ func (w *DefaultWrangler) Kill() error {
 func (w *DefaultWrangler) Cleanup() error {
 	return nil
 }
+
+func (w *DefaultWrangler) Resize(cgroupslib.Limits) error {
+	return cgroupslib.ErrResizeUnsupported
+}
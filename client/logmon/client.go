@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/client/logmon/proto"
 	"github.com/hashicorp/nomad/helper/pluginutils/grpcutils"
 )
@@ -22,13 +23,17 @@ const logmonRPCTimeout = 1 * time.Minute
 
 func (c *logmonClient) Start(cfg *LogConfig) error {
 	req := &proto.StartRequest{
-		LogDir:         cfg.LogDir,
-		StdoutFileName: cfg.StdoutLogFile,
-		StderrFileName: cfg.StderrLogFile,
-		MaxFiles:       uint32(cfg.MaxFiles),
-		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
-		StdoutFifo:     cfg.StdoutFifo,
-		StderrFifo:     cfg.StderrFifo,
+		LogDir:           cfg.LogDir,
+		StdoutFileName:   cfg.StdoutLogFile,
+		StderrFileName:   cfg.StderrLogFile,
+		MaxFiles:         uint32(cfg.MaxFiles),
+		MaxFileSizeMb:    uint32(cfg.MaxFileSizeMB),
+		StdoutFifo:       cfg.StdoutFifo,
+		StderrFifo:       cfg.StderrFifo,
+		Sinks:            sinksToProto(cfg.Sinks),
+		Compression:      cfg.Compression,
+		RotateIntervalNs: cfg.RotateInterval.Nanoseconds(),
+		Timestamps:       cfg.Timestamps,
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
@@ -37,6 +42,26 @@ func (c *logmonClient) Start(cfg *LogConfig) error {
 	return grpcutils.HandleGrpcErr(err, c.doneCtx)
 }
 
+func sinksToProto(sinks []*logging.SinkConfig) []*proto.LogSink {
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	out := make([]*proto.LogSink, len(sinks))
+	for i, sink := range sinks {
+		out[i] = &proto.LogSink{
+			Type:            sink.Type,
+			Address:         sink.Address,
+			Tag:             sink.Tag,
+			Headers:         sink.Headers,
+			BufferSize:      uint32(sink.BufferSize),
+			BatchSize:       uint32(sink.BatchSize),
+			BatchIntervalNs: sink.BatchInterval.Nanoseconds(),
+		}
+	}
+	return out
+}
+
 func (c *logmonClient) Stop() error {
 	req := &proto.StopRequest{}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
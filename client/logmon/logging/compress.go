@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionGzip and CompressionZstd are the algorithms rotated log
+	// files can be compressed with.
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+const (
+	// uncompressedSizeExt is the extension of the file recording the size of
+	// a compressed log file before it was compressed.
+	uncompressedSizeExt = ".size"
+
+	// maxUncompressedSizeFileSize bounds how much of a file recording the
+	// uncompressed size of a log file is read.
+	maxUncompressedSizeFileSize = 32
+)
+
+// compressionExts are the file extensions of the rotated log files compressed
+// with each algorithm.
+var compressionExts = map[string]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// SplitCompressedExt returns the name of a log file without the extension
+// added when it was compressed, and the algorithm it was compressed with. The
+// algorithm is empty if the file is not compressed.
+func SplitCompressedExt(name string) (string, string) {
+	for compression, ext := range compressionExts {
+		if base, ok := strings.CutSuffix(name, ext); ok {
+			return base, compression
+		}
+	}
+	return name, ""
+}
+
+// UncompressedSizeName returns the name of the file recording the size of a
+// compressed log file before it was compressed. Like the timestamp index it is
+// hidden, so it is never mistaken for a log file.
+func UncompressedSizeName(name string) string {
+	name, _ = SplitCompressedExt(name)
+	return "." + name + uncompressedSizeExt
+}
+
+// ReadUncompressedSize returns the size recorded by the file returned by
+// UncompressedSizeName.
+func ReadUncompressedSize(r io.Reader) (int64, error) {
+	b, err := io.ReadAll(io.LimitReader(r, maxUncompressedSizeFileSize))
+	if err != nil {
+		return 0, err
+	}
+	size, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid uncompressed size %q", b)
+	}
+	return size, nil
+}
+
+// NewDecompressReader returns a reader of the decompressed content of r,
+// which was compressed with the algorithm.
+func NewDecompressReader(compression string, r io.Reader) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unknown compression %q", compression)
+	}
+}
+
+func newCompressWriter(compression string, w io.Writer) (io.WriteCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, fmt.Errorf("unknown compression %q", compression)
+	}
+}
+
+// compressFile replaces the file at path with a compressed copy, and records
+// the size of the file before it was compressed so readers don't have to
+// decompress it to find it. The copy is written to a hidden temporary file
+// first, so readers never see a partially compressed file.
+func compressFile(path, compression string) error {
+	ext, ok := compressionExts[compression]
+	if !ok {
+		return fmt.Errorf("unknown compression %q", compression)
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dir, name := filepath.Split(path)
+	tmpPath := filepath.Join(dir, "."+name+ext+".tmp")
+	dst, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+	defer dst.Close()
+
+	w, err := newCompressWriter(compression, dst)
+	if err != nil {
+		return err
+	}
+	size, err := io.Copy(w, src)
+	if err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	// The size is recorded before the compressed file appears, so it always
+	// exists for the files compressed by the rotator
+	sizePath := filepath.Join(dir, UncompressedSizeName(name))
+	if err := os.WriteFile(sizePath, []byte(strconv.FormatInt(size, 10)), 0644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path+ext); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -40,22 +40,42 @@ type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	// RotateInterval is the age at which the current file is rotated, even
+	// if it has not reached FileSize. Zero disables time-based rotation.
+	RotateInterval time.Duration
+
+	// Compression is the algorithm files are compressed with once rotated.
+	// Rotated files are left uncompressed if it is empty.
+	Compression string
+
+	// TimestampIndex enables recording the time at which lines are written
+	// to each file in a hidden index next to it.
+	TimestampIndex bool
+
 	path         string // path is the path on the file system where the rotated set of files are opened
 	baseFileName string // baseFileName is the base file name of the rotated files
 	logFileIdx   int    // logFileIdx is the current index of the rotated files
+	activeIdx    int    // activeIdx is the index of the file being written, guarded by fileLock
 
 	oldestLogFileIdx int // oldestLogFileIdx is the index of the oldest log file in a path
 	closed           bool
 	fileLock         sync.Mutex
 
-	currentFile *os.File // currentFile is the file that is currently getting written
-	currentWr   int64    // currentWr is the number of bytes written to the current file
-	bufw        *bufio.Writer
-	bufLock     sync.Mutex
+	currentFile   *os.File  // currentFile is the file that is currently getting written
+	currentWr     int64     // currentWr is the number of bytes written to the current file
+	currentOpened time.Time // currentOpened is when the current file was opened
+	bufw          *bufio.Writer
+	bufLock       sync.Mutex
+
+	indexFile *os.File      // indexFile is the timestamp index of the current file
+	indexw    *bufio.Writer // indexw buffers writes to indexFile, guarded by bufLock
+	lineStart bool          // lineStart is whether the next byte written starts a line
 
 	flushTicker *time.Ticker
 	logger      hclog.Logger
 	purgeCh     chan struct{}
+	compressCh  chan struct{}
+	compressed  chan struct{} // compressed is closed once the compressor exits
 	doneCh      chan struct{}
 }
 
@@ -73,6 +93,8 @@ func NewFileRotator(path string, baseFile string, maxFiles int,
 		flushTicker: time.NewTicker(bufferFlushDuration),
 		logger:      logger,
 		purgeCh:     make(chan struct{}, 1),
+		compressCh:  make(chan struct{}, 1),
+		compressed:  make(chan struct{}),
 		doneCh:      make(chan struct{}),
 	}
 
@@ -80,6 +102,7 @@ func NewFileRotator(path string, baseFile string, maxFiles int,
 		return nil, err
 	}
 	go rotator.purgeOldFiles()
+	go rotator.compressRotatedFiles()
 	go rotator.flushPeriodically()
 	return rotator, nil
 }
@@ -89,13 +112,15 @@ func NewFileRotator(path string, baseFile string, maxFiles int,
 func (f *FileRotator) Write(p []byte) (n int, err error) {
 	n = 0
 	var forceRotate bool
+	now := time.Now()
 
 	for n < len(p) {
 		// Check if we still have space in the current file, otherwise close and
 		// open the next file
-		if forceRotate || f.currentWr >= f.FileSize {
+		if forceRotate || f.currentWr >= f.FileSize || f.intervalElapsed() {
 			forceRotate = false
 			f.flushBuffer()
+			f.closeIndex()
 			f.currentFile.Close()
 			if err := f.nextFile(); err != nil {
 				f.logger.Error("error creating next file", "error", err)
@@ -139,12 +164,16 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 			nw, err = f.writeToBuffer(p[n:])
 		}
 
+		if nw > 0 && f.TimestampIndex {
+			f.indexLine(now, p[n:n+nw])
+		}
+
 		// Increment the number of bytes written so far in this method
 		// invocation
 		n += nw
 
 		// Increment the total number of bytes in the file
-		f.currentWr += int64(n)
+		f.currentWr += int64(nw)
 		if err != nil {
 			f.logger.Error("error writing to file", "error", err)
 
@@ -159,6 +188,82 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	return
 }
 
+// indexLine records the time of the first line starting in p, which is about
+// to be appended to the current file, in the timestamp index of the file.
+func (f *FileRotator) indexLine(t time.Time, p []byte) {
+	if f.indexFile == nil {
+		if err := f.openIndex(); err != nil {
+			f.logger.Error("error opening timestamp index", "error", err)
+			return
+		}
+	}
+
+	offset := int64(-1)
+	if f.lineStart {
+		offset = f.currentWr
+	} else if i := bytes.IndexByte(p, newLineDelimiter); i >= 0 && i+1 < len(p) {
+		offset = f.currentWr + int64(i) + 1
+	}
+	f.lineStart = p[len(p)-1] == newLineDelimiter
+	if offset < 0 {
+		return
+	}
+
+	f.bufLock.Lock()
+	defer f.bufLock.Unlock()
+	if _, err := f.indexw.Write(appendTimestampRecord(nil, t, offset)); err != nil {
+		f.logger.Error("error writing to timestamp index", "error", err)
+		f.indexw.Reset(f.indexFile)
+	}
+}
+
+// openIndex opens the timestamp index of the current file.
+func (f *FileRotator) openIndex() error {
+	logFileName := fmt.Sprintf("%s.%d", f.baseFileName, f.logFileIdx)
+	file, err := os.OpenFile(filepath.Join(f.path, TimestampIndexName(logFileName)),
+		os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	// An existing file may end in the middle of a line
+	f.lineStart = true
+	if f.currentWr > 0 {
+		last := make([]byte, 1)
+		if _, err := f.currentFile.ReadAt(last, f.currentWr-1); err == nil {
+			f.lineStart = last[0] == newLineDelimiter
+		}
+	}
+
+	f.bufLock.Lock()
+	defer f.bufLock.Unlock()
+	f.indexFile = file
+	f.indexw = bufio.NewWriterSize(file, timestampIndexBufferSize)
+	return nil
+}
+
+// closeIndex flushes and closes the timestamp index of the current file.
+func (f *FileRotator) closeIndex() {
+	f.bufLock.Lock()
+	defer f.bufLock.Unlock()
+	if f.indexFile == nil {
+		return
+	}
+	if err := f.indexw.Flush(); err != nil {
+		f.logger.Error("error flushing timestamp index", "error", err)
+	}
+	f.indexFile.Close()
+	f.indexFile = nil
+	f.indexw = nil
+}
+
+// intervalElapsed returns whether the current file has been written to for
+// longer than the rotation interval.
+func (f *FileRotator) intervalElapsed() bool {
+	return f.RotateInterval > 0 && f.currentWr > 0 &&
+		time.Since(f.currentOpened) >= f.RotateInterval
+}
+
 // nextFile opens the next file and purges older files if the number of rotated
 // files is larger than the maximum files configured by the user
 func (f *FileRotator) nextFile() error {
@@ -166,6 +271,9 @@ func (f *FileRotator) nextFile() error {
 	for {
 		nextFileIdx += 1
 		logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, nextFileIdx))
+		if f.isCompressed(logFileName) {
+			continue
+		}
 		if fi, err := os.Stat(logFileName); err == nil {
 			if fi.IsDir() || fi.Size() >= f.FileSize {
 				continue
@@ -177,10 +285,24 @@ func (f *FileRotator) nextFile() error {
 		}
 		break
 	}
-	// Purge old files if we have more files than MaxFiles
+
 	f.fileLock.Lock()
 	defer f.fileLock.Unlock()
-	if f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles && !f.closed {
+	f.activeIdx = f.logFileIdx
+	if f.closed {
+		return nil
+	}
+
+	// Compress the file which was just rotated
+	if f.Compression != "" {
+		select {
+		case f.compressCh <- struct{}{}:
+		default:
+		}
+	}
+
+	// Purge old files if we have more files than MaxFiles
+	if f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles {
 		select {
 		case f.purgeCh <- struct{}{}:
 		default:
@@ -189,6 +311,31 @@ func (f *FileRotator) nextFile() error {
 	return nil
 }
 
+// isCompressed returns whether a compressed copy of the log file exists.
+func (f *FileRotator) isCompressed(logFileName string) bool {
+	for _, ext := range compressionExts {
+		if _, err := os.Stat(logFileName + ext); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// fileIndex returns the index of the rotated file, and whether the file is one
+// of the rotated files.
+func (f *FileRotator) fileIndex(name string) (int, bool) {
+	name, _ = SplitCompressedExt(name)
+	idx, ok := strings.CutPrefix(name, f.baseFileName+".")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(idx)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
 // lastFile finds out the rotated file with the largest index in a path.
 func (f *FileRotator) lastFile() error {
 	finfos, err := os.ReadDir(f.path)
@@ -196,22 +343,22 @@ func (f *FileRotator) lastFile() error {
 		return err
 	}
 
-	prefix := fmt.Sprintf("%s.", f.baseFileName)
 	for _, fi := range finfos {
 		if fi.IsDir() {
 			continue
 		}
-		if strings.HasPrefix(fi.Name(), prefix) {
-			fileIdx := strings.TrimPrefix(fi.Name(), prefix)
-			n, err := strconv.Atoi(fileIdx)
-			if err != nil {
-				continue
-			}
-			if n > f.logFileIdx {
-				f.logFileIdx = n
-			}
+		if n, ok := f.fileIndex(fi.Name()); ok && n > f.logFileIdx {
+			f.logFileIdx = n
 		}
 	}
+
+	// Never append to a file which was already compressed
+	logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, f.logFileIdx))
+	if f.isCompressed(logFileName) {
+		f.logFileIdx++
+	}
+	f.activeIdx = f.logFileIdx
+
 	if err := f.createFile(); err != nil {
 		return err
 	}
@@ -232,6 +379,7 @@ func (f *FileRotator) createFile() error {
 		return err
 	}
 	f.currentWr = fi.Size()
+	f.currentOpened = time.Now()
 	f.createOrResetBuffer()
 	return nil
 }
@@ -253,7 +401,6 @@ func (f *FileRotator) flushPeriodically() {
 // Close flushes and closes the rotator. It never returns an error.
 func (f *FileRotator) Close() error {
 	f.fileLock.Lock()
-	defer f.fileLock.Unlock()
 
 	// Stop the ticker and flush for one last time
 	f.flushTicker.Stop()
@@ -264,9 +411,14 @@ func (f *FileRotator) Close() error {
 		close(f.doneCh)
 		close(f.purgeCh)
 		f.closed = true
+		f.closeIndex()
 		f.currentFile.Close()
 	}
+	f.fileLock.Unlock()
 
+	// Wait for the compression of the current file to finish, as the
+	// compressor needs fileLock
+	<-f.compressed
 	return nil
 }
 
@@ -282,16 +434,15 @@ func (f *FileRotator) purgeOldFiles() {
 				f.logger.Error("error getting directory listing", "error", err)
 				return
 			}
-			// Inserting all the rotated files in a slice
+			// Inserting all the rotated files in a slice. A file may briefly
+			// exist both compressed and uncompressed.
+			seen := make(map[int]struct{})
 			for _, fi := range files {
-				if strings.HasPrefix(fi.Name(), f.baseFileName) {
-					fileIdx := strings.TrimPrefix(fi.Name(), fmt.Sprintf("%s.", f.baseFileName))
-					n, err := strconv.Atoi(fileIdx)
-					if err != nil {
-						f.logger.Error("error extracting file index", "error", err)
-						continue
+				if n, ok := f.fileIndex(fi.Name()); ok {
+					if _, ok := seen[n]; !ok {
+						seen[n] = struct{}{}
+						fIndexes = append(fIndexes, n)
 					}
-					fIndexes = append(fIndexes, n)
 				}
 			}
 
@@ -311,6 +462,19 @@ func (f *FileRotator) purgeOldFiles() {
 				if err != nil {
 					f.logger.Error("error removing file", "filename", fname, "error", err)
 				}
+				for _, ext := range compressionExts {
+					if err := os.RemoveAll(fname + ext); err != nil {
+						f.logger.Error("error removing file", "filename", fname+ext, "error", err)
+					}
+				}
+				iname := filepath.Join(f.path, TimestampIndexName(filepath.Base(fname)))
+				if err := os.RemoveAll(iname); err != nil {
+					f.logger.Error("error removing file", "filename", iname, "error", err)
+				}
+				sname := filepath.Join(f.path, UncompressedSizeName(filepath.Base(fname)))
+				if err := os.RemoveAll(sname); err != nil {
+					f.logger.Error("error removing file", "filename", sname, "error", err)
+				}
 			}
 
 			f.fileLock.Lock()
@@ -322,12 +486,67 @@ func (f *FileRotator) purgeOldFiles() {
 	}
 }
 
-// flushBuffer flushes the buffer
+// compressRotatedFiles compresses the files which are no longer written to,
+// including any left uncompressed by a previous rotator.
+func (f *FileRotator) compressRotatedFiles() {
+	defer close(f.compressed)
+	for {
+		select {
+		case <-f.compressCh:
+		case <-f.doneCh:
+			return
+		}
+
+		f.fileLock.Lock()
+		activeIdx := f.activeIdx
+		f.fileLock.Unlock()
+
+		files, err := os.ReadDir(f.path)
+		if err != nil {
+			f.logger.Error("error getting directory listing", "error", err)
+			continue
+		}
+		for _, fi := range files {
+			n, ok := f.fileIndex(fi.Name())
+			if !ok || n >= activeIdx {
+				continue
+			}
+			if _, compression := SplitCompressedExt(fi.Name()); compression != "" {
+				continue
+			}
+
+			fname := filepath.Join(f.path, fi.Name())
+			if f.isCompressed(fname) {
+				// Compressed by a previous rotator which stopped before
+				// removing the original
+				os.Remove(fname)
+				continue
+			}
+			if err := compressFile(fname, f.Compression); err != nil && !os.IsNotExist(err) {
+				f.logger.Error("error compressing file", "filename", fname, "error", err)
+			}
+
+			select {
+			case <-f.doneCh:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// flushBuffer flushes the buffer, and then the buffer of the timestamp index
+// so the index never refers to lines which are not in the file yet.
 func (f *FileRotator) flushBuffer() error {
 	f.bufLock.Lock()
 	defer f.bufLock.Unlock()
 	if f.bufw != nil {
-		return f.bufw.Flush()
+		if err := f.bufw.Flush(); err != nil {
+			return err
+		}
+	}
+	if f.indexw != nil {
+		return f.indexw.Flush()
 	}
 	return nil
 }
@@ -5,10 +5,13 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/testutil"
@@ -185,6 +188,40 @@ func TestFileRotator_RotateFiles_Boundary(t *testing.T) {
 	})
 }
 
+func TestFileRotator_MultipleLinesInWrite(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 10, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+
+	// A write of several lines is split into a write per line, which must
+	// only count the bytes of that line towards the size of the file, so the
+	// next write still fits in it
+	for _, str := range []string{"ab\ncd\n", "ef\n"} {
+		nw, err := fr.Write([]byte(str))
+		must.NoError(t, err)
+		must.Eq(t, len(str), nw)
+	}
+	must.Eq(t, filepath.Join(path, "redis.stdout.0"), fr.currentFile.Name())
+
+	testutil.WaitForResult(func() (bool, error) {
+		fname := filepath.Join(path, "redis.stdout.0")
+		fi, err := os.Stat(fname)
+		if err != nil {
+			return false, fmt.Errorf("failed to stat file %v: %w", fname, err)
+		}
+		if fi.Size() != 9 {
+			return false, fmt.Errorf("expected size: %v, actual: %v", 9, fi.Size())
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
 func TestFileRotator_WriteRemaining(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -275,6 +312,187 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	})
 }
 
+func TestFileRotator_Compression(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			path := t.TempDir()
+
+			fr, err := NewFileRotator(path, baseFileName, 10, 5, testlog.HCLogger(t))
+			must.NoError(t, err)
+			fr.Compression = compression
+
+			for _, str := range []string{"abcde", "fghij", "kl"} {
+				_, err := fr.Write([]byte(str))
+				must.NoError(t, err)
+			}
+
+			// The rotated files are compressed but the current one is not, and
+			// their uncompressed sizes are recorded
+			ext := compressionExts[compression]
+			expected := []string{
+				".redis.stdout.0" + uncompressedSizeExt,
+				".redis.stdout.1" + uncompressedSizeExt,
+				"redis.stdout.0" + ext,
+				"redis.stdout.1" + ext,
+				"redis.stdout.2",
+			}
+			testutil.WaitForResult(func() (bool, error) {
+				entries, err := os.ReadDir(path)
+				if err != nil {
+					return false, err
+				}
+				var names []string
+				for _, entry := range entries {
+					names = append(names, entry.Name())
+				}
+				if !slices.Equal(expected, names) {
+					return false, fmt.Errorf("expected files %v, got %v", expected, names)
+				}
+				return true, nil
+			}, func(err error) {
+				must.NoError(t, err)
+			})
+			must.NoError(t, fr.Close())
+
+			f, err := os.Open(filepath.Join(path, expected[3]))
+			must.NoError(t, err)
+			defer f.Close()
+
+			r, err := NewDecompressReader(compression, f)
+			must.NoError(t, err)
+			defer r.Close()
+
+			content, err := io.ReadAll(r)
+			must.NoError(t, err)
+			must.Eq(t, "fghij", string(content))
+
+			sf, err := os.Open(filepath.Join(path, UncompressedSizeName(expected[3])))
+			must.NoError(t, err)
+			defer sf.Close()
+
+			size, err := ReadUncompressedSize(sf)
+			must.NoError(t, err)
+			must.Eq(t, int64(len(content)), size)
+		})
+	}
+}
+
+func TestFileRotator_OpenLastFile_Compressed(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	// A rotator never appends to a compressed file
+	f, err := os.Create(filepath.Join(path, "redis.stdout.3.gz"))
+	must.NoError(t, err)
+	f.Close()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 10, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+
+	must.Eq(t, filepath.Join(path, "redis.stdout.4"), fr.currentFile.Name())
+}
+
+func TestFileRotator_RotateInterval(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 1024, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+	fr.RotateInterval = 50 * time.Millisecond
+
+	_, err = fr.Write([]byte("abc\n"))
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(path, "redis.stdout.0"), fr.currentFile.Name())
+
+	// The file is rotated on the first write after the interval elapsed
+	time.Sleep(fr.RotateInterval)
+	_, err = fr.Write([]byte("def\n"))
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(path, "redis.stdout.1"), fr.currentFile.Name())
+}
+
+func TestFileRotator_TimestampIndex(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 16, testlog.HCLogger(t))
+	must.NoError(t, err)
+	fr.TimestampIndex = true
+
+	start := time.Now()
+	_, err = fr.Write([]byte("abc\nde"))
+	must.NoError(t, err)
+	middle := time.Now()
+	_, err = fr.Write([]byte("f\ngh\n"))
+	must.NoError(t, err)
+
+	// The line which doesn't fit is written to the next file
+	_, err = fr.Write([]byte("ijklm\n"))
+	must.NoError(t, err)
+	must.NoError(t, fr.Close())
+
+	readIndex := func(name string) []TimestampRecord {
+		f, err := os.Open(filepath.Join(path, TimestampIndexName(name)))
+		must.NoError(t, err)
+		defer f.Close()
+		records, err := ReadTimestampIndex(f)
+		must.NoError(t, err)
+		return records
+	}
+
+	// Lines are recorded at the time their first byte was written
+	records := readIndex("redis.stdout.0")
+	must.Len(t, 3, records)
+	must.Eq(t, 0, records[0].Offset)
+	must.Eq(t, 4, records[1].Offset)
+	must.Eq(t, 8, records[2].Offset)
+
+	offset, ok := SearchTimestampIndex(records, start)
+	must.True(t, ok)
+	must.Eq(t, 0, offset)
+	offset, ok = SearchTimestampIndex(records, middle)
+	must.True(t, ok)
+	must.Eq(t, 8, offset)
+	_, ok = SearchTimestampIndex(records, time.Now())
+	must.False(t, ok)
+
+	records = readIndex("redis.stdout.1")
+	must.Len(t, 1, records)
+	must.Eq(t, 0, records[0].Offset)
+}
+
+func TestFileRotator_TimestampIndex_Append(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	// The last file ends in the middle of a line
+	must.NoError(t, os.WriteFile(filepath.Join(path, "redis.stdout.0"), []byte("abc"), 0644))
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 1024, testlog.HCLogger(t))
+	must.NoError(t, err)
+	fr.TimestampIndex = true
+
+	_, err = fr.Write([]byte("def\nghi\n"))
+	must.NoError(t, err)
+	must.NoError(t, fr.Close())
+
+	f, err := os.Open(filepath.Join(path, TimestampIndexName("redis.stdout.0")))
+	must.NoError(t, err)
+	defer f.Close()
+	records, err := ReadTimestampIndex(f)
+	must.NoError(t, err)
+	must.Len(t, 1, records)
+	must.Eq(t, 7, records[0].Offset)
+}
+
 func BenchmarkRotator(b *testing.B) {
 	kb := 1024
 	for _, inputSize := range []int{kb, 2 * kb, 4 * kb, 8 * kb, 16 * kb, 32 * kb, 64 * kb, 128 * kb, 256 * kb} {
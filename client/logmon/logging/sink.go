@@ -0,0 +1,439 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// SinkTypeSyslog ships log lines to a syslog server.
+	SinkTypeSyslog = "syslog"
+
+	// SinkTypeSocket ships newline delimited log lines to a unix socket.
+	SinkTypeSocket = "socket"
+
+	// SinkTypeHTTP ships batches of log lines to an HTTP endpoint.
+	SinkTypeHTTP = "http"
+)
+
+const (
+	// defaultSinkBufferSize is the number of log lines buffered in memory
+	// for a sink when none is configured.
+	defaultSinkBufferSize = 1024
+
+	// maxSinkLineSize is the size at which a log line without a newline is
+	// shipped anyway, so a task never writing newlines can't grow the buffer
+	// without bound.
+	maxSinkLineSize = 64 * 1024
+
+	// sinkRetryMin and sinkRetryMax bound the backoff between attempts to
+	// ship log lines to an unavailable sink.
+	sinkRetryMin = 500 * time.Millisecond
+	sinkRetryMax = 30 * time.Second
+
+	// defaultSinkBatchInterval is the time a partial batch of an http sink
+	// waits before being shipped when no interval is configured.
+	defaultSinkBatchInterval = time.Second
+
+	// sinkDropReportInterval is the interval at which the number of log
+	// lines dropped by a sink is logged.
+	sinkDropReportInterval = 30 * time.Second
+
+	// sinkCloseTimeout is the time given to a sink to ship its buffered
+	// log lines when it is closed.
+	sinkCloseTimeout = 2 * time.Second
+
+	// sinkWriteTimeout bounds a single attempt to ship log lines.
+	sinkWriteTimeout = 10 * time.Second
+)
+
+// SinkConfig is the configuration of an external destination task logs are
+// shipped to.
+type SinkConfig struct {
+	// Type is the type of the sink: syslog, socket or http.
+	Type string
+
+	// Address is the destination of the sink. It is a URL for syslog and
+	// http sinks, and the path of a unix socket for socket sinks.
+	Address string
+
+	// Tag identifies the task in the shipped log lines.
+	Tag string
+
+	// Headers are added to the requests made by http sinks.
+	Headers map[string]string
+
+	// BufferSize is the number of log lines buffered in memory while the
+	// sink is slow or unavailable. Lines written to a full buffer are dropped.
+	BufferSize int
+
+	// BatchSize and BatchInterval control how many log lines are shipped in
+	// a single request by http sinks, and how long a partial batch waits
+	// before being shipped.
+	BatchSize     int
+	BatchInterval time.Duration
+}
+
+// SinkAllowlist restricts the destinations task logs may be shipped to, so
+// that jobs can't use the client to reach arbitrary hosts or sockets.
+type SinkAllowlist struct {
+	// Hosts are the hosts syslog and http sinks may ship to. An entry
+	// without a port allows any port of the host.
+	Hosts []string
+
+	// SocketDirs are the directories containing the unix sockets socket and
+	// syslog sinks may ship to.
+	SocketDirs []string
+}
+
+// Check returns an error if the sink ships to a destination which isn't
+// allowed.
+func (a *SinkAllowlist) Check(config *SinkConfig) error {
+	switch config.Type {
+	case SinkTypeSocket:
+		return a.checkSocket(config.Address)
+	case SinkTypeSyslog, SinkTypeHTTP:
+		u, err := url.Parse(config.Address)
+		if err != nil {
+			return fmt.Errorf("invalid %s address %q: %v", config.Type, config.Address, err)
+		}
+		if u.Scheme == "unix" {
+			return a.checkSocket(u.Path)
+		}
+		return a.checkHost(u.Host)
+	default:
+		return fmt.Errorf("unknown log sink type %q", config.Type)
+	}
+}
+
+func (a *SinkAllowlist) checkHost(hostport string) error {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	for _, allowed := range a.Hosts {
+		if strings.EqualFold(allowed, hostport) || strings.EqualFold(allowed, host) {
+			return nil
+		}
+	}
+	return fmt.Errorf("log sink host %q is not allowed by the client configuration", hostport)
+}
+
+func (a *SinkAllowlist) checkSocket(path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("socket address %q must be an absolute path", path)
+	}
+
+	// Resolve the socket, or its directory if it doesn't exist yet, so that
+	// a symlink can't point outside of the allowed directories
+	resolved := filepath.Clean(path)
+	if _, err := os.Lstat(resolved); err == nil {
+		resolved, err = filepath.EvalSymlinks(resolved)
+		if err != nil {
+			return fmt.Errorf("failed to resolve log sink socket %q: %v", path, err)
+		}
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(resolved))
+	if err != nil {
+		return fmt.Errorf("failed to resolve log sink socket %q: %v", path, err)
+	}
+	for _, allowed := range a.SocketDirs {
+		allowed, err := filepath.EvalSymlinks(filepath.Clean(allowed))
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(allowed, dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("log sink socket %q is not allowed by the client configuration", path)
+}
+
+// sinkLine is a single log line waiting to be shipped.
+type sinkLine struct {
+	time    time.Time
+	message string
+}
+
+// shipper sends log lines to an external destination.
+type shipper interface {
+	// ship sends the log lines. It reconnects to the destination if needed
+	// and must respect the cancellation of the context.
+	ship(ctx context.Context, lines []sinkLine) error
+
+	// close releases any connection held by the shipper.
+	close() error
+}
+
+// SinkWriter is an io.WriteCloser that ships the log lines written to it to an
+// external sink. Writes never block on the sink: lines are buffered in memory
+// and dropped when the buffer is full, so a slow or unavailable sink can't
+// stall the task or the rotated log files.
+type SinkWriter struct {
+	config *SinkConfig
+	ship   shipper
+	logger hclog.Logger
+
+	lines   chan sinkLine
+	dropped atomic.Uint64
+
+	partial     []byte
+	partialLock sync.Mutex
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	shutdownCh chan struct{}
+	doneCh     chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewSinkWriter returns a SinkWriter shipping the log lines of the given
+// stream, either stdout or stderr, to the sink.
+func NewSinkWriter(config *SinkConfig, stream string, logger hclog.Logger) (*SinkWriter, error) {
+	var (
+		s   shipper
+		err error
+	)
+	switch config.Type {
+	case SinkTypeSyslog:
+		s, err = newSyslogShipper(config, stream)
+	case SinkTypeSocket:
+		s, err = newSocketShipper(config)
+	case SinkTypeHTTP:
+		s, err = newHTTPShipper(config, stream)
+	default:
+		err = fmt.Errorf("unknown sink type %q", config.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bufferSize := config.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultSinkBufferSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &SinkWriter{
+		config:     config,
+		ship:       s,
+		logger:     logger.Named("sink").With("type", config.Type, "stream", stream),
+		lines:      make(chan sinkLine, bufferSize),
+		ctx:        ctx,
+		cancel:     cancel,
+		shutdownCh: make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Write buffers the complete lines of p to be shipped. Incomplete lines are
+// held until the rest of the line is written.
+func (w *SinkWriter) Write(p []byte) (int, error) {
+	w.partialLock.Lock()
+	defer w.partialLock.Unlock()
+
+	now := time.Now()
+	data := p
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, newLineDelimiter)
+		if i < 0 {
+			w.partial = append(w.partial, data...)
+			for len(w.partial) >= maxSinkLineSize {
+				w.enqueue(now, w.partial[:maxSinkLineSize])
+				w.partial = append(w.partial[:0], w.partial[maxSinkLineSize:]...)
+			}
+			break
+		}
+
+		line := data[:i]
+		if len(w.partial) > 0 {
+			line = append(w.partial, line...)
+			w.partial = w.partial[:0]
+		}
+		w.enqueue(now, line)
+		data = data[i+1:]
+	}
+	return len(p), nil
+}
+
+// enqueue buffers the line without blocking, dropping it if the buffer is full
+// or the writer is closed.
+func (w *SinkWriter) enqueue(t time.Time, line []byte) {
+	select {
+	case <-w.shutdownCh:
+		w.dropped.Add(1)
+		return
+	default:
+	}
+
+	select {
+	case w.lines <- sinkLine{time: t, message: string(bytes.TrimSuffix(line, []byte{'\r'}))}:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// Close ships any buffered log lines, waiting up to sinkCloseTimeout for the
+// sink to accept them, and then closes the connection to the sink.
+func (w *SinkWriter) Close() error {
+	w.closeOnce.Do(func() {
+		w.partialLock.Lock()
+		if len(w.partial) > 0 {
+			w.enqueue(time.Now(), w.partial)
+			w.partial = nil
+		}
+		close(w.shutdownCh)
+		w.partialLock.Unlock()
+
+		select {
+		case <-w.doneCh:
+		case <-time.After(sinkCloseTimeout):
+			w.logger.Warn("timed out shipping buffered log lines")
+			w.cancel()
+			<-w.doneCh
+		}
+		w.cancel()
+
+		if err := w.ship.close(); err != nil {
+			w.logger.Debug("failed to close sink", "error", err)
+		}
+		w.reportDropped()
+	})
+	return nil
+}
+
+// run ships the buffered log lines in batches until the writer is closed.
+func (w *SinkWriter) run() {
+	defer close(w.doneCh)
+
+	batchSize, batchInterval := 1, time.Duration(0)
+	if w.config.Type == SinkTypeHTTP {
+		if w.config.BatchSize > 0 {
+			batchSize = w.config.BatchSize
+		}
+		batchInterval = w.config.BatchInterval
+		if batchInterval <= 0 {
+			batchInterval = defaultSinkBatchInterval
+		}
+	}
+
+	report := time.NewTicker(sinkDropReportInterval)
+	defer report.Stop()
+
+	var (
+		batch   []sinkLine
+		timer   *time.Timer
+		flushCh <-chan time.Time
+	)
+	for {
+		select {
+		case line := <-w.lines:
+			batch = append(batch, line)
+			if len(batch) < batchSize {
+				if timer == nil && batchInterval > 0 {
+					timer = time.NewTimer(batchInterval)
+					flushCh = timer.C
+				}
+				continue
+			}
+		case <-flushCh:
+		case <-report.C:
+			w.reportDropped()
+			continue
+		case <-w.shutdownCh:
+			if timer != nil {
+				timer.Stop()
+			}
+			w.drain(batch, batchSize)
+			return
+		}
+
+		if timer != nil {
+			timer.Stop()
+			timer, flushCh = nil, nil
+		}
+		if !w.shipWithRetry(batch) {
+			w.drain(batch, batchSize)
+			return
+		}
+		batch = nil
+	}
+}
+
+// shipWithRetry ships the batch, retrying with backoff while the sink is
+// unavailable. It returns false if the writer was closed before the batch
+// could be shipped.
+func (w *SinkWriter) shipWithRetry(batch []sinkLine) bool {
+	backoff := sinkRetryMin
+	for {
+		err := w.shipOnce(batch)
+		if err == nil {
+			return true
+		}
+		w.logger.Warn("failed to ship log lines", "error", err, "retry", backoff)
+
+		select {
+		case <-time.After(backoff):
+		case <-w.shutdownCh:
+			return false
+		}
+		backoff = min(backoff*2, sinkRetryMax)
+	}
+}
+
+func (w *SinkWriter) shipOnce(batch []sinkLine) error {
+	ctx, cancel := context.WithTimeout(w.ctx, sinkWriteTimeout)
+	defer cancel()
+	return w.ship.ship(ctx, batch)
+}
+
+// drain makes a single attempt at shipping the pending batch and the lines
+// still buffered once the writer is closed. Lines that can't be shipped are
+// dropped.
+func (w *SinkWriter) drain(batch []sinkLine, batchSize int) {
+	for {
+		for len(batch) < batchSize {
+			select {
+			case line := <-w.lines:
+				batch = append(batch, line)
+				continue
+			default:
+			}
+			break
+		}
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := w.shipOnce(batch); err != nil {
+			w.dropped.Add(uint64(len(batch) + len(w.lines)))
+			w.logger.Warn("failed to ship buffered log lines", "error", err)
+			return
+		}
+		batch = nil
+	}
+}
+
+// reportDropped logs the number of log lines dropped since the last report.
+func (w *SinkWriter) reportDropped() {
+	if n := w.dropped.Swap(0); n > 0 {
+		w.logger.Warn("dropped log lines which could not be shipped", "dropped", n)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"time"
+)
+
+const (
+	// syslogFacilityUser is the syslog facility of task logs.
+	syslogFacilityUser = 1
+
+	// syslogSeverityErr and syslogSeverityInfo are the syslog severities of
+	// the stderr and stdout log lines.
+	syslogSeverityErr  = 3
+	syslogSeverityInfo = 6
+
+	// syslogTimeFormat is the RFC 5424 timestamp format.
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// connShipper ships log lines over a connection, which is established again
+// after writing to it fails.
+type connShipper struct {
+	network string
+	address string
+	format  func(sinkLine) []byte
+
+	conn net.Conn
+}
+
+func (s *connShipper) ship(ctx context.Context, lines []sinkLine) error {
+	if s.conn == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, s.network, s.address)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	conn := s.conn
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetWriteDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetWriteDeadline(time.Now())
+	})
+	defer stop()
+
+	for _, line := range lines {
+		if _, err := conn.Write(s.format(line)); err != nil {
+			conn.Close()
+			s.conn = nil
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *connShipper) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// newSyslogShipper returns a shipper sending log lines as RFC 5424 messages
+// to the udp://host:port, tcp://host:port or unix:///path syslog server.
+func newSyslogShipper(config *SinkConfig, stream string) (shipper, error) {
+	u, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog address %q: %v", config.Address, err)
+	}
+
+	severity := syslogSeverityInfo
+	if stream == "stderr" {
+		severity = syslogSeverityErr
+	}
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+	tag := config.Tag
+	if tag == "" {
+		tag = "-"
+	}
+	message := func(line sinkLine) string {
+		return fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+			syslogFacilityUser*8+severity, line.time.Format(syslogTimeFormat),
+			hostname, tag, stream, line.message)
+	}
+
+	s := &connShipper{
+		format: func(line sinkLine) []byte {
+			return []byte(message(line))
+		},
+	}
+	switch u.Scheme {
+	case "udp":
+		s.network, s.address = "udp", u.Host
+	case "tcp":
+		// Messages sent over a stream are framed by their length, as
+		// described by RFC 6587.
+		s.network, s.address = "tcp", u.Host
+		s.format = func(line sinkLine) []byte {
+			m := message(line)
+			return []byte(fmt.Sprintf("%d %s", len(m), m))
+		}
+	case "unix":
+		s.network, s.address = "unixgram", u.Path
+	default:
+		return nil, fmt.Errorf("unsupported syslog address scheme %q", u.Scheme)
+	}
+	return s, nil
+}
+
+// newSocketShipper returns a shipper writing newline delimited log lines to a
+// unix socket.
+func newSocketShipper(config *SinkConfig) (shipper, error) {
+	if !path.IsAbs(config.Address) {
+		return nil, fmt.Errorf("socket address %q must be an absolute path", config.Address)
+	}
+	return &connShipper{
+		network: "unix",
+		address: config.Address,
+		format: func(line sinkLine) []byte {
+			return []byte(line.message + "\n")
+		},
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+)
+
+// httpLogEntry is a log line in the JSON array posted by http sinks.
+type httpLogEntry struct {
+	Time    time.Time `json:"time"`
+	Stream  string    `json:"stream"`
+	Tag     string    `json:"tag,omitempty"`
+	Message string    `json:"message"`
+}
+
+// httpShipper posts batches of log lines as a JSON array to an HTTP endpoint.
+type httpShipper struct {
+	address string
+	headers map[string]string
+	stream  string
+	tag     string
+	client  *http.Client
+}
+
+func newHTTPShipper(config *SinkConfig, stream string) (shipper, error) {
+	u, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid http address %q: %v", config.Address, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported http address scheme %q", u.Scheme)
+	}
+
+	return &httpShipper{
+		address: config.Address,
+		headers: config.Headers,
+		stream:  stream,
+		tag:     config.Tag,
+		client: &http.Client{
+			Transport: cleanhttp.DefaultTransport(),
+			// Redirects could lead to hosts the client doesn't allow
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}, nil
+}
+
+func (s *httpShipper) ship(ctx context.Context, lines []sinkLine) error {
+	entries := make([]httpLogEntry, 0, len(lines))
+	for _, line := range lines {
+		entries = append(entries, httpLogEntry{
+			Time:    line.time,
+			Stream:  s.stream,
+			Tag:     s.tag,
+			Message: line.message,
+		})
+	}
+	body, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *httpShipper) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
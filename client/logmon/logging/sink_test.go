@@ -0,0 +1,385 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	"go.uber.org/goleak"
+)
+
+func TestSinkWriter_SyslogUDP(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	must.NoError(t, err)
+	defer conn.Close()
+
+	w, err := NewSinkWriter(&SinkConfig{
+		Type:    SinkTypeSyslog,
+		Address: "udp://" + conn.LocalAddr().String(),
+		Tag:     "web",
+	}, "stdout", testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("hello\nwor"))
+	must.NoError(t, err)
+	_, err = w.Write([]byte("ld\n"))
+	must.NoError(t, err)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 1024)
+	for _, expected := range []string{"hello", "world"} {
+		n, _, err := conn.ReadFrom(buf)
+		must.NoError(t, err)
+		msg := string(buf[:n])
+		must.StrHasPrefix(t, "<14>1 ", msg)
+		must.StrHasSuffix(t, " web - stdout - "+expected, msg)
+	}
+}
+
+func TestSinkWriter_SyslogTCP(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	defer l.Close()
+
+	w, err := NewSinkWriter(&SinkConfig{
+		Type:    SinkTypeSyslog,
+		Address: "tcp://" + l.Addr().String(),
+		Tag:     "web",
+	}, "stderr", testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("oops\n"))
+	must.NoError(t, err)
+
+	conn, err := l.Accept()
+	must.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	// Messages are framed by their length
+	r := bufio.NewReader(conn)
+	length, err := r.ReadString(' ')
+	must.NoError(t, err)
+	var n int
+	_, err = fmt.Sscanf(length, "%d ", &n)
+	must.NoError(t, err)
+
+	msg := make([]byte, n)
+	_, err = r.Read(msg)
+	must.NoError(t, err)
+	must.StrHasPrefix(t, "<11>1 ", string(msg))
+	must.StrHasSuffix(t, " web - stderr - oops", string(msg))
+}
+
+func TestSinkWriter_Socket(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	// Unix socket paths are limited in length, so avoid the long paths of
+	// t.TempDir
+	dir, err := os.MkdirTemp("", "sink")
+	must.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "logs.sock")
+	l, err := net.Listen("unix", path)
+	must.NoError(t, err)
+	defer l.Close()
+
+	w, err := NewSinkWriter(&SinkConfig{
+		Type:    SinkTypeSocket,
+		Address: path,
+	}, "stdout", testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	_, err = w.Write([]byte("hello\r\nworld\nunterminated"))
+	must.NoError(t, err)
+
+	conn, err := l.Accept()
+	must.NoError(t, err)
+	defer conn.Close()
+
+	// Closing the writer ships the incomplete line
+	must.NoError(t, w.Close())
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var lines []string
+	s := bufio.NewScanner(conn)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	must.Eq(t, []string{"hello", "world", "unterminated"}, lines)
+}
+
+// testHTTPSink is an HTTP server recording the log entries posted to it.
+type testHTTPSink struct {
+	*httptest.Server
+
+	lock    sync.Mutex
+	batches [][]httpLogEntry
+	headers []http.Header
+}
+
+func newTestHTTPSink(handler func(w http.ResponseWriter, r *http.Request)) *testHTTPSink {
+	s := &testHTTPSink{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler != nil {
+			handler(w, r)
+		}
+
+		var batch []httpLogEntry
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.batches = append(s.batches, batch)
+		s.headers = append(s.headers, r.Header)
+	}))
+	return s
+}
+
+func (s *testHTTPSink) messages() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var messages []string
+	for _, batch := range s.batches {
+		for _, entry := range batch {
+			messages = append(messages, entry.Message)
+		}
+	}
+	return messages
+}
+
+func TestSinkWriter_HTTP(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ts := newTestHTTPSink(nil)
+	defer ts.Close()
+
+	w, err := NewSinkWriter(&SinkConfig{
+		Type:          SinkTypeHTTP,
+		Address:       ts.URL,
+		Tag:           "web",
+		Headers:       map[string]string{"Authorization": "Bearer secret"},
+		BatchSize:     2,
+		BatchInterval: time.Hour,
+	}, "stdout", testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	_, err = w.Write([]byte("one\ntwo\nthree\n"))
+	must.NoError(t, err)
+
+	// The partial batch is shipped when the writer is closed
+	must.NoError(t, w.Close())
+
+	must.Eq(t, []string{"one", "two", "three"}, ts.messages())
+	must.Len(t, 2, ts.batches)
+	must.Len(t, 2, ts.batches[0])
+	must.Eq(t, "stdout", ts.batches[0][0].Stream)
+	must.Eq(t, "web", ts.batches[0][0].Tag)
+	must.Eq(t, "Bearer secret", ts.headers[0].Get("Authorization"))
+}
+
+func TestSinkWriter_HTTP_Retry(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var lock sync.Mutex
+	failures := 1
+	ts := newTestHTTPSink(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	defer ts.Close()
+
+	w, err := NewSinkWriter(&SinkConfig{
+		Type:          SinkTypeHTTP,
+		Address:       ts.URL,
+		BatchSize:     1,
+		BatchInterval: time.Millisecond,
+	}, "stdout", testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("hello\n"))
+	must.NoError(t, err)
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			return len(ts.messages()) == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
+
+func TestSinkWriter_FullBuffer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	// The sink blocks until released, so the writer buffer fills up
+	releaseCh := make(chan struct{})
+	ts := newTestHTTPSink(func(w http.ResponseWriter, r *http.Request) {
+		<-releaseCh
+	})
+	defer ts.Close()
+
+	w, err := NewSinkWriter(&SinkConfig{
+		Type:          SinkTypeHTTP,
+		Address:       ts.URL,
+		BufferSize:    2,
+		BatchSize:     1,
+		BatchInterval: time.Millisecond,
+	}, "stdout", testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	// Writes must not block on the sink
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			w.Write([]byte(fmt.Sprintf("line %d\n", i)))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("writes blocked on a full sink")
+	}
+	must.Positive(t, w.dropped.Load())
+
+	close(releaseCh)
+	must.NoError(t, w.Close())
+
+	messages := ts.messages()
+	must.SliceNotEmpty(t, messages)
+	must.Less(t, 100, len(messages))
+	must.Eq(t, "line 0", messages[0])
+}
+
+func TestNewSinkWriter_Invalid(t *testing.T) {
+	cases := []struct {
+		config *SinkConfig
+		err    string
+	}{
+		{
+			config: &SinkConfig{Type: "kafka", Address: "kafka:9092"},
+			err:    `unknown sink type "kafka"`,
+		},
+		{
+			config: &SinkConfig{Type: SinkTypeSyslog, Address: "http://localhost:514"},
+			err:    `unsupported syslog address scheme "http"`,
+		},
+		{
+			config: &SinkConfig{Type: SinkTypeSocket, Address: "logs.sock"},
+			err:    "must be an absolute path",
+		},
+		{
+			config: &SinkConfig{Type: SinkTypeHTTP, Address: "tcp://localhost:8080"},
+			err:    `unsupported http address scheme "tcp"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.config.Type, func(t *testing.T) {
+			_, err := NewSinkWriter(tc.config, "stdout", testlog.HCLogger(t))
+			must.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestSinkWriter_LongLine(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ts := newTestHTTPSink(nil)
+	defer ts.Close()
+
+	w, err := NewSinkWriter(&SinkConfig{
+		Type:          SinkTypeHTTP,
+		Address:       ts.URL,
+		BatchSize:     10,
+		BatchInterval: time.Hour,
+	}, "stdout", testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	// Lines without a newline are split once they reach the maximum size
+	_, err = w.Write([]byte(strings.Repeat("a", maxSinkLineSize+10)))
+	must.NoError(t, err)
+	must.NoError(t, w.Close())
+
+	messages := ts.messages()
+	must.Len(t, 2, messages)
+	must.Eq(t, maxSinkLineSize, len(messages[0]))
+	must.Eq(t, 10, len(messages[1]))
+}
+
+func TestSinkAllowlist_Check(t *testing.T) {
+	allowedDir := t.TempDir()
+	otherDir := t.TempDir()
+
+	// A symlink in the allowed directory to a socket outside of it
+	link := filepath.Join(allowedDir, "link.sock")
+	must.NoError(t, os.WriteFile(filepath.Join(otherDir, "other.sock"), nil, 0o600))
+	must.NoError(t, os.Symlink(filepath.Join(otherDir, "other.sock"), link))
+
+	allowlist := &SinkAllowlist{
+		Hosts:      []string{"logs.example.com", "10.0.0.1:514"},
+		SocketDirs: []string{allowedDir},
+	}
+
+	cases := []struct {
+		name    string
+		sink    *SinkConfig
+		allowed bool
+	}{
+		{"http host", &SinkConfig{Type: SinkTypeHTTP, Address: "https://logs.example.com/ingest"}, true},
+		{"http host any port", &SinkConfig{Type: SinkTypeHTTP, Address: "http://LOGS.example.com:8080/"}, true},
+		{"http other host", &SinkConfig{Type: SinkTypeHTTP, Address: "http://169.254.169.254/latest/meta-data"}, false},
+		{"syslog host and port", &SinkConfig{Type: SinkTypeSyslog, Address: "udp://10.0.0.1:514"}, true},
+		{"syslog other port", &SinkConfig{Type: SinkTypeSyslog, Address: "tcp://10.0.0.1:22"}, false},
+		{"syslog unix", &SinkConfig{Type: SinkTypeSyslog, Address: "unix://" + filepath.Join(allowedDir, "log")}, true},
+		{"syslog unix other dir", &SinkConfig{Type: SinkTypeSyslog, Address: "unix:///dev/log"}, false},
+		{"socket", &SinkConfig{Type: SinkTypeSocket, Address: filepath.Join(allowedDir, "logs.sock")}, true},
+		{"socket escape", &SinkConfig{Type: SinkTypeSocket, Address: filepath.Join(allowedDir, "..", "logs.sock")}, false},
+		{"socket symlink", &SinkConfig{Type: SinkTypeSocket, Address: link}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := allowlist.Check(tc.sink)
+			if tc.allowed {
+				must.NoError(t, err)
+			} else {
+				must.Error(t, err)
+			}
+		})
+	}
+
+	// Nothing is allowed by an empty allowlist
+	must.Error(t, (&SinkAllowlist{}).Check(cases[0].sink))
+}
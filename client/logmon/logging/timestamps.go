@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	// timestampIndexExt is the extension of the timestamp index of a log file.
+	timestampIndexExt = ".idx"
+
+	// timestampRecordSize is the size of a record in a timestamp index: the
+	// time in unix nanoseconds and the offset of the line, both as big endian
+	// int64s.
+	timestampRecordSize = 16
+
+	// timestampIndexBufferSize is the size of the buffer of the timestamp
+	// index being written.
+	timestampIndexBufferSize = 4 * 1024
+)
+
+// TimestampIndexName returns the name of the timestamp index of a log file.
+// The index is hidden so it is never mistaken for a log file, and is shared by
+// the uncompressed and compressed copies of the log file.
+func TimestampIndexName(name string) string {
+	name, _ = SplitCompressedExt(name)
+	return "." + name + timestampIndexExt
+}
+
+// TimestampRecord is a record of a timestamp index. All the lines from Offset
+// in the log file up to the offset of the next record were written at Time.
+type TimestampRecord struct {
+	Time   time.Time
+	Offset int64
+}
+
+// ReadTimestampIndex returns the records of a timestamp index. A trailing
+// partial record, still being written, is ignored.
+func ReadTimestampIndex(r io.Reader) ([]TimestampRecord, error) {
+	var records []TimestampRecord
+	buf := make([]byte, timestampRecordSize)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return records, nil
+			}
+			return nil, err
+		}
+		records = append(records, TimestampRecord{
+			Time:   time.Unix(0, int64(binary.BigEndian.Uint64(buf[:8]))),
+			Offset: int64(binary.BigEndian.Uint64(buf[8:])),
+		})
+	}
+}
+
+// SearchTimestampIndex returns the offset of the first line written at or
+// after t, and false if all the lines in the index were written before t.
+func SearchTimestampIndex(records []TimestampRecord, t time.Time) (int64, bool) {
+	i := sort.Search(len(records), func(i int) bool {
+		return !records[i].Time.Before(t)
+	})
+	if i == len(records) {
+		return 0, false
+	}
+	return records[i].Offset, true
+}
+
+// appendTimestampRecord appends the encoded record of a line written at t to
+// the offset of a log file.
+func appendTimestampRecord(b []byte, t time.Time, offset int64) []byte {
+	b = binary.BigEndian.AppendUint64(b, uint64(t.UnixNano()))
+	return binary.BigEndian.AppendUint64(b, uint64(offset))
+}
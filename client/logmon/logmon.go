@@ -44,6 +44,19 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// RotateInterval is the max age of a log file before rotation occurs
+	RotateInterval time.Duration
+
+	// Compression is the algorithm rotated log files are compressed with
+	Compression string
+
+	// Timestamps enables recording the time each log line was written
+	Timestamps bool
+
+	// Sinks are the external destinations logs are shipped to in addition to
+	// the log files
+	Sinks []*logging.SinkConfig
 }
 
 type LogMon interface {
@@ -147,8 +160,16 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
+	lro.RotateInterval = cfg.RotateInterval
+	lro.Compression = cfg.Compression
+	lro.TimestampIndex = cfg.Timestamps
+
+	stdout, err := withSinks(lro, cfg.Sinks, "stdout", logger)
+	if err != nil {
+		return nil, err
+	}
 
-	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, stdout)
 	if err != nil {
 		return nil, err
 	}
@@ -160,8 +181,16 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
+	lre.RotateInterval = cfg.RotateInterval
+	lre.Compression = cfg.Compression
+	lre.TimestampIndex = cfg.Timestamps
 
-	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
+	stderr, err := withSinks(lre, cfg.Sinks, "stderr", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, stderr)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +201,54 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 
 }
 
+// withSinks returns a writer copying the logs of the stream written to the
+// rotator to the sinks as well.
+func withSinks(rotator io.WriteCloser, sinks []*logging.SinkConfig, stream string, logger hclog.Logger) (io.WriteCloser, error) {
+	if len(sinks) == 0 {
+		return rotator, nil
+	}
+
+	w := &sinkTeeWriter{rotator: rotator}
+	for _, sink := range sinks {
+		sw, err := logging.NewSinkWriter(sink, stream, logger)
+		if err != nil {
+			w.Close()
+			return nil, fmt.Errorf("failed to create %s log sink: %v", sink.Type, err)
+		}
+		w.sinks = append(w.sinks, sw)
+	}
+	return w, nil
+}
+
+// sinkTeeWriter writes logs to the rotator and its sinks. Sinks never fail
+// writes, so only the errors of the rotator are returned.
+type sinkTeeWriter struct {
+	rotator io.WriteCloser
+	sinks   []*logging.SinkWriter
+}
+
+func (w *sinkTeeWriter) Write(p []byte) (int, error) {
+	for _, sink := range w.sinks {
+		sink.Write(p)
+	}
+	return w.rotator.Write(p)
+}
+
+// Close closes the sinks concurrently, as each may wait to ship its buffered
+// logs, and then the rotator.
+func (w *sinkTeeWriter) Close() error {
+	var wg sync.WaitGroup
+	for _, sink := range w.sinks {
+		wg.Add(1)
+		go func(sink *logging.SinkWriter) {
+			defer wg.Done()
+			sink.Close()
+		}(sink)
+	}
+	wg.Wait()
+	return w.rotator.Close()
+}
+
 // logRotatorWrapper wraps our log rotator and exposes a pipe that can feed the
 // log rotator data. The processOutWriter should be attached to the process and
 // data will be copied from the reader to the rotator.
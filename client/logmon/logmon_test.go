@@ -6,13 +6,16 @@ package logmon
 import (
 	"crypto/rand"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/testutil"
@@ -76,6 +79,68 @@ func TestLogmon_Start_rotate(t *testing.T) {
 	must.NoError(t, lm.Stop())
 }
 
+// asserts that logs are shipped to the sinks as well as written to the log
+// files
+func TestLogmon_Start_sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	must.NoError(t, err)
+	defer conn.Close()
+
+	var stdoutFifoPath, stderrFifoPath string
+
+	dir := t.TempDir()
+
+	if runtime.GOOS == "windows" {
+		stdoutFifoPath = "//./pipe/test-sinks.stdout"
+		stderrFifoPath = "//./pipe/test-sinks.stderr"
+	} else {
+		stdoutFifoPath = filepath.Join(dir, "stdout.fifo")
+		stderrFifoPath = filepath.Join(dir, "stderr.fifo")
+	}
+
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    stdoutFifoPath,
+		StderrLogFile: "stderr",
+		StderrFifo:    stderrFifoPath,
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Sinks: []*logging.SinkConfig{{
+			Type:    logging.SinkTypeSyslog,
+			Address: "udp://" + conn.LocalAddr().String(),
+			Tag:     "web",
+		}},
+	}
+
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.NoError(t, lm.Start(cfg))
+	defer lm.Stop()
+
+	stdout, err := fifo.OpenWriter(stdoutFifoPath)
+	must.NoError(t, err)
+	_, err = stdout.Write([]byte("hello\n"))
+	must.NoError(t, err)
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	must.NoError(t, err)
+	must.StrHasSuffix(t, " web - stdout - hello", string(buf[:n]))
+
+	testutil.WaitForResult(func() (bool, error) {
+		raw, err := os.ReadFile(filepath.Join(dir, "stdout.0"))
+		if err != nil {
+			return false, err
+		}
+		return string(raw) == "hello\n", fmt.Errorf("unexpected log file contents: %q", raw)
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
 // asserts that calling Start twice restarts the log rotator and that any logs
 // published while the listener was unavailable are received.
 func TestLogmon_Start_restart_flusheslogs(t *testing.T) {
@@ -25,16 +25,20 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StartRequest struct {
-	LogDir               string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
-	StdoutFileName       string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
-	StderrFileName       string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
-	MaxFiles             uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
-	MaxFileSizeMb        uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
-	StdoutFifo           string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
-	StderrFifo           string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LogDir               string     `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
+	StdoutFileName       string     `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
+	StderrFileName       string     `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
+	MaxFiles             uint32     `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxFileSizeMb        uint32     `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
+	StdoutFifo           string     `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
+	StderrFifo           string     `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
+	Sinks                []*LogSink `protobuf:"bytes,8,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	Compression          string     `protobuf:"bytes,9,opt,name=compression,proto3" json:"compression,omitempty"`
+	RotateIntervalNs     int64      `protobuf:"varint,10,opt,name=rotate_interval_ns,json=rotateIntervalNs,proto3" json:"rotate_interval_ns,omitempty"`
+	Timestamps           bool       `protobuf:"varint,11,opt,name=timestamps,proto3" json:"timestamps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -111,6 +115,34 @@ func (m *StartRequest) GetStderrFifo() string {
 	return ""
 }
 
+func (m *StartRequest) GetSinks() []*LogSink {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+func (m *StartRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *StartRequest) GetRotateIntervalNs() int64 {
+	if m != nil {
+		return m.RotateIntervalNs
+	}
+	return 0
+}
+
+func (m *StartRequest) GetTimestamps() bool {
+	if m != nil {
+		return m.Timestamps
+	}
+	return false
+}
+
 type StartResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -204,11 +236,100 @@ func (m *StopResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopResponse proto.InternalMessageInfo
 
+type LogSink struct {
+	Type                 string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Address              string            `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Tag                  string            `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	Headers              map[string]string `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BufferSize           uint32            `protobuf:"varint,5,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	BatchSize            uint32            `protobuf:"varint,6,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	BatchIntervalNs      int64             `protobuf:"varint,7,opt,name=batch_interval_ns,json=batchIntervalNs,proto3" json:"batch_interval_ns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LogSink) Reset()         { *m = LogSink{} }
+func (m *LogSink) String() string { return proto.CompactTextString(m) }
+func (*LogSink) ProtoMessage()    {}
+func (*LogSink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{4}
+}
+
+func (m *LogSink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogSink.Unmarshal(m, b)
+}
+func (m *LogSink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogSink.Marshal(b, m, deterministic)
+}
+func (m *LogSink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogSink.Merge(m, src)
+}
+func (m *LogSink) XXX_Size() int {
+	return xxx_messageInfo_LogSink.Size(m)
+}
+func (m *LogSink) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogSink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogSink proto.InternalMessageInfo
+
+func (m *LogSink) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LogSink) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LogSink) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *LogSink) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *LogSink) GetBufferSize() uint32 {
+	if m != nil {
+		return m.BufferSize
+	}
+	return 0
+}
+
+func (m *LogSink) GetBatchSize() uint32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *LogSink) GetBatchIntervalNs() int64 {
+	if m != nil {
+		return m.BatchIntervalNs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*StartRequest)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest")
 	proto.RegisterType((*StartResponse)(nil), "hashicorp.nomad.client.logmon.proto.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "hashicorp.nomad.client.logmon.proto.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "hashicorp.nomad.client.logmon.proto.StopResponse")
+	proto.RegisterType((*LogSink)(nil), "hashicorp.nomad.client.logmon.proto.LogSink")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.client.logmon.proto.LogSink.HeadersEntry")
 }
 
 func init() {
@@ -216,27 +337,41 @@ func init() {
 }
 
 var fileDescriptor_be72d5e24d2ecba6 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x31, 0x6f, 0xc2, 0x30,
-	0x10, 0x85, 0x1b, 0x0a, 0xa1, 0x1c, 0x0d, 0x45, 0x5e, 0x1a, 0xd1, 0xa1, 0x28, 0x1d, 0xca, 0x14,
-	0x0a, 0xfd, 0x07, 0x55, 0xd5, 0xa9, 0x74, 0x80, 0xad, 0x4b, 0x64, 0xc0, 0x09, 0x96, 0x62, 0x5f,
-	0x6a, 0x1b, 0x09, 0xb1, 0xf6, 0xd7, 0xf6, 0x5f, 0x54, 0x71, 0x4c, 0x94, 0x11, 0x26, 0xeb, 0xee,
-	0x7d, 0x4f, 0xf7, 0xfc, 0x60, 0xbc, 0xc9, 0x39, 0x93, 0x66, 0x9a, 0x63, 0x26, 0x50, 0x4e, 0x0b,
-	0x85, 0x06, 0xdd, 0x10, 0xdb, 0x81, 0x3c, 0xed, 0xa8, 0xde, 0xf1, 0x0d, 0xaa, 0x22, 0x96, 0x28,
-	0xe8, 0x36, 0xae, 0x1c, 0x71, 0x13, 0x8a, 0x7e, 0x5b, 0x70, 0xbb, 0x32, 0x54, 0x99, 0x25, 0xfb,
-	0xd9, 0x33, 0x6d, 0xc8, 0x3d, 0x74, 0x73, 0xcc, 0x92, 0x2d, 0x57, 0xa1, 0x37, 0xf6, 0x26, 0xbd,
-	0xa5, 0x9f, 0x63, 0xf6, 0xce, 0x15, 0x99, 0xc0, 0x50, 0x9b, 0x2d, 0xee, 0x4d, 0x92, 0xf2, 0x9c,
-	0x25, 0x92, 0x0a, 0x16, 0xb6, 0x2c, 0x31, 0xa8, 0xf6, 0x1f, 0x3c, 0x67, 0x5f, 0x54, 0x30, 0x47,
-	0x32, 0xa5, 0x1a, 0xe4, 0x75, 0x4d, 0x32, 0xa5, 0x6a, 0xf2, 0x01, 0x7a, 0x82, 0x1e, 0x2c, 0xa6,
-	0xc3, 0xf6, 0xd8, 0x9b, 0x04, 0xcb, 0x1b, 0x41, 0x0f, 0xa5, 0xae, 0xc9, 0x33, 0x0c, 0x4f, 0x62,
-	0xa2, 0xf9, 0x91, 0x25, 0x62, 0x1d, 0x76, 0x2c, 0x13, 0x38, 0x66, 0xc5, 0x8f, 0x6c, 0xb1, 0x26,
-	0x8f, 0xd0, 0xaf, 0x93, 0xa5, 0x18, 0xfa, 0xf6, 0x14, 0x9c, 0x42, 0xa5, 0xe8, 0x80, 0x2a, 0x50,
-	0x8a, 0x61, 0xb7, 0x06, 0x6c, 0x96, 0x14, 0xa3, 0x3b, 0x08, 0x5c, 0x09, 0xba, 0x40, 0xa9, 0x59,
-	0x14, 0x40, 0x7f, 0x65, 0xb0, 0x70, 0xa5, 0x44, 0x83, 0xb2, 0xa4, 0x72, 0xac, 0xe4, 0xf9, 0x9f,
-	0x07, 0xfe, 0x27, 0x66, 0x0b, 0x94, 0xa4, 0x80, 0x8e, 0xb5, 0x92, 0x59, 0x7c, 0x46, 0xdf, 0x71,
-	0xb3, 0xeb, 0xd1, 0xfc, 0x12, 0x8b, 0x4b, 0x76, 0x45, 0x04, 0xb4, 0xcb, 0x30, 0xe4, 0xe5, 0x4c,
-	0x77, 0xfd, 0x8d, 0xd1, 0xec, 0x02, 0xc7, 0xe9, 0xdc, 0x5b, 0xf7, 0xbb, 0x63, 0xf7, 0x6b, 0xdf,
-	0x3e, 0xaf, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xb4, 0x87, 0xfc, 0x7a, 0x02, 0x00, 0x00,
+	// 543 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x51, 0x6f, 0xd3, 0x3c,
+	0x14, 0xfd, 0xb2, 0xae, 0x4d, 0x7b, 0xdb, 0x6e, 0xfd, 0x2c, 0x24, 0xa2, 0x22, 0x20, 0x2a, 0x0f,
+	0x44, 0x68, 0xca, 0x58, 0x79, 0x81, 0x3d, 0x4e, 0x80, 0x40, 0xda, 0xf6, 0x90, 0xbe, 0xf1, 0x12,
+	0xb9, 0x8d, 0x93, 0x5a, 0x8d, 0xed, 0x60, 0xbb, 0xd3, 0xba, 0x3f, 0xc6, 0x3f, 0xe0, 0xbf, 0xf0,
+	0x2f, 0x50, 0x6c, 0xa7, 0xe4, 0xb1, 0x7d, 0xaa, 0xef, 0x3d, 0xe7, 0xf6, 0x1e, 0x9f, 0xe3, 0x40,
+	0xb8, 0x2a, 0x29, 0xe1, 0xfa, 0xb2, 0x14, 0x05, 0x13, 0xfc, 0xb2, 0x92, 0x42, 0x0b, 0x57, 0xc4,
+	0xa6, 0x40, 0x6f, 0xd6, 0x58, 0xad, 0xe9, 0x4a, 0xc8, 0x2a, 0xe6, 0x82, 0xe1, 0x2c, 0xb6, 0x13,
+	0x71, 0x9b, 0x34, 0xfb, 0xd5, 0x81, 0xd1, 0x42, 0x63, 0xa9, 0x13, 0xf2, 0x73, 0x4b, 0x94, 0x46,
+	0xcf, 0xc1, 0x2f, 0x45, 0x91, 0x66, 0x54, 0x06, 0x5e, 0xe8, 0x45, 0x83, 0xa4, 0x57, 0x8a, 0xe2,
+	0x33, 0x95, 0x28, 0x82, 0x89, 0xd2, 0x99, 0xd8, 0xea, 0x34, 0xa7, 0x25, 0x49, 0x39, 0x66, 0x24,
+	0x38, 0x31, 0x8c, 0x33, 0xdb, 0xff, 0x4a, 0x4b, 0x72, 0x8f, 0x19, 0x71, 0x4c, 0x22, 0x65, 0x8b,
+	0xd9, 0xd9, 0x33, 0x89, 0x94, 0x7b, 0xe6, 0x0b, 0x18, 0x30, 0xfc, 0x68, 0x68, 0x2a, 0x38, 0x0d,
+	0xbd, 0x68, 0x9c, 0xf4, 0x19, 0x7e, 0xac, 0x71, 0x85, 0xde, 0xc2, 0xa4, 0x01, 0x53, 0x45, 0x9f,
+	0x48, 0xca, 0x96, 0x41, 0xd7, 0x70, 0xc6, 0x8e, 0xb3, 0xa0, 0x4f, 0xe4, 0x6e, 0x89, 0x5e, 0xc3,
+	0x70, 0xaf, 0x2c, 0x17, 0x41, 0xcf, 0xac, 0x82, 0x46, 0x54, 0x2e, 0x1c, 0xc1, 0x0a, 0xca, 0x45,
+	0xe0, 0xef, 0x09, 0x46, 0x4b, 0x2e, 0xd0, 0x0d, 0x74, 0x15, 0xe5, 0x1b, 0x15, 0xf4, 0xc3, 0x4e,
+	0x34, 0x9c, 0x5f, 0xc4, 0x07, 0x58, 0x17, 0xdf, 0x8a, 0x62, 0x41, 0xf9, 0x26, 0xb1, 0xa3, 0x28,
+	0x84, 0xe1, 0x4a, 0xb0, 0x4a, 0x12, 0xa5, 0xa8, 0xe0, 0xc1, 0xc0, 0x2c, 0x69, 0xb7, 0xd0, 0x05,
+	0x20, 0x29, 0x34, 0xd6, 0x24, 0xa5, 0x5c, 0x13, 0xf9, 0x80, 0xcb, 0x94, 0xab, 0x00, 0x42, 0x2f,
+	0xea, 0x24, 0x13, 0x8b, 0x7c, 0x77, 0xc0, 0xbd, 0x42, 0xaf, 0x00, 0x34, 0x65, 0x44, 0x69, 0xcc,
+	0x2a, 0x15, 0x0c, 0x43, 0x2f, 0xea, 0x27, 0xad, 0xce, 0xec, 0x1c, 0xc6, 0x2e, 0x38, 0x55, 0x09,
+	0xae, 0xc8, 0x6c, 0x0c, 0xc3, 0x85, 0x16, 0x95, 0x0b, 0x72, 0x76, 0x06, 0x23, 0x5b, 0x3a, 0xf8,
+	0xf7, 0x09, 0xf8, 0x4e, 0x32, 0x42, 0x70, 0xaa, 0x77, 0x15, 0x71, 0x09, 0x9b, 0x33, 0x0a, 0xc0,
+	0xc7, 0x59, 0x56, 0x6b, 0x75, 0xb1, 0x36, 0x25, 0x9a, 0x40, 0x47, 0xe3, 0xc2, 0x45, 0x58, 0x1f,
+	0xd1, 0x02, 0xfc, 0x35, 0xc1, 0x19, 0x91, 0x75, 0x6a, 0xb5, 0x63, 0x9f, 0x8e, 0x71, 0x2c, 0xfe,
+	0x66, 0x67, 0xbf, 0x70, 0x2d, 0x77, 0x49, 0xf3, 0x4f, 0x75, 0x4a, 0xcb, 0x6d, 0x9e, 0x13, 0x69,
+	0xd2, 0x76, 0x51, 0x83, 0x6d, 0xd5, 0x49, 0xa3, 0x97, 0x00, 0x4b, 0xac, 0x57, 0x6b, 0x8b, 0xf7,
+	0x0c, 0x3e, 0x30, 0x1d, 0x03, 0xbf, 0x83, 0xff, 0x2d, 0xdc, 0x76, 0xd7, 0x37, 0xee, 0x9e, 0x1b,
+	0xe0, 0x9f, 0xb9, 0xd3, 0x6b, 0x18, 0xb5, 0x45, 0xd4, 0x57, 0xdc, 0x90, 0x9d, 0xf3, 0xa3, 0x3e,
+	0xa2, 0x67, 0xd0, 0x7d, 0xc0, 0xe5, 0xb6, 0x79, 0xe3, 0xb6, 0xb8, 0x3e, 0xf9, 0xe8, 0xcd, 0xff,
+	0x78, 0xd0, 0xbb, 0x15, 0xc5, 0x9d, 0xe0, 0xa8, 0x82, 0xae, 0xc9, 0x00, 0x5d, 0x1d, 0x74, 0xff,
+	0xf6, 0x87, 0x36, 0x9d, 0x1f, 0x33, 0xe2, 0x32, 0xfc, 0x0f, 0x31, 0x38, 0xad, 0x53, 0x45, 0xef,
+	0x0f, 0x9c, 0xde, 0xbf, 0x87, 0xe9, 0xd5, 0x11, 0x13, 0xcd, 0xba, 0x1b, 0xff, 0x47, 0xd7, 0xf4,
+	0x97, 0x3d, 0xf3, 0xf3, 0xe1, 0xef, 0x00, 0x12, 0x90, 0xed, 0x2b, 0x77, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
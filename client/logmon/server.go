@@ -5,8 +5,10 @@ package logmon
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	"github.com/hashicorp/nomad/client/logmon/proto"
 )
 
@@ -17,13 +19,17 @@ type logmonServer struct {
 
 func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*proto.StartResponse, error) {
 	cfg := &LogConfig{
-		LogDir:        req.LogDir,
-		StdoutLogFile: req.StdoutFileName,
-		StderrLogFile: req.StderrFileName,
-		MaxFiles:      int(req.MaxFiles),
-		MaxFileSizeMB: int(req.MaxFileSizeMb),
-		StdoutFifo:    req.StdoutFifo,
-		StderrFifo:    req.StderrFifo,
+		LogDir:         req.LogDir,
+		StdoutLogFile:  req.StdoutFileName,
+		StderrLogFile:  req.StderrFileName,
+		MaxFiles:       int(req.MaxFiles),
+		MaxFileSizeMB:  int(req.MaxFileSizeMb),
+		StdoutFifo:     req.StdoutFifo,
+		StderrFifo:     req.StderrFifo,
+		Sinks:          sinksFromProto(req.Sinks),
+		Compression:    req.Compression,
+		RotateInterval: time.Duration(req.RotateIntervalNs),
+		Timestamps:     req.Timestamps,
 	}
 
 	err := s.impl.Start(cfg)
@@ -34,6 +40,26 @@ func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*pro
 	return resp, nil
 }
 
+func sinksFromProto(sinks []*proto.LogSink) []*logging.SinkConfig {
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	out := make([]*logging.SinkConfig, len(sinks))
+	for i, sink := range sinks {
+		out[i] = &logging.SinkConfig{
+			Type:          sink.Type,
+			Address:       sink.Address,
+			Tag:           sink.Tag,
+			Headers:       sink.Headers,
+			BufferSize:    int(sink.BufferSize),
+			BatchSize:     int(sink.BatchSize),
+			BatchInterval: time.Duration(sink.BatchIntervalNs),
+		}
+	}
+	return out
+}
+
 func (s *logmonServer) Stop(ctx context.Context, req *proto.StopRequest) (*proto.StopResponse, error) {
 	return &proto.StopResponse{}, s.impl.Stop()
 }
@@ -6,6 +6,7 @@ package checks
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -20,6 +21,10 @@ import (
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/helper/useragent"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"oss.indeed.com/go/libtime"
 )
 
@@ -35,7 +40,7 @@ type Checker interface {
 	Do(context.Context, *QueryContext, *Query) *structs.CheckQueryResult
 }
 
-// New creates a new Checker capable of executing HTTP and TCP checks.
+// New creates a new Checker capable of executing HTTP, TCP, and gRPC checks.
 func New(log hclog.Logger) Checker {
 	httpClient := cleanhttp.DefaultPooledClient()
 	httpClient.Timeout = maxTimeoutHTTP
@@ -66,6 +71,8 @@ func (c *checker) Do(ctx context.Context, qc *QueryContext, q *Query) *structs.C
 	switch q.Type {
 	case "http":
 		qr = c.checkHTTP(timeout, qc, q)
+	case "grpc":
+		qr = c.checkGRPC(timeout, qc, q)
 	default:
 		qr = c.checkTCP(timeout, qc, q)
 	}
@@ -141,6 +148,64 @@ func (c *checker) checkTCP(ctx context.Context, qc *QueryContext, q *Query) *str
 	return qr
 }
 
+func (c *checker) checkGRPC(ctx context.Context, qc *QueryContext, q *Query) *structs.CheckQueryResult {
+	qr := &structs.CheckQueryResult{
+		Mode:      q.Mode,
+		Timestamp: c.now(),
+		Status:    structs.CheckPending,
+	}
+
+	addr, err := address(qc, q)
+	if err != nil {
+		qr.Output = err.Error()
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+
+	creds := insecure.NewCredentials()
+	if q.GRPCUseTLS {
+		creds = credentials.NewTLS(&tls.Config{
+			ServerName:         q.TLSServerName,
+			InsecureSkipVerify: q.TLSSkipVerify,
+		})
+	}
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUserAgent(useragent.String()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		qr.Output = fmt.Sprintf("nomad: %s", err.Error())
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// query the grpc.health.v1 health service for the configured service,
+	// where an empty service name indicates the overall server health
+	response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: q.GRPCService,
+	})
+	if err != nil {
+		qr.Output = fmt.Sprintf("nomad: %s", err.Error())
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+
+	if status := response.GetStatus(); status != healthpb.HealthCheckResponse_SERVING {
+		qr.Output = fmt.Sprintf("nomad: grpc health status %s", status)
+		qr.Status = structs.CheckFailure
+		return qr
+	}
+
+	qr.Output = "nomad: grpc ok"
+	qr.Status = structs.CheckSuccess
+	return qr
+}
+
 func (c *checker) checkHTTP(ctx context.Context, qc *QueryContext, q *Query) *structs.CheckQueryResult {
 	qr := &structs.CheckQueryResult{
 		Mode:      q.Mode,
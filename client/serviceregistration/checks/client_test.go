@@ -21,6 +21,9 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"oss.indeed.com/go/libtime/libtimetest"
 )
 
@@ -538,3 +541,97 @@ func tcpServer(t *testing.T, ctx context.Context, port int) {
 		}
 	}()
 }
+
+func TestChecker_Do_GRPC(t *testing.T) {
+	ci.Parallel(t)
+
+	// create a mock clock so we can assert time is set
+	now := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	clock := libtimetest.NewClockMock(t).NowMock.Return(now)
+
+	// create a grpc server exposing the grpc.health.v1 service
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("ok.Service", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("down.Service", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	qc := &QueryContext{
+		ID:               "abc123",
+		CustomAddress:    "127.0.0.1",
+		ServicePortLabel: fmt.Sprintf("%d", port),
+		NetworkStatus:    mock.NewNetworkStatus("127.0.0.1"),
+		Group:            "group",
+		Task:             "task",
+		Service:          "service",
+		Check:            "check",
+	}
+
+	makeQuery := func(service string, useTLS bool) *Query {
+		return &Query{
+			Mode:        structs.Healthiness,
+			Type:        "grpc",
+			Timeout:     1 * time.Second,
+			AddressMode: "auto",
+			PortLabel:   fmt.Sprintf("%d", port),
+			GRPCService: service,
+			GRPCUseTLS:  useTLS,
+		}
+	}
+
+	cases := []struct {
+		name      string
+		q         *Query
+		expStatus structs.CheckStatus
+		expOutput string
+	}{{
+		name:      "server ok",
+		q:         makeQuery("", false),
+		expStatus: structs.CheckSuccess,
+		expOutput: "nomad: grpc ok",
+	}, {
+		name:      "service ok",
+		q:         makeQuery("ok.Service", false),
+		expStatus: structs.CheckSuccess,
+		expOutput: "nomad: grpc ok",
+	}, {
+		name:      "service not serving",
+		q:         makeQuery("down.Service", false),
+		expStatus: structs.CheckFailure,
+		expOutput: "nomad: grpc health status NOT_SERVING",
+	}, {
+		name:      "service unknown",
+		q:         makeQuery("unknown.Service", false),
+		expStatus: structs.CheckFailure,
+		expOutput: "nomad: rpc error: code = NotFound desc = unknown service",
+	}, {
+		name:      "tls against plaintext server",
+		q:         makeQuery("", true),
+		expStatus: structs.CheckFailure,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := testlog.HCLogger(t)
+
+			c := New(logger)
+			c.(*checker).clock = clock
+
+			result := c.Do(context.Background(), qc, tc.q)
+			must.Eq(t, tc.expStatus, result.Status)
+			must.Eq(t, now.Unix(), result.Timestamp)
+			must.Eq(t, "check", result.Check)
+			if tc.expOutput != "" {
+				must.Eq(t, tc.expOutput, result.Output)
+			}
+		})
+	}
+}
@@ -28,6 +28,11 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 		Method:      c.Method,
 		Headers:     maps.Clone(c.Header),
 		Body:        c.Body,
+
+		GRPCService:   c.GRPCService,
+		GRPCUseTLS:    c.GRPCUseTLS,
+		TLSServerName: c.TLSServerName,
+		TLSSkipVerify: c.TLSSkipVerify,
 	}
 }
 
@@ -35,7 +40,7 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 // amount of information needed to actually execute that check.
 type Query struct {
 	Mode structs.CheckMode // readiness or healthiness
-	Type string            // tcp or http
+	Type string            // tcp, http, or grpc
 
 	Timeout time.Duration // connection / request timeout
 
@@ -47,6 +52,11 @@ type Query struct {
 	Method   string      // http checks only
 	Headers  http.Header // http checks only
 	Body     string      // http checks only
+
+	GRPCService   string // grpc checks only
+	GRPCUseTLS    bool   // grpc checks only
+	TLSServerName string // grpc checks using tls only
+	TLSSkipVerify bool   // grpc checks using tls only
 }
 
 // A QueryContext contains allocation and service parameters necessary for
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nsd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// healthSyncInterval is the interval at which the health syncer inspects the
+// status of Nomad checks and updates service registrations.
+const healthSyncInterval = 2 * time.Second
+
+// trackedRegistration is a service registration along with the IDs of the
+// Nomad checks defined on the service.
+type trackedRegistration struct {
+	registration *structs.ServiceRegistration
+	checkIDs     []string
+}
+
+// healthSyncer propagates the pass/fail status of Nomad checks to the
+// ChecksFailing field of service registrations, so that servers can exclude
+// unhealthy instances when answering DNS queries.
+type healthSyncer struct {
+	log    hclog.Logger
+	getter serviceregistration.CheckStatusGetter
+	upsert func(*structs.ServiceRegistration) error
+
+	// upsertLock serializes the upserts of sync with untrack, so a
+	// registration is never upserted once it has been untracked and may be
+	// deleted.
+	upsertLock sync.Mutex
+
+	lock    sync.Mutex
+	tracked map[string]*trackedRegistration
+}
+
+func newHealthSyncer(
+	log hclog.Logger,
+	getter serviceregistration.CheckStatusGetter,
+	upsert func(*structs.ServiceRegistration) error) *healthSyncer {
+
+	return &healthSyncer{
+		log:     log,
+		getter:  getter,
+		upsert:  upsert,
+		tracked: make(map[string]*trackedRegistration),
+	}
+}
+
+// track starts tracking the health of the service registration. Services
+// without checks are always considered healthy and are therefore not tracked.
+func (h *healthSyncer) track(reg *structs.ServiceRegistration, checkIDs []string) {
+	if len(checkIDs) == 0 {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.tracked[reg.ID] = &trackedRegistration{
+		registration: reg.Copy(),
+		checkIDs:     checkIDs,
+	}
+}
+
+// untrack stops tracking the health of the service registration. It waits for
+// any upsert of the registration in progress to complete.
+func (h *healthSyncer) untrack(id string) {
+	h.upsertLock.Lock()
+	defer h.upsertLock.Unlock()
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.tracked, id)
+}
+
+// failing returns whether any of the checks are currently failing, according
+// to the provided check status snapshot.
+func failing(statuses map[string]string, checkIDs []string) bool {
+	for _, checkID := range checkIDs {
+		if statuses[checkID] == string(structs.CheckFailure) {
+			return true
+		}
+	}
+	return false
+}
+
+// run periodically syncs the check status of tracked registrations until the
+// shutdown channel is closed.
+func (h *healthSyncer) run(shutdownCh <-chan struct{}) {
+	ticker := time.NewTicker(healthSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case <-ticker.C:
+			h.sync()
+		}
+	}
+}
+
+// sync performs a single pass over the tracked registrations, upserting any
+// whose check status has changed.
+func (h *healthSyncer) sync() {
+	statuses, err := h.getter.Get()
+	if err != nil {
+		h.log.Warn("failed to get check statuses", "error", err)
+		return
+	}
+
+	var changed []*structs.ServiceRegistration
+
+	h.lock.Lock()
+	for _, t := range h.tracked {
+		isFailing := failing(statuses, t.checkIDs)
+		if isFailing == t.registration.ChecksFailing {
+			continue
+		}
+		reg := t.registration.Copy()
+		reg.ChecksFailing = isFailing
+		changed = append(changed, reg)
+	}
+	h.lock.Unlock()
+
+	for _, reg := range changed {
+		h.syncRegistration(reg)
+	}
+}
+
+// syncRegistration upserts the registration with its changed check status,
+// unless it was untracked since the change was detected.
+func (h *healthSyncer) syncRegistration(reg *structs.ServiceRegistration) {
+	h.upsertLock.Lock()
+	defer h.upsertLock.Unlock()
+
+	h.lock.Lock()
+	_, ok := h.tracked[reg.ID]
+	h.lock.Unlock()
+	if !ok {
+		return
+	}
+
+	if err := h.upsert(reg); err != nil {
+		h.log.Warn("failed to update service registration check status",
+			"service_id", reg.ID, "error", err)
+		return
+	}
+
+	// Only record the new status once the servers have it. The registration
+	// can't have been untracked in the meantime.
+	h.lock.Lock()
+	h.tracked[reg.ID].registration.ChecksFailing = reg.ChecksFailing
+	h.lock.Unlock()
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nsd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+type mockStatusGetter struct {
+	statuses map[string]string
+}
+
+func (m *mockStatusGetter) Get() (map[string]string, error) {
+	return m.statuses, nil
+}
+
+func TestHealthSyncer_sync(t *testing.T) {
+	ci.Parallel(t)
+
+	getter := &mockStatusGetter{statuses: map[string]string{
+		"check1": string(structs.CheckSuccess),
+		"check2": string(structs.CheckPending),
+	}}
+
+	var upserts []*structs.ServiceRegistration
+	var upsertErr error
+	h := newHealthSyncer(hclog.NewNullLogger(), getter, func(reg *structs.ServiceRegistration) error {
+		if upsertErr != nil {
+			return upsertErr
+		}
+		upserts = append(upserts, reg)
+		return nil
+	})
+
+	// Registrations without checks are not tracked.
+	h.track(&structs.ServiceRegistration{ID: "no-checks"}, nil)
+	must.MapLen(t, 0, h.tracked)
+
+	h.track(&structs.ServiceRegistration{ID: "reg1"}, []string{"check1", "check2"})
+
+	// Passing and pending checks do not trigger an update.
+	h.sync()
+	must.Len(t, 0, upserts)
+
+	// A failing check marks the registration as failing.
+	getter.statuses["check2"] = string(structs.CheckFailure)
+	h.sync()
+	must.Len(t, 1, upserts)
+	must.True(t, upserts[0].ChecksFailing)
+
+	// The update is only sent once.
+	h.sync()
+	must.Len(t, 1, upserts)
+
+	// A failed update is retried on the next sync.
+	getter.statuses["check2"] = string(structs.CheckSuccess)
+	upsertErr = errors.New("rpc failed")
+	h.sync()
+	must.Len(t, 1, upserts)
+
+	upsertErr = nil
+	h.sync()
+	must.Len(t, 2, upserts)
+	must.False(t, upserts[1].ChecksFailing)
+
+	// Untracked registrations are no longer updated.
+	h.untrack("reg1")
+	getter.statuses["check1"] = string(structs.CheckFailure)
+	h.sync()
+	must.Len(t, 2, upserts)
+}
+
+func TestHealthSyncer_untrackDuringSync(t *testing.T) {
+	ci.Parallel(t)
+
+	getter := &mockStatusGetter{statuses: map[string]string{
+		"check1": string(structs.CheckFailure),
+	}}
+
+	upsertingCh := make(chan struct{})
+	releaseCh := make(chan struct{})
+	h := newHealthSyncer(hclog.NewNullLogger(), getter, func(reg *structs.ServiceRegistration) error {
+		close(upsertingCh)
+		<-releaseCh
+		return nil
+	})
+	h.track(&structs.ServiceRegistration{ID: "reg1"}, []string{"check1"})
+
+	syncDoneCh := make(chan struct{})
+	go func() {
+		h.sync()
+		close(syncDoneCh)
+	}()
+	<-upsertingCh
+
+	// Untracking waits for the upsert in progress, so the registration can't
+	// be deleted before it is upserted again.
+	untrackDoneCh := make(chan struct{})
+	go func() {
+		h.untrack("reg1")
+		close(untrackDoneCh)
+	}()
+
+	select {
+	case <-untrackDoneCh:
+		t.Fatal("untrack should wait for the upsert to complete")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(releaseCh)
+	<-syncDoneCh
+	<-untrackDoneCh
+	must.MapLen(t, 0, h.tracked)
+}
@@ -24,6 +24,11 @@ type ServiceRegistrationHandler struct {
 	// and restarts associated tasks in accordance with their check_restart block.
 	checkWatcher serviceregistration.CheckWatcher
 
+	// healthSyncer propagates the status of Nomad checks to the service
+	// registrations held by the servers. It is nil if no CheckStatusGetter
+	// was configured.
+	healthSyncer *healthSyncer
+
 	// registrationEnabled tracks whether this handler is enabled for
 	// registrations. This is needed as it's possible a client has its config
 	// changed whilst allocations using this provider are running on it. In
@@ -62,6 +67,10 @@ type ServiceRegistrationHandlerCfg struct {
 	// CheckWatcher watches checks of services in the Nomad service provider,
 	// and restarts associated tasks in accordance with their check_restart block.
 	CheckWatcher serviceregistration.CheckWatcher
+
+	// CheckStatusGetter is used to read the current status of Nomad checks,
+	// so failing services can be marked as such within their registration.
+	CheckStatusGetter serviceregistration.CheckStatusGetter
 }
 
 // NewServiceRegistrationHandler returns a ready to use
@@ -69,13 +78,18 @@ type ServiceRegistrationHandlerCfg struct {
 // interface.
 func NewServiceRegistrationHandler(log hclog.Logger, cfg *ServiceRegistrationHandlerCfg) serviceregistration.Handler {
 	go cfg.CheckWatcher.Run(context.TODO())
-	return &ServiceRegistrationHandler{
+	s := &ServiceRegistrationHandler{
 		cfg:                 cfg,
 		log:                 log.Named("service_registration.nomad"),
 		registrationEnabled: cfg.Enabled,
 		checkWatcher:        cfg.CheckWatcher,
 		shutDownCh:          make(chan struct{}),
 	}
+	if cfg.CheckStatusGetter != nil {
+		s.healthSyncer = newHealthSyncer(s.log, cfg.CheckStatusGetter, s.upsertRegistration)
+		go s.healthSyncer.run(s.shutDownCh)
+	}
+	return s
 }
 
 func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistration.WorkloadServices) error {
@@ -130,6 +144,35 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 
 	var resp structs.ServiceRegistrationUpsertResponse
 
+	if err := s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp); err != nil {
+		return err
+	}
+
+	// Track the registrations which have checks, so their check status can
+	// be kept up to date.
+	if s.healthSyncer != nil {
+		for i, service := range workload.Services {
+			checkIDs := make([]string, 0, len(service.Checks))
+			for _, check := range service.Checks {
+				checkIDs = append(checkIDs, string(structs.NomadCheckID(workload.AllocInfo.AllocID, workload.AllocInfo.Group, check)))
+			}
+			s.healthSyncer.track(registrations[i], checkIDs)
+		}
+	}
+	return nil
+}
+
+// upsertRegistration sends a single service registration to the servers. It
+// is used to update an existing registration when its check status changes.
+func (s *ServiceRegistrationHandler) upsertRegistration(reg *structs.ServiceRegistration) error {
+	args := structs.ServiceRegistrationUpsertRequest{
+		Services: []*structs.ServiceRegistration{reg},
+		WriteRequest: structs.WriteRequest{
+			Region:    s.cfg.Region,
+			AuthToken: s.cfg.NodeSecret,
+		},
+	}
+	var resp structs.ServiceRegistrationUpsertResponse
 	return s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp)
 }
 
@@ -172,6 +215,10 @@ func (s *ServiceRegistrationHandler) removeWorkload(
 	// Generate the consistent ID for this service, so we know what to remove.
 	id := serviceregistration.MakeAllocServiceID(workload.AllocInfo.AllocID, workload.Name(), serviceSpec)
 
+	if s.healthSyncer != nil {
+		s.healthSyncer.untrack(id)
+	}
+
 	deleteArgs := structs.ServiceRegistrationDeleteByIDRequest{
 		ID: id,
 		WriteRequest: structs.WriteRequest{
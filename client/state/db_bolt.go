@@ -138,6 +138,10 @@ var (
 
 	// nodeRegistrationKey is the key at which node registration data is stored.
 	nodeRegistrationKey = []byte("node_registration")
+
+	// hostVolBucket is the bucket name in which dynamic host volume state is
+	// stored, keyed by volume ID.
+	hostVolBucket = []byte("host_volumes")
 )
 
 // taskBucketName returns the bucket name for the given task name.
@@ -1048,6 +1052,45 @@ func (s *BoltStateDB) GetNodeRegistration() (*cstructs.NodeRegistration, error)
 	return &reg, err
 }
 
+// PutDynamicHostVolume stores the state of a dynamic host volume created on
+// this client.
+func (s *BoltStateDB) PutDynamicHostVolume(vol *cstructs.HostVolumeState) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(hostVolBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(vol.ID), vol)
+	})
+}
+
+// GetDynamicHostVolumes retrieves all the dynamic host volumes created on
+// this client.
+func (s *BoltStateDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	var vols []*cstructs.HostVolumeState
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		b := tx.Bucket(hostVolBucket)
+		if b == nil {
+			return nil // nothing set yet
+		}
+		return boltdd.Iterate(b, nil, func(key []byte, vol cstructs.HostVolumeState) {
+			vols = append(vols, &vol)
+		})
+	})
+	return vols, err
+}
+
+// DeleteDynamicHostVolume removes the state of a dynamic host volume.
+func (s *BoltStateDB) DeleteDynamicHostVolume(id string) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		b := tx.Bucket(hostVolBucket)
+		if b == nil {
+			return nil // nothing set yet
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
 // init initializes metadata entries in a newly created state database.
 func (s *BoltStateDB) init() error {
 	return s.db.Update(func(tx *boltdd.Tx) error {
@@ -60,6 +60,9 @@ type MemDB struct {
 
 	nodeRegistration *cstructs.NodeRegistration
 
+	// volume_id -> host volume state
+	dynamicHostVolumes map[string]*cstructs.HostVolumeState
+
 	logger hclog.Logger
 
 	mu sync.RWMutex
@@ -68,15 +71,16 @@ type MemDB struct {
 func NewMemDB(logger hclog.Logger) *MemDB {
 	logger = logger.Named("memdb")
 	return &MemDB{
-		allocs:            make(map[string]*structs.Allocation),
-		deployStatus:      make(map[string]*structs.AllocDeploymentStatus),
-		networkStatus:     make(map[string]*structs.AllocNetworkStatus),
-		acknowledgedState: make(map[string]*arstate.State),
-		localTaskState:    make(map[string]map[string]*state.LocalState),
-		taskState:         make(map[string]map[string]*structs.TaskState),
-		checks:            make(checks.ClientResults),
-		identities:        make(map[string][]*structs.SignedWorkloadIdentity),
-		logger:            logger,
+		allocs:             make(map[string]*structs.Allocation),
+		deployStatus:       make(map[string]*structs.AllocDeploymentStatus),
+		networkStatus:      make(map[string]*structs.AllocNetworkStatus),
+		acknowledgedState:  make(map[string]*arstate.State),
+		localTaskState:     make(map[string]map[string]*state.LocalState),
+		taskState:          make(map[string]map[string]*structs.TaskState),
+		checks:             make(checks.ClientResults),
+		identities:         make(map[string][]*structs.SignedWorkloadIdentity),
+		dynamicHostVolumes: make(map[string]*cstructs.HostVolumeState),
+		logger:             logger,
 	}
 }
 
@@ -354,6 +358,30 @@ func (m *MemDB) GetNodeRegistration() (*cstructs.NodeRegistration, error) {
 	return m.nodeRegistration, nil
 }
 
+func (m *MemDB) PutDynamicHostVolume(vol *cstructs.HostVolumeState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dynamicHostVolumes[vol.ID] = vol
+	return nil
+}
+
+func (m *MemDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	vols := make([]*cstructs.HostVolumeState, 0, len(m.dynamicHostVolumes))
+	for _, vol := range m.dynamicHostVolumes {
+		vols = append(vols, vol)
+	}
+	return vols, nil
+}
+
+func (m *MemDB) DeleteDynamicHostVolume(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.dynamicHostVolumes, id)
+	return nil
+}
+
 func (m *MemDB) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -137,6 +137,18 @@ type StateDB interface {
 	PutNodeRegistration(*cstructs.NodeRegistration) error
 	GetNodeRegistration() (*cstructs.NodeRegistration, error)
 
+	// PutDynamicHostVolume stores the state of a dynamic host volume created
+	// on this client.
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+
+	// GetDynamicHostVolumes retrieves all the dynamic host volumes created on
+	// this client so they can be restored.
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+
+	// DeleteDynamicHostVolume removes the state of a dynamic host volume. No
+	// error is returned if it does not exist.
+	DeleteDynamicHostVolume(string) error
+
 	// Close the database. Unsafe for further use after calling regardless
 	// of return value.
 	Close() error
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+// ClientHostVolumeCreateRequest is sent from the server to the client to
+// create a dynamic host volume with the requested plugin.
+type ClientHostVolumeCreateRequest struct {
+	// ID is a UUID-like string generated by the server.
+	ID string
+
+	// Name is the name that group.volume will use to identify the volume
+	// source.
+	Name string
+
+	// PluginID is the name of the host volume plugin on the client that will
+	// be used for creating the volume.
+	PluginID string
+
+	// Namespace is the Nomad namespace for the volume.
+	Namespace string
+
+	// NodeID is the node where the volume is placed. It's included in the
+	// client RPC request so that the server can route the request to the
+	// correct node.
+	NodeID string
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are the
+	// optional inputs to the plugin for the size of the volume.
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+
+	// Parameters are an opaque map of parameters for the host volume plugin.
+	Parameters map[string]string
+}
+
+// ClientHostVolumeCreateResponse is the response to a host volume create
+// request.
+type ClientHostVolumeCreateResponse struct {
+	// HostPath is the host path where the volume's mount point was created.
+	HostPath string
+
+	// CapacityBytes is the size of the volume as reported by the plugin.
+	CapacityBytes int64
+}
+
+// ClientHostVolumeDeleteRequest is sent from the server to the client to
+// delete a dynamic host volume.
+type ClientHostVolumeDeleteRequest struct {
+	// ID is the volume ID generated by the server when the volume was
+	// created.
+	ID string
+
+	// PluginID is the name of the host volume plugin on the client that will
+	// be used for deleting the volume.
+	PluginID string
+
+	// NodeID is the node where the volume is placed. It's included in the
+	// client RPC request so that the server can route the request to the
+	// correct node.
+	NodeID string
+
+	// HostPath is the host path where the volume's mount point was created.
+	HostPath string
+
+	// Parameters are an opaque map of parameters for the host volume plugin.
+	Parameters map[string]string
+}
+
+// ClientHostVolumeDeleteResponse is the response to a host volume delete
+// request.
+type ClientHostVolumeDeleteResponse struct{}
+
+// HostVolumeState is the client's persisted record of a dynamic host volume,
+// used to restore the volume to the node after the agent restarts.
+type HostVolumeState struct {
+	ID        string
+	HostPath  string
+	CreateReq *ClientHostVolumeCreateRequest
+}
@@ -161,6 +161,15 @@ type FsLogsRequest struct {
 	// Follow follows logs.
 	Follow bool
 
+	// Since, if set, starts streaming at the first line written at or after
+	// it instead of at Offset. It requires the task to record log timestamps.
+	Since time.Time
+
+	// Until, if set, ends the stream before the first line written after it.
+	// It requires the task to record log timestamps and can't be combined
+	// with Follow.
+	Until time.Time
+
 	structs.QueryOptions
 }
 
@@ -317,10 +326,26 @@ type AllocResourceUsage struct {
 	// Tasks contains the resource usage of each task
 	Tasks map[string]*TaskResourceUsage
 
+	// DiskStats is the usage of the ephemeral disk of the allocation
+	DiskStats *AllocDiskStats
+
 	// The max timestamp of all the Tasks
 	Timestamp int64
 }
 
+// AllocDiskStats holds the disk usage of the ephemeral disk of an allocation.
+type AllocDiskStats struct {
+	// Used is the number of bytes used by the shared alloc directory and the
+	// local and tmp directories of the tasks
+	Used uint64
+
+	// Size is the ephemeral disk size reserved by the allocation in bytes
+	Size uint64
+
+	// Timestamp is the time the usage was measured at (UnixNano)
+	Timestamp int64
+}
+
 // joinStringSet takes two slices of strings and joins them
 func joinStringSet(s1, s2 []string) []string {
 	lookup := make(map[string]struct{}, len(s1))
@@ -76,6 +76,10 @@ const (
 	// AllocIndex is the environment variable for passing the allocation index.
 	AllocIndex = "NOMAD_ALLOC_INDEX"
 
+	// ArrayIndex is the environment variable for passing the index of the
+	// allocation in a job array.
+	ArrayIndex = "NOMAD_ARRAY_INDEX"
+
 	// Datacenter is the environment variable for passing the datacenter in which the alloc is running.
 	Datacenter = "NOMAD_DC"
 
@@ -418,6 +422,7 @@ type Builder struct {
 	memMaxLimit          int64
 	taskName             string
 	allocIndex           int
+	arrayIndex           int
 	datacenter           string
 	cgroupParent         string
 	namespace            string
@@ -476,9 +481,10 @@ func NewBuilder(node *structs.Node, alloc *structs.Allocation, task *structs.Tas
 // NewEmptyBuilder creates a new environment builder.
 func NewEmptyBuilder() *Builder {
 	return &Builder{
-		mu:       &sync.RWMutex{},
-		hookEnvs: map[string]map[string]string{},
-		envvars:  make(map[string]string),
+		mu:         &sync.RWMutex{},
+		hookEnvs:   map[string]map[string]string{},
+		envvars:    make(map[string]string),
+		arrayIndex: -1,
 	}
 }
 
@@ -529,6 +535,9 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	if b.allocIndex != -1 {
 		envMap[AllocIndex] = strconv.Itoa(b.allocIndex)
 	}
+	if b.arrayIndex != -1 {
+		envMap[ArrayIndex] = strconv.Itoa(b.arrayIndex)
+	}
 	if b.taskName != "" {
 		envMap[TaskName] = b.taskName
 	}
@@ -734,6 +743,9 @@ func (b *Builder) setAlloc(alloc *structs.Allocation) *Builder {
 	b.allocName = alloc.Name
 	b.groupName = alloc.TaskGroup
 	b.allocIndex = int(alloc.Index())
+	if alloc.Job.Array != nil {
+		b.arrayIndex = alloc.Job.Array.Index(alloc.Index())
+	}
 	b.jobID = alloc.Job.ID
 	b.jobName = alloc.Job.Name
 	b.jobParentID = alloc.Job.ParentID
@@ -693,6 +693,23 @@ func TestEnvironment_DashesInTaskName(t *testing.T) {
 	}
 }
 
+// TestEnvironment_ArrayIndex asserts the array index is only set for the
+// allocations of a job array.
+func TestEnvironment_ArrayIndex(t *testing.T) {
+	ci.Parallel(t)
+
+	a := mock.Alloc()
+	task := a.Job.TaskGroups[0].Tasks[0]
+	envMap := NewBuilder(mock.Node(), a, task, "global").Build().Map()
+	require.NotContains(t, envMap, ArrayIndex)
+
+	a.Job.Array = &structs.JobArray{Start: 100, End: 199}
+	a.Name = structs.AllocName(a.JobID, a.TaskGroup, 7)
+	envMap = NewBuilder(mock.Node(), a, task, "global").Build().Map()
+	require.Equal(t, "107", envMap[ArrayIndex])
+	require.Equal(t, "7", envMap[AllocIndex])
+}
+
 // TestEnvironment_UpdateTask asserts env vars and task meta are updated when a
 // task is updated.
 func TestEnvironment_UpdateTask(t *testing.T) {
@@ -307,6 +307,12 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.EventBufferSize = int64(*agentConfig.Server.EventBufferSize)
 	}
+	if dir := agentConfig.Server.EventSinkFileDir; dir != "" {
+		if !filepath.IsAbs(dir) {
+			return nil, fmt.Errorf("Invalid Config, event_sink_file_dir must be an absolute path")
+		}
+		conf.EventSinkFileDir = dir
+	}
 	if agentConfig.Autopilot != nil {
 		if agentConfig.Autopilot.CleanupDeadServers != nil {
 			conf.AutopilotConfig.CleanupDeadServers = *agentConfig.Autopilot.CleanupDeadServers
@@ -421,6 +427,21 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.JobGCThreshold = dur
 	}
+	if interval := agentConfig.Server.RebalanceInterval; interval != "" {
+		dur, err := time.ParseDuration(interval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rebalance_interval: %v", err)
+		} else if dur <= time.Duration(0) {
+			return nil, fmt.Errorf("rebalance_interval should be greater than 0s")
+		}
+		conf.RebalanceInterval = dur
+	}
+	if max := agentConfig.Server.RebalanceMaxMigrations; max != nil {
+		if *max < 0 {
+			return nil, fmt.Errorf("rebalance_max_migrations must not be negative")
+		}
+		conf.RebalanceMaxMigrations = *max
+	}
 	if gcThreshold := agentConfig.Server.EvalGCThreshold; gcThreshold != "" {
 		dur, err := time.ParseDuration(gcThreshold)
 		if err != nil {
@@ -578,6 +599,17 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 	}
 
+	// Set eval broker configuration.
+	if evalBrokerConf := agentConfig.Server.EvalBroker; evalBrokerConf != nil {
+		if evalBrokerConf.FairShare != nil {
+			conf.EvalBrokerFairShare = *evalBrokerConf.FairShare
+		}
+		if evalBrokerConf.PriorityAging < 0 {
+			return nil, fmt.Errorf("eval_broker.priority_aging cannot be negative")
+		}
+		conf.EvalBrokerPriorityAging = evalBrokerConf.PriorityAging
+	}
+
 	// Add Enterprise license configs
 	conf.LicenseConfig = &nomad.LicenseConfig{
 		BuildDate:         agentConfig.Version.BuildDate,
@@ -722,6 +754,8 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		conf.StateDir = filepath.Join(agentConfig.DataDir, "client")
 		conf.AllocDir = filepath.Join(agentConfig.DataDir, "alloc")
 		conf.AllocMountsDir = filepath.Join(agentConfig.DataDir, "mounts")
+		conf.HostVolumesDir = filepath.Join(agentConfig.DataDir, "host_volumes")
+		conf.HostVolumePluginDir = filepath.Join(agentConfig.DataDir, "host_volume_plugins")
 	}
 	if agentConfig.Client.StateDir != "" {
 		conf.StateDir = agentConfig.Client.StateDir
@@ -732,6 +766,12 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if agentConfig.Client.AllocMountsDir != "" {
 		conf.AllocMountsDir = agentConfig.Client.AllocMountsDir
 	}
+	if agentConfig.Client.HostVolumesDir != "" {
+		conf.HostVolumesDir = agentConfig.Client.HostVolumesDir
+	}
+	if agentConfig.Client.HostVolumePluginDir != "" {
+		conf.HostVolumePluginDir = agentConfig.Client.HostVolumePluginDir
+	}
 	if agentConfig.Client.NetworkInterface != "" {
 		conf.NetworkInterface = agentConfig.Client.NetworkInterface
 	}
@@ -764,6 +804,19 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.MaxDynamicPort = agentConfig.Client.MaxDynamicPort
 	conf.MinDynamicPort = agentConfig.Client.MinDynamicPort
 	conf.DisableRemoteExec = agentConfig.Client.DisableRemoteExec
+	conf.LogSinkAllowedHosts = agentConfig.Client.LogSinkAllowedHosts
+	for _, dir := range agentConfig.Client.LogSinkSocketDirs {
+		if !filepath.IsAbs(dir) {
+			return nil, fmt.Errorf("Invalid Config, log_sink_socket_dirs must be absolute paths")
+		}
+	}
+	conf.LogSinkSocketDirs = agentConfig.Client.LogSinkSocketDirs
+	conf.EnforceEphemeralDisk = agentConfig.Client.EnforceEphemeralDisk
+	if interval := agentConfig.Client.DiskUsageInterval; interval < 0 {
+		return nil, fmt.Errorf("Invalid Config, disk_usage_interval must be positive")
+	} else if interval > 0 {
+		conf.DiskUsageInterval = interval
+	}
 
 	if agentConfig.Client.TemplateConfig != nil {
 		conf.TemplateConfig = conf.TemplateConfig.Merge(agentConfig.Client.TemplateConfig)
@@ -447,6 +447,31 @@ func TestAgent_ServerConfig_PlanRejectionTracker(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_EvalBroker(t *testing.T) {
+	ci.Parallel(t)
+
+	config := DevConfig(nil)
+	must.NoError(t, config.normalizeAddrs())
+
+	serverConfig, err := convertServerConfig(config)
+	must.NoError(t, err)
+	must.False(t, serverConfig.EvalBrokerFairShare)
+	must.Eq(t, time.Minute, serverConfig.EvalBrokerPriorityAging)
+
+	config.Server.EvalBroker = &EvalBrokerConfig{
+		FairShare:     pointer.Of(true),
+		PriorityAging: 3 * time.Minute,
+	}
+	serverConfig, err = convertServerConfig(config)
+	must.NoError(t, err)
+	must.True(t, serverConfig.EvalBrokerFairShare)
+	must.Eq(t, 3*time.Minute, serverConfig.EvalBrokerPriorityAging)
+
+	config.Server.EvalBroker.PriorityAging = -time.Minute
+	_, err = convertServerConfig(config)
+	must.ErrorContains(t, err, "priority_aging cannot be negative")
+}
+
 func TestAgent_ServerConfig_RaftMultiplier_Ok(t *testing.T) {
 	ci.Parallel(t)
 
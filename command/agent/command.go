@@ -55,6 +55,7 @@ type Command struct {
 	args           []string
 	agent          *Agent
 	httpServers    []*HTTPServer
+	dnsServer      *DNSServer
 	logFilter      *logutils.LevelFilter
 	logOutput      io.Writer
 	retryJoinErrCh chan struct{}
@@ -645,6 +646,18 @@ func (c *Command) setupAgent(config *Config, logger hclog.InterceptLogger, logOu
 	}
 	c.httpServers = httpServers
 
+	// Setup the DNS server, if enabled
+	dnsServer, err := NewDNSServer(agent, config)
+	if err != nil {
+		agent.Shutdown()
+		for _, srv := range httpServers {
+			srv.Shutdown()
+		}
+		c.Ui.Error(fmt.Sprintf("Error starting DNS server: %s", err))
+		return err
+	}
+	c.dnsServer = dnsServer
+
 	for _, vault := range config.Vaults {
 		if vault.Token != "" {
 			logger.Warn("Setting a Vault token in the agent configuration is deprecated and will be removed in Nomad 1.9. Migrate your Vault configuration to use workload identity.", "cluster", vault.Name)
@@ -851,6 +864,7 @@ func (c *Command) Run(args []string) int {
 
 	defer func() {
 		c.agent.Shutdown()
+		c.dnsServer.Shutdown()
 
 		// Shutdown the http server at the end, to ease debugging if
 		// the agent takes long to shutdown
@@ -190,6 +190,10 @@ type Config struct {
 	// Reporting is used to enable go census reporting
 	Reporting *config.ReportingConfig `hcl:"reporting,block"`
 
+	// DNS is used to configure the agent DNS interface for Nomad native
+	// service discovery
+	DNS *DNSConfig `hcl:"dns"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -226,6 +230,14 @@ type ClientConfig struct {
 	// AllocMountsDir is the directory for storing mounts into allocation data
 	AllocMountsDir string `hcl:"alloc_mounts_dir"`
 
+	// HostVolumesDir is the directory in which dynamic host volumes are
+	// created
+	HostVolumesDir string `hcl:"host_volumes_dir"`
+
+	// HostVolumePluginDir is the directory with the executables for dynamic
+	// host volume plugins
+	HostVolumePluginDir string `hcl:"host_volume_plugin_dir"`
+
 	// Servers is a list of known server addresses. These are as "host:port"
 	Servers []string `hcl:"servers"`
 
@@ -386,6 +398,23 @@ type ClientConfig struct {
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
+	// LogSinkAllowedHosts are the hosts the syslog and http log sinks of
+	// tasks may ship to. An entry without a port allows any port.
+	LogSinkAllowedHosts []string `hcl:"log_sink_allowed_hosts"`
+
+	// LogSinkSocketDirs are the directories containing the unix sockets the
+	// socket and syslog log sinks of tasks may ship to.
+	LogSinkSocketDirs []string `hcl:"log_sink_socket_dirs"`
+
+	// EnforceEphemeralDisk fails the tasks of allocations which use more disk
+	// than their ephemeral disk size, instead of only warning.
+	EnforceEphemeralDisk bool `hcl:"enforce_ephemeral_disk"`
+
+	// DiskUsageInterval is how often the disk usage of allocations is
+	// measured
+	DiskUsageInterval    time.Duration
+	DiskUsageIntervalHCL string `hcl:"disk_usage_interval" json:"-"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -410,10 +439,100 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
 	nc.Users = c.Users.Copy()
+	nc.LogSinkAllowedHosts = slices.Clone(c.LogSinkAllowedHosts)
+	nc.LogSinkSocketDirs = slices.Clone(c.LogSinkSocketDirs)
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
 
+// DNSConfig is the configuration for the agent DNS interface, which answers
+// queries for services registered with the Nomad service provider.
+type DNSConfig struct {
+	// Enabled controls whether the agent runs the DNS server.
+	Enabled *bool `hcl:"enabled"`
+
+	// Address is the address the DNS server binds to. If empty, the agent
+	// bind_addr is used.
+	Address string `hcl:"address"`
+
+	// Port is the UDP and TCP port the DNS server listens on.
+	Port int `hcl:"port"`
+
+	// Domain is the top level domain the DNS server is authoritative for.
+	// Services are resolvable as <service>.service[.<namespace>].<domain>.
+	Domain string `hcl:"domain"`
+
+	// TTL is the time-to-live set on records in DNS answers.
+	TTL    time.Duration `hcl:"-"`
+	TTLHCL string        `hcl:"ttl" json:"-"`
+
+	// Token is the ACL token used to look up service registrations. If empty,
+	// the anonymous policy applies and only services in namespaces it can
+	// read are resolvable.
+	Token string `hcl:"token"`
+
+	// AllowStale allows any server to answer the service lookup rather than
+	// forwarding it to the leader.
+	AllowStale *bool `hcl:"allow_stale"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// DefaultDNSConfig returns the default agent DNS configuration.
+func DefaultDNSConfig() *DNSConfig {
+	return &DNSConfig{
+		Enabled:    pointer.Of(false),
+		Port:       4653,
+		Domain:     "nomad",
+		TTL:        0,
+		AllowStale: pointer.Of(true),
+	}
+}
+
+// Copy returns a deep copy of the DNS config.
+func (d *DNSConfig) Copy() *DNSConfig {
+	if d == nil {
+		return nil
+	}
+	nd := *d
+	nd.Enabled = pointer.Copy(d.Enabled)
+	nd.AllowStale = pointer.Copy(d.AllowStale)
+	nd.ExtraKeysHCL = slices.Clone(d.ExtraKeysHCL)
+	return &nd
+}
+
+// Merge merges two DNS configs together.
+func (d *DNSConfig) Merge(b *DNSConfig) *DNSConfig {
+	result := *d
+
+	if b.Enabled != nil {
+		result.Enabled = pointer.Of(*b.Enabled)
+	}
+	if b.Address != "" {
+		result.Address = b.Address
+	}
+	if b.Port != 0 {
+		result.Port = b.Port
+	}
+	if b.Domain != "" {
+		result.Domain = b.Domain
+	}
+	if b.TTL != 0 {
+		result.TTL = b.TTL
+	}
+	if b.TTLHCL != "" {
+		result.TTLHCL = b.TTLHCL
+	}
+	if b.Token != "" {
+		result.Token = b.Token
+	}
+	if b.AllowStale != nil {
+		result.AllowStale = pointer.Of(*b.AllowStale)
+	}
+	return &result
+}
+
 // ACLConfig is configuration specific to the ACL system
 type ACLConfig struct {
 	// Enabled controls if we are enforce and manage ACLs
@@ -522,6 +641,15 @@ type ServerConfig struct {
 	// can be used to filter by age.
 	JobGCThreshold string `hcl:"job_gc_threshold"`
 
+	// RebalanceInterval controls how often the leader looks for allocations
+	// of jobs that opted in to rebalancing which could be placed better.
+	RebalanceInterval string `hcl:"rebalance_interval"`
+
+	// RebalanceMaxMigrations is the maximum number of allocations migrated
+	// by a single rebalance. Rebalancing is disabled unless it is set above
+	// zero.
+	RebalanceMaxMigrations *int `hcl:"rebalance_max_migrations"`
+
 	// EvalGCThreshold controls how "old" an eval must be to be collected by GC.
 	// Age is not the only requirement for a eval to be GCed but the threshold
 	// can be used to filter by age. Please note that batch job evaluations are
@@ -644,6 +772,9 @@ type ServerConfig struct {
 	// detects potentially bad nodes.
 	PlanRejectionTracker *PlanRejectionTracker `hcl:"plan_rejection_tracker"`
 
+	// EvalBroker configures how the eval broker queues evaluations.
+	EvalBroker *EvalBrokerConfig `hcl:"eval_broker"`
+
 	// EnableEventBroker configures whether this server's state store
 	// will generate events for its event stream.
 	EnableEventBroker *bool `hcl:"enable_event_broker"`
@@ -653,6 +784,10 @@ type ServerConfig struct {
 	// for the EventBufferSize is 1.
 	EventBufferSize *int `hcl:"event_buffer_size"`
 
+	// EventSinkFileDir is the directory file event sinks may write to. File
+	// sinks are disabled when it is empty.
+	EventSinkFileDir string `hcl:"event_sink_file_dir"`
+
 	// LicensePath is the path to search for an enterprise license.
 	LicensePath string `hcl:"license_path"`
 
@@ -724,12 +859,14 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns := *s
 	ns.RaftMultiplier = pointer.Copy(s.RaftMultiplier)
 	ns.NumSchedulers = pointer.Copy(s.NumSchedulers)
+	ns.RebalanceMaxMigrations = pointer.Copy(s.RebalanceMaxMigrations)
 	ns.EnabledSchedulers = slices.Clone(s.EnabledSchedulers)
 	ns.StartJoin = slices.Clone(s.StartJoin)
 	ns.RetryJoin = slices.Clone(s.RetryJoin)
 	ns.ServerJoin = s.ServerJoin.Copy()
 	ns.DefaultSchedulerConfig = s.DefaultSchedulerConfig.Copy()
 	ns.PlanRejectionTracker = s.PlanRejectionTracker.Copy()
+	ns.EvalBroker = s.EvalBroker.Copy()
 	ns.EnableEventBroker = pointer.Copy(s.EnableEventBroker)
 	ns.EventBufferSize = pointer.Copy(s.EventBufferSize)
 	ns.JobMaxSourceSize = pointer.Copy(s.JobMaxSourceSize)
@@ -824,6 +961,57 @@ func (p *PlanRejectionTracker) Merge(b *PlanRejectionTracker) *PlanRejectionTrac
 	return &result
 }
 
+// EvalBrokerConfig is used in servers to configure how the eval broker queues
+// evaluations.
+type EvalBrokerConfig struct {
+	// FairShare controls if the ready evaluations are dequeued by weighted
+	// fair queueing across namespaces, instead of by priority alone.
+	FairShare *bool `hcl:"fair_share"`
+
+	// PriorityAging is how long a ready evaluation waits before its priority
+	// is raised by one when FairShare is enabled.
+	PriorityAging    time.Duration
+	PriorityAgingHCL string `hcl:"priority_aging" json:"-"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (e *EvalBrokerConfig) Copy() *EvalBrokerConfig {
+	if e == nil {
+		return nil
+	}
+
+	ne := *e
+	ne.FairShare = pointer.Copy(e.FairShare)
+	ne.ExtraKeysHCL = slices.Clone(e.ExtraKeysHCL)
+	return &ne
+}
+
+func (e *EvalBrokerConfig) Merge(b *EvalBrokerConfig) *EvalBrokerConfig {
+	if e == nil {
+		return b
+	}
+
+	result := *e
+
+	if b == nil {
+		return &result
+	}
+
+	if b.FairShare != nil {
+		result.FairShare = b.FairShare
+	}
+
+	if b.PriorityAging != 0 {
+		result.PriorityAging = b.PriorityAging
+	}
+	if b.PriorityAgingHCL != "" {
+		result.PriorityAgingHCL = b.PriorityAgingHCL
+	}
+	return &result
+}
+
 // Search is used in servers to configure search API options.
 type Search struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -1382,6 +1570,7 @@ func DefaultConfig() *Config {
 			GCMaxAllocs:           50,
 			NoHostUUID:            pointer.Of(true),
 			DisableRemoteExec:     false,
+			DiskUsageInterval:     30 * time.Second,
 			ServerJoin: &ServerJoin{
 				RetryJoin:        []string{},
 				RetryInterval:    30 * time.Second,
@@ -1407,6 +1596,10 @@ func DefaultConfig() *Config {
 				NodeThreshold: 100,
 				NodeWindow:    5 * time.Minute,
 			},
+			EvalBroker: &EvalBrokerConfig{
+				FairShare:     pointer.Of(false),
+				PriorityAging: 1 * time.Minute,
+			},
 			ServerJoin: &ServerJoin{
 				RetryJoin:        []string{},
 				RetryInterval:    30 * time.Second,
@@ -1444,6 +1637,7 @@ func DefaultConfig() *Config {
 		DisableUpdateCheck: pointer.Of(false),
 		Limits:             config.DefaultLimits(),
 		Reporting:          config.DefaultReporting(),
+		DNS:                DefaultDNSConfig(),
 	}
 
 	return cfg
@@ -1554,6 +1748,13 @@ func (c *Config) Merge(b *Config) *Config {
 		result.Reporting = result.Reporting.Merge(b.Reporting)
 	}
 
+	// Apply the DNS Config
+	if result.DNS == nil && b.DNS != nil {
+		result.DNS = b.DNS.Copy()
+	} else if b.DNS != nil {
+		result.DNS = result.DNS.Merge(b.DNS)
+	}
+
 	// Apply the TLS Config
 	if result.TLSConfig == nil && b.TLSConfig != nil {
 		result.TLSConfig = b.TLSConfig.Copy()
@@ -1773,6 +1974,7 @@ func (c *Config) Copy() *Config {
 	nc.Limits = c.Limits.Copy()
 	nc.Audit = c.Audit.Copy()
 	nc.Reporting = c.Reporting.Copy()
+	nc.DNS = c.DNS.Copy()
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
@@ -2070,6 +2272,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.JobGCThreshold != "" {
 		result.JobGCThreshold = b.JobGCThreshold
 	}
+	if b.RebalanceInterval != "" {
+		result.RebalanceInterval = b.RebalanceInterval
+	}
+	if b.RebalanceMaxMigrations != nil {
+		result.RebalanceMaxMigrations = pointer.Of(*b.RebalanceMaxMigrations)
+	}
 	if b.JobDefaultPriority != nil {
 		result.JobDefaultPriority = pointer.Of(*b.JobDefaultPriority)
 	}
@@ -2166,12 +2374,20 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.EventBufferSize = b.EventBufferSize
 	}
 
+	if b.EventSinkFileDir != "" {
+		result.EventSinkFileDir = b.EventSinkFileDir
+	}
+
 	result.JobMaxSourceSize = pointer.Merge(s.JobMaxSourceSize, b.JobMaxSourceSize)
 
 	if b.PlanRejectionTracker != nil {
 		result.PlanRejectionTracker = result.PlanRejectionTracker.Merge(b.PlanRejectionTracker)
 	}
 
+	if b.EvalBroker != nil {
+		result.EvalBroker = result.EvalBroker.Merge(b.EvalBroker)
+	}
+
 	if b.DefaultSchedulerConfig != nil {
 		c := *b.DefaultSchedulerConfig
 		result.DefaultSchedulerConfig = &c
@@ -2252,6 +2468,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.AllocMountsDir != "" {
 		result.AllocMountsDir = b.AllocMountsDir
 	}
+	if b.HostVolumesDir != "" {
+		result.HostVolumesDir = b.HostVolumesDir
+	}
+	if b.HostVolumePluginDir != "" {
+		result.HostVolumePluginDir = b.HostVolumePluginDir
+	}
 	if b.NodeClass != "" {
 		result.NodeClass = b.NodeClass
 	}
@@ -2409,6 +2631,23 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result.Drain = a.Drain.Merge(b.Drain)
 	result.Users = a.Users.Merge(b.Users)
 
+	if len(b.LogSinkAllowedHosts) != 0 {
+		result.LogSinkAllowedHosts = b.LogSinkAllowedHosts
+	}
+	if len(b.LogSinkSocketDirs) != 0 {
+		result.LogSinkSocketDirs = b.LogSinkSocketDirs
+	}
+
+	if b.EnforceEphemeralDisk {
+		result.EnforceEphemeralDisk = b.EnforceEphemeralDisk
+	}
+	if b.DiskUsageInterval != 0 {
+		result.DiskUsageInterval = b.DiskUsageInterval
+	}
+	if b.DiskUsageIntervalHCL != "" {
+		result.DiskUsageIntervalHCL = b.DiskUsageIntervalHCL
+	}
+
 	return &result
 }
 
@@ -53,6 +53,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		},
 		Server: &ServerConfig{
 			PlanRejectionTracker: &PlanRejectionTracker{},
+			EvalBroker:           &EvalBrokerConfig{},
 			ServerJoin:           &ServerJoin{},
 		},
 		ACL:       &ACLConfig{},
@@ -95,6 +96,7 @@ func ParseConfigFile(path string) (*Config, error) {
 	// convert strings to time.Durations
 	tds := []durationConversionMap{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL, nil},
+		{"client.disk_usage_interval", &c.Client.DiskUsageInterval, &c.Client.DiskUsageIntervalHCL, nil},
 		{"acl.token_ttl", &c.ACL.TokenTTL, &c.ACL.TokenTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.PolicyTTL, &c.ACL.PolicyTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.RoleTTL, &c.ACL.RoleTTLHCL, nil},
@@ -105,6 +107,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"server.min_heartbeat_ttl", &c.Server.MinHeartbeatTTL, &c.Server.MinHeartbeatTTLHCL, nil},
 		{"server.failover_heartbeat_ttl", &c.Server.FailoverHeartbeatTTL, &c.Server.FailoverHeartbeatTTLHCL, nil},
 		{"server.plan_rejection_tracker.node_window", &c.Server.PlanRejectionTracker.NodeWindow, &c.Server.PlanRejectionTracker.NodeWindowHCL, nil},
+		{"server.eval_broker.priority_aging", &c.Server.EvalBroker.PriorityAging, &c.Server.EvalBroker.PriorityAgingHCL, nil},
 		{"server.retry_interval", &c.Server.RetryInterval, &c.Server.RetryIntervalHCL, nil},
 		{"server.server_join.retry_interval", &c.Server.ServerJoin.RetryInterval, &c.Server.ServerJoin.RetryIntervalHCL, nil},
 		{"autopilot.server_stabilization_time", &c.Autopilot.ServerStabilizationTime, &c.Autopilot.ServerStabilizationTimeHCL, nil},
@@ -213,6 +216,11 @@ func ParseConfigFile(path string) (*Config, error) {
 		}
 	}
 
+	if c.DNS != nil {
+		tds = append(tds, durationConversionMap{
+			"dns.ttl", &c.DNS.TTL, &c.DNS.TTLHCL, nil})
+	}
+
 	// Add enterprise audit sinks for time.Duration parsing
 	for i, sink := range c.Audit.Sinks {
 		tds = append(tds, durationConversionMap{
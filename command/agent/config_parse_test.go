@@ -89,6 +89,11 @@ var basicConfig = &Config{
 		GCMaxAllocs:           50,
 		NoHostUUID:            pointer.Of(false),
 		DisableRemoteExec:     true,
+		LogSinkAllowedHosts:   []string{"logs.example.com", "10.0.0.1:514"},
+		LogSinkSocketDirs:     []string{"/var/run/logs"},
+		EnforceEphemeralDisk:  true,
+		DiskUsageInterval:     10 * time.Second,
+		DiskUsageIntervalHCL:  "10s",
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
@@ -109,6 +114,8 @@ var basicConfig = &Config{
 		EvalGCThreshold:           "12h",
 		JobGCInterval:             "3m",
 		JobGCThreshold:            "12h",
+		RebalanceInterval:         "10m",
+		RebalanceMaxMigrations:    pointer.Of(3),
 		DeploymentGCThreshold:     "12h",
 		CSIVolumeClaimGCInterval:  "3m",
 		CSIVolumeClaimGCThreshold: "12h",
@@ -133,12 +140,18 @@ var basicConfig = &Config{
 		EncryptKey:                "abc",
 		EnableEventBroker:         pointer.Of(false),
 		EventBufferSize:           pointer.Of(200),
+		EventSinkFileDir:          "/var/lib/nomad/events",
 		PlanRejectionTracker: &PlanRejectionTracker{
 			Enabled:       pointer.Of(true),
 			NodeThreshold: 100,
 			NodeWindow:    41 * time.Minute,
 			NodeWindowHCL: "41m",
 		},
+		EvalBroker: &EvalBrokerConfig{
+			FairShare:        pointer.Of(true),
+			PriorityAging:    3 * time.Minute,
+			PriorityAgingHCL: "3m",
+		},
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -348,6 +361,16 @@ var basicConfig = &Config{
 			Enabled: pointer.Of(true),
 		},
 	},
+	DNS: &DNSConfig{
+		Enabled:    pointer.Of(true),
+		Address:    "127.0.0.1",
+		Port:       8600,
+		Domain:     "example",
+		TTL:        30 * time.Second,
+		TTLHCL:     "30s",
+		Token:      "dns-token",
+		AllowStale: pointer.Of(false),
+	},
 }
 
 var pluginConfig = &Config{
@@ -597,6 +620,9 @@ func (c *Config) addDefaults() {
 	if c.Server.PlanRejectionTracker == nil {
 		c.Server.PlanRejectionTracker = &PlanRejectionTracker{}
 	}
+	if c.Server.EvalBroker == nil {
+		c.Server.EvalBroker = &EvalBrokerConfig{}
+	}
 	if c.Reporting == nil {
 		c.Reporting = &config.ReportingConfig{
 			&config.LicenseReportingConfig{
@@ -686,6 +712,7 @@ var sample0 = &Config{
 			NodeWindow:    31 * time.Minute,
 			NodeWindowHCL: "31m",
 		},
+		EvalBroker: &EvalBrokerConfig{},
 	},
 	ACL: &ACLConfig{
 		Enabled: true,
@@ -784,6 +811,7 @@ var sample1 = &Config{
 			NodeWindow:    31 * time.Minute,
 			NodeWindowHCL: "31m",
 		},
+		EvalBroker: &EvalBrokerConfig{},
 	},
 	ACL: &ACLConfig{
 		Enabled: true,
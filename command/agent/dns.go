@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/miekg/dns"
+)
+
+const (
+	// dnsServiceLabel is the label which identifies a service lookup, such
+	// as <service>.service.<namespace>.<domain>.
+	dnsServiceLabel = "service"
+
+	// dnsAddrLabel is the label which identifies an address lookup, used as
+	// the target of SRV records, such as <hex ip>.addr.<domain>.
+	dnsAddrLabel = "addr"
+)
+
+// DNSServer answers DNS queries for services registered with the Nomad
+// service provider. Lookups are performed using the agent RPC, so the agent
+// ACL token and namespace visibility rules are enforced by the servers.
+type DNSServer struct {
+	agent  *Agent
+	logger hclog.Logger
+
+	region     string
+	domain     string
+	ttl        uint32
+	token      string
+	allowStale bool
+
+	servers []*dns.Server
+}
+
+// NewDNSServer starts the agent DNS server listening on both UDP and TCP. It
+// returns nil if the DNS interface is not enabled.
+func NewDNSServer(agent *Agent, config *Config) (*DNSServer, error) {
+	if config.DNS == nil || config.DNS.Enabled == nil || !*config.DNS.Enabled {
+		return nil, nil
+	}
+
+	domain := config.DNS.Domain
+	if domain == "" {
+		domain = "nomad"
+	}
+
+	s := &DNSServer{
+		agent:      agent,
+		logger:     agent.logger.Named("dns"),
+		region:     config.Region,
+		domain:     dns.Fqdn(strings.ToLower(domain)),
+		ttl:        uint32(config.DNS.TTL / time.Second),
+		token:      config.DNS.Token,
+		allowStale: config.DNS.AllowStale == nil || *config.DNS.AllowStale,
+	}
+
+	bindAddr := config.DNS.Address
+	if bindAddr == "" {
+		bindAddr = config.BindAddr
+	}
+	addr := net.JoinHostPort(bindAddr, strconv.Itoa(config.DNS.Port))
+
+	mux := dns.NewServeMux()
+	mux.HandleFunc(s.domain, s.handleQuery)
+
+	udpConn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start DNS UDP listener on %q: %v", addr, err)
+	}
+	tcpListener, err := net.Listen("tcp", addr)
+	if err != nil {
+		udpConn.Close()
+		return nil, fmt.Errorf("failed to start DNS TCP listener on %q: %v", addr, err)
+	}
+
+	s.servers = []*dns.Server{
+		{PacketConn: udpConn, Handler: mux},
+		{Listener: tcpListener, Handler: mux},
+	}
+	for _, srv := range s.servers {
+		go func(srv *dns.Server) {
+			if err := srv.ActivateAndServe(); err != nil {
+				s.logger.Error("DNS server failed", "error", err)
+			}
+		}(srv)
+	}
+
+	s.logger.Info("DNS server started", "address", addr, "domain", s.domain)
+	return s, nil
+}
+
+// Shutdown stops the DNS server listeners.
+func (s *DNSServer) Shutdown() {
+	if s == nil {
+		return
+	}
+	for _, srv := range s.servers {
+		if err := srv.Shutdown(); err != nil {
+			s.logger.Debug("failed to shutdown DNS server", "error", err)
+		}
+	}
+}
+
+// handleQuery is the dns.HandlerFunc answering queries within the configured
+// domain.
+func (s *DNSServer) handleQuery(w dns.ResponseWriter, req *dns.Msg) {
+	defer metrics.MeasureSince([]string{"agent", "dns", "query"}, time.Now())
+
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Authoritative = true
+	resp.RecursionAvailable = false
+
+	if len(req.Question) == 0 {
+		resp.SetRcode(req, dns.RcodeFormatError)
+		s.write(w, resp)
+		return
+	}
+
+	q := req.Question[0]
+	rcode := s.answer(q, resp)
+	resp.SetRcode(req, rcode)
+
+	// Truncate UDP responses which are too large for the client, so it may
+	// retry using TCP.
+	if _, ok := w.RemoteAddr().(*net.UDPAddr); ok {
+		size := dns.MinMsgSize
+		if opt := req.IsEdns0(); opt != nil {
+			size = int(opt.UDPSize())
+		}
+		resp.Truncate(size)
+	}
+
+	s.write(w, resp)
+}
+
+func (s *DNSServer) write(w dns.ResponseWriter, resp *dns.Msg) {
+	if err := w.WriteMsg(resp); err != nil {
+		s.logger.Debug("failed to write DNS response", "error", err)
+	}
+}
+
+// answer populates the response for the question and returns the response
+// code to use.
+func (s *DNSServer) answer(q dns.Question, resp *dns.Msg) int {
+	name := strings.ToLower(q.Name)
+	if !dns.IsSubDomain(s.domain, name) {
+		return dns.RcodeRefused
+	}
+
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, s.domain))
+	if len(labels) == 0 {
+		// Query for the domain itself; there is nothing to answer but the
+		// name exists.
+		return dns.RcodeSuccess
+	}
+
+	switch labels[len(labels)-1] {
+	case dnsAddrLabel:
+		return s.answerAddr(q, labels[:len(labels)-1], resp)
+	case dnsServiceLabel:
+		// <service>.service.<domain>
+		return s.answerService(q, labels[:len(labels)-1], structs.DefaultNamespace, resp)
+	}
+
+	// <service>.service.<namespace>.<domain>
+	if len(labels) >= 3 && labels[len(labels)-2] == dnsServiceLabel {
+		return s.answerService(q, labels[:len(labels)-2], labels[len(labels)-1], resp)
+	}
+	return dns.RcodeNameError
+}
+
+// answerService answers a query for the service identified by labels in the
+// given namespace. The labels are either the service name, or the RFC 2782
+// form of _<service>._<protocol>.
+func (s *DNSServer) answerService(q dns.Question, labels []string, namespace string, resp *dns.Msg) int {
+	var service string
+	switch {
+	case len(labels) == 1:
+		service = labels[0]
+	case len(labels) == 2 && strings.HasPrefix(labels[0], "_") && strings.HasPrefix(labels[1], "_"):
+		service = strings.TrimPrefix(labels[0], "_")
+	default:
+		return dns.RcodeNameError
+	}
+
+	args := structs.ServiceRegistrationByNameRequest{
+		ServiceName: service,
+		QueryOptions: structs.QueryOptions{
+			Region:     s.region,
+			Namespace:  namespace,
+			AuthToken:  s.token,
+			AllowStale: s.allowStale,
+		},
+	}
+	var reply structs.ServiceRegistrationByNameResponse
+	if err := s.agent.RPC(structs.ServiceRegistrationGetServiceRPCMethod, &args, &reply); err != nil {
+		// Do not leak the existence of services the token is not allowed to
+		// see.
+		if structs.IsErrPermissionDenied(err) {
+			return dns.RcodeNameError
+		}
+		s.logger.Error("failed to lookup service", "service", service, "namespace", namespace, "error", err)
+		return dns.RcodeServerFailure
+	}
+
+	// Only answer with healthy instances.
+	services := make([]*structs.ServiceRegistration, 0, len(reply.Services))
+	for _, reg := range reply.Services {
+		if reg.ChecksFailing {
+			continue
+		}
+		services = append(services, reg)
+	}
+	if len(services) == 0 {
+		return dns.RcodeNameError
+	}
+
+	rand.Shuffle(len(services), func(i, j int) {
+		services[i], services[j] = services[j], services[i]
+	})
+
+	for _, reg := range services {
+		ip := net.ParseIP(reg.Address)
+		if ip == nil {
+			continue
+		}
+		switch q.Qtype {
+		case dns.TypeA, dns.TypeAAAA, dns.TypeANY:
+			if rr := s.addrRecord(q.Name, q.Qtype, ip); rr != nil {
+				resp.Answer = append(resp.Answer, rr)
+			}
+		case dns.TypeSRV:
+			target := s.addrTarget(ip)
+			resp.Answer = append(resp.Answer, &dns.SRV{
+				Hdr:      s.header(q.Name, dns.TypeSRV),
+				Priority: 1,
+				Weight:   1,
+				Port:     uint16(reg.Port),
+				Target:   target,
+			})
+			if rr := s.addrRecord(target, dns.TypeANY, ip); rr != nil {
+				resp.Extra = append(resp.Extra, rr)
+			}
+		}
+	}
+	return dns.RcodeSuccess
+}
+
+// answerAddr answers a query for an address target generated within SRV
+// records, which has the form <hex ip>.addr.<domain>.
+func (s *DNSServer) answerAddr(q dns.Question, labels []string, resp *dns.Msg) int {
+	if len(labels) != 1 {
+		return dns.RcodeNameError
+	}
+	b, err := hex.DecodeString(labels[0])
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		return dns.RcodeNameError
+	}
+	if rr := s.addrRecord(q.Name, q.Qtype, net.IP(b)); rr != nil {
+		resp.Answer = append(resp.Answer, rr)
+	}
+	return dns.RcodeSuccess
+}
+
+// addrRecord returns an A or AAAA record for the IP if it matches the query
+// type, or nil otherwise.
+func (s *DNSServer) addrRecord(name string, qtype uint16, ip net.IP) dns.RR {
+	if ip4 := ip.To4(); ip4 != nil {
+		if qtype != dns.TypeA && qtype != dns.TypeANY {
+			return nil
+		}
+		return &dns.A{Hdr: s.header(name, dns.TypeA), A: ip4}
+	}
+	if qtype != dns.TypeAAAA && qtype != dns.TypeANY {
+		return nil
+	}
+	return &dns.AAAA{Hdr: s.header(name, dns.TypeAAAA), AAAA: ip}
+}
+
+// addrTarget returns the name used as the target of SRV records.
+func (s *DNSServer) addrTarget(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return hex.EncodeToString(ip) + "." + dnsAddrLabel + "." + s.domain
+}
+
+func (s *DNSServer) header(name string, rrtype uint16) dns.RR_Header {
+	return dns.RR_Header{
+		Name:   name,
+		Rrtype: rrtype,
+		Class:  dns.ClassINET,
+		Ttl:    s.ttl,
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/miekg/dns"
+	"github.com/shoenig/test/must"
+)
+
+// testDNSServer starts a DNS server for the test agent listening on a random
+// port and returns the UDP address to query.
+func testDNSServer(t *testing.T, s *TestAgent, token string) string {
+	t.Helper()
+
+	config := s.Agent.config.Copy()
+	config.DNS = &DNSConfig{
+		Enabled: pointer.Of(true),
+		Address: "127.0.0.1",
+		Port:    0,
+		Domain:  "nomad",
+		Token:   token,
+	}
+	srv, err := NewDNSServer(s.Agent, config)
+	must.NoError(t, err)
+	must.NotNil(t, srv)
+	t.Cleanup(srv.Shutdown)
+
+	return srv.servers[0].PacketConn.LocalAddr().String()
+}
+
+func testDNSQuery(t *testing.T, addr, name string, qtype uint16) *dns.Msg {
+	t.Helper()
+
+	m := new(dns.Msg)
+	m.SetQuestion(name, qtype)
+	c := new(dns.Client)
+	in, _, err := c.Exchange(m, addr)
+	must.NoError(t, err)
+	return in
+}
+
+func TestDNSServer_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		srv, err := NewDNSServer(s.Agent, s.Agent.config)
+		must.NoError(t, err)
+		must.Nil(t, srv)
+
+		// Shutdown of a disabled server must be safe.
+		srv.Shutdown()
+	})
+}
+
+func TestDNSServer_Service(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		regs := mock.ServiceRegistrations()
+
+		failing := regs[0].Copy()
+		failing.ID = "_nomad-task-failing"
+		failing.AllocID = "4dd24b6c-35ff-4bc4-c1e8-3c04e7a0f7d3"
+		failing.Address = "192.168.10.2"
+		failing.ChecksFailing = true
+
+		ipv6 := regs[1].Copy()
+		ipv6.ID = "_nomad-task-ipv6"
+		ipv6.AllocID = "1a6fd4b3-07cf-b3f1-83e5-2a0b0a2ab1a9"
+		ipv6.ServiceName = "ipv6"
+		ipv6.Address = "2001:db8::1"
+
+		regs = append(regs, failing, ipv6)
+		must.NoError(t, s.Agent.server.State().UpsertServiceRegistrations(
+			structs.MsgTypeTestSetup, 10, regs))
+
+		addr := testDNSServer(t, s, "")
+
+		// Services in the default namespace can be looked up without the
+		// namespace label, and instances with failing checks are excluded.
+		for _, name := range []string{"example-cache.service.nomad.", "example-cache.service.default.nomad."} {
+			resp := testDNSQuery(t, addr, name, dns.TypeA)
+			must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+			must.Len(t, 1, resp.Answer)
+			a, ok := resp.Answer[0].(*dns.A)
+			must.True(t, ok)
+			must.Eq(t, "192.168.10.1", a.A.String())
+		}
+
+		// SRV records point at the addr target, which is included as an
+		// additional record.
+		resp := testDNSQuery(t, addr, "countdash-api.service.platform.nomad.", dns.TypeSRV)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 1, resp.Answer)
+		srv, ok := resp.Answer[0].(*dns.SRV)
+		must.True(t, ok)
+		must.Eq(t, 29000, srv.Port)
+		must.Eq(t, "c0a8c8c8.addr.nomad.", srv.Target)
+		must.Len(t, 1, resp.Extra)
+		must.Eq(t, "192.168.200.200", resp.Extra[0].(*dns.A).A.String())
+
+		// The RFC 2782 form is also supported.
+		resp = testDNSQuery(t, addr, "_countdash-api._tcp.service.platform.nomad.", dns.TypeSRV)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 1, resp.Answer)
+
+		// The addr target resolves.
+		resp = testDNSQuery(t, addr, srv.Target, dns.TypeA)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 1, resp.Answer)
+		must.Eq(t, "192.168.200.200", resp.Answer[0].(*dns.A).A.String())
+
+		// IPv6 services are answered with AAAA records only.
+		resp = testDNSQuery(t, addr, "ipv6.service.platform.nomad.", dns.TypeAAAA)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 1, resp.Answer)
+		must.True(t, resp.Answer[0].(*dns.AAAA).AAAA.Equal(net.ParseIP("2001:db8::1")))
+
+		resp = testDNSQuery(t, addr, "ipv6.service.platform.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 0, resp.Answer)
+
+		// Services in another namespace are not found.
+		resp = testDNSQuery(t, addr, "countdash-api.service.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+
+		// Unknown services and malformed names are not found.
+		resp = testDNSQuery(t, addr, "unknown.service.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+		resp = testDNSQuery(t, addr, "example-cache.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+	})
+}
+
+func TestDNSServer_Service_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		must.NoError(t, state.UpsertServiceRegistrations(
+			structs.MsgTypeTestSetup, 10, mock.ServiceRegistrations()))
+
+		// The anonymous token cannot see any services.
+		addr := testDNSServer(t, s, "")
+		resp := testDNSQuery(t, addr, "example-cache.service.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+
+		// A token with read access to the default namespace can only see
+		// services within it.
+		token := mock.CreatePolicyAndToken(t, state, 20, "dns",
+			mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+		addr = testDNSServer(t, s, token.SecretID)
+
+		resp = testDNSQuery(t, addr, "example-cache.service.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+		must.Len(t, 1, resp.Answer)
+
+		resp = testDNSQuery(t, addr, "countdash-api.service.platform.nomad.", dns.TypeA)
+		must.Eq(t, dns.RcodeNameError, resp.Rcode)
+	})
+}
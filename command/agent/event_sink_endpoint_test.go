@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_EventSink_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		sink := mock.EventSink()
+
+		// Register the sink.
+		req, err := http.NewRequest(http.MethodPut, "/v1/event/sink/"+sink.ID, encodeReq(sink))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.EventSinkSpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Header().Get("X-Nomad-Index"))
+
+		// The ID in the path must match the body.
+		req, err = http.NewRequest(http.MethodPut, "/v1/event/sink/other", encodeReq(sink))
+		must.NoError(t, err)
+		_, err = s.Server.EventSinkSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "does not match request path")
+
+		// List and read the sink.
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sinks", nil)
+		must.NoError(t, err)
+		obj, err := s.Server.EventSinksRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		must.SliceLen(t, 1, obj.([]*structs.EventSink))
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sink/"+sink.ID, nil)
+		must.NoError(t, err)
+		obj, err = s.Server.EventSinkSpecificRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		must.True(t, sink.EqualConfig(obj.(*structs.EventSink)))
+
+		// Delete the sink.
+		req, err = http.NewRequest(http.MethodDelete, "/v1/event/sink/"+sink.ID, nil)
+		must.NoError(t, err)
+		_, err = s.Server.EventSinkSpecificRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/event/sink/"+sink.ID, nil)
+		must.NoError(t, err)
+		_, err = s.Server.EventSinkSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "event sink not found")
+	})
+}
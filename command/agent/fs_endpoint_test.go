@@ -254,6 +254,15 @@ func TestHTTP_FS_Logs_MissingParams(t *testing.T) {
 		require.Equal(respW.Body.String(), logTypeNotPresentErr.Error())
 		require.Equal(400, respW.Code)
 
+		// Invalid since
+		req, err = http.NewRequest(http.MethodGet, "/v1/client/fs/logs/foo?task=foo&type=stdout&since=10m", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Contains(respW.Body.String(), "error parsing since")
+		require.Equal(400, respW.Code)
+
 		// case where all parameters are set but alloc isn't found
 		req, err = http.NewRequest(http.MethodGet, "/v1/client/fs/logs/foo?task=foo&type=stdout", nil)
 		require.NoError(err)
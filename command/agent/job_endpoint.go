@@ -1030,6 +1030,7 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		Type:           *job.Type,
 		Priority:       *job.Priority,
 		AllAtOnce:      *job.AllAtOnce,
+		Rebalance:      *job.Rebalance,
 		Datacenters:    job.Datacenters,
 		NodePool:       *job.NodePool,
 		Payload:        job.Payload,
@@ -1062,6 +1063,17 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	if len(job.DependsOn) > 0 {
+		j.DependsOn = []*structs.JobDependency{}
+		for _, d := range job.DependsOn {
+			j.DependsOn = append(j.DependsOn, &structs.JobDependency{
+				JobID:     d.JobID,
+				Namespace: d.Namespace,
+				Status:    d.Status,
+			})
+		}
+	}
+
 	if job.Periodic != nil {
 		j.Periodic = &structs.PeriodicConfig{
 			Enabled:         *job.Periodic.Enabled,
@@ -1077,6 +1089,30 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		if job.Periodic.Specs != nil {
 			j.Periodic.Specs = job.Periodic.Specs
 		}
+
+		for _, e := range job.Periodic.Exclude {
+			j.Periodic.Exclude = append(j.Periodic.Exclude, &structs.PeriodicExclusion{
+				Cron:  e.Cron,
+				Start: e.Start,
+				End:   e.End,
+			})
+		}
+
+		if job.Periodic.StartAfter != nil {
+			j.Periodic.StartAfter = *job.Periodic.StartAfter
+		}
+
+		if job.Periodic.EndBefore != nil {
+			j.Periodic.EndBefore = *job.Periodic.EndBefore
+		}
+
+		if job.Periodic.Catchup != nil {
+			j.Periodic.Catchup = *job.Periodic.Catchup
+		}
+
+		if job.Periodic.CatchupWindow != nil {
+			j.Periodic.CatchupWindow = *job.Periodic.CatchupWindow
+		}
 	}
 
 	if job.ParameterizedJob != nil {
@@ -1141,10 +1177,18 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.PreventRescheduleOnLost = *taskGroup.PreventRescheduleOnLost
 	}
 
+	if taskGroup.Gang != nil {
+		tg.Gang = *taskGroup.Gang
+	}
+
 	if taskGroup.ShutdownDelay != nil {
 		tg.ShutdownDelay = taskGroup.ShutdownDelay
 	}
 
+	if taskGroup.MaxRuntime != nil {
+		tg.MaxRuntime = taskGroup.MaxRuntime
+	}
+
 	if taskGroup.StopAfterClientDisconnect != nil {
 		tg.StopAfterClientDisconnect = taskGroup.StopAfterClientDisconnect
 	}
@@ -1188,6 +1232,12 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		}
 	}
 
+	if taskGroup.DisruptionBudget != nil {
+		tg.DisruptionBudget = &structs.DisruptionBudget{
+			MaxUnavailable: *taskGroup.DisruptionBudget.MaxUnavailable,
+		}
+	}
+
 	if taskGroup.Scaling != nil {
 		tg.Scaling = ApiScalingPolicyToStructs(tg.Count, taskGroup.Scaling).TargetTaskGroup(job, tg)
 	}
@@ -1286,6 +1336,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 	structsTask.Meta = apiTask.Meta
 	structsTask.KillTimeout = *apiTask.KillTimeout
 	structsTask.ShutdownDelay = apiTask.ShutdownDelay
+	structsTask.MaxRuntime = apiTask.MaxRuntime
 	structsTask.KillSignal = apiTask.KillSignal
 	structsTask.Kind = structs.TaskKind(apiTask.Kind)
 	structsTask.Constraints = ApiConstraintsToStructs(apiTask.Constraints)
@@ -2016,11 +2067,44 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 		return nil
 	}
 
-	return &structs.LogConfig{
+	out := &structs.LogConfig{
 		Disabled:      dereferenceBool(in.Disabled),
 		MaxFiles:      dereferenceInt(in.MaxFiles),
 		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
+		Sinks:         apiLogSinksToStructs(in.Sinks),
+	}
+	if in.Compression != nil {
+		out.Compression = *in.Compression
+	}
+	if in.RotateInterval != nil {
+		out.RotateInterval = *in.RotateInterval
+	}
+	if in.Timestamps != nil {
+		out.Timestamps = *in.Timestamps
+	}
+	return out
+}
+
+func apiLogSinksToStructs(in []*api.LogSink) []*structs.LogSink {
+	if len(in) == 0 {
+		return nil
 	}
+
+	out := make([]*structs.LogSink, len(in))
+	for i, sink := range in {
+		out[i] = &structs.LogSink{
+			Type:       sink.Type,
+			Address:    sink.Address,
+			Tag:        sink.Tag,
+			Headers:    maps.Clone(sink.Headers),
+			BufferSize: dereferenceInt(sink.BufferSize),
+			BatchSize:  dereferenceInt(sink.BatchSize),
+		}
+		if sink.BatchInterval != nil {
+			out[i].BatchInterval = *sink.BatchInterval
+		}
+	}
+	return out
 }
 
 func dereferenceBool(in *bool) bool {
@@ -2088,6 +2172,8 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	ret.MaxSkew = a1.MaxSkew
+	ret.Hard = a1.Hard
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
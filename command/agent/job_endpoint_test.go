@@ -2593,6 +2593,13 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				},
 			},
 		},
+		DependsOn: []*api.JobDependency{
+			{
+				JobID:     "extract",
+				Namespace: "etl",
+				Status:    "failed",
+			},
+		},
 		Periodic: &api.PeriodicConfig{
 			Enabled:         pointer.Of(true),
 			Spec:            pointer.Of("spec"),
@@ -2600,6 +2607,14 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        pointer.Of("cron"),
 			ProhibitOverlap: pointer.Of(true),
 			TimeZone:        pointer.Of("test zone"),
+			Exclude: []*api.PeriodicExclusion{
+				{Cron: "* * * * 0"},
+				{Start: "2024-12-24", End: "2024-12-26"},
+			},
+			StartAfter:    pointer.Of("2024-01-01"),
+			EndBefore:     pointer.Of("2025-01-01"),
+			Catchup:       pointer.Of("all"),
+			CatchupWindow: pointer.Of(time.Hour),
 		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
 			Payload:      "payload",
@@ -2910,9 +2925,22 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						KillTimeout: pointer.Of(10 * time.Second),
 						KillSignal:  "SIGQUIT",
 						LogConfig: &api.LogConfig{
-							Disabled:      pointer.Of(true),
-							MaxFiles:      pointer.Of(10),
-							MaxFileSizeMB: pointer.Of(100),
+							Disabled:       pointer.Of(true),
+							MaxFiles:       pointer.Of(10),
+							MaxFileSizeMB:  pointer.Of(100),
+							Compression:    pointer.Of("zstd"),
+							RotateInterval: pointer.Of(time.Hour),
+							Timestamps:     pointer.Of(true),
+							Sinks: []*api.LogSink{
+								{
+									Type:          api.LogSinkTypeHTTP,
+									Address:       "http://127.0.0.1:8080",
+									Headers:       map[string]string{"Authorization": "Bearer secret"},
+									BufferSize:    pointer.Of(100),
+									BatchSize:     pointer.Of(10),
+									BatchInterval: pointer.Of(5 * time.Second),
+								},
+							},
 						},
 						Artifacts: []*api.TaskArtifact{
 							{
@@ -3017,6 +3045,13 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				},
 			},
 		},
+		DependsOn: []*structs.JobDependency{
+			{
+				JobID:     "extract",
+				Namespace: "etl",
+				Status:    "failed",
+			},
+		},
 		Update: structs.UpdateStrategy{
 			Stagger:     1 * time.Second,
 			MaxParallel: 5,
@@ -3028,6 +3063,14 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        "cron",
 			ProhibitOverlap: true,
 			TimeZone:        "test zone",
+			Exclude: []*structs.PeriodicExclusion{
+				{Cron: "* * * * 0"},
+				{Start: "2024-12-24", End: "2024-12-26"},
+			},
+			StartAfter:    "2024-01-01",
+			EndBefore:     "2025-01-01",
+			Catchup:       "all",
+			CatchupWindow: time.Hour,
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
 			Payload:      "payload",
@@ -3355,9 +3398,22 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						KillTimeout: 10 * time.Second,
 						KillSignal:  "SIGQUIT",
 						LogConfig: &structs.LogConfig{
-							Disabled:      true,
-							MaxFiles:      10,
-							MaxFileSizeMB: 100,
+							Disabled:       true,
+							MaxFiles:       10,
+							MaxFileSizeMB:  100,
+							Compression:    "zstd",
+							RotateInterval: time.Hour,
+							Timestamps:     true,
+							Sinks: []*structs.LogSink{
+								{
+									Type:          structs.LogSinkTypeHTTP,
+									Address:       "http://127.0.0.1:8080",
+									Headers:       map[string]string{"Authorization": "Bearer secret"},
+									BufferSize:    100,
+									BatchSize:     10,
+									BatchInterval: 5 * time.Second,
+								},
+							},
 						},
 						Artifacts: []*structs.TaskArtifact{
 							{
@@ -329,6 +329,17 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled,
 		},
 	}
+	for _, w := range conf.NodeWeights {
+		if w == nil {
+			continue
+		}
+		args.Config.NodeWeights = append(args.Config.NodeWeights, &structs.SchedulerNodeWeight{
+			Attribute: w.Attribute,
+			Value:     w.Value,
+			Operand:   w.Operand,
+			Weight:    w.Weight,
+		})
+	}
 
 	if err := args.Config.Validate(); err != nil {
 		return nil, CodedError(http.StatusBadRequest, err.Error())
@@ -353,6 +364,27 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	return reply, nil
 }
 
+// OperatorSchedulerRebalance is used to report the allocations the rebalancer
+// would migrate in its next cycle.
+func (s *HTTPServer) OperatorSchedulerRebalance(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.SchedulerRebalanceReportResponse
+	if err := s.agent.RPC("Operator.SchedulerRebalanceReport", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
 func (s *HTTPServer) SnapshotRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case http.MethodGet:
@@ -508,6 +508,24 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	})
 }
 
+func TestOperator_SchedulerRebalance(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/operator/scheduler/rebalance", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerRebalance(resp, req)
+		must.NoError(t, err)
+		must.Eq(t, 200, resp.Code)
+		out, ok := obj.(structs.SchedulerRebalanceReportResponse)
+		must.True(t, ok)
+		must.Len(t, 0, out.Moves)
+
+		req, _ = http.NewRequest(http.MethodPut, "/v1/operator/scheduler/rebalance", nil)
+		_, err = s.Server.OperatorSchedulerRebalance(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, ErrInvalidMethod)
+	})
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
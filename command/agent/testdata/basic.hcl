@@ -97,6 +97,10 @@ client {
   gc_max_allocs            = 50
   no_host_uuid             = false
   disable_remote_exec      = true
+  log_sink_allowed_hosts   = ["logs.example.com", "10.0.0.1:514"]
+  log_sink_socket_dirs     = ["/var/run/logs"]
+  enforce_ephemeral_disk   = true
+  disk_usage_interval      = "10s"
 
   host_volume "tmp" {
     path = "/tmp"
@@ -118,6 +122,8 @@ server {
   node_gc_threshold             = "12h"
   job_gc_interval               = "3m"
   job_gc_threshold              = "12h"
+  rebalance_interval            = "10m"
+  rebalance_max_migrations      = 3
   eval_gc_threshold             = "12h"
   deployment_gc_threshold       = "12h"
   csi_volume_claim_gc_interval  = "3m"
@@ -140,6 +146,7 @@ server {
   raft_multiplier               = 4
   enable_event_broker           = false
   event_buffer_size             = 200
+  event_sink_file_dir           = "/var/lib/nomad/events"
   job_default_priority          = 100
   job_max_priority              = 200
 
@@ -149,6 +156,11 @@ server {
     node_window    = "41m"
   }
 
+  eval_broker {
+    fair_share     = true
+    priority_aging = "3m"
+  }
+
   server_join {
     retry_join     = ["1.1.1.1", "2.2.2.2"]
     retry_max      = 3
@@ -349,3 +361,13 @@ reporting {
     enabled = true
   }
 }
+
+dns {
+  enabled     = true
+  address     = "127.0.0.1"
+  port        = 8600
+  domain      = "example"
+  ttl         = "30s"
+  token       = "dns-token"
+  allow_stale = false
+}
@@ -27,6 +27,10 @@ type AllocLogsCommand struct {
 	numLines                                   int64
 	numBytes                                   int64
 	task                                       string
+	since, until                               string
+
+	// sinceTime and untilTime are the parsed -since and -until flags.
+	sinceTime, untilTime time.Time
 }
 
 func (l *AllocLogsCommand) Help() string {
@@ -80,6 +84,16 @@ Logs Specific Options:
   -c
     Sets the tail location in number of bytes relative to the end of the logs.
 
+  -since
+    Show the logs written since a time, given either as a duration before now
+    like "10m" or as an RFC3339 timestamp. Requires the task to record log
+    timestamps with the "timestamps" option of its logs block.
+
+  -until
+    Show the logs written until a time, given either as a duration before now
+    or as an RFC3339 timestamp. Requires the task to record log timestamps,
+    and can't be used with -f.
+
   Note that the -no-color option applies to Nomad's own output. If the task's
   logs include terminal escape sequences for color codes, Nomad will not
   remove them.
@@ -104,6 +118,8 @@ func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 			"-tail":    complete.PredictAnything,
 			"-n":       complete.PredictAnything,
 			"-c":       complete.PredictAnything,
+			"-since":   complete.PredictAnything,
+			"-until":   complete.PredictAnything,
 		})
 }
 
@@ -137,12 +153,37 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.Int64Var(&l.numLines, "n", -1, "")
 	flags.Int64Var(&l.numBytes, "c", -1, "")
 	flags.StringVar(&l.task, "task", "", "")
+	flags.StringVar(&l.since, "since", "", "")
+	flags.StringVar(&l.until, "until", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 	args = flags.Args()
 
+	var err error
+	now := time.Now()
+	if l.since != "" {
+		if l.sinceTime, err = parseLogTime(l.since, now); err != nil {
+			l.Ui.Error(fmt.Sprintf("Failed to parse -since: %v", err))
+			return 1
+		}
+	}
+	if l.until != "" {
+		if l.untilTime, err = parseLogTime(l.until, now); err != nil {
+			l.Ui.Error(fmt.Sprintf("Failed to parse -until: %v", err))
+			return 1
+		}
+		if l.follow {
+			l.Ui.Error("-until can't be used with -f")
+			return 1
+		}
+	}
+	if (l.since != "" || l.until != "") && (l.tail || l.numLines != -1 || l.numBytes != -1) {
+		l.Ui.Error("-since and -until can't be used with -tail, -n or -c")
+		return 1
+	}
+
 	if numArgs := len(args); numArgs < 1 {
 		if l.job {
 			l.Ui.Error("A job ID is required")
@@ -314,7 +355,7 @@ func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
 	logType, origin string, offset int64) (io.ReadCloser, error) {
 
 	cancel := make(chan struct{})
-	frames, errCh := client.AllocFS().Logs(alloc, l.follow, l.task, logType, origin, offset, cancel, nil)
+	frames, errCh := l.logs(client, alloc, l.follow, logType, origin, offset, cancel)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -357,8 +398,8 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	// exit.
 	defer close(cancel)
 
-	stdoutFrames, stdoutErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStdout, api.OriginEnd, 0, cancel, nil)
+	stdoutFrames, stdoutErrCh := l.logs(
+		client, alloc, true, api.FSLogNameStdout, api.OriginEnd, 0, cancel)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -368,8 +409,8 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	default:
 	}
 
-	stderrFrames, stderrErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStderr, api.OriginEnd, 0, cancel, nil)
+	stderrFrames, stderrErrCh := l.logs(
+		client, alloc, true, api.FSLogNameStderr, api.OriginEnd, 0, cancel)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -416,6 +457,30 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	}
 }
 
+// logs streams the logs of the task, from the -since time if it is set or
+// from the offset otherwise.
+func (l *AllocLogsCommand) logs(client *api.Client, alloc *api.Allocation, follow bool,
+	logType, origin string, offset int64, cancel <-chan struct{}) (<-chan *api.StreamFrame, <-chan error) {
+
+	if !l.sinceTime.IsZero() || !l.untilTime.IsZero() {
+		return client.AllocFS().LogsByTime(alloc, follow, l.task, logType, l.sinceTime, l.untilTime, cancel, nil)
+	}
+	return client.AllocFS().Logs(alloc, follow, l.task, logType, origin, offset, cancel, nil)
+}
+
+// parseLogTime parses the value of the -since and -until flags, which is
+// either a duration before now or an RFC3339 timestamp.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration nor an RFC3339 timestamp", value)
+	}
+	return t, nil
+}
+
 func lookupAllocTask(alloc *api.Allocation) (string, error) {
 	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
 	if tg == nil {
@@ -5,6 +5,7 @@ package command
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -33,6 +34,20 @@ func TestLogsCommand_Fails(t *testing.T) {
 
 	ui.ErrorWriter.Reset()
 
+	// Fails on invalid times
+	code = cmd.Run([]string{"-address=" + url, "-since=yesterday", "foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Failed to parse -since")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on following logs until a time
+	code = cmd.Run([]string{"-address=" + url, "-until=5m", "-f", "foobar"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "-until can't be used with -f")
+
+	ui.ErrorWriter.Reset()
+
 	// Fails on connection failure
 	code = cmd.Run([]string{"-address=nope", "foobar"})
 	must.One(t, code)
@@ -68,6 +83,23 @@ func TestLogsCommand_Fails(t *testing.T) {
 	must.StrContains(t, out, "No allocation(s) with prefix or id")
 }
 
+func TestLogsCommand_parseLogTime(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	since, err := parseLogTime("10m", now)
+	must.NoError(t, err)
+	must.Eq(t, now.Add(-10*time.Minute), since)
+
+	since, err = parseLogTime("2024-03-01T11:30:00Z", now)
+	must.NoError(t, err)
+	must.Eq(t, now.Add(-30*time.Minute), since)
+
+	_, err = parseLogTime("yesterday", now)
+	must.ErrorContains(t, err, "neither a duration nor an RFC3339 timestamp")
+}
+
 func TestLogsCommand_AutocompleteArgs(t *testing.T) {
 	ci.Parallel(t)
 
@@ -614,9 +614,13 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 	if max := resource.MemoryMaxMB; max != nil && *max != 0 && *max != *resource.MemoryMB {
 		memMax = "Max: " + humanize.IBytes(uint64(*resource.MemoryMaxMB*bytesPerMegabyte))
 	}
+	diskUsage := humanize.IBytes(uint64(*alloc.Resources.DiskMB * bytesPerMegabyte))
 	var deviceStats []*api.DeviceGroupStats
 
 	if stats != nil {
+		if ds := stats.DiskStats; ds != nil {
+			diskUsage = fmt.Sprintf("%v/%v", humanize.IBytes(ds.Used), diskUsage)
+		}
 		if ru, ok := stats.Tasks[task]; ok && ru != nil && ru.ResourceUsage != nil {
 			if cs := ru.ResourceUsage.CpuStats; cs != nil {
 				cpuUsage = fmt.Sprintf("%v/%v", math.Floor(cs.TotalTicks), cpuUsage)
@@ -636,7 +640,7 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 	resourcesOutput = append(resourcesOutput, fmt.Sprintf("%v MHz|%v|%v|%v",
 		cpuUsage,
 		memUsage,
-		humanize.IBytes(uint64(*alloc.Resources.DiskMB*bytesPerMegabyte)),
+		diskUsage,
 		firstAddr))
 	if memMax != "" || secondAddr != "" {
 		resourcesOutput = append(resourcesOutput, fmt.Sprintf("|%v||%v", memMax, secondAddr))
@@ -428,6 +428,14 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job logs": func() (cli.Command, error) {
+			// Use a *cli.ConcurrentUi because this command spawns several
+			// goroutines that write to the terminal concurrently.
+			meta.Ui = &cli.ConcurrentUi{Ui: meta.Ui}
+			return &JobLogsCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job restart": func() (cli.Command, error) {
 			// Use a *cli.ConcurrentUi because this command spawns several
 			// goroutines that write to the terminal concurrently.
@@ -476,6 +484,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job periodic next": func() (cli.Command, error) {
+			return &JobPeriodicNextCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job plan": func() (cli.Command, error) {
 			return &JobPlanCommand{
 				Meta: meta,
@@ -784,6 +797,31 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator event-sink": func() (cli.Command, error) {
+			return &OperatorEventSinkCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator event-sink delete": func() (cli.Command, error) {
+			return &OperatorEventSinkDeleteCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator event-sink list": func() (cli.Command, error) {
+			return &OperatorEventSinkListCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator event-sink register": func() (cli.Command, error) {
+			return &OperatorEventSinkRegisterCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator event-sink status": func() (cli.Command, error) {
+			return &OperatorEventSinkStatusCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator scheduler": func() (cli.Command, error) {
 			return &OperatorSchedulerCommand{
 				Meta: meta,
@@ -794,11 +832,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler rebalance": func() (cli.Command, error) {
+			return &OperatorSchedulerRebalanceCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator scheduler set-config": func() (cli.Command, error) {
 			return &OperatorSchedulerSetConfig{
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler simulate": func() (cli.Command, error) {
+			return &OperatorSchedulerSimulateCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator root keyring": func() (cli.Command, error) {
 			return &OperatorRootKeyringCommand{
 				Meta: meta,
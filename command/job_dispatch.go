@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -27,6 +28,10 @@ Usage: nomad job dispatch [options] <parameterized job> [input source]
   path to a file. Metadata can be supplied by using the meta flag one or more
   times.
 
+  A range of work items can be dispatched as a single job array with the array
+  flag. Each task group of the dispatched job then runs one allocation per
+  array index, which is available to its tasks as NOMAD_ARRAY_INDEX.
+
   An optional idempotency token can be used to prevent more than one instance
   of the job to be dispatched. If an instance with the same token already
   exists, the command returns without any action.
@@ -54,6 +59,15 @@ Dispatch Options:
     once to inject multiple metadata key/value pairs. Arbitrary keys are not
     allowed. The parameterized job must allow the key to be merged.
 
+  -array <start>-<end>
+    Dispatch a single job array running one allocation of each task group per
+    index from start to end, inclusive. Only batch jobs may be dispatched as
+    job arrays.
+
+  -array-max-parallel <count>
+    Maximum number of allocations of each task group of the job array running
+    at the same time. Defaults to 0, which places the whole array at once.
+
   -detach
     Return immediately instead of entering monitor mode. After job dispatch,
     the evaluation ID will be printed to the screen, which can be used to
@@ -79,10 +93,12 @@ func (c *JobDispatchCommand) Synopsis() string {
 func (c *JobDispatchCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-meta":              complete.PredictAnything,
-			"-detach":            complete.PredictNothing,
-			"-idempotency-token": complete.PredictAnything,
-			"-verbose":           complete.PredictNothing,
+			"-meta":               complete.PredictAnything,
+			"-array":              complete.PredictAnything,
+			"-array-max-parallel": complete.PredictAnything,
+			"-detach":             complete.PredictNothing,
+			"-idempotency-token":  complete.PredictAnything,
+			"-verbose":            complete.PredictNothing,
 		})
 }
 
@@ -117,6 +133,8 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	var idempotencyToken string
 	var meta []string
 	var idPrefixTemplate string
+	var array string
+	var arrayMaxParallel int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -125,6 +143,8 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	flags.StringVar(&idempotencyToken, "idempotency-token", "", "")
 	flags.Var((*flaghelper.StringFlag)(&meta), "meta", "")
 	flags.StringVar(&idPrefixTemplate, "id-prefix-template", "", "")
+	flags.StringVar(&array, "array", "", "")
+	flags.IntVar(&arrayMaxParallel, "array-max-parallel", 0, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -144,6 +164,20 @@ func (c *JobDispatchCommand) Run(args []string) int {
 		return 1
 	}
 
+	var jobArray *api.JobArray
+	if array != "" {
+		var err error
+		jobArray, err = parseJobArray(array)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing array: %s", err))
+			return 1
+		}
+		jobArray.MaxParallel = arrayMaxParallel
+	} else if arrayMaxParallel != 0 {
+		c.Ui.Error("The -array-max-parallel flag requires the -array flag")
+		return 1
+	}
+
 	var payload []byte
 	var readErr error
 
@@ -195,7 +229,7 @@ func (c *JobDispatchCommand) Run(args []string) int {
 		IdempotencyToken: idempotencyToken,
 		Namespace:        namespace,
 	}
-	resp, _, err := client.Jobs().Dispatch(jobID, metaMap, payload, idPrefixTemplate, w)
+	resp, _, err := client.Jobs().DispatchArray(jobID, metaMap, payload, idPrefixTemplate, jobArray, w)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Failed to dispatch job: %s", err))
 		return 1
@@ -222,3 +256,24 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	mon := newMonitor(c.Ui, client, length)
 	return mon.monitor(resp.EvalID)
 }
+
+// parseJobArray parses an array range of the form "<start>-<end>".
+func parseJobArray(s string) (*api.JobArray, error) {
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, fmt.Errorf("expected <start>-<end>, got %q", s)
+	}
+
+	var array api.JobArray
+	var err error
+	if array.Start, err = strconv.Atoi(start); err != nil {
+		return nil, fmt.Errorf("invalid start %q", start)
+	}
+	if array.End, err = strconv.Atoi(end); err != nil {
+		return nil, fmt.Errorf("invalid end %q", end)
+	}
+	if array.End < array.Start {
+		return nil, fmt.Errorf("end %d is lower than start %d", array.End, array.Start)
+	}
+	return &array, nil
+}
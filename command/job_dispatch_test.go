@@ -54,6 +54,24 @@ func TestJobDispatchCommand_Fails(t *testing.T) {
 	ui.ErrorWriter.Reset()
 }
 
+func TestJobDispatchCommand_ParseJobArray(t *testing.T) {
+	ci.Parallel(t)
+
+	array, err := parseJobArray("0-9999")
+	must.NoError(t, err)
+	must.Eq(t, &api.JobArray{Start: 0, End: 9999}, array)
+
+	for _, s := range []string{"10", "a-9", "0-b", "9-0"} {
+		_, err := parseJobArray(s)
+		must.Error(t, err, must.Sprintf("expected error parsing %q", s))
+	}
+
+	ui := cli.NewMockUi()
+	cmd := &JobDispatchCommand{Meta: Meta{Ui: ui}}
+	must.One(t, cmd.Run([]string{"-array-max-parallel=2", "foo"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "requires the -array flag")
+}
+
 func TestJobDispatchCommand_AutocompleteArgs(t *testing.T) {
 	ci.Parallel(t)
 
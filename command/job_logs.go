@@ -0,0 +1,432 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobLogsCommand struct {
+	Meta
+
+	// The fields below represent the commands flags.
+	verbose, stderr, follow bool
+	group, task, since      string
+
+	// sinceTime is the parsed -since flag.
+	sinceTime time.Time
+
+	client *api.Client
+	length int
+
+	// streams are the allocation tasks whose logs are being streamed, keyed
+	// by allocation ID and task name.
+	streams map[string]struct{}
+}
+
+func (c *JobLogsCommand) Help() string {
+	helpText := `
+Usage: nomad job logs [options] <job>
+
+  Streams the logs of the tasks of every running allocation of a job. Each line
+  is prefixed with the allocation ID and the task it was logged by. When
+  following the logs, the allocations placed for the job afterwards are
+  followed as well.
+
+  When ACLs are enabled, this command requires a token with the 'read-logs',
+  'read-job', and 'list-jobs' capabilities for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Logs Options:
+
+  -group <group-name>
+    Only stream the logs of the allocations of the task group.
+
+  -task <task-name>
+    Only stream the logs of the task. By default, the logs of all the tasks of
+    the allocations are streamed.
+
+  -stderr
+    Display stderr logs instead of stdout logs.
+
+  -f
+    Causes the output to not stop when the end of the logs are reached, but
+    rather to wait for additional output and for new allocations.
+
+  -since
+    Show the logs written since a time, given either as a duration before now
+    like "10m" or as an RFC3339 timestamp. Requires the tasks to record log
+    timestamps with the "timestamps" option of their logs block.
+
+  -verbose
+    Display full allocation IDs.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobLogsCommand) Synopsis() string {
+	return "Streams the logs of all the allocations of a job"
+}
+
+func (c *JobLogsCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-group":   complete.PredictAnything,
+			"-task":    complete.PredictAnything,
+			"-stderr":  complete.PredictNothing,
+			"-f":       complete.PredictNothing,
+			"-since":   complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *JobLogsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobLogsCommand) Name() string { return "job logs" }
+
+func (c *JobLogsCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&c.verbose, "verbose", false, "")
+	flags.BoolVar(&c.stderr, "stderr", false, "")
+	flags.BoolVar(&c.follow, "f", false, "")
+	flags.StringVar(&c.group, "group", "", "")
+	flags.StringVar(&c.task, "task", "", "")
+	flags.StringVar(&c.since, "since", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	var err error
+	if c.since != "" {
+		if c.sinceTime, err = parseLogTime(c.since, time.Now()); err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse -since: %v", err))
+			return 1
+		}
+	}
+
+	// Truncate the id unless full length is requested
+	c.length = shortId
+	if c.verbose {
+		c.length = fullId
+	}
+
+	// Get the HTTP client
+	c.client, err = c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(c.client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	q := &api.QueryOptions{Namespace: namespace}
+	job, _, err := c.client.Jobs().Info(jobID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving job: %s", err))
+		return 1
+	}
+	if err := c.validateFilters(job); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	allocs, qm, err := c.client.Jobs().Allocations(jobID, false, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving allocations: %s", err))
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, 1)
+	c.streams = make(map[string]struct{})
+	c.streamAllocs(ctx, allocs, &wg, errCh)
+
+	if !c.follow {
+		if len(c.streams) == 0 {
+			c.Ui.Error(fmt.Sprintf("No running allocations found for job %q", jobID))
+			return 1
+		}
+
+		// Stream errors don't stop the other streams, but make the command
+		// fail once they are done.
+		go func() {
+			wg.Wait()
+			close(errCh)
+		}()
+		code := 0
+		for err := range errCh {
+			if err != nil {
+				c.Ui.Error(err.Error())
+				code = 1
+			}
+		}
+		return code
+	}
+
+	// Watch the job allocations to follow the allocations placed afterwards
+	allocsCh := make(chan []*api.AllocationListStub)
+	go c.watchAllocs(ctx, jobID, qm.LastIndex, q, allocsCh, errCh)
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	for {
+		select {
+		case <-signalCh:
+			return 0
+		case allocs := <-allocsCh:
+			c.streamAllocs(ctx, allocs, &wg, errCh)
+		case err := <-errCh:
+			c.Ui.Error(err.Error())
+		}
+	}
+}
+
+// validateFilters returns an error if the -group or -task flags don't match
+// the job.
+func (c *JobLogsCommand) validateFilters(job *api.Job) error {
+	var groups []*api.TaskGroup
+	if c.group != "" {
+		tg := job.LookupTaskGroup(c.group)
+		if tg == nil {
+			return fmt.Errorf("Group %q not found in job %q", c.group, *job.ID)
+		}
+		groups = []*api.TaskGroup{tg}
+	} else {
+		groups = job.TaskGroups
+	}
+
+	if c.task == "" {
+		return nil
+	}
+	for _, tg := range groups {
+		for _, task := range tg.Tasks {
+			if task.Name == c.task {
+				return nil
+			}
+		}
+	}
+	if c.group != "" {
+		return fmt.Errorf("Task %q not found in group %q", c.task, c.group)
+	}
+	return fmt.Errorf("Task %q not found in job %q", c.task, *job.ID)
+}
+
+// streamAllocs starts streaming the logs of the started tasks of the running
+// allocations which are not already being streamed. Errors are sent to errCh.
+func (c *JobLogsCommand) streamAllocs(ctx context.Context, allocs []*api.AllocationListStub,
+	wg *sync.WaitGroup, errCh chan<- error) {
+
+	for _, alloc := range allocs {
+		if alloc.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+		if c.group != "" && alloc.TaskGroup != c.group {
+			continue
+		}
+
+		tasks := make([]string, 0, len(alloc.TaskStates))
+		for task, state := range alloc.TaskStates {
+			if state == nil || state.StartedAt.IsZero() {
+				continue
+			}
+			if c.task != "" && task != c.task {
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+		sort.Strings(tasks)
+
+		for _, task := range tasks {
+			key := alloc.ID + "/" + task
+			if _, ok := c.streams[key]; ok {
+				continue
+			}
+			c.streams[key] = struct{}{}
+
+			wg.Add(1)
+			go func(alloc *api.AllocationListStub, task string) {
+				defer wg.Done()
+				if err := c.streamTask(ctx, alloc, task); err != nil {
+					select {
+					case errCh <- err:
+					case <-ctx.Done():
+					}
+				}
+			}(alloc, task)
+		}
+	}
+}
+
+// streamTask outputs the log lines of the task, prefixed with the allocation
+// ID and task name, until the end of the logs is reached or ctx is cancelled.
+func (c *JobLogsCommand) streamTask(ctx context.Context, stub *api.AllocationListStub, task string) error {
+	prefix := fmt.Sprintf("[%s/%s]", limit(stub.ID, c.length), task)
+
+	q := &api.QueryOptions{Namespace: stub.Namespace}
+	alloc, _, err := c.client.Allocations().Info(stub.ID, q)
+	if err != nil {
+		return fmt.Errorf("%s Error querying allocation: %v", prefix, err)
+	}
+
+	logType := api.FSLogNameStdout
+	output := c.Ui.Output
+	if c.stderr {
+		logType = api.FSLogNameStderr
+		output = c.Ui.Warn
+	}
+
+	cancel := make(chan struct{})
+	defer close(cancel)
+
+	var frames <-chan *api.StreamFrame
+	var errCh <-chan error
+	if !c.sinceTime.IsZero() {
+		frames, errCh = c.client.AllocFS().LogsByTime(
+			alloc, c.follow, task, logType, c.sinceTime, time.Time{}, cancel, nil)
+	} else {
+		frames, errCh = c.client.AllocFS().Logs(
+			alloc, c.follow, task, logType, api.OriginStart, 0, cancel, nil)
+	}
+
+	w := &logLineWriter{prefix: prefix, output: output}
+	defer w.Flush()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			return fmt.Errorf("%s Error streaming logs: %v", prefix, err)
+		case frame, ok := <-frames:
+			if !ok {
+				return nil
+			}
+			w.Write(frame.Data)
+		}
+	}
+}
+
+// watchAllocs sends the allocations of the job to allocsCh every time they
+// change, until ctx is cancelled.
+func (c *JobLogsCommand) watchAllocs(ctx context.Context, jobID string, index uint64,
+	q *api.QueryOptions, allocsCh chan<- []*api.AllocationListStub, errCh chan<- error) {
+
+	q = q.WithContext(ctx)
+	q.WaitIndex = index
+	for {
+		allocs, qm, err := c.client.Jobs().Allocations(jobID, false, q)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case errCh <- fmt.Errorf("Error retrieving allocations: %v", err):
+			case <-ctx.Done():
+				return
+			}
+
+			// Retry after a while instead of hammering the server
+			select {
+			case <-time.After(time.Second):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if qm.LastIndex != q.WaitIndex {
+			select {
+			case allocsCh <- allocs:
+			case <-ctx.Done():
+				return
+			}
+		}
+		q.WaitIndex = qm.LastIndex
+	}
+}
+
+// logLineWriter splits the logs of a task into lines, and outputs each line
+// with a prefix identifying the allocation and task it was logged by.
+type logLineWriter struct {
+	prefix  string
+	output  func(string)
+	partial []byte
+}
+
+// Write outputs the complete lines of p. An incomplete line is held until the
+// rest of the line is written or the writer is flushed.
+func (w *logLineWriter) Write(p []byte) {
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.partial = append(w.partial, p...)
+			return
+		}
+
+		line := p[:i]
+		if len(w.partial) > 0 {
+			line = append(w.partial, line...)
+			w.partial = w.partial[:0]
+		}
+		w.output(w.prefix + " " + string(bytes.TrimSuffix(line, []byte{'\r'})))
+		p = p[i+1:]
+	}
+}
+
+// Flush outputs the incomplete line held by the writer, if any.
+func (w *logLineWriter) Flush() {
+	if len(w.partial) > 0 {
+		w.output(w.prefix + " " + string(w.partial))
+		w.partial = nil
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobLogsCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobLogsCommand{}
+}
+
+func TestJobLogsCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid time
+	code = cmd.Run([]string{"-address=" + url, "-since=yesterday", "foo"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Failed to parse -since")
+
+	ui.ErrorWriter.Reset()
+
+	// Bad job name
+	code = cmd.Run([]string{"-address=" + url, "foo"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No job(s) with prefix or ID \"foo\" found")
+
+	ui.ErrorWriter.Reset()
+
+	job := mock.Job()
+	state := srv.Agent.Server().State()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	// Bad group and task names
+	code = cmd.Run([]string{"-address=" + url, "-group=api", job.ID})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `Group "api" not found`)
+
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-group=web", "-task=api", job.ID})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `Task "api" not found in group "web"`)
+
+	ui.ErrorWriter.Reset()
+
+	// No running allocations
+	code = cmd.Run([]string{"-address=" + url, "-task=web", job.ID})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No running allocations found")
+}
+
+func TestJobLogsCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to be ready
+	waitForNodes(t, client)
+
+	jobID := uuid.Generate()
+	job := testJob(jobID)
+	job.TaskGroups[0].Count = pointer.Of(2)
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for":       "30s",
+		"stdout_string": "hello\n",
+	}
+	resp, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+
+	evalUi := cli.NewMockUi()
+	code := waitForSuccess(evalUi, client, fullId, t, resp.EvalID)
+	must.Zero(t, code)
+
+	var allocIDs []string
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, _, err := client.Jobs().Allocations(jobID, false, nil)
+		if err != nil {
+			return false, fmt.Errorf("failed to get allocations: %v", err)
+		}
+		if len(allocs) != 2 {
+			return false, fmt.Errorf("expected 2 allocations, got %d", len(allocs))
+		}
+
+		allocIDs = nil
+		for _, alloc := range allocs {
+			if alloc.ClientStatus != api.AllocClientStatusRunning {
+				return false, fmt.Errorf("alloc is not running yet: %v", alloc.ClientStatus)
+			}
+			allocIDs = append(allocIDs, alloc.ID)
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+
+	// The logs of every allocation are output with their prefix
+	testutil.WaitForResult(func() (bool, error) {
+		ui := cli.NewMockUi()
+		cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+		if code := cmd.Run([]string{"-address=" + url, "-verbose", jobID}); code != 0 {
+			return false, fmt.Errorf("expected exit code 0, got %d: %s", code, ui.ErrorWriter.String())
+		}
+
+		out := ui.OutputWriter.String()
+		for _, id := range allocIDs {
+			if line := fmt.Sprintf("[%s/task1] hello", id); !strings.Contains(out, line) {
+				return false, fmt.Errorf("expected output to contain %q, got %q", line, out)
+			}
+		}
+		return true, nil
+	}, func(err error) { must.NoError(t, err) })
+}
+
+func TestJobLogsCommand_logLineWriter(t *testing.T) {
+	ci.Parallel(t)
+
+	var lines []string
+	w := &logLineWriter{
+		prefix: "[abcd1234/web]",
+		output: func(line string) { lines = append(lines, line) },
+	}
+
+	w.Write([]byte("hello\r\nwor"))
+	w.Write([]byte("ld\n\nunterminated"))
+	must.Eq(t, []string{"[abcd1234/web] hello", "[abcd1234/web] world", "[abcd1234/web] "}, lines)
+
+	// Flushing outputs the incomplete line
+	w.Flush()
+	must.Eq(t, "[abcd1234/web] unterminated", lines[len(lines)-1])
+
+	w.Flush()
+	must.Len(t, 4, lines)
+}
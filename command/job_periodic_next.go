@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type JobPeriodicNextCommand struct {
+	Meta
+}
+
+func (c *JobPeriodicNextCommand) Help() string {
+	helpText := `
+Usage: nomad job periodic next [options] <job id>
+
+  This command is used to display the schedule of a periodic job and the times
+  of its next launches. The launch times honor the exclusions and the
+  start_after and end_before bounds of the job's periodic block.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Periodic Next Options:
+
+  -count <n>
+    The number of upcoming launches to display. Defaults to 5.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobPeriodicNextCommand) Synopsis() string {
+	return "Display the next launches of a periodic job"
+}
+
+func (c *JobPeriodicNextCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-count": complete.PredictAnything,
+		})
+}
+
+func (c *JobPeriodicNextCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Jobs().PrefixList(a.Last)
+		if err != nil {
+			return []string{}
+		}
+
+		// filter this by periodic jobs
+		matches := make([]string, 0, len(resp))
+		for _, job := range resp {
+			if job.Periodic {
+				matches = append(matches, job.ID)
+			}
+		}
+		return matches
+	})
+}
+
+func (c *JobPeriodicNextCommand) Name() string { return "job periodic next" }
+
+func (c *JobPeriodicNextCommand) Run(args []string) int {
+	var count int
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.IntVar(&count, "count", 5, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if count < 1 {
+		c.Ui.Error("The -count flag must be greater than zero")
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, func(j *api.JobListStub) bool {
+		return j.Periodic
+	})
+	if err != nil {
+		var noPrefixErr *NoJobWithPrefixError
+		if errors.As(err, &noPrefixErr) {
+			err = fmt.Errorf("No periodic job(s) with prefix or ID %q found", jobIDPrefix)
+		}
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	job, _, err := client.Jobs().Info(jobID, &api.QueryOptions{Namespace: namespace})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying job %q: %s", jobID, err))
+		return 1
+	}
+	if job.Periodic == nil {
+		c.Ui.Error(fmt.Sprintf("Job %q is not periodic", jobID))
+		return 1
+	}
+
+	c.Ui.Output(formatKV(formatPeriodicSchedule(job.Periodic)))
+
+	if len(job.Periodic.Exclude) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Exclusions[reset]"))
+		c.Ui.Output(formatList(formatPeriodicExclusions(job.Periodic.Exclude)))
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Next Launches[reset]"))
+	if job.Stop != nil && *job.Stop {
+		c.Ui.Output("No launches (job stopped)")
+		return 0
+	}
+
+	launches, err := periodicNextLaunches(job.Periodic, time.Now(), count)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error computing next launches: %s", err))
+		return 1
+	}
+	if len(launches) == 0 {
+		c.Ui.Output("No launches")
+		return 0
+	}
+	for _, launch := range launches {
+		c.Ui.Output(formatTime(launch))
+	}
+	return 0
+}
+
+// formatPeriodicSchedule returns the key/value pairs describing the schedule
+// of a periodic job.
+func formatPeriodicSchedule(p *api.PeriodicConfig) []string {
+	specs := p.Specs
+	if p.Spec != nil && *p.Spec != "" {
+		specs = append([]string{*p.Spec}, specs...)
+	}
+
+	timeZone := "UTC"
+	if p.TimeZone != nil && *p.TimeZone != "" {
+		timeZone = *p.TimeZone
+	}
+
+	out := []string{
+		fmt.Sprintf("Cron|%s", strings.Join(specs, ", ")),
+		fmt.Sprintf("Time Zone|%s", timeZone),
+	}
+	if p.StartAfter != nil && *p.StartAfter != "" {
+		out = append(out, fmt.Sprintf("Start After|%s", *p.StartAfter))
+	}
+	if p.EndBefore != nil && *p.EndBefore != "" {
+		out = append(out, fmt.Sprintf("End Before|%s", *p.EndBefore))
+	}
+	if p.Catchup != nil && *p.Catchup != "" {
+		catchup := *p.Catchup
+		if p.CatchupWindow != nil && *p.CatchupWindow > 0 {
+			catchup = fmt.Sprintf("%s (within %s)", catchup, *p.CatchupWindow)
+		}
+		out = append(out, fmt.Sprintf("Catch Up|%s", catchup))
+	}
+	return out
+}
+
+// formatPeriodicExclusions returns the rows of the exclusions table.
+func formatPeriodicExclusions(exclusions []*api.PeriodicExclusion) []string {
+	out := make([]string, 0, len(exclusions)+1)
+	out = append(out, "Cron|Start|End")
+	for _, e := range exclusions {
+		out = append(out, fmt.Sprintf("%s|%s|%s",
+			limit(e.Cron, 40), e.Start, e.End))
+	}
+	return out
+}
+
+// periodicNextLaunches returns up to count launch times of the periodic
+// configuration after now. Fewer launches are returned if the schedule ends.
+func periodicNextLaunches(p *api.PeriodicConfig, now time.Time, count int) ([]time.Time, error) {
+	location, err := p.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	launches := make([]time.Time, 0, count)
+	from := now.In(location)
+	for len(launches) < count {
+		next, err := p.Next(from)
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() {
+			break
+		}
+		launches = append(launches, next)
+		from = next
+	}
+	return launches, nil
+}
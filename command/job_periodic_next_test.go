@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobPeriodicNextCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobPeriodicNextCommand{}
+}
+
+func TestJobPeriodicNextCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobPeriodicNextCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-count=0", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "must be greater than zero")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "12"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying job prefix")
+}
+
+func TestJobPeriodicNextCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Register a periodic job which skips the weekends
+	j := testJob("job_is_periodic")
+	j.Periodic = &api.PeriodicConfig{
+		SpecType: pointer.Of(api.PeriodicSpecCron),
+		Spec:     pointer.Of("0 2 * * *"),
+		Exclude: []*api.PeriodicExclusion{
+			{Cron: "* * * * 0,6"},
+		},
+	}
+	_, _, err := client.Jobs().Register(j, nil)
+	must.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	cmd := &JobPeriodicNextCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	code := cmd.Run([]string{"-address=" + url, "-count=3", "job_is_periodic"})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "0 2 * * *")
+	must.StrContains(t, out, "Exclusions")
+	must.StrContains(t, out, "* * * * 0,6")
+
+	_, launches, found := strings.Cut(out, "Next Launches")
+	must.True(t, found)
+	lines := strings.Fields(launches)
+	must.Len(t, 3, lines)
+	for _, line := range lines {
+		launch, err := time.Parse(time.RFC3339, line)
+		must.NoError(t, err)
+		must.NotEq(t, time.Saturday, launch.Weekday())
+		must.NotEq(t, time.Sunday, launch.Weekday())
+	}
+}
+
+func TestPeriodicNextLaunches(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &api.PeriodicConfig{
+		SpecType:  pointer.Of(api.PeriodicSpecCron),
+		Spec:      pointer.Of("0 2 * * *"),
+		EndBefore: pointer.Of("2024-01-04"),
+	}
+	p.Canonicalize()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	launches, err := periodicNextLaunches(p, now, 5)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{
+		time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 3, 2, 0, 0, 0, time.UTC),
+	}, launches)
+}
@@ -452,6 +452,13 @@ func formatDryRun(resp *api.JobPlanResponse, job *api.Job) string {
 				noun += "s"
 			}
 			out += fmt.Sprintf("%s[yellow]Task Group %q (failed to place %d %s):\n[reset]", strings.Repeat(" ", 2), tg, metrics.CoalescedFailures+1, noun)
+			if group := job.LookupTaskGroup(tg); group != nil && group.Gang != nil && *group.Gang {
+				// The reverted placements are not counted as failures
+				failed := metrics.CoalescedFailures + 1
+				total := failed + metrics.GangPlacementsReverted
+				out += fmt.Sprintf("%s[yellow]Gang task group requires all %d allocations to be placed at once, %d could not be placed\n[reset]",
+					strings.Repeat(" ", 4), total, failed)
+			}
 			out += fmt.Sprintf("[yellow]%s[reset]\n\n", formatAllocMetrics(metrics, false, strings.Repeat(" ", 4)))
 		}
 		if rolling == nil {
@@ -345,3 +345,30 @@ func TestPlanCommand_JSON(t *testing.T) {
 	must.Eq(t, 255, code)
 	must.StrContains(t, ui.ErrorWriter.String(), "Error during plan: Put")
 }
+
+func TestPlanCommand_formatDryRun_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &api.Job{
+		ID:   pointer.Of("training"),
+		Type: pointer.Of(api.JobTypeBatch),
+		TaskGroups: []*api.TaskGroup{
+			{Name: pointer.Of("workers"), Gang: pointer.Of(true)},
+		},
+	}
+	resp := &api.JobPlanResponse{
+		FailedTGAllocs: map[string]*api.AllocationMetric{
+			"workers": {
+				NodesEvaluated:         2,
+				NodesExhausted:         2,
+				CoalescedFailures:      2,
+				GangPlacementsReverted: 5,
+			},
+		},
+	}
+
+	out := formatDryRun(resp, job)
+	must.StrContains(t, out, `Task Group "workers" (failed to place 3 allocations)`)
+	must.StrContains(t, out, "Gang task group requires all 8 allocations to be placed at once, 3 could not be placed")
+	must.StrContains(t, out, "5 placements reverted, as the gang task group could not be placed entirely")
+}
@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -274,6 +275,11 @@ func (c *JobStatusCommand) Run(args []string) int {
 		return 0
 	}
 
+	if err := c.outputDependencies(client, job); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
 	// Print periodic job information
 	if periodic && !parameterized {
 		if err := c.outputPeriodicInfo(client, job); err != nil {
@@ -454,6 +460,16 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 
 	c.outputReschedulingEvals(client, job, jobAllocs, c.length)
 
+	if job.Array != nil {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Job Array[reset]"))
+		c.Ui.Output(formatJobArray(job, jobAllocs))
+	}
+
+	if budgets := formatDisruptionBudgets(job, jobAllocs); budgets != "" {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Disruption Budgets[reset]"))
+		c.Ui.Output(budgets)
+	}
+
 	if latestDeployment != nil {
 		c.Ui.Output(c.Colorize().Color("\n[bold]Latest Deployment[reset]"))
 		c.Ui.Output(c.Colorize().Color(c.formatDeployment(client, latestDeployment)))
@@ -465,6 +481,109 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 	return nil
 }
 
+// outputDependencies prints the jobs the passed job depends on, followed by
+// their own dependencies, along with the job requiring each of them. If a
+// request fails, an error is returned.
+func (c *JobStatusCommand) outputDependencies(client *api.Client, job *api.Job) error {
+	if len(job.DependsOn) == 0 {
+		return nil
+	}
+
+	rows := []string{"Job ID|Namespace|Required By|Required Status|Status|Satisfied"}
+	path := map[string]struct{}{*job.Namespace + "/" + *job.ID: {}}
+	rows, err := formatJobDependencies(client, job, rows, path)
+	if err != nil {
+		return err
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Dependencies[reset]"))
+	c.Ui.Output(formatList(rows))
+	return nil
+}
+
+// formatJobDependencies appends a row for each dependency of the job to rows,
+// each followed by the rows of its own dependencies. The path holds the jobs
+// between the root and the job, so that a cycle is only printed once.
+func formatJobDependencies(client *api.Client, job *api.Job, rows []string,
+	path map[string]struct{}) ([]string, error) {
+
+	for _, d := range job.DependsOn {
+		namespace := d.Namespace
+		if namespace == "" {
+			namespace = *job.Namespace
+		}
+		status := d.Status
+		if status == "" {
+			status = api.JobDependencyStatusComplete
+		}
+
+		q := &api.QueryOptions{Namespace: namespace}
+		dependency, _, err := client.Jobs().Info(d.JobID, q)
+		if err != nil {
+			if strings.Contains(err.Error(), "404") {
+				rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|<none>|false",
+					d.JobID, namespace, *job.ID, status))
+				continue
+			}
+			return nil, fmt.Errorf("Error querying dependency %q: %s", d.JobID, err)
+		}
+		allocs, _, err := client.Jobs().Allocations(d.JobID, false, q)
+		if err != nil {
+			return nil, fmt.Errorf("Error querying dependency %q allocations: %s", d.JobID, err)
+		}
+
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s|%t",
+			d.JobID, namespace, *job.ID, status,
+			getStatusString(*dependency.Status, dependency.Stop),
+			jobDependencySatisfied(status, dependency, allocs)))
+
+		key := namespace + "/" + d.JobID
+		if _, ok := path[key]; ok {
+			continue
+		}
+		path[key] = struct{}{}
+		rows, err = formatJobDependencies(client, dependency, rows, path)
+		if err != nil {
+			return nil, err
+		}
+		delete(path, key)
+	}
+	return rows, nil
+}
+
+// jobDependencySatisfied returns whether the job depended on has reached the
+// required status, considering the allocations of its current version which
+// haven't been replaced.
+func jobDependencySatisfied(status string, job *api.Job, allocs []*api.AllocationListStub) bool {
+	if *job.Status != "dead" {
+		return false
+	}
+	if status == api.JobDependencyStatusDead {
+		return true
+	}
+
+	var complete, failed int
+	for _, alloc := range allocs {
+		if alloc.NextAllocation != "" || alloc.JobVersion != *job.Version {
+			continue
+		}
+		switch alloc.ClientStatus {
+		case api.AllocClientStatusComplete:
+			complete++
+		case api.AllocClientStatusFailed, api.AllocClientStatusLost:
+			failed++
+		}
+	}
+
+	switch status {
+	case api.JobDependencyStatusComplete:
+		return !*job.Stop && complete > 0 && failed == 0
+	case api.JobDependencyStatusFailed:
+		return failed > 0
+	}
+	return false
+}
+
 func (c *JobStatusCommand) formatDeployment(client *api.Client, d *api.Deployment) string {
 	// Format the high-level elements
 	high := []string{
@@ -493,6 +612,98 @@ func (c *JobStatusCommand) formatDeployment(client *api.Client, d *api.Deploymen
 	return base
 }
 
+// formatDisruptionBudgets returns a table of how much of the disruption budget
+// of each task group remains, or an empty string if no task group of the job
+// has a disruption budget.
+func formatDisruptionBudgets(job *api.Job, stubs []*api.AllocationListStub) string {
+	available := make(map[string]int)
+	for _, alloc := range stubs {
+		if alloc.DesiredStatus != api.AllocDesiredStatusRun ||
+			alloc.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+		if alloc.DeploymentStatus != nil && alloc.DeploymentStatus.Healthy != nil &&
+			!*alloc.DeploymentStatus.Healthy {
+			continue
+		}
+		available[alloc.TaskGroup]++
+	}
+
+	var rows []string
+	for _, tg := range job.TaskGroups {
+		if tg.DisruptionBudget == nil || tg.DisruptionBudget.MaxUnavailable == nil {
+			continue
+		}
+
+		maxUnavailable := *tg.DisruptionBudget.MaxUnavailable
+		unavailable := max(*tg.Count-available[*tg.Name], 0)
+		rows = append(rows, fmt.Sprintf("%s|%d|%d|%d",
+			*tg.Name,
+			maxUnavailable,
+			unavailable,
+			max(maxUnavailable-unavailable, 0),
+		))
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	out := []string{"Task Group|Max Unavailable|Unavailable|Remaining"}
+	return formatList(append(out, rows...))
+}
+
+// formatJobArray returns the indexes of the job array along with the number
+// of indexes of each task group in each state. Only the latest allocation of
+// each index is counted, and indexes without one are waiting to be placed.
+func formatJobArray(job *api.Job, stubs []*api.AllocationListStub) string {
+	type arrayStates struct {
+		running, complete, failed int
+	}
+	states := make(map[string]*arrayStates)
+	for _, alloc := range stubs {
+		if alloc.NextAllocation != "" {
+			continue
+		}
+		s, ok := states[alloc.TaskGroup]
+		if !ok {
+			s = &arrayStates{}
+			states[alloc.TaskGroup] = s
+		}
+		switch alloc.ClientStatus {
+		case api.AllocClientStatusComplete:
+			s.complete++
+		case api.AllocClientStatusFailed, api.AllocClientStatusLost:
+			s.failed++
+		default:
+			if alloc.DesiredStatus == api.AllocDesiredStatusRun {
+				s.running++
+			}
+		}
+	}
+
+	maxParallel := "unlimited"
+	if job.Array.MaxParallel > 0 {
+		maxParallel = strconv.Itoa(job.Array.MaxParallel)
+	}
+	size := job.Array.Size()
+	kv := formatKV([]string{
+		fmt.Sprintf("Indexes|%d-%d", job.Array.Start, job.Array.End),
+		fmt.Sprintf("Max Parallel|%s", maxParallel),
+	})
+
+	rows := []string{"Task Group|Size|Waiting|Running|Complete|Failed"}
+	for _, tg := range job.TaskGroups {
+		s, ok := states[*tg.Name]
+		if !ok {
+			s = &arrayStates{}
+		}
+		waiting := max(size-s.running-s.complete-s.failed, 0)
+		rows = append(rows, fmt.Sprintf("%s|%d|%d|%d|%d|%d",
+			*tg.Name, size, waiting, s.running, s.complete, s.failed))
+	}
+	return kv + "\n\n" + formatList(rows)
+}
+
 func formatAllocListStubs(stubs []*api.AllocationListStub, verbose bool, uuidLength int) string {
 	if len(stubs) == 0 {
 		return "No allocations placed"
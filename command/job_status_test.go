@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -391,6 +392,35 @@ func TestJobStatusCommand_RescheduleEvals(t *testing.T) {
 	must.StrContains(t, out, e.ID[:8])
 }
 
+func TestJobStatusCommand_Dependencies(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobStatusCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	state := srv.Agent.Server().State()
+
+	extract := mock.BatchJob()
+	extract.ID = "extract"
+	extract.DependsOn = []*structs.JobDependency{{JobID: "fetch", Status: structs.JobDependencyStatusDead}}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 900, nil, extract))
+
+	report := mock.BatchJob()
+	report.ID = "report"
+	report.DependsOn = []*structs.JobDependency{{JobID: "extract"}}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 901, nil, report))
+
+	code := cmd.Run([]string{"-address=" + url, report.ID})
+	must.Zero(t, code)
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Dependencies")
+	must.RegexMatch(t, regexp.MustCompile(`\nextract +default +report +complete +pending +false`), out)
+	must.RegexMatch(t, regexp.MustCompile(`\nfetch +default +extract +dead +<none> +false`), out)
+}
+
 func TestJobStatusCommand_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -490,6 +520,110 @@ namespace "default" {
 	}
 }
 
+func TestJobStatusCommand_FormatDisruptionBudgets(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &api.Job{
+		TaskGroups: []*api.TaskGroup{
+			{
+				Name:  pointer.Of("web"),
+				Count: pointer.Of(4),
+				DisruptionBudget: &api.DisruptionBudget{
+					MaxUnavailable: pointer.Of(2),
+				},
+			},
+			{
+				Name:  pointer.Of("cache"),
+				Count: pointer.Of(1),
+			},
+		},
+	}
+
+	// No task group has a budget
+	must.Eq(t, "", formatDisruptionBudgets(&api.Job{
+		TaskGroups: job.TaskGroups[1:],
+	}, nil))
+
+	stubs := []*api.AllocationListStub{
+		{
+			TaskGroup:     "web",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusRunning,
+		},
+		{
+			TaskGroup:     "web",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusRunning,
+			DeploymentStatus: &api.AllocDeploymentStatus{
+				Healthy: pointer.Of(true),
+			},
+		},
+		{
+			TaskGroup:     "web",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusRunning,
+			DeploymentStatus: &api.AllocDeploymentStatus{
+				Healthy: pointer.Of(false),
+			},
+		},
+		{
+			TaskGroup:     "web",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusPending,
+		},
+		{
+			TaskGroup:     "cache",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusRunning,
+		},
+	}
+
+	out := formatDisruptionBudgets(job, stubs)
+	must.StrContains(t, out, "Task Group  Max Unavailable  Unavailable  Remaining")
+	must.RegexMatch(t, regexp.MustCompile(`web\s+2\s+2\s+0`), out)
+	must.StrNotContains(t, out, "cache")
+}
+
+func TestJobStatusCommand_FormatJobArray(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &api.Job{
+		Array: &api.JobArray{Start: 0, End: 9, MaxParallel: 3},
+		TaskGroups: []*api.TaskGroup{
+			{Name: pointer.Of("web")},
+		},
+	}
+	stubs := []*api.AllocationListStub{
+		{
+			TaskGroup:     "web",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusRunning,
+		},
+		{
+			TaskGroup:     "web",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusComplete,
+		},
+		{
+			TaskGroup:      "web",
+			DesiredStatus:  api.AllocDesiredStatusStop,
+			ClientStatus:   api.AllocClientStatusFailed,
+			NextAllocation: "replacement",
+		},
+		{
+			TaskGroup:     "web",
+			DesiredStatus: api.AllocDesiredStatusRun,
+			ClientStatus:  api.AllocClientStatusFailed,
+		},
+	}
+
+	out := formatJobArray(job, stubs)
+	must.RegexMatch(t, regexp.MustCompile(`Indexes\s+= 0-9`), out)
+	must.RegexMatch(t, regexp.MustCompile(`Max Parallel\s+= 3`), out)
+	must.StrContains(t, out, "Task Group  Size  Waiting  Running  Complete  Failed")
+	must.RegexMatch(t, regexp.MustCompile(`web\s+10\s+7\s+1\s+1\s+1`), out)
+}
+
 func waitForSuccess(ui cli.Ui, client *api.Client, length int, t *testing.T, evalId string) int {
 	mon := newMonitor(ui, client, length)
 	monErr := mon.monitor(evalId)
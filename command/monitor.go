@@ -359,6 +359,11 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print gang scheduling info
+	if reverted := metrics.GangPlacementsReverted; reverted > 0 {
+		out += fmt.Sprintf("%s* %d placements reverted, as the gang task group could not be placed entirely\n", prefix, reverted)
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+// Ensure OperatorEventSinkCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorEventSinkCommand{}
+
+type OperatorEventSinkCommand struct {
+	Meta
+}
+
+func (o *OperatorEventSinkCommand) Help() string {
+	helpText := `
+Usage: nomad operator event-sink <subcommand> [options]
+
+  This command groups subcommands for interacting with event sinks. Event sinks
+  are run by the leader and forward the events of the event stream matching
+  their topics to a webhook, or append them to a file on the leader. The index
+  of the last delivered events is recorded in Raft, so that delivery resumes
+  after a leader election.
+
+  Register or update an event sink:
+
+      $ cat sink.json
+      {
+        "ID": "my-sink",
+        "Type": "webhook",
+        "Address": "http://127.0.0.1:8080",
+        "Topics": {
+          "Job": ["*"]
+        }
+      }
+      $ nomad operator event-sink register sink.json
+
+  List event sinks:
+
+      $ nomad operator event-sink list
+
+  Delete an event sink:
+
+      $ nomad operator event-sink delete my-sink
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (o *OperatorEventSinkCommand) Synopsis() string {
+	return "Interact with event sinks"
+}
+
+func (o *OperatorEventSinkCommand) Name() string { return "operator event-sink" }
+
+func (o *OperatorEventSinkCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+// formatEventSinkTopics formats the topics of a sink as Topic[key,...]
+// entries, sorted by topic.
+func formatEventSinkTopics(topics map[api.Topic][]string) string {
+	out := make([]string, 0, len(topics))
+	for topic, keys := range topics {
+		out = append(out, fmt.Sprintf("%s[%s]", topic, strings.Join(keys, ",")))
+	}
+	slices.Sort(out)
+	return strings.Join(out, " ")
+}
+
+// formatEventSinkDestination returns the address or path events are
+// delivered to.
+func formatEventSinkDestination(sink *api.EventSink) string {
+	if sink.Type == api.EventSinkFile {
+		return sink.Path
+	}
+	return sink.Address
+}
+
+func formatEventSinkList(sinks []*api.EventSink) string {
+	out := make([]string, len(sinks)+1)
+	out[0] = "ID|Type|Destination|Topics|Latest Index"
+	for i, s := range sinks {
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%d",
+			s.ID,
+			s.Type,
+			formatEventSinkDestination(s),
+			formatEventSinkTopics(s.Topics),
+			s.LatestIndex,
+		)
+	}
+	return formatList(out)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type OperatorEventSinkDeleteCommand struct {
+	Meta
+}
+
+func (c *OperatorEventSinkDeleteCommand) Name() string {
+	return "operator event-sink delete"
+}
+
+func (c *OperatorEventSinkDeleteCommand) Synopsis() string {
+	return "Delete an event sink"
+}
+
+func (c *OperatorEventSinkDeleteCommand) Help() string {
+	helpText := `
+Usage: nomad operator event-sink delete [options] <id>
+
+  Delete is used to remove an event sink. The leader stops delivering events
+  to the sink.
+
+  If ACLs are enabled, this command requires a management token or a token
+  with the 'operator:write' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEventSinkDeleteCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *OperatorEventSinkDeleteCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorEventSinkDeleteCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	id := args[0]
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.EventSinks().Delete(id, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting event sink: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted %q event sink!", id))
+	return 0
+}
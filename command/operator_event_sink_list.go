@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type OperatorEventSinkListCommand struct {
+	Meta
+}
+
+func (c *OperatorEventSinkListCommand) Name() string {
+	return "operator event-sink list"
+}
+
+func (c *OperatorEventSinkListCommand) Synopsis() string {
+	return "List event sinks"
+}
+
+func (c *OperatorEventSinkListCommand) Help() string {
+	helpText := `
+Usage: nomad operator event-sink list [options]
+
+  List is used to list the registered event sinks.
+
+  If ACLs are enabled, this command requires a management token or a token
+  with the 'operator:read' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+List Options:
+
+  -json
+    Output the event sinks in their JSON format.
+
+  -t
+    Format and display the event sinks using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEventSinkListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *OperatorEventSinkListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorEventSinkListCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we don't have any arguments.
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Make list request.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	sinks, _, err := client.EventSinks().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying event sinks: %s", err))
+		return 1
+	}
+
+	// Format output if requested.
+	if json || tmpl != "" {
+		out, err := Format(json, tmpl, sinks)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(sinks) == 0 {
+		c.Ui.Output("No event sinks found")
+		return 0
+	}
+
+	c.Ui.Output(formatEventSinkList(sinks))
+	return 0
+}
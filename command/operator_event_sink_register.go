@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorEventSinkRegisterCommand struct {
+	Meta
+
+	// testStdin is used by tests to replace os.Stdin.
+	testStdin io.Reader
+}
+
+func (c *OperatorEventSinkRegisterCommand) Name() string {
+	return "operator event-sink register"
+}
+
+func (c *OperatorEventSinkRegisterCommand) Synopsis() string {
+	return "Register or update an event sink"
+}
+
+func (c *OperatorEventSinkRegisterCommand) Help() string {
+	helpText := `
+Usage: nomad operator event-sink register [options] <file>
+
+  Register is used to create or update an event sink from a JSON
+  specification. If the path to the file is "-", the specification is read
+  from stdin. Updating a sink does not reset its progress.
+
+  The specification contains the following fields:
+
+    ID:        The unique identifier of the sink.
+    Type:      The type of sink, either "webhook" or "file".
+    Address:   The URL events are POSTed to by webhook sinks.
+    Path:      The absolute path of the file events are appended to by file
+               sinks, on the leader. It must be below the directory set by
+               the event_sink_file_dir server configuration.
+    Topics:    The topics and keys to subscribe to, with the same semantics as
+               the event stream API. Defaults to all topics.
+    Namespace: The namespace of the events to deliver. Defaults to all
+               namespaces.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault)
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEventSinkRegisterCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *OperatorEventSinkRegisterCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*.json")
+}
+
+func (c *OperatorEventSinkRegisterCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <file>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Read the specification.
+	path := args[0]
+	var content []byte
+	var err error
+	if path == "-" {
+		var stdin io.Reader = os.Stdin
+		if c.testStdin != nil {
+			stdin = c.testStdin
+		}
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading event sink specification: %s", err))
+		return 1
+	}
+
+	var sink api.EventSink
+	if err := json.Unmarshal(content, &sink); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing event sink specification: %s", err))
+		return 1
+	}
+
+	// Make API request.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if _, err := client.EventSinks().Register(&sink, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error registering event sink: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully registered %q event sink!", sink.ID))
+	return 0
+}
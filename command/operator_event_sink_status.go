@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type OperatorEventSinkStatusCommand struct {
+	Meta
+}
+
+func (c *OperatorEventSinkStatusCommand) Name() string {
+	return "operator event-sink status"
+}
+
+func (c *OperatorEventSinkStatusCommand) Synopsis() string {
+	return "Display the status of an event sink"
+}
+
+func (c *OperatorEventSinkStatusCommand) Help() string {
+	helpText := `
+Usage: nomad operator event-sink status [options] <id>
+
+  Status is used to display the configuration of an event sink and the index
+  of the last events it delivered.
+
+  If ACLs are enabled, this command requires a management token or a token
+  with the 'operator:read' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Status Options:
+
+  -json
+    Output the event sink in its JSON format.
+
+  -t
+    Format and display the event sink using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorEventSinkStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *OperatorEventSinkStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorEventSinkStatusCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we only have one argument.
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	sink, _, err := client.EventSinks().Info(args[0], nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying event sink: %s", err))
+		return 1
+	}
+
+	// Format output if requested.
+	if json || tmpl != "" {
+		out, err := Format(json, tmpl, sink)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting output: %s", err))
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("ID|%s", sink.ID),
+		fmt.Sprintf("Type|%s", sink.Type),
+		fmt.Sprintf("Destination|%s", formatEventSinkDestination(sink)),
+		fmt.Sprintf("Namespace|%s", sink.Namespace),
+		fmt.Sprintf("Topics|%s", formatEventSinkTopics(sink.Topics)),
+		fmt.Sprintf("Latest Index|%d", sink.LatestIndex),
+		fmt.Sprintf("Create Index|%d", sink.CreateIndex),
+		fmt.Sprintf("Modify Index|%d", sink.ModifyIndex),
+	}))
+	return 0
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorEventSinkCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorEventSinkCommand{}
+	var _ cli.Command = &OperatorEventSinkRegisterCommand{}
+	var _ cli.Command = &OperatorEventSinkListCommand{}
+	var _ cli.Command = &OperatorEventSinkStatusCommand{}
+	var _ cli.Command = &OperatorEventSinkDeleteCommand{}
+}
+
+func TestOperatorEventSinkCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Register a sink from a file.
+	spec := filepath.Join(t.TempDir(), "sink.json")
+	must.NoError(t, os.WriteFile(spec, []byte(`{
+  "ID": "my-sink",
+  "Type": "webhook",
+  "Address": "http://127.0.0.1:8080",
+  "Topics": {"Job": ["*"]}
+}`), 0o644))
+
+	ui := cli.NewMockUi()
+	register := &OperatorEventSinkRegisterCommand{Meta: Meta{Ui: ui}}
+	code := register.Run([]string{"-address=" + url, spec})
+	must.Eq(t, 0, code, must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), `Successfully registered "my-sink" event sink!`)
+
+	// Update it from stdin.
+	ui = cli.NewMockUi()
+	register = &OperatorEventSinkRegisterCommand{
+		Meta: Meta{Ui: ui},
+		testStdin: strings.NewReader(`{
+  "ID": "my-sink",
+  "Type": "webhook",
+  "Address": "http://127.0.0.1:9090",
+  "Topics": {"Job": ["*"], "Node": ["*"]}
+}`),
+	}
+	code = register.Run([]string{"-address=" + url, "-"})
+	must.Eq(t, 0, code, must.Sprint(ui.ErrorWriter.String()))
+
+	// Invalid sinks are rejected.
+	ui = cli.NewMockUi()
+	register = &OperatorEventSinkRegisterCommand{
+		Meta:      Meta{Ui: ui},
+		testStdin: strings.NewReader(`{"ID": "invalid", "Type": "unknown"}`),
+	}
+	code = register.Run([]string{"-address=" + url, "-"})
+	must.Eq(t, 1, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `invalid sink type "unknown"`)
+
+	ui = cli.NewMockUi()
+	list := &OperatorEventSinkListCommand{Meta: Meta{Ui: ui}}
+	code = list.Run([]string{"-address=" + url})
+	must.Eq(t, 0, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "my-sink")
+	must.StrContains(t, out, "http://127.0.0.1:9090")
+	must.StrContains(t, out, "Job[*] Node[*]")
+
+	ui = cli.NewMockUi()
+	status := &OperatorEventSinkStatusCommand{Meta: Meta{Ui: ui}}
+	code = status.Run([]string{"-address=" + url, "my-sink"})
+	must.Eq(t, 0, code)
+	out = ui.OutputWriter.String()
+	must.StrContains(t, out, "webhook")
+	must.StrContains(t, out, "Namespace")
+
+	ui = cli.NewMockUi()
+	del := &OperatorEventSinkDeleteCommand{Meta: Meta{Ui: ui}}
+	code = del.Run([]string{"-address=" + url, "my-sink"})
+	must.Eq(t, 0, code)
+	must.StrContains(t, ui.OutputWriter.String(), `Successfully deleted "my-sink" event sink!`)
+
+	ui = cli.NewMockUi()
+	status = &OperatorEventSinkStatusCommand{Meta: Meta{Ui: ui}}
+	code = status.Run([]string{"-address=" + url, "my-sink"})
+	must.Eq(t, 1, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "event sink not found")
+
+	ui = cli.NewMockUi()
+	list = &OperatorEventSinkListCommand{Meta: Meta{Ui: ui}}
+	code = list.Run([]string{"-address=" + url})
+	must.Eq(t, 0, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No event sinks found")
+}
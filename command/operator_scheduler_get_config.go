@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 )
@@ -88,9 +89,28 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
+
+	if len(schedConfig.NodeWeights) > 0 {
+		o.Ui.Output(o.Colorize().Color("\n[bold]Node Weights[reset]"))
+		o.Ui.Output(formatList(formatSchedulerNodeWeights(schedConfig.NodeWeights)))
+	}
 	return 0
 }
 
+// formatSchedulerNodeWeights returns the rows of the node weights table.
+func formatSchedulerNodeWeights(weights []*api.SchedulerNodeWeight) []string {
+	out := make([]string, 0, len(weights)+1)
+	out = append(out, "Attribute|Operator|Value|Weight")
+	for _, w := range weights {
+		operand := w.Operand
+		if operand == "" {
+			operand = "="
+		}
+		out = append(out, fmt.Sprintf("%s|%s|%s|%d", w.Attribute, operand, w.Value, w.Weight))
+	}
+	return out
+}
+
 func (o *OperatorSchedulerGetConfig) Synopsis() string {
 	return "Display the current scheduler configuration"
 }
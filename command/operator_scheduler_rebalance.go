@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerRebalanceCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerRebalanceCommand{}
+
+type OperatorSchedulerRebalanceCommand struct {
+	Meta
+
+	json bool
+	tmpl string
+}
+
+func (o *OperatorSchedulerRebalanceCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		},
+	)
+}
+
+func (o *OperatorSchedulerRebalanceCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (o *OperatorSchedulerRebalanceCommand) Name() string { return "operator scheduler rebalance" }
+
+func (o *OperatorSchedulerRebalanceCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := o.Meta.FlagSet("rebalance", FlagSetClient)
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if len(flags.Args()) != 0 {
+		o.Ui.Error("This command takes no arguments")
+		o.Ui.Error(commandErrorText(o))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := o.Meta.Client()
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	resp, _, err := client.Operator().SchedulerRebalanceReport(nil)
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error querying rebalance report: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, resp)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	if len(resp.Moves) == 0 {
+		o.Ui.Output("No allocations to rebalance")
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+	o.Ui.Output(formatList(formatRebalanceMoves(resp.Moves, length)))
+	return 0
+}
+
+// formatRebalanceMoves returns the rows of the rebalance report table.
+func formatRebalanceMoves(moves []*api.RebalanceMove, length int) []string {
+	out := make([]string, 0, len(moves)+1)
+	out = append(out, "Alloc ID|Job ID|Namespace|Task Group|Node ID|Score|Reason")
+	for _, m := range moves {
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%.3f|%s",
+			limit(m.AllocID, length),
+			m.JobID,
+			m.Namespace,
+			m.TaskGroup,
+			limit(m.NodeID, length),
+			m.Score,
+			m.Reason,
+		))
+	}
+	return out
+}
+
+func (o *OperatorSchedulerRebalanceCommand) Synopsis() string {
+	return "Display the allocations the rebalancer would migrate"
+}
+
+func (o *OperatorSchedulerRebalanceCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler rebalance [options]
+
+  Displays the allocations the background rebalancer would migrate in its next
+  cycle, without migrating them. Only jobs with "rebalance" enabled are
+  considered, and the number of allocations is bounded by the server's
+  "rebalance_max_migrations" configuration. Rebalancing is disabled unless
+  "rebalance_max_migrations" is set, in which case no allocations are shown.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Scheduler Rebalance Options:
+
+  -json
+    Output the rebalance report in its JSON format.
+
+  -t
+    Format and display the rebalance report using a Go template.
+
+  -verbose
+    Display full allocation and node IDs.
+`
+
+	return strings.TrimSpace(helpText)
+}
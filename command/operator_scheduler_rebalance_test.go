@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerRebalanceCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerRebalanceCommand{Meta: Meta{Ui: ui}}
+
+	must.Zero(t, c.Run([]string{"-address=" + addr}))
+	must.StrContains(t, ui.OutputWriter.String(), "No allocations to rebalance")
+	ui.OutputWriter.Reset()
+
+	// Test an unsupported argument.
+	must.One(t, c.Run([]string{"-address=" + addr, "foo"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+}
+
+func TestOperatorSchedulerRebalanceCommand_FormatMoves(t *testing.T) {
+	ci.Parallel(t)
+
+	moves := []*api.RebalanceMove{{
+		AllocID:   "11111111-2222-3333-4444-555555555555",
+		Namespace: "default",
+		JobID:     "example",
+		TaskGroup: "web",
+		NodeID:    "66666666-7777-8888-9999-000000000000",
+		Reason:    "spread on ${node.datacenter}",
+		Score:     0.5,
+	}}
+
+	out := formatList(formatRebalanceMoves(moves, shortId))
+	must.StrContains(t, out, "Alloc ID")
+	must.StrContains(t, out, "11111111")
+	must.StrNotContains(t, out, "11111111-2222")
+	must.StrContains(t, out, "0.500")
+	must.StrContains(t, out, "spread on ${node.datacenter}")
+}
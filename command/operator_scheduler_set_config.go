@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -40,6 +41,7 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-scheduler-algorithm": complete.PredictSet(
 				string(api.SchedulerAlgorithmBinpack),
 				string(api.SchedulerAlgorithmSpread),
+				string(api.SchedulerAlgorithmWeighted),
 			),
 			"-memory-oversubscription":    complete.PredictSet("true", "false"),
 			"-reject-job-registration":    complete.PredictSet("true", "false"),
@@ -48,6 +50,7 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
 			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
 			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-node-weight":                complete.PredictAnything,
 		},
 	)
 }
@@ -60,6 +63,8 @@ func (o *OperatorSchedulerSetConfig) Name() string { return "operator scheduler
 
 func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 
+	var nodeWeightFlags flagHelper.StringFlag
+
 	flags := o.Meta.FlagSet("set-config", FlagSetClient)
 	flags.Usage = func() { o.Ui.Output(o.Help()) }
 
@@ -72,6 +77,7 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	flags.Var(&nodeWeightFlags, "node-weight", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -92,6 +98,18 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 		return 1
 	}
 
+	// Parse the node weights before contacting the servers, so that typos are
+	// reported early.
+	var nodeWeights []*api.SchedulerNodeWeight
+	for _, raw := range nodeWeightFlags {
+		weight, err := parseSchedulerNodeWeight(raw)
+		if err != nil {
+			o.Ui.Error(fmt.Sprintf("Error parsing node-weight value %q: %v", raw, err))
+			return 1
+		}
+		nodeWeights = append(nodeWeights, weight)
+	}
+
 	// Convert the check index string and handle any errors before adding this
 	// to our request. This parsing handles empty values correctly.
 	checkIndex, _, err := parseCheckIndex(o.checkIndex)
@@ -127,6 +145,9 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	if o.schedulerAlgorithm != "" {
 		schedulerConfig.SchedulerAlgorithm = api.SchedulerAlgorithm(o.schedulerAlgorithm)
 	}
+	if len(nodeWeights) > 0 {
+		schedulerConfig.NodeWeights = nodeWeights
+	}
 	o.memoryOversubscription.Merge(&schedulerConfig.MemoryOversubscriptionEnabled)
 	o.rejectJobRegistration.Merge(&schedulerConfig.RejectJobRegistration)
 	o.pauseEvalBroker.Merge(&schedulerConfig.PauseEvalBroker)
@@ -149,6 +170,51 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	return 1
 }
 
+// schedulerNodeWeightOperators are the operators supported by the node-weight
+// flag. Operators sharing a prefix must be listed longest first.
+var schedulerNodeWeightOperators = []string{"!=", "<=", ">=", "==", "=", "<", ">"}
+
+// parseSchedulerNodeWeight parses a node weight in the format
+// "<attribute><operator><value>:<weight>", such as "${meta.cost_tier}=spot:50".
+func parseSchedulerNodeWeight(raw string) (*api.SchedulerNodeWeight, error) {
+	if !strings.HasPrefix(raw, "${") {
+		return nil, fmt.Errorf("attribute must be an interpolated node attribute, such as ${meta.cost_tier}")
+	}
+	end := strings.Index(raw, "}")
+	if end == -1 {
+		return nil, fmt.Errorf("attribute is missing the closing brace")
+	}
+	attribute, rest := raw[:end+1], raw[end+1:]
+
+	var operand string
+	for _, op := range schedulerNodeWeightOperators {
+		if strings.HasPrefix(rest, op) {
+			operand = op
+			break
+		}
+	}
+	if operand == "" {
+		return nil, fmt.Errorf("missing operator, must be one of %s", strings.Join(schedulerNodeWeightOperators, ", "))
+	}
+	rest = strings.TrimPrefix(rest, operand)
+
+	sep := strings.LastIndex(rest, ":")
+	if sep == -1 {
+		return nil, fmt.Errorf("missing weight, expected <attribute><operator><value>:<weight>")
+	}
+	weight, err := strconv.ParseInt(rest[sep+1:], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid weight: %v", err)
+	}
+
+	return &api.SchedulerNodeWeight{
+		Attribute: attribute,
+		Value:     rest[:sep],
+		Operand:   operand,
+		Weight:    int8(weight),
+	}, nil
+}
+
 func (o *OperatorSchedulerSetConfig) Synopsis() string {
 	return "Modify the current scheduler configuration"
 }
@@ -173,9 +239,18 @@ Scheduler Set Config Options:
     matches the current server side version. If a non-zero value is passed, it
     ensures that the scheduler config is being updated from a known state.
 
-  -scheduler-algorithm=["binpack"|"spread"]
+  -scheduler-algorithm=["binpack"|"spread"|"weighted"]
     Specifies whether scheduler binpacks or spreads allocations on available
-    nodes.
+    nodes. The "weighted" algorithm binpacks allocations and prefers nodes
+    according to the node weights set with -node-weight.
+
+  -node-weight=<attribute><operator><value>:<weight>
+    Adds a node weight used to score nodes by the "weighted" scheduler
+    algorithm, such as '${meta.cost_tier}=spot:50'. The operator is one of
+    "=", "!=", "<", "<=", ">" or ">=", and the weight must be within the range
+    [-100, 100], with negative weights making the scheduler avoid the matching
+    nodes. Can be specified multiple times, and replaces the current node
+    weights when set.
 
   -memory-oversubscription=[true|false]
     When true, tasks may exceed their reserved memory limit, if the client has
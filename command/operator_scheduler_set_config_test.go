@@ -102,10 +102,89 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 	ui.OutputWriter.Reset()
 }
 
+func TestOperatorSchedulerSetConfig_NodeWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerSetConfig{Meta: Meta{Ui: ui}}
+
+	// The weighted algorithm requires node weights.
+	must.One(t, c.Run([]string{"-address=" + addr, "-scheduler-algorithm=weighted"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "requires at least one node weight")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	// Invalid node weights are rejected before contacting the servers.
+	must.One(t, c.Run([]string{"-address=" + addr, "-node-weight=cost_tier=spot:50"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error parsing node-weight value")
+	ui.ErrorWriter.Reset()
+	ui.OutputWriter.Reset()
+
+	must.Zero(t, c.Run([]string{
+		"-address=" + addr,
+		"-scheduler-algorithm=weighted",
+		"-node-weight=${meta.cost_tier}=spot:50",
+		"-node-weight=${meta.hw_generation}>=4:-20",
+	}), must.Sprint(ui.ErrorWriter.String()))
+	must.StrContains(t, ui.OutputWriter.String(), "Scheduler configuration updated!")
+
+	resp, _, err := srv.APIClient().Operator().SchedulerGetConfiguration(nil)
+	must.NoError(t, err)
+	must.Eq(t, api.SchedulerAlgorithmWeighted, resp.SchedulerConfig.SchedulerAlgorithm)
+	must.Eq(t, []*api.SchedulerNodeWeight{
+		{Attribute: "${meta.cost_tier}", Value: "spot", Operand: "=", Weight: 50},
+		{Attribute: "${meta.hw_generation}", Value: "4", Operand: ">=", Weight: -20},
+	}, resp.SchedulerConfig.NodeWeights)
+}
+
+func TestOperatorSchedulerSetConfig_parseSchedulerNodeWeight(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		input       string
+		expected    *api.SchedulerNodeWeight
+		expectedErr string
+	}{
+		{
+			input:    "${meta.cost_tier}=spot:50",
+			expected: &api.SchedulerNodeWeight{Attribute: "${meta.cost_tier}", Operand: "=", Value: "spot", Weight: 50},
+		},
+		{
+			input:    "${attr.cpu.arch}!=arm64:-100",
+			expected: &api.SchedulerNodeWeight{Attribute: "${attr.cpu.arch}", Operand: "!=", Value: "arm64", Weight: -100},
+		},
+		{
+			input:    "${meta.zone}=us:east:10",
+			expected: &api.SchedulerNodeWeight{Attribute: "${meta.zone}", Operand: "=", Value: "us:east", Weight: 10},
+		},
+		{input: "meta.cost_tier=spot:50", expectedErr: "interpolated node attribute"},
+		{input: "${meta.cost_tier~spot:50", expectedErr: "closing brace"},
+		{input: "${meta.cost_tier}~spot:50", expectedErr: "missing operator"},
+		{input: "${meta.cost_tier}=spot", expectedErr: "missing weight"},
+		{input: "${meta.cost_tier}=spot:500", expectedErr: "invalid weight"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := parseSchedulerNodeWeight(tc.input)
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, got)
+		})
+	}
+}
+
 func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfiguration) {
 	must.Eq(t, expected.SchedulerAlgorithm, actual.SchedulerAlgorithm)
 	must.Eq(t, expected.RejectJobRegistration, actual.RejectJobRegistration)
 	must.Eq(t, expected.MemoryOversubscriptionEnabled, actual.MemoryOversubscriptionEnabled)
 	must.Eq(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
 	must.Eq(t, expected.PreemptionConfig, actual.PreemptionConfig)
+	must.Eq(t, expected.NodeWeights, actual.NodeWeights)
 }
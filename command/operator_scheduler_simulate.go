@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerSimulateCommand satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] -snapshot=<file> <path>
+
+  Simulates the scheduling of a job against the cluster state stored in a
+  snapshot file, as created by "nomad operator snapshot save". The job is
+  registered in a local copy of the state and the scheduler of the job type
+  places it, without contacting the Nomad servers. The simulation reports the
+  placements, the placement failures and the allocations of other jobs which
+  would be preempted, so capacity planning can be done offline against the
+  state of a production cluster.
+
+  Because the simulation runs against the snapshot, it does not account for
+  changes to the cluster state made after the snapshot was taken.
+
+Simulate Options:
+
+  -snapshot=<file>
+    Path to the snapshot file to simulate the scheduling against. Required.
+
+  -verbose
+    List every placement instead of a summary for each task group.
+
+  -hcl1
+    Parses the job file as HCLv1. Takes precedence over "-hcl2-strict".
+
+  -hcl2-strict
+    Whether an error should be produced from the HCL2 parser where a variable
+    has been supplied which is not defined within the root variables. Defaults
+    to true, but ignored if "-hcl1" is also defined.
+
+  -json
+    Parses the job file as JSON. If the outer object has a Job field, such as
+    from "nomad job inspect" or "nomad run -output", the value of the field is
+    used as the job.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate the scheduling of a job against a snapshot"
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-snapshot":    complete.PredictFiles("*.snap"),
+		"-verbose":     complete.PredictNothing,
+		"-hcl1":        complete.PredictNothing,
+		"-hcl2-strict": complete.PredictNothing,
+		"-json":        complete.PredictNothing,
+		"-var":         complete.PredictAnything,
+		"-var-file":    complete.PredictFiles("*.var"),
+	}
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Name() string { return "operator scheduler simulate" }
+
+func (c *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	var snapshotPath string
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&snapshotPath, "snapshot", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&c.JobGetter.JSON, "json", false, "")
+	flags.BoolVar(&c.JobGetter.HCL1, "hcl1", false, "")
+	flags.BoolVar(&c.JobGetter.Strict, "hcl2-strict", true, "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if snapshotPath == "" {
+		c.Ui.Error("The -snapshot flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if c.JobGetter.HCL1 {
+		c.JobGetter.Strict = false
+	}
+
+	if err := c.JobGetter.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid job options: %s", err))
+		return 1
+	}
+
+	// Get Job struct from Jobfile
+	_, aj, err := c.JobGetter.Get(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		return 1
+	}
+
+	aj.Canonicalize()
+	job := agent.ApiJobToStructJob(aj)
+	job.Canonicalize()
+	if err := job.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Job validation errors:\n%s", err))
+		return 1
+	}
+	if job.IsPeriodic() || job.IsParameterized() {
+		c.Ui.Error("Periodic and parameterized jobs can't be simulated, as they aren't scheduled when registered")
+		return 1
+	}
+
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	state, meta, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read archive file: %s", err))
+		return 1
+	}
+
+	result, err := scheduler.Simulate(hclog.NewNullLogger(), state, job)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error simulating job %q: %s", job.ID, err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[bold]Simulated scheduling of job %q against snapshot at index %d[reset]\n",
+		job.ID, meta.Index)))
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Evaluation Status|%s", result.Eval.Status),
+		fmt.Sprintf("Placed|%d", len(result.Placed)),
+		fmt.Sprintf("Stopped|%d", len(result.Stopped)),
+		fmt.Sprintf("Preempted|%d", len(result.Preempted)),
+	}))
+
+	if len(result.Placed) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Placements[reset]"))
+		if verbose {
+			c.Ui.Output(formatList(formatSimulatedPlacements(result.Placed)))
+		} else {
+			c.Ui.Output(formatList(formatSimulatedPlacementSummary(result.Placed)))
+		}
+	}
+
+	if len(result.Eval.FailedTGAllocs) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold][yellow]Placement Failures[reset]"))
+		tgs := make([]string, 0, len(result.Eval.FailedTGAllocs))
+		for tg := range result.Eval.FailedTGAllocs {
+			tgs = append(tgs, tg)
+		}
+		sort.Strings(tgs)
+
+		for _, tg := range tgs {
+			metrics, err := apiAllocMetric(result.Eval.FailedTGAllocs[tg])
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Error formatting placement failures: %s", err))
+				return 1
+			}
+
+			noun := "allocation"
+			if metrics.CoalescedFailures > 0 {
+				noun += "s"
+			}
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[yellow]Task Group %q (failed to place %d %s):[reset]",
+				tg, metrics.CoalescedFailures+1, noun)))
+			c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[yellow]%s[reset]",
+				formatAllocMetrics(metrics, false, strings.Repeat(" ", 2)))))
+		}
+	}
+
+	if len(result.Preempted) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold][yellow]Preemptions[reset]"))
+		c.Ui.Output(formatList(formatSimulatedPreemptions(result.Preempted)))
+	}
+
+	return 0
+}
+
+// formatSimulatedPlacementSummary returns the rows of a table with the number
+// of placements and nodes of each task group.
+func formatSimulatedPlacementSummary(allocs []*structs.Allocation) []string {
+	placed := make(map[string]int)
+	nodes := make(map[string]map[string]struct{})
+	for _, alloc := range allocs {
+		placed[alloc.TaskGroup]++
+		if nodes[alloc.TaskGroup] == nil {
+			nodes[alloc.TaskGroup] = make(map[string]struct{})
+		}
+		nodes[alloc.TaskGroup][alloc.NodeID] = struct{}{}
+	}
+
+	tgs := make([]string, 0, len(placed))
+	for tg := range placed {
+		tgs = append(tgs, tg)
+	}
+	sort.Strings(tgs)
+
+	out := make([]string, 0, len(tgs)+1)
+	out = append(out, "Task Group|Placed|Nodes")
+	for _, tg := range tgs {
+		out = append(out, fmt.Sprintf("%s|%d|%d", tg, placed[tg], len(nodes[tg])))
+	}
+	return out
+}
+
+// formatSimulatedPlacements returns the rows of a table with every placement.
+func formatSimulatedPlacements(allocs []*structs.Allocation) []string {
+	sorted := make([]*structs.Allocation, len(allocs))
+	copy(sorted, allocs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	out := make([]string, 0, len(sorted)+1)
+	out = append(out, "Name|Node ID|Node Name")
+	for _, alloc := range sorted {
+		out = append(out, fmt.Sprintf("%s|%s|%s", alloc.Name, alloc.NodeID, alloc.NodeName))
+	}
+	return out
+}
+
+// formatSimulatedPreemptions returns the rows of a table with the preempted
+// allocations.
+func formatSimulatedPreemptions(allocs []*structs.Allocation) []string {
+	out := make([]string, 0, len(allocs)+1)
+	out = append(out, "Alloc ID|Job ID|Namespace|Task Group|Node ID")
+	for _, alloc := range allocs {
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s",
+			alloc.ID, alloc.JobID, alloc.Namespace, alloc.TaskGroup, alloc.NodeID))
+	}
+	return out
+}
+
+// apiAllocMetric converts the allocation metrics of the scheduler to the API
+// representation returned by the agent, so they can be formatted the same.
+func apiAllocMetric(metric *structs.AllocMetric) (*api.AllocationMetric, error) {
+	buf, err := json.Marshal(metric)
+	if err != nil {
+		return nil, err
+	}
+	var out api.AllocationMetric
+	if err := json.Unmarshal(buf, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails without a snapshot
+	code = cmd.Run([]string{"example.nomad"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "The -snapshot flag is required")
+	ui.ErrorWriter.Reset()
+
+	// Fails on a missing job file
+	code = cmd.Run([]string{"-snapshot=backup.snap", "/unicorns/leprechauns"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error getting job struct")
+}
+
+func TestOperatorSchedulerSimulateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Take a snapshot of a cluster with two nodes.
+	snapPath := generateSnapshotFile(t, func(srv *agent.TestAgent, _ *api.Client, _ string) {
+		state := srv.Agent.Server().State()
+		for i := 0; i < 2; i++ {
+			must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(1000+i), mock.Node()))
+		}
+	})
+
+	jobPath := filepath.Join(t.TempDir(), "example.nomad")
+	must.NoError(t, os.WriteFile(jobPath, []byte(`
+job "example" {
+  group "web" {
+    count = 3
+
+    task "web" {
+      driver = "exec"
+
+      config {
+        command = "/bin/date"
+      }
+
+      resources {
+        cpu    = 100
+        memory = 64
+      }
+    }
+  }
+
+  group "huge" {
+    task "huge" {
+      driver = "exec"
+
+      config {
+        command = "/bin/date"
+      }
+
+      resources {
+        cpu    = 100
+        memory = 1048576
+      }
+    }
+  }
+}
+`), 0o600))
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-snapshot=" + snapPath, jobPath})
+	must.Zero(t, code, must.Sprint(ui.ErrorWriter.String()))
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, `Simulated scheduling of job "example"`)
+	must.RegexMatch(t, regexp.MustCompile(`Placed\s+= 3`), out)
+	must.RegexMatch(t, regexp.MustCompile(`web\s+3\s+2`), out)
+	must.StrContains(t, out, `Task Group "huge" (failed to place 1 allocation)`)
+	must.StrContains(t, out, "Resources exhausted on 2 nodes")
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestHostVolumeDecode(t *testing.T) {
+	ci.Parallel(t)
+
+	hclTestFile := `
+namespace = "prod"
+name      = "database"
+type      = "host"
+plugin_id = "plugin_id"
+node_pool = "default"
+
+capacity_min = "10GiB"
+capacity_max = "20G"
+
+constraint {
+  attribute = "${attr.kernel.name}"
+  value     = "linux"
+}
+
+constraint {
+  attribute = "${meta.rack}"
+  operator  = "!="
+  value     = "r1"
+}
+
+parameters {
+  foo = "bar"
+}
+`
+
+	ast, err := hcl.ParseString(hclTestFile)
+	must.NoError(t, err)
+
+	vol, err := decodeHostVolume(ast)
+	must.NoError(t, err)
+	must.Eq(t, &api.HostVolume{
+		Namespace:                 "prod",
+		Name:                      "database",
+		PluginID:                  "plugin_id",
+		NodePool:                  "default",
+		RequestedCapacityMinBytes: 10737418240,
+		RequestedCapacityMaxBytes: 20000000000,
+		Constraints: []*api.Constraint{
+			{LTarget: "${attr.kernel.name}", RTarget: "linux", Operand: "="},
+			{LTarget: "${meta.rack}", RTarget: "r1", Operand: "!="},
+		},
+		Parameters: map[string]string{"foo": "bar"},
+	}, vol)
+}
@@ -30,7 +30,8 @@ Usage: nomad volume delete [options] <vol id>
 
   When ACLs are enabled, this command requires a token with the
   'csi-write-volume' and 'csi-read-volume' capabilities for the volume's
-  namespace.
+  namespace. Deleting a dynamic host volume requires the 'host-volume-delete'
+  capability instead.
 
 General Options:
 
@@ -41,13 +42,19 @@ Delete Options:
   -secret
     Secrets to pass to the plugin to delete the snapshot. Accepts multiple
     flags in the form -secret key=value
+
+  -type <type>
+    Type of volume to delete. Must be one of "csi" or "host". Defaults to
+    "csi".
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *VolumeDeleteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
-		complete.Flags{})
+		complete.Flags{
+			"-type": complete.PredictSet("csi", "host"),
+		})
 }
 
 func (c *VolumeDeleteCommand) AutocompleteArgs() complete.Predictor {
@@ -80,9 +87,11 @@ func (c *VolumeDeleteCommand) Name() string { return "volume delete" }
 
 func (c *VolumeDeleteCommand) Run(args []string) int {
 	var secretsArgs flaghelper.StringFlag
+	var typeArg string
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.Var(&secretsArgs, "secret", "secrets for snapshot, ex. -secret key=value")
+	flags.StringVar(&typeArg, "type", "csi", "type of volume (csi or host)")
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing arguments %s", err))
@@ -105,6 +114,15 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 		return 1
 	}
 
+	switch typeArg {
+	case "csi":
+	case "host":
+		return c.deleteHostVolume(client, volID)
+	default:
+		c.Ui.Error(fmt.Sprintf("No such volume type %q", typeArg))
+		return 1
+	}
+
 	secrets := api.CSISecrets{}
 	for _, kv := range secretsArgs {
 		if key, value, found := strings.Cut(kv, "="); found {
@@ -127,3 +145,14 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 	c.Ui.Output(fmt.Sprintf("Successfully deleted volume %q!", volID))
 	return 0
 }
+
+func (c *VolumeDeleteCommand) deleteHostVolume(client *api.Client, volID string) int {
+	_, err := client.HostVolumes().Delete(volID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting volume: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted volume %q!", volID))
+	return 0
+}
@@ -24,12 +24,12 @@ func (c *VolumeStatusCommand) Help() string {
 	helpText := `
 Usage: nomad volume status [options] <id>
 
-  Display status information about a CSI volume. If no volume id is given, a
-  list of all volumes will be displayed.
+  Display status information about a CSI volume or dynamic host volume. If no
+  volume id is given, a list of all volumes will be displayed.
 
   When ACLs are enabled, this command requires a token with the
   'csi-read-volume' and 'csi-list-volumes' capability for the volume's
-  namespace.
+  namespace, or the 'host-volume-read' capability for host volumes.
 
 General Options:
 
@@ -38,7 +38,8 @@ General Options:
 Status Options:
 
   -type <type>
-    List only volumes of type <type>.
+    List only volumes of type <type>, either "csi" or "host". Defaults to
+    "csi".
 
   -short
     Display short output. Used only when a single volume is being
@@ -130,12 +131,13 @@ func (c *VolumeStatusCommand) Run(args []string) int {
 		id = args[0]
 	}
 
-	code := c.csiStatus(client, id)
-	if code != 0 {
-		return code
+	switch typeArg {
+	case "csi", "":
+		return c.csiStatus(client, id)
+	case "host":
+		return c.hostVolumeStatus(client, id)
+	default:
+		c.Ui.Error(fmt.Sprintf("No such volume type %q", typeArg))
+		return 1
 	}
-
-	// Extend this section with other volume implementations
-
-	return 0
 }
@@ -106,7 +106,8 @@ var (
 			drivers.NetIsolationModeHost,
 			drivers.NetIsolationModeGroup,
 		},
-		MountConfigs: drivers.MountConfigSupportAll,
+		MountConfigs:    drivers.MountConfigSupportAll,
+		ResizeResources: true,
 	}
 )
 
@@ -110,7 +110,8 @@ var (
 			drivers.NetIsolationModeHost,
 			drivers.NetIsolationModeGroup,
 		},
-		MountConfigs: drivers.MountConfigSupportNone,
+		MountConfigs:    drivers.MountConfigSupportNone,
+		ResizeResources: true,
 	}
 
 	_ drivers.DriverPlugin = (*Driver)(nil)
@@ -97,7 +97,8 @@ var (
 			drivers.NetIsolationModeHost,
 			drivers.NetIsolationModeGroup,
 		},
-		MountConfigs: drivers.MountConfigSupportNone,
+		MountConfigs:    drivers.MountConfigSupportNone,
+		ResizeResources: true,
 	}
 )
 
@@ -203,6 +203,8 @@ func StateAsMap(store *state.StateStore) map[string][]interface{} {
 		"CSIVolumes":       toArray(store.CSIVolumes(nil)),
 		"Deployments":      toArray(store.Deployments(nil, state.SortDefault)),
 		"Evals":            toArray(store.Evals(nil, state.SortDefault)),
+		"EventSinks":       toArray(store.EventSinks(nil)),
+		"HostVolumes":      toArray(store.HostVolumes(nil)),
 		"Indexes":          toArray(store.Indexes()),
 		"JobSummaries":     toArray(store.JobSummaries(nil)),
 		"JobVersions":      toArray(store.JobVersions(nil)),
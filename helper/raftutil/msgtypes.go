@@ -65,6 +65,11 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.ACLBindingRulesDeleteRequestType:             "ACLBindingRulesDeleteRequestType",
 	structs.NodePoolUpsertRequestType:                    "NodePoolUpsertRequestType",
 	structs.NodePoolDeleteRequestType:                    "NodePoolDeleteRequestType",
+	structs.HostVolumeRegisterRequestType:                "HostVolumeRegisterRequestType",
+	structs.HostVolumeDeleteRequestType:                  "HostVolumeDeleteRequestType",
+	structs.EventSinkRegisterRequestType:                 "EventSinkRegisterRequestType",
 	structs.NamespaceUpsertRequestType:                   "NamespaceUpsertRequestType",
 	structs.NamespaceDeleteRequestType:                   "NamespaceDeleteRequestType",
+	structs.EventSinkDeregisterRequestType:               "EventSinkDeregisterRequestType",
+	structs.EventSinkProgressRequestType:                 "EventSinkProgressRequestType",
 }
@@ -309,6 +309,8 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 			"attribute",
 			"weight",
 			"target",
+			"max_skew",
+			"hard",
 		}
 		if err := checkHCLKeys(o.Val, valid); err != nil {
 			return err
@@ -393,6 +395,53 @@ func parseSpreadTarget(result *[]*api.SpreadTarget, list *ast.ObjectList) error
 	return nil
 }
 
+func parseJobDependencies(result *[]*api.JobDependency, list *ast.ObjectList) error {
+	seen := make(map[string]struct{})
+	for _, item := range list.Items {
+		if len(item.Keys) != 1 {
+			return fmt.Errorf("missing dependency job ID")
+		}
+		n := item.Keys[0].Token.Value().(string)
+
+		// Make sure we haven't already found this
+		if _, ok := seen[n]; ok {
+			return fmt.Errorf("dependency '%s' defined more than once", n)
+		}
+		seen[n] = struct{}{}
+
+		// We need this later
+		var listVal *ast.ObjectList
+		if ot, ok := item.Val.(*ast.ObjectType); ok {
+			listVal = ot.List
+		} else {
+			return fmt.Errorf("dependency should be an object")
+		}
+
+		// Check for invalid keys
+		valid := []string{
+			"namespace",
+			"status",
+		}
+		if err := checkHCLKeys(listVal, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, item.Val); err != nil {
+			return err
+		}
+
+		// Decode dependency
+		var d api.JobDependency
+		d.JobID = n
+		if err := mapstructure.WeakDecode(m, &d); err != nil {
+			return err
+		}
+		*result = append(*result, &d)
+	}
+	return nil
+}
+
 // parseBool takes an interface value and tries to convert it to a boolean and
 // returns an error if the type can't be converted.
 func parseBool(value interface{}) (bool, error) {
@@ -523,6 +572,38 @@ func parseMigrate(result **api.MigrateStrategy, list *ast.ObjectList) error {
 	return dec.Decode(m)
 }
 
+func parseDisruptionBudget(result **api.DisruptionBudget, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'disruption_budget' block allowed")
+	}
+
+	// Get our resource object
+	o := list.Items[0]
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+
+	// Check for invalid keys
+	valid := []string{
+		"max_unavailable",
+	}
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return err
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(m)
+}
+
 func parseVault(result *api.Vault, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) == 0 {
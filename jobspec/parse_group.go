@@ -54,14 +54,17 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"reschedule",
 			"vault",
 			"migrate",
+			"disruption_budget",
 			"spread",
 			"shutdown_delay",
+			"max_runtime",
 			"network",
 			"service",
 			"volume",
 			"scaling",
 			"stop_after_client_disconnect",
 			"max_client_disconnect",
+			"gang",
 		}
 		if err := checkHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -83,6 +86,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		delete(m, "disconnect")
 		delete(m, "vault")
 		delete(m, "migrate")
+		delete(m, "disruption_budget")
 		delete(m, "spread")
 		delete(m, "network")
 		delete(m, "service")
@@ -184,6 +188,13 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			}
 		}
 
+		// If we have a disruption budget, then parse that
+		if o := listVal.Filter("disruption_budget"); len(o.Items) > 0 {
+			if err := parseDisruptionBudget(&g.DisruptionBudget, o); err != nil {
+				return multierror.Prefix(err, "disruption_budget ->")
+			}
+		}
+
 		// Parse out meta fields. These are in HCL as a list so we need
 		// to iterate over them and merge them.
 		if metaO := listVal.Filter("meta"); len(metaO.Items) > 0 {
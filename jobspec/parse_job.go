@@ -40,6 +40,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 	delete(m, "update")
 	delete(m, "vault")
 	delete(m, "spread")
+	delete(m, "depends_on")
 	delete(m, "multiregion")
 
 	// Set the ID and name to the object key
@@ -66,6 +67,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"affinity",
 		"spread",
 		"datacenters",
+		"depends_on",
 		"node_pool",
 		"group",
 		"id",
@@ -76,6 +78,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"parameterized",
 		"periodic",
 		"priority",
+		"rebalance",
 		"region",
 		"reschedule",
 		"task",
@@ -125,6 +128,13 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		}
 	}
 
+	// Parse dependencies
+	if o := listVal.Filter("depends_on"); len(o.Items) > 0 {
+		if err := parseJobDependencies(&result.DependsOn, o); err != nil {
+			return multierror.Prefix(err, "depends_on ->")
+		}
+	}
+
 	// If we have a parameterized definition, then parse that
 	if o := listVal.Filter("parameterized"); len(o.Items) > 0 {
 		if err := parseParameterizedJob(&result.ParameterizedJob, o); err != nil {
@@ -238,10 +248,16 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 		"crons",
 		"prohibit_overlap",
 		"time_zone",
+		"exclude",
+		"start_after",
+		"end_before",
+		"catchup",
+		"catchup_window",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
 	}
+	delete(m, "exclude")
 
 	if value, ok := m["enabled"]; ok {
 		enabled, err := parseBool(value)
@@ -265,9 +281,35 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 
 	// Build the constraint
 	var p api.PeriodicConfig
-	if err := mapstructure.WeakDecode(m, &p); err != nil {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &p,
+	})
+	if err != nil {
 		return err
 	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	// Parse the exclusions
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		if ex := ot.List.Filter("exclude"); len(ex.Items) > 0 {
+			for _, item := range ex.Items {
+				if err := checkHCLKeys(item.Val, []string{"cron", "start", "end"}); err != nil {
+					return multierror.Prefix(err, "exclude ->")
+				}
+
+				var exclusion api.PeriodicExclusion
+				if err := hcl.DecodeObject(&exclusion, item.Val); err != nil {
+					return err
+				}
+				p.Exclude = append(p.Exclude, &exclusion)
+			}
+		}
+	}
+
 	*result = &p
 	return nil
 }
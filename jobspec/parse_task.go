@@ -33,6 +33,7 @@ var (
 
 	normalTaskKeys = append(commonTaskKeys,
 		"action",
+		"max_runtime",
 		"artifact",
 		"constraint",
 		"affinity",
@@ -265,6 +266,10 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 			"max_file_size",
 			"enabled", // COMPAT(1.6.0): remove in favor of disabled
 			"disabled",
+			"compression",
+			"rotate_interval",
+			"timestamps",
+			"sink",
 		}
 		if err := checkHCLKeys(logsBlock.Val, valid); err != nil {
 			return nil, multierror.Prefix(err, "logs ->")
@@ -273,12 +278,29 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 		if err := hcl.DecodeObject(&m, logsBlock.Val); err != nil {
 			return nil, err
 		}
+		delete(m, "sink")
 
 		var log api.LogConfig
-		if err := mapstructure.WeakDecode(m, &log); err != nil {
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &log,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := dec.Decode(m); err != nil {
 			return nil, err
 		}
 
+		if ot, ok := logsBlock.Val.(*ast.ObjectType); ok {
+			if o := ot.List.Filter("sink"); len(o.Items) > 0 {
+				if err := parseLogSinks(&log.Sinks, o); err != nil {
+					return nil, multierror.Prefix(err, "logs -> sink ->")
+				}
+			}
+		}
+
 		t.LogConfig = &log
 	}
 
@@ -430,6 +452,46 @@ func parseArtifacts(result *[]*api.TaskArtifact, list *ast.ObjectList) error {
 	return nil
 }
 
+func parseLogSinks(result *[]*api.LogSink, list *ast.ObjectList) error {
+	for _, o := range list.Elem().Items {
+		// Check for invalid keys
+		valid := []string{
+			"type",
+			"address",
+			"tag",
+			"headers",
+			"buffer_size",
+			"batch_size",
+			"batch_interval",
+		}
+		if err := checkHCLKeys(o.Val, valid); err != nil {
+			return err
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		var sink api.LogSink
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &sink,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
+			return err
+		}
+
+		*result = append(*result, &sink)
+	}
+
+	return nil
+}
+
 func parseArtifactOption(result map[string]string, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) > 1 {
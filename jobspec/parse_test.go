@@ -590,6 +590,248 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"periodic-exclude.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				Periodic: &api.PeriodicConfig{
+					SpecType:      stringToPtr(api.PeriodicSpecCron),
+					Specs:         []string{"0 2 * * *"},
+					StartAfter:    stringToPtr("2024-01-01"),
+					EndBefore:     stringToPtr("2025-01-01T00:00:00"),
+					Catchup:       stringToPtr(api.PeriodicCatchupAll),
+					CatchupWindow: timeToPtr(6 * time.Hour),
+					Exclude: []*api.PeriodicExclusion{
+						{Cron: "* * * * 0,6"},
+						{Start: "2024-12-24", End: "2024-12-26"},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"group-gang.hcl",
+			&api.Job{
+				ID:   stringToPtr("training"),
+				Name: stringToPtr("training"),
+				Type: stringToPtr("batch"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:  stringToPtr("workers"),
+						Count: intToPtr(8),
+						Gang:  boolToPtr(true),
+						Tasks: []*api.Task{
+							{
+								Name:   "worker",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"group-disruption-budget.hcl",
+			&api.Job{
+				ID:   stringToPtr("web"),
+				Name: stringToPtr("web"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:  stringToPtr("frontend"),
+						Count: intToPtr(5),
+						DisruptionBudget: &api.DisruptionBudget{
+							MaxUnavailable: intToPtr(2),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"spread-max-skew.hcl",
+			&api.Job{
+				ID:   stringToPtr("web"),
+				Name: stringToPtr("web"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:  stringToPtr("frontend"),
+						Count: intToPtr(6),
+						Spreads: []*api.Spread{
+							{
+								Attribute: "${node.datacenter}",
+								MaxSkew:   1,
+								Hard:      true,
+							},
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"depends-on.hcl",
+			&api.Job{
+				ID:   stringToPtr("report"),
+				Name: stringToPtr("report"),
+				Type: stringToPtr("batch"),
+				DependsOn: []*api.JobDependency{
+					{
+						JobID: "extract",
+					},
+					{
+						JobID:     "cleanup",
+						Namespace: "ops",
+						Status:    "dead",
+					},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("report"),
+						Tasks: []*api.Task{
+							{
+								Name:   "render",
+								Driver: "exec",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"max-runtime.hcl",
+			&api.Job{
+				ID:   stringToPtr("etl"),
+				Name: stringToPtr("etl"),
+				Type: stringToPtr("batch"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:       stringToPtr("etl"),
+						MaxRuntime: timeToPtr(time.Hour),
+						Tasks: []*api.Task{
+							{
+								Name:       "extract",
+								Driver:     "exec",
+								MaxRuntime: 10 * time.Minute,
+							},
+							{
+								Name:   "load",
+								Driver: "exec",
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"log-rotation.hcl",
+			&api.Job{
+				ID:   stringToPtr("web"),
+				Name: stringToPtr("web"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+								LogConfig: &api.LogConfig{
+									MaxFiles:       intToPtr(5),
+									MaxFileSizeMB:  intToPtr(20),
+									Compression:    stringToPtr("zstd"),
+									RotateInterval: timeToPtr(time.Hour),
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"log-timestamps.hcl",
+			&api.Job{
+				ID:   stringToPtr("web"),
+				Name: stringToPtr("web"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+								LogConfig: &api.LogConfig{
+									Timestamps: boolToPtr(true),
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"log-sinks.hcl",
+			&api.Job{
+				ID:   stringToPtr("web"),
+				Name: stringToPtr("web"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("web"),
+						Tasks: []*api.Task{
+							{
+								Name:   "server",
+								Driver: "docker",
+								LogConfig: &api.LogConfig{
+									MaxFiles: intToPtr(5),
+									Sinks: []*api.LogSink{
+										{
+											Type:    "syslog",
+											Address: "udp://127.0.0.1:514",
+											Tag:     "web",
+										},
+										{
+											Type:          "http",
+											Address:       "https://logs.example.com/ingest",
+											BatchSize:     intToPtr(50),
+											BatchInterval: timeToPtr(5 * time.Second),
+											Headers: map[string]string{
+												"Authorization": "Bearer secret",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"specify-job.hcl",
 			&api.Job{
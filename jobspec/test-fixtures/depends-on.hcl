@@ -0,0 +1,19 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "report" {
+  type = "batch"
+
+  depends_on "extract" {}
+
+  depends_on "cleanup" {
+    namespace = "ops"
+    status    = "dead"
+  }
+
+  group "report" {
+    task "render" {
+      driver = "exec"
+    }
+  }
+}
@@ -0,0 +1,16 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "web" {
+  group "frontend" {
+    count = 5
+
+    disruption_budget {
+      max_unavailable = 2
+    }
+
+    task "server" {
+      driver = "docker"
+    }
+  }
+}
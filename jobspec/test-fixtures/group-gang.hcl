@@ -0,0 +1,15 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "training" {
+  type = "batch"
+
+  group "workers" {
+    count = 8
+    gang  = true
+
+    task "worker" {
+      driver = "docker"
+    }
+  }
+}
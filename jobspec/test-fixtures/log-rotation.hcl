@@ -0,0 +1,17 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "web" {
+  group "web" {
+    task "server" {
+      driver = "docker"
+
+      logs {
+        max_files       = 5
+        max_file_size   = 20
+        compression     = "zstd"
+        rotate_interval = "1h"
+      }
+    }
+  }
+}
@@ -0,0 +1,31 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "web" {
+  group "web" {
+    task "server" {
+      driver = "docker"
+
+      logs {
+        max_files = 5
+
+        sink {
+          type    = "syslog"
+          address = "udp://127.0.0.1:514"
+          tag     = "web"
+        }
+
+        sink {
+          type           = "http"
+          address        = "https://logs.example.com/ingest"
+          batch_size     = 50
+          batch_interval = "5s"
+
+          headers {
+            Authorization = "Bearer secret"
+          }
+        }
+      }
+    }
+  }
+}
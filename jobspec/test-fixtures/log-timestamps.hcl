@@ -0,0 +1,14 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "web" {
+  group "web" {
+    task "server" {
+      driver = "docker"
+
+      logs {
+        timestamps = true
+      }
+    }
+  }
+}
@@ -0,0 +1,19 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "etl" {
+  type = "batch"
+
+  group "etl" {
+    max_runtime = "1h"
+
+    task "extract" {
+      driver      = "exec"
+      max_runtime = "10m"
+    }
+
+    task "load" {
+      driver = "exec"
+    }
+  }
+}
@@ -0,0 +1,22 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "foo" {
+  periodic {
+    crons       = ["0 2 * * *"]
+    start_after = "2024-01-01"
+    end_before  = "2025-01-01T00:00:00"
+
+    catchup        = "all"
+    catchup_window = "6h"
+
+    exclude {
+      cron = "* * * * 0,6"
+    }
+
+    exclude {
+      start = "2024-12-24"
+      end   = "2024-12-26"
+    }
+  }
+}
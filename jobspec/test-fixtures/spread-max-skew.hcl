@@ -0,0 +1,18 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: MPL-2.0
+
+job "web" {
+  group "frontend" {
+    count = 6
+
+    spread {
+      attribute = "${node.datacenter}"
+      max_skew  = 1
+      hard      = true
+    }
+
+    task "server" {
+      driver = "docker"
+    }
+  }
+}
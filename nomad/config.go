@@ -67,6 +67,10 @@ type Config struct {
 	// EventBufferSize is the amount of events to hold in memory.
 	EventBufferSize int64
 
+	// EventSinkFileDir is the directory file event sinks may write to. File
+	// sinks are disabled when it is empty.
+	EventSinkFileDir string
+
 	// JobMaxSourceSize limits the maximum size of a jobs source hcl/json
 	// before being discarded automatically. A value of zero indicates no job
 	// sources will be stored.
@@ -231,6 +235,15 @@ type Config struct {
 	// before it's rotated
 	RootKeyRotationThreshold time.Duration
 
+	// RebalanceInterval is how often we dispatch a job to migrate the
+	// allocations of jobs which opted into rebalancing when their placement
+	// has become a poor fit.
+	RebalanceInterval time.Duration
+
+	// RebalanceMaxMigrations is the maximum number of allocations migrated
+	// by each run of the rebalancer. Zero, the default, disables rebalancing.
+	RebalanceMaxMigrations int
+
 	// VariablesRekeyInterval is how often we dispatch a job to
 	// rekey any variables associated with a key in the Rekeying state
 	VariablesRekeyInterval time.Duration
@@ -277,6 +290,18 @@ type Config struct {
 	// additional delay is selected from this range randomly.
 	EvalFailedFollowupDelayRange time.Duration
 
+	// EvalBrokerFairShare controls if the eval broker dequeues the ready
+	// evaluations of each scheduler by weighted fair queueing across
+	// namespaces, instead of by priority alone. The weight of each namespace
+	// is set on the namespace.
+	EvalBrokerFairShare bool
+
+	// EvalBrokerPriorityAging is how long a ready evaluation waits in the eval
+	// broker before its priority is raised by one, so that low priority
+	// evaluations are eventually dequeued. It only applies when
+	// EvalBrokerFairShare is enabled, and zero disables aging.
+	EvalBrokerPriorityAging time.Duration
+
 	// NodePlanRejectionEnabled controls if node rejection tracker is enabled.
 	NodePlanRejectionEnabled bool
 
@@ -569,6 +594,7 @@ func DefaultConfig() *Config {
 		RootKeyGCThreshold:               1 * time.Hour,
 		RootKeyRotationThreshold:         720 * time.Hour, // 30 days
 		VariablesRekeyInterval:           10 * time.Minute,
+		RebalanceInterval:                5 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
 		EvalNackInitialReenqueueDelay:    1 * time.Second,
@@ -576,6 +602,8 @@ func DefaultConfig() *Config {
 		EvalFailedFollowupBaselineDelay:  1 * time.Minute,
 		EvalFailedFollowupDelayRange:     5 * time.Minute,
 		EvalReapCancelableInterval:       5 * time.Second,
+		EvalBrokerFairShare:              false,
+		EvalBrokerPriorityAging:          1 * time.Minute,
 		MinHeartbeatTTL:                  10 * time.Second,
 		MaxHeartbeatsPerSecond:           50.0,
 		HeartbeatGrace:                   10 * time.Second,
@@ -14,6 +14,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -66,6 +67,8 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.rootKeyRotateOrGC(eval)
 	case structs.CoreJobVariablesRekey:
 		return c.variablesRekey(eval)
+	case structs.CoreJobRebalance:
+		return c.rebalance(eval)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
 	default:
@@ -1123,6 +1126,65 @@ func (c *CoreScheduler) rotateVariables(iter memdb.ResultIterator, eval *structs
 	return nil
 }
 
+// rebalance migrates the running allocations of the jobs which opted into
+// rebalancing whose placement has become a poor fit.
+func (c *CoreScheduler) rebalance(eval *structs.Evaluation) error {
+	moves, err := scheduler.Rebalance(c.snap, c.srv.config.RebalanceMaxMigrations, c.logger)
+	if err != nil {
+		return err
+	}
+	if len(moves) == 0 {
+		return nil
+	}
+
+	// Create an eval for each job so the allocations are migrated
+	now := time.Now().UTC().UnixNano()
+	transitions := make(map[string]*structs.DesiredTransition, len(moves))
+	jobEvals := make(map[structs.NamespacedID]*structs.Evaluation)
+	for _, move := range moves {
+		c.logger.Debug("rebalancing allocation", "alloc_id", move.AllocID,
+			"job_id", move.JobID, "namespace", move.Namespace, "reason", move.Reason)
+		transitions[move.AllocID] = &structs.DesiredTransition{
+			Migrate: pointer.Of(true),
+		}
+
+		id := structs.NewNamespacedID(move.JobID, move.Namespace)
+		if _, ok := jobEvals[id]; ok {
+			continue
+		}
+		job, err := c.snap.JobByID(nil, move.Namespace, move.JobID)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			continue
+		}
+		jobEvals[id] = &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   job.Namespace,
+			Priority:    job.Priority,
+			Type:        job.Type,
+			TriggeredBy: structs.EvalTriggerRebalance,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		}
+	}
+
+	req := &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs: transitions,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.Region(),
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	for _, eval := range jobEvals {
+		req.Evals = append(req.Evals, eval)
+	}
+	return c.srv.RPC("Alloc.UpdateDesiredTransition", req, &structs.GenericResponse{})
+}
+
 // getThreshold returns the index threshold for determining whether an
 // object is old enough to GC
 func (c *CoreScheduler) getThreshold(eval *structs.Evaluation, objectName, configName string, configThreshold time.Duration) uint64 {
@@ -2855,3 +2855,64 @@ func TestCoreScheduler_ExpiredACLTokenGC_Force(t *testing.T) {
 	tokens = fromIteratorFunc(iter)
 	require.ElementsMatch(t, append(nonExpiredGlobalTokens, nonExpiredLocalTokens...), tokens)
 }
+
+func TestCoreScheduler_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.RebalanceMaxMigrations = 2
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+
+	// Register nodes in two datacenters and a job spread across them whose
+	// allocations all run in the first one
+	var nodes []*structs.Node
+	for _, dc := range []string{"dc1", "dc2"} {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.Job()
+	job.Rebalance = true
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.Spreads = []*structs.Spread{{Attribute: "${node.datacenter}", Weight: 100}}
+	job.TaskGroups[0].Count = 2
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.AllocForNode(nodes[0])
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1002, allocs))
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	core := NewCoreScheduler(s1, snap)
+
+	must.NoError(t, core.Process(s1.coreJobEval(structs.CoreJobRebalance, 1003)))
+
+	// Only one allocation is migrated because of the migrate strategy
+	migrating := 0
+	for _, alloc := range allocs {
+		out, err := store.AllocByID(nil, alloc.ID)
+		must.NoError(t, err)
+		if out.DesiredTransition.ShouldMigrate() {
+			migrating++
+		}
+	}
+	must.Eq(t, 1, migrating)
+
+	evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerRebalance, evals[0].TriggeredBy)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// dependencyWatcherRaftShim is the shim that provides the state watching
+// methods. These should be set by the server and passed to the dependency
+// watcher.
+type dependencyWatcherRaftShim struct {
+	// apply is used to apply a message to Raft
+	apply raftApplyFn
+}
+
+func (d *dependencyWatcherRaftShim) UpdateEvals(evals []*structs.Evaluation) (uint64, error) {
+	update := &structs.EvalUpdateRequest{
+		Evals: evals,
+	}
+	fsmErrIntf, index, raftErr := d.apply(structs.EvalUpdateRequestType, update)
+	if fsmErr, ok := fsmErrIntf.(error); ok && fsmErr != nil {
+		return index, fsmErr
+	}
+	return index, raftErr
+}
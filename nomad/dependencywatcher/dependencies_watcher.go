@@ -0,0 +1,345 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dependencywatcher
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"golang.org/x/time/rate"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// LimitStateQueriesPerSecond is the number of state queries allowed per
+	// second
+	LimitStateQueriesPerSecond = 10.0
+)
+
+// DependencyRaftEndpoints exposes the dependency watcher to a set of functions
+// to apply data transforms via Raft.
+type DependencyRaftEndpoints interface {
+	// UpdateEvals is used to upsert evaluations
+	UpdateEvals(evals []*structs.Evaluation) (uint64, error)
+}
+
+// Watcher is used to watch the evaluations blocked on the dependencies of
+// their job and unblock them once the dependencies are satisfied.
+type Watcher struct {
+	enabled bool
+	logger  log.Logger
+
+	// queryLimiter is used to limit the rate of blocking queries
+	queryLimiter *rate.Limiter
+
+	// raft contains the set of Raft endpoints that can be used by the
+	// dependency watcher
+	raft DependencyRaftEndpoints
+
+	// state is the state that is watched for state changes.
+	state *state.StateStore
+
+	// ctx and exitFn are used to cancel the watcher
+	ctx    context.Context
+	exitFn context.CancelFunc
+
+	l sync.RWMutex
+}
+
+// NewDependencyWatcher returns a dependency watcher that is used to unblock
+// the evaluations of jobs with dependencies.
+func NewDependencyWatcher(logger log.Logger, raft DependencyRaftEndpoints,
+	stateQueriesPerSecond float64) *Watcher {
+
+	return &Watcher{
+		raft:         raft,
+		queryLimiter: rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
+		logger:       logger.Named("dependency_watcher"),
+	}
+}
+
+// SetEnabled is used to control if the watcher is enabled. The watcher
+// should only be enabled on the active leader. When being enabled the state is
+// passed in as it is no longer valid once a leader election has taken place.
+func (w *Watcher) SetEnabled(enabled bool, state *state.StateStore) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	wasEnabled := w.enabled
+	w.enabled = enabled
+
+	if state != nil {
+		w.state = state
+	}
+
+	// Kill everything associated with the watcher
+	if w.exitFn != nil {
+		w.exitFn()
+	}
+	w.ctx, w.exitFn = context.WithCancel(context.Background())
+
+	// If we are starting now, launch the watch daemon
+	if enabled && !wasEnabled {
+		go w.watch(w.ctx)
+	}
+}
+
+// blockedJob tracks the evaluations of a job blocked on its dependencies and
+// the watch on the state its dependencies were last checked against.
+type blockedJob struct {
+	// evals maps the ID of the blocked evaluations to their modify index
+	evals map[string]uint64
+
+	// cancel stops watching the state the dependencies were checked against
+	cancel context.CancelFunc
+}
+
+// watch is the long lived go-routine that watches for evaluations blocked on
+// dependencies and the jobs and allocations they depend on. Only the jobs
+// whose blocked evaluations or dependencies changed are checked again.
+func (w *Watcher) watch(ctx context.Context) {
+	blocked := make(map[structs.NamespacedID]*blockedJob)
+	defer func() {
+		for _, job := range blocked {
+			job.cancel()
+		}
+	}()
+
+	// changedCh receives the jobs whose dependencies have changed since they
+	// were last checked
+	changedCh := make(chan structs.NamespacedID)
+	dirty := make(map[structs.NamespacedID]struct{})
+
+	for {
+		if err := w.queryLimiter.Wait(ctx); err != nil {
+			return
+		}
+
+		// state can be updated concurrently
+		w.l.Lock()
+		store := w.state
+		w.l.Unlock()
+
+		ws := memdb.NewWatchSet()
+		evals, err := blockedEvals(ws, store)
+		if err != nil {
+			w.logger.Error("failed to retrieve evaluations blocked on dependencies", "error", err)
+			continue
+		}
+
+		// Stop tracking the jobs which are no longer blocked
+		for id, job := range blocked {
+			if _, ok := evals[id]; !ok {
+				job.cancel()
+				delete(blocked, id)
+				delete(dirty, id)
+			}
+		}
+
+		var updates []*structs.Evaluation
+		for id, jobEvals := range evals {
+			job, ok := blocked[id]
+			if _, changed := dirty[id]; ok && !changed && job.unchanged(jobEvals) {
+				continue
+			}
+			delete(dirty, id)
+			if ok {
+				job.cancel()
+			}
+
+			jobWs := memdb.NewWatchSet()
+			u, err := jobUpdates(jobWs, store, id, jobEvals)
+			if err != nil {
+				w.logger.Error("failed to check dependencies", "job_id", id.ID,
+					"namespace", id.Namespace, "error", err)
+				delete(blocked, id)
+				dirty[id] = struct{}{}
+				continue
+			}
+			updates = append(updates, u...)
+			blocked[id] = w.watchJob(ctx, id, jobEvals, jobWs, changedCh)
+		}
+
+		if len(updates) != 0 {
+			if _, err := w.raft.UpdateEvals(updates); err != nil {
+				// Retry checking the jobs on the next iteration
+				w.logger.Error("failed to update evaluations blocked on dependencies", "error", err)
+				for _, eval := range updates {
+					dirty[structs.NamespacedID{ID: eval.JobID, Namespace: eval.Namespace}] = struct{}{}
+				}
+				continue
+			}
+		}
+
+		// Nothing left to retry, wait for the blocked evaluations or the
+		// dependencies of a blocked job to change
+		if len(dirty) != 0 {
+			continue
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		select {
+		case <-ctx.Done():
+			cancel()
+			return
+		case <-ws.WatchCh(watchCtx):
+		case id := <-changedCh:
+			dirty[id] = struct{}{}
+		}
+		cancel()
+	}
+}
+
+// watchJob returns the tracking of a job blocked on its dependencies, which
+// notifies changedCh once the state in the watch set changes.
+func (w *Watcher) watchJob(ctx context.Context, id structs.NamespacedID,
+	evals []*structs.Evaluation, ws memdb.WatchSet,
+	changedCh chan<- structs.NamespacedID) *blockedJob {
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	job := &blockedJob{
+		evals:  make(map[string]uint64, len(evals)),
+		cancel: cancel,
+	}
+	for _, eval := range evals {
+		job.evals[eval.ID] = eval.ModifyIndex
+	}
+
+	go func() {
+		if err := ws.WatchCtx(jobCtx); err != nil {
+			return
+		}
+		select {
+		case changedCh <- id:
+		case <-jobCtx.Done():
+		}
+	}()
+
+	return job
+}
+
+// unchanged returns whether the blocked evaluations of the job are the ones
+// its dependencies were last checked for.
+func (b *blockedJob) unchanged(evals []*structs.Evaluation) bool {
+	if len(evals) != len(b.evals) {
+		return false
+	}
+	for _, eval := range evals {
+		if idx, ok := b.evals[eval.ID]; !ok || idx != eval.ModifyIndex {
+			return false
+		}
+	}
+	return true
+}
+
+// blockedEvals returns the evaluations blocked on dependencies grouped by
+// their job.
+func blockedEvals(ws memdb.WatchSet, store *state.StateStore) (map[structs.NamespacedID][]*structs.Evaluation, error) {
+	iter, err := store.EvalsBlockedOnDependencies(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make(map[structs.NamespacedID][]*structs.Evaluation)
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		eval := raw.(*structs.Evaluation)
+		id := structs.NamespacedID{ID: eval.JobID, Namespace: eval.Namespace}
+		blocked[id] = append(blocked[id], eval)
+	}
+	return blocked, nil
+}
+
+// jobUpdates returns the updates to the evaluations of a job blocked on its
+// dependencies. Only the most recent evaluation is kept blocked, and it is
+// unblocked once all the dependencies of the job are satisfied. The
+// evaluations are canceled if the job has been stopped or purged.
+func jobUpdates(ws memdb.WatchSet, store *state.StateStore, id structs.NamespacedID,
+	evals []*structs.Evaluation) ([]*structs.Evaluation, error) {
+
+	now := time.Now().UTC().UnixNano()
+	sort.Slice(evals, func(i, j int) bool {
+		return evals[i].CreateIndex > evals[j].CreateIndex
+	})
+
+	job, err := store.JobByID(ws, id.Namespace, id.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []*structs.Evaluation
+	cancel := func(eval *structs.Evaluation, desc string) {
+		eval = eval.Copy()
+		eval.Status = structs.EvalStatusCancelled
+		eval.StatusDescription = desc
+		eval.ModifyTime = now
+		updates = append(updates, eval)
+	}
+
+	switch {
+	case job == nil:
+		for _, eval := range evals {
+			cancel(eval, "job purged")
+		}
+		return updates, nil
+	case job.Stop:
+		for _, eval := range evals {
+			cancel(eval, "job stopped")
+		}
+		return updates, nil
+	}
+
+	latest := evals[0]
+	for _, eval := range evals[1:] {
+		cancel(eval, fmt.Sprintf("superseded by evaluation %q", latest.ID))
+	}
+
+	satisfied, err := DependenciesSatisfied(ws, store, job)
+	if err != nil {
+		return nil, err
+	}
+	if satisfied {
+		eval := latest.Copy()
+		eval.Status = structs.EvalStatusPending
+		eval.StatusDescription = ""
+		eval.BlockedOnDependencies = false
+		eval.ModifyTime = now
+		updates = append(updates, eval)
+	}
+
+	return updates, nil
+}
+
+// DependenciesSatisfied returns whether all the dependencies of the job are
+// satisfied by the jobs they depend on.
+func DependenciesSatisfied(ws memdb.WatchSet, store *state.StateStore, job *structs.Job) (bool, error) {
+	satisfied := true
+	for _, d := range job.DependsOn {
+		id := d.NamespacedID(job.Namespace)
+		dependency, err := store.JobByID(ws, id.Namespace, id.ID)
+		if err != nil {
+			return false, err
+		}
+		allocs, err := store.AllocsByJob(ws, id.Namespace, id.ID, false)
+		if err != nil {
+			return false, err
+		}
+
+		// Keep going when a dependency isn't satisfied so that all the
+		// dependencies are added to the watch set.
+		if !d.SatisfiedBy(dependency, allocs) {
+			satisfied = false
+		}
+	}
+	return satisfied, nil
+}
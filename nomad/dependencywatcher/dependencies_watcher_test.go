@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package dependencywatcher
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+// mockRaft applies evaluation updates directly to a state store at the index
+// following its latest index.
+type mockRaft struct {
+	state *state.StateStore
+	l     sync.Mutex
+}
+
+func (m *mockRaft) UpdateEvals(evals []*structs.Evaluation) (uint64, error) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	index, err := m.state.LatestIndex()
+	if err != nil {
+		return 0, err
+	}
+	index++
+	return index, m.state.UpsertEvals(structs.MsgTypeTestSetup, index, evals)
+}
+
+func testWatcher(t *testing.T) (*Watcher, *state.StateStore) {
+	store := state.TestStateStore(t)
+	w := NewDependencyWatcher(testlog.HCLogger(t), &mockRaft{state: store},
+		LimitStateQueriesPerSecond)
+	w.SetEnabled(true, store)
+	t.Cleanup(func() { w.SetEnabled(false, nil) })
+	return w, store
+}
+
+func blockedEval(job *structs.Job) *structs.Evaluation {
+	eval := mock.Eval()
+	eval.Namespace = job.Namespace
+	eval.JobID = job.ID
+	eval.BlockOnDependencies(job)
+	return eval
+}
+
+func waitForEvalStatus(t *testing.T, store *state.StateStore, id, status string) {
+	testutil.WaitForResultUntil(5*time.Second, func() (bool, error) {
+		eval, err := store.EvalByID(nil, id)
+		if err != nil {
+			return false, err
+		}
+		if eval.Status != status {
+			return false, fmt.Errorf("expected eval status %q, got %q", status, eval.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
+func TestWatcher_UnblocksSatisfiedDependencies(t *testing.T) {
+	ci.Parallel(t)
+	_, store := testWatcher(t)
+
+	extract := mock.BatchJob()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, extract))
+
+	report := mock.BatchJob()
+	report.DependsOn = []*structs.JobDependency{{JobID: extract.ID}}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, report))
+
+	older, eval := blockedEval(report), blockedEval(report)
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 102,
+		[]*structs.Evaluation{older}))
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 103,
+		[]*structs.Evaluation{eval}))
+
+	// Only the latest evaluation stays blocked
+	waitForEvalStatus(t, store, older.ID, structs.EvalStatusCancelled)
+	out, err := store.EvalByID(nil, eval.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalStatusBlocked, out.Status)
+
+	// Complete the allocation of the dependency, which makes it dead
+	alloc := mock.Alloc()
+	alloc.Job = extract
+	alloc.JobID = extract.ID
+	alloc.TaskGroup = extract.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 110,
+		[]*structs.Allocation{alloc}))
+
+	waitForEvalStatus(t, store, eval.ID, structs.EvalStatusPending)
+	out, err = store.EvalByID(nil, eval.ID)
+	must.NoError(t, err)
+	must.False(t, out.BlockedOnDependencies)
+}
+
+func TestWatcher_CancelsStoppedJob(t *testing.T) {
+	ci.Parallel(t)
+	_, store := testWatcher(t)
+
+	report := mock.BatchJob()
+	report.DependsOn = []*structs.JobDependency{{JobID: "extract"}}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, report))
+
+	eval := blockedEval(report)
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 101,
+		[]*structs.Evaluation{eval}))
+
+	stopped := report.Copy()
+	stopped.Stop = true
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, stopped))
+
+	waitForEvalStatus(t, store, eval.ID, structs.EvalStatusCancelled)
+	out, err := store.EvalByID(nil, eval.ID)
+	must.NoError(t, err)
+	must.Eq(t, "job stopped", out.StatusDescription)
+}
+
+func TestWatcher_UnblocksOnlyDependents(t *testing.T) {
+	ci.Parallel(t)
+	_, store := testWatcher(t)
+
+	extract, load := mock.BatchJob(), mock.BatchJob()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, extract))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, load))
+
+	report := mock.BatchJob()
+	report.DependsOn = []*structs.JobDependency{{JobID: extract.ID}}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, report))
+	publish := mock.BatchJob()
+	publish.DependsOn = []*structs.JobDependency{{JobID: load.ID}}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 103, nil, publish))
+
+	reportEval, publishEval := blockedEval(report), blockedEval(publish)
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 104,
+		[]*structs.Evaluation{reportEval, publishEval}))
+
+	// Complete the allocation of the dependency of the report job only
+	alloc := mock.Alloc()
+	alloc.Job = extract
+	alloc.JobID = extract.ID
+	alloc.TaskGroup = extract.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 110,
+		[]*structs.Allocation{alloc}))
+
+	waitForEvalStatus(t, store, reportEval.ID, structs.EvalStatusPending)
+	out, err := store.EvalByID(nil, publishEval.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalStatusBlocked, out.Status)
+	must.True(t, out.BlockedOnDependencies)
+}
@@ -0,0 +1,10 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// dependencywatcher tracks the evaluations of jobs which depend on other jobs
+// reaching a terminal status, and unblocks them once all the dependencies of
+// their job are satisfied.
+//
+// - The watcher is only enabled on the active raft leader.
+// - func (w *Watcher) watch() is the main dependency watcher process
+package dependencywatcher
@@ -411,8 +411,13 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 		return nil
 	}
 
-	// Determine how many we can drain
-	thresholdCount := tg.Count - tg.Migrate.MaxParallel
+	// Determine how many we can drain. A disruption budget further limits
+	// how many allocations may be unavailable at once.
+	maxParallel := tg.Migrate.MaxParallel
+	if tg.DisruptionBudget != nil {
+		maxParallel = min(maxParallel, tg.DisruptionBudget.MaxUnavailable)
+	}
+	thresholdCount := tg.Count - maxParallel
 	numToDrain := healthy - thresholdCount
 	numToDrain = min(len(drainable), numToDrain)
 	if numToDrain <= 0 {
@@ -324,10 +324,11 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 	ci.Parallel(t)
 
 	testCases := []struct {
-		name        string
-		batch       bool // use a batch job
-		allocCount  int  // number of allocs in test (defaults to 10)
-		maxParallel int  // max_parallel (defaults to 1)
+		name           string
+		batch          bool // use a batch job
+		allocCount     int  // number of allocs in test (defaults to 10)
+		maxParallel    int  // max_parallel (defaults to 1)
+		maxUnavailable int  // disruption budget max_unavailable (defaults to none)
 
 		// addAllocFn will be called allocCount times to create test allocs,
 		// and the allocs default to be healthy on the draining node
@@ -577,6 +578,24 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 				}
 			},
 		},
+		{
+			// with max_parallel=5 but a disruption budget of 2, only 2 allocs
+			// can be drained at a time
+			name:           "drain-respects-disruption-budget",
+			expectDrained:  2,
+			expectMigrated: 0,
+			maxParallel:    5,
+			maxUnavailable: 2,
+		},
+		{
+			// a disruption budget larger than max_parallel doesn't allow more
+			// allocs to be drained than max_parallel
+			name:           "drain-max-parallel-below-disruption-budget",
+			expectDrained:  2,
+			expectMigrated: 0,
+			maxParallel:    2,
+			maxUnavailable: 5,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -599,6 +618,11 @@ func TestDrainingJobWatcher_HandleTaskGroup(t *testing.T) {
 			if tc.maxParallel > 0 {
 				job.TaskGroups[0].Migrate.MaxParallel = tc.maxParallel
 			}
+			if tc.maxUnavailable > 0 {
+				job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{
+					MaxUnavailable: tc.maxUnavailable,
+				}
+			}
 			must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, nil, job))
 
 			var allocs []*structs.Allocation
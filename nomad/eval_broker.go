@@ -77,6 +77,19 @@ type EvalBroker struct {
 	// ready tracks the ready jobs by scheduler in a priority queue
 	ready map[string]ReadyEvaluations
 
+	// fairReady tracks the ready jobs by scheduler in a fair share queue,
+	// instead of ready, when fair share queueing is enabled
+	fairReady map[string]*fairShareQueue
+
+	// fairShare controls if the ready evaluations of each scheduler are
+	// dequeued by weighted fair queueing across namespaces. The
+	// priorityAging is how long a ready evaluation waits before its priority
+	// is raised by one, and namespaceWeights are the weights of the
+	// namespaces which don't have the default weight.
+	fairShare        bool
+	priorityAging    time.Duration
+	namespaceWeights map[string]int
+
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
 
@@ -158,6 +171,7 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		pending:              make(map[structs.NamespacedID]PendingEvaluations),
 		cancelable:           make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest),
 		ready:                make(map[string]ReadyEvaluations),
+		fairReady:            make(map[string]*fairShareQueue),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -170,11 +184,43 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
 }
 
+// SetFairShare enables fair share queueing of the ready evaluations. The ready
+// evaluations of each scheduler are dequeued by weighted fair queueing across
+// namespaces, so that a namespace with a large backlog of evaluations can't
+// starve the evaluations of other namespaces. The weights of the namespaces
+// are set with SetNamespaceWeights, and the priority of a ready evaluation is
+// raised by one for every priorityAging it waits, so that low priority
+// evaluations are eventually dequeued. A zero priorityAging disables aging.
+func (b *EvalBroker) SetFairShare(priorityAging time.Duration) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.fairShare = true
+	b.priorityAging = priorityAging
+}
+
+// SetNamespaceWeights sets the weights of the namespaces for fair share
+// queueing. Namespaces which aren't in the map have a weight of one.
+func (b *EvalBroker) SetNamespaceWeights(weights map[string]int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.namespaceWeights = weights
+}
+
+// namespaceWeight returns the weight of a namespace for fair share queueing.
+// This assumes locks are held.
+func (b *EvalBroker) namespaceWeight(namespace string) int {
+	if weight, ok := b.namespaceWeights[namespace]; ok && weight > 0 {
+		return weight
+	}
+	return 1
+}
+
 // Enabled is used to check if the broker is enabled.
 func (b *EvalBroker) Enabled() bool {
 	b.l.RLock()
@@ -348,18 +394,11 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		return
 	}
 
-	// Find the next ready eval by scheduler class
-	readyQueue, ok := b.ready[sched]
-	if !ok {
-		readyQueue = make([]*structs.Evaluation, 0, 16)
-		if _, ok := b.waiting[sched]; !ok {
-			b.waiting[sched] = make(chan struct{}, 1)
-		}
+	// Push onto the ready queue of the scheduler class
+	if _, ok := b.waiting[sched]; !ok {
+		b.waiting[sched] = make(chan struct{}, 1)
 	}
-
-	// Push onto the heap
-	heap.Push(&readyQueue, eval)
-	b.ready[sched] = readyQueue
+	b.pushReady(eval, sched)
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -369,6 +408,12 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		b.stats.ByScheduler[sched] = bySched
 	}
 	bySched.Ready += 1
+	byNamespace, ok := b.stats.ByNamespace[eval.Namespace]
+	if !ok {
+		byNamespace = &NamespaceStats{}
+		b.stats.ByNamespace[eval.Namespace] = byNamespace
+	}
+	byNamespace.Ready += 1
 
 	// Unblock any pending dequeues
 	select {
@@ -445,14 +490,8 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 	var eligibleSched []string
 	var eligiblePriority int
 	for _, sched := range schedulers {
-		// Get the ready queue for this scheduler
-		readyQueue, ok := b.ready[sched]
-		if !ok {
-			continue
-		}
-
-		// Peek at the next item
-		ready := readyQueue.Peek()
+		// Peek at the next item of the ready queue for this scheduler
+		ready := b.peekReady(sched)
 		if ready == nil {
 			continue
 		}
@@ -491,10 +530,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
-	readyQueue := b.ready[sched]
-	raw := heap.Pop(&readyQueue)
-	b.ready[sched] = readyQueue
-	eval := raw.(*structs.Evaluation)
+	eval := b.popReady(sched)
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -520,10 +556,65 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	byNamespace := b.stats.ByNamespace[eval.Namespace]
+	byNamespace.Ready -= 1
+	byNamespace.Unacked += 1
 
 	return eval, token, nil
 }
 
+// pushReady is used to add an evaluation to the ready queue of a scheduler.
+// This assumes locks are held.
+func (b *EvalBroker) pushReady(eval *structs.Evaluation, sched string) {
+	if b.fairShare {
+		readyQueue, ok := b.fairReady[sched]
+		if !ok {
+			readyQueue = newFairShareQueue(b.priorityAging, b.namespaceWeight)
+			b.fairReady[sched] = readyQueue
+		}
+		readyQueue.Push(eval, time.Now())
+		return
+	}
+
+	readyQueue, ok := b.ready[sched]
+	if !ok {
+		readyQueue = make([]*structs.Evaluation, 0, 16)
+	}
+	heap.Push(&readyQueue, eval)
+	b.ready[sched] = readyQueue
+}
+
+// peekReady returns the next evaluation of the ready queue of a scheduler, or
+// nil if there is none. This assumes locks are held.
+func (b *EvalBroker) peekReady(sched string) *structs.Evaluation {
+	if b.fairShare {
+		readyQueue, ok := b.fairReady[sched]
+		if !ok {
+			return nil
+		}
+		return readyQueue.Peek()
+	}
+
+	readyQueue, ok := b.ready[sched]
+	if !ok {
+		return nil
+	}
+	return readyQueue.Peek()
+}
+
+// popReady removes and returns the next evaluation of the ready queue of a
+// scheduler. This assumes locks are held and that the scheduler has work.
+func (b *EvalBroker) popReady(sched string) *structs.Evaluation {
+	if b.fairShare {
+		return b.fairReady[sched].Pop()
+	}
+
+	readyQueue := b.ready[sched]
+	raw := heap.Pop(&readyQueue)
+	b.ready[sched] = readyQueue
+	return raw.(*structs.Evaluation)
+}
+
 // waitForSchedulers is used to wait for work on any of the scheduler or until a timeout.
 // Returns if there is work waiting potentially.
 func (b *EvalBroker) waitForSchedulers(schedulers []string, timeoutCh <-chan time.Time) bool {
@@ -629,6 +720,9 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	}
 	bySched := b.stats.ByScheduler[queue]
 	bySched.Unacked -= 1
+	if byNamespace, ok := b.stats.ByNamespace[unack.Eval.Namespace]; ok {
+		byNamespace.Unacked -= 1
+	}
 
 	// Cleanup
 	delete(b.unack, evalID)
@@ -703,6 +797,9 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 	b.stats.TotalUnacked -= 1
 	bySched := b.stats.ByScheduler[unack.Eval.Type]
 	bySched.Unacked -= 1
+	if byNamespace, ok := b.stats.ByNamespace[unack.Eval.Namespace]; ok {
+		byNamespace.Unacked -= 1
+	}
 
 	// Check if we've hit the delivery limit, and re-enqueue
 	// in the failedQueue
@@ -839,11 +936,13 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalCancelable = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.pending = make(map[structs.NamespacedID]PendingEvaluations)
 	b.cancelable = make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest)
 	b.ready = make(map[string]ReadyEvaluations)
+	b.fairReady = make(map[string]*fairShareQueue)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -928,6 +1027,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -946,6 +1046,10 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for namespace, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		stats.ByNamespace[namespace] = &subStatCopy
+	}
 	return stats
 }
 
@@ -996,6 +1100,11 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for namespace, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: namespace}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "unacked"}, float32(nsStats.Unacked), labels)
+			}
 
 		case <-stopCh:
 			return
@@ -1012,6 +1121,7 @@ type BrokerStats struct {
 	TotalCancelable int
 	DelayedEvals    map[string]*structs.Evaluation
 	ByScheduler     map[string]*SchedulerStats
+	ByNamespace     map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -1020,6 +1130,12 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Unacked int
+}
+
 // Len is for the sorting interface
 func (r ReadyEvaluations) Len() int {
 	return len(r)
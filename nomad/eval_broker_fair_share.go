@@ -0,0 +1,210 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// fairShareQueue is a queue of the ready evaluations of a scheduler which is
+// dequeued by weighted fair queueing across namespaces. Each namespace has its
+// own priority queue of evaluations and a virtual time, which advances by the
+// inverse of the namespace weight every time an evaluation of the namespace is
+// dequeued. The namespace with the lowest virtual time is dequeued next, so
+// namespaces are served in proportion to their weights regardless of the
+// number of evaluations they have queued.
+type fairShareQueue struct {
+	// aging is how long an evaluation waits before its priority is raised by
+	// one. Zero disables aging.
+	aging time.Duration
+
+	// weight returns the weight of a namespace. It is called with the lock
+	// of the broker held, so it must not block.
+	weight func(namespace string) int
+
+	// namespaces are the ready evaluations by namespace. Namespaces without
+	// ready evaluations are removed once they have no virtual time left to
+	// account for.
+	namespaces map[string]*namespaceReadyEvaluations
+
+	// virtualTime is the virtual time of the namespace dequeued last. A
+	// namespace which becomes ready starts from it, so that namespaces don't
+	// accumulate credit while they have nothing queued.
+	virtualTime float64
+}
+
+// namespaceReadyEvaluations are the ready evaluations of a namespace in a
+// fairShareQueue.
+type namespaceReadyEvaluations struct {
+	evals       *AgingEvaluations
+	virtualTime float64
+}
+
+// newFairShareQueue returns an empty fairShareQueue.
+func newFairShareQueue(aging time.Duration, weight func(namespace string) int) *fairShareQueue {
+	return &fairShareQueue{
+		aging:      aging,
+		weight:     weight,
+		namespaces: make(map[string]*namespaceReadyEvaluations),
+	}
+}
+
+// Push adds an evaluation to the queue, which became ready at the given time.
+func (q *fairShareQueue) Push(eval *structs.Evaluation, now time.Time) {
+	ns, ok := q.namespaces[eval.Namespace]
+	if !ok {
+		ns = &namespaceReadyEvaluations{
+			evals: &AgingEvaluations{Aging: q.aging},
+		}
+		q.namespaces[eval.Namespace] = ns
+	}
+	if ns.evals.Len() == 0 && ns.virtualTime < q.virtualTime {
+		ns.virtualTime = q.virtualTime
+	}
+	heap.Push(ns.evals, agingEval{eval: eval, enqueueTime: now.UnixNano()})
+}
+
+// Peek returns the evaluation which would be dequeued next, or nil if the
+// queue is empty.
+func (q *fairShareQueue) Peek() *structs.Evaluation {
+	_, ns := q.next()
+	if ns == nil {
+		return nil
+	}
+	return ns.evals.Peek()
+}
+
+// Pop removes and returns the evaluation of the namespace with the lowest
+// virtual time, or nil if the queue is empty.
+func (q *fairShareQueue) Pop() *structs.Evaluation {
+	name, ns := q.next()
+	if ns == nil {
+		return nil
+	}
+	eval := heap.Pop(ns.evals).(agingEval).eval
+
+	weight := 1
+	if q.weight != nil {
+		if w := q.weight(name); w > 0 {
+			weight = w
+		}
+	}
+	q.virtualTime = ns.virtualTime
+	ns.virtualTime += 1 / float64(weight)
+	return eval
+}
+
+// Len returns the number of evaluations in the queue.
+func (q *fairShareQueue) Len() int {
+	n := 0
+	for _, ns := range q.namespaces {
+		n += ns.evals.Len()
+	}
+	return n
+}
+
+// next returns the namespace with ready evaluations and the lowest virtual
+// time. Ties are broken by the priority of the next evaluation and then by
+// namespace name, so the order is deterministic. Namespaces without ready
+// evaluations which aren't ahead of the queue are removed, as they would start
+// from the virtual time of the queue if they became ready again.
+func (q *fairShareQueue) next() (string, *namespaceReadyEvaluations) {
+	var nextName string
+	var next *namespaceReadyEvaluations
+	for name, ns := range q.namespaces {
+		if ns.evals.Len() == 0 {
+			if ns.virtualTime <= q.virtualTime {
+				delete(q.namespaces, name)
+			}
+			continue
+		}
+		if next == nil || ns.virtualTime < next.virtualTime {
+			nextName, next = name, ns
+			continue
+		}
+		if ns.virtualTime > next.virtualTime {
+			continue
+		}
+
+		priority, nextPriority := ns.evals.Peek().Priority, next.evals.Peek().Priority
+		if priority > nextPriority || (priority == nextPriority && name < nextName) {
+			nextName, next = name, ns
+		}
+	}
+	return nextName, next
+}
+
+// agingEval is a ready evaluation along with the time it was enqueued in the
+// broker, in nanoseconds since the epoch.
+type agingEval struct {
+	eval        *structs.Evaluation
+	enqueueTime int64
+}
+
+// AgingEvaluations is a list of ready evaluations where the priority of an
+// evaluation is raised by one for every Aging interval it has waited since it
+// was enqueued. We implement the container/heap interface so that this is a
+// priority queue.
+type AgingEvaluations struct {
+	Evals []agingEval
+	Aging time.Duration
+}
+
+// agedPriority returns the priority of the evaluation, lowered by the number
+// of aging intervals between the epoch and its enqueue. Every evaluation ages
+// at the same rate, so ordering by the time of enqueue is equivalent to
+// ordering by the time waited, and the order doesn't change as time passes.
+// The enqueue time is recorded by the broker rather than taken from the
+// evaluation, so that evaluations can't be aged by their creator.
+func (a *AgingEvaluations) agedPriority(e agingEval) float64 {
+	if a.Aging <= 0 {
+		return float64(e.eval.Priority)
+	}
+	return float64(e.eval.Priority) - float64(e.enqueueTime)/float64(a.Aging)
+}
+
+// Len is for the sorting interface
+func (a *AgingEvaluations) Len() int {
+	return len(a.Evals)
+}
+
+// Less is for the sorting interface. We flip the check so that the "min" in
+// the min-heap is the element with the highest aged priority
+func (a *AgingEvaluations) Less(i, j int) bool {
+	pi, pj := a.agedPriority(a.Evals[i]), a.agedPriority(a.Evals[j])
+	if pi != pj {
+		return pi > pj
+	}
+	return a.Evals[i].eval.CreateIndex < a.Evals[j].eval.CreateIndex
+}
+
+// Swap is for the sorting interface
+func (a *AgingEvaluations) Swap(i, j int) {
+	a.Evals[i], a.Evals[j] = a.Evals[j], a.Evals[i]
+}
+
+// Push is used to add a new evaluation to the slice
+func (a *AgingEvaluations) Push(e interface{}) {
+	a.Evals = append(a.Evals, e.(agingEval))
+}
+
+// Pop is used to remove an evaluation from the slice
+func (a *AgingEvaluations) Pop() interface{} {
+	n := len(a.Evals)
+	e := a.Evals[n-1]
+	a.Evals[n-1] = agingEval{}
+	a.Evals = a.Evals[:n-1]
+	return e
+}
+
+// Peek is used to peek at the next element that would be popped
+func (a *AgingEvaluations) Peek() *structs.Evaluation {
+	if len(a.Evals) == 0 {
+		return nil
+	}
+	return a.Evals[0].eval
+}
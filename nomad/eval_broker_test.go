@@ -436,6 +436,7 @@ func TestEvalBroker_Serialize_DuplicateJobID(t *testing.T) {
 		stats := b.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -1424,6 +1425,164 @@ func TestEvalBroker_ReadyEvals_Ordering(t *testing.T) {
 
 }
 
+func TestEvalBroker_FairShare(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetFairShare(0)
+	b.SetNamespaceWeights(map[string]int{"weighted": 2})
+	b.SetEnabled(true)
+
+	enqueue := func(namespace string, priority int) *structs.Evaluation {
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		eval.Priority = priority
+		b.Enqueue(eval)
+		return eval
+	}
+
+	// The flooding namespace enqueues a backlog of high priority evals
+	// before the other namespaces enqueue theirs.
+	for i := 0; i < 6; i++ {
+		enqueue("flood", 70)
+	}
+	other := enqueue("other", 50)
+	weighted := []*structs.Evaluation{
+		enqueue("weighted", 50),
+		enqueue("weighted", 50),
+	}
+
+	stats := b.Stats()
+	must.Eq(t, 9, stats.TotalReady)
+	must.Eq(t, 6, stats.ByNamespace["flood"].Ready)
+	must.Eq(t, 2, stats.ByNamespace["weighted"].Ready)
+
+	dequeue := func() *structs.Evaluation {
+		t.Helper()
+		eval, token, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.NotNil(t, eval)
+		must.NoError(t, b.Ack(eval.ID, token))
+		return eval
+	}
+
+	// Every namespace is served once before the flooding namespace is served
+	// again, with ties broken by priority, and the weighted namespace is
+	// served twice as often.
+	must.Eq(t, "flood", dequeue().Namespace)
+	must.Eq(t, other.ID, dequeue().ID)
+	must.Eq(t, weighted[0].ID, dequeue().ID)
+	must.Eq(t, weighted[1].ID, dequeue().ID)
+	for i := 0; i < 5; i++ {
+		must.Eq(t, "flood", dequeue().Namespace)
+	}
+
+	stats = b.Stats()
+	must.Zero(t, stats.TotalReady)
+	must.Zero(t, stats.ByNamespace["flood"].Ready)
+	must.Zero(t, stats.ByNamespace["flood"].Unacked)
+
+	// Namespaces without ready evals are removed from the queue once they
+	// aren't ahead of it, as they start from the virtual time of the queue
+	// when they become ready again.
+	b.l.Lock()
+	namespaces := b.fairReady[mock.Eval().Type].namespaces
+	must.MapNotContainsKeys(t, namespaces, []string{"other", "weighted"})
+	must.MapContainsKey(t, namespaces, "flood")
+	b.l.Unlock()
+}
+
+func TestEvalBroker_FairShare_AgingFromEnqueue(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetFairShare(time.Minute)
+	b.SetEnabled(true)
+
+	// An eval which claims to be old isn't aged past a higher priority eval
+	// which was enqueued before it.
+	high := mock.Eval()
+	high.Priority = 60
+	b.Enqueue(high)
+
+	low := mock.Eval()
+	low.Priority = 50
+	low.CreateTime = time.Now().Add(-24 * time.Hour).UnixNano()
+	b.Enqueue(low)
+
+	out, _, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, high.ID, out.ID)
+}
+
+func TestEvalBroker_FairShare_Stats(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetFairShare(time.Minute)
+	b.SetEnabled(true)
+
+	eval := mock.Eval()
+	eval.Namespace = "n1"
+	b.Enqueue(eval)
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval.ID, out.ID)
+
+	stats := b.Stats()
+	must.Zero(t, stats.ByNamespace["n1"].Ready)
+	must.Eq(t, 1, stats.ByNamespace["n1"].Unacked)
+
+	// A Nack makes the eval ready again
+	must.NoError(t, b.Nack(out.ID, token))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return b.Stats().ByNamespace["n1"].Ready == 1 }),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.Zero(t, b.Stats().ByNamespace["n1"].Unacked)
+
+	// Disabling the broker resets the stats
+	b.SetEnabled(false)
+	must.MapEmpty(t, b.Stats().ByNamespace)
+}
+
+func TestEvalBroker_AgingEvals_Ordering(t *testing.T) {
+	ci.Parallel(t)
+
+	ready := &AgingEvaluations{Aging: time.Minute}
+
+	now := time.Now()
+	newEval := func(evalID string, priority int, enqueued time.Time, index uint64) agingEval {
+		eval := mock.Eval()
+		eval.ID = evalID
+		eval.Priority = priority
+		eval.CreateIndex = index
+		return agingEval{eval: eval, enqueueTime: enqueued.UnixNano()}
+	}
+
+	heap.Push(ready, newEval("eval01", 70, now, 1))
+	heap.Push(ready, newEval("eval02", 50, now.Add(-30*time.Minute), 2))
+	heap.Push(ready, newEval("eval03", 50, now.Add(-10*time.Minute), 3))
+	heap.Push(ready, newEval("eval04", 60, now.Add(-10*time.Minute), 4))
+
+	next := heap.Pop(ready).(agingEval).eval
+	test.Eq(t, "eval02", next.ID,
+		test.Sprint("expected the eval which waited long enough to be aged past the others"))
+
+	next = heap.Pop(ready).(agingEval).eval
+	test.Eq(t, "eval01", next.ID,
+		test.Sprint("expected highest Priority to be next ready"))
+
+	next = heap.Pop(ready).(agingEval).eval
+	test.Eq(t, "eval04", next.ID,
+		test.Sprint("expected highest Priority among evals of the same age to be next ready"))
+
+	// Without aging only the priority matters
+	ready = &AgingEvaluations{}
+	heap.Push(ready, newEval("eval05", 50, now.Add(-time.Hour), 5))
+	heap.Push(ready, newEval("eval06", 60, now, 6))
+	test.Eq(t, "eval06", ready.Peek().ID)
+}
+
 func TestEvalBroker_PendingEval_Ordering(t *testing.T) {
 	pending := PendingEvaluations{}
 
@@ -1567,6 +1726,7 @@ func TestEvalBroker_IntegrationTest(t *testing.T) {
 		stats := srv.evalBroker.Stats()
 		stats.DelayedEvals = nil
 		stats.ByScheduler = nil
+		stats.ByNamespace = nil
 		return *stats
 	}
 
@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// EventSink is the server RPC endpoint for event stream sinks.
+type EventSink struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger hclog.Logger
+}
+
+func NewEventSinkEndpoint(srv *Server, ctx *RPCContext) *EventSink {
+	return &EventSink{srv: srv, ctx: ctx, logger: srv.logger.Named("event_sink")}
+}
+
+// Register creates or updates an event sink. The progress of an existing
+// sink is preserved.
+func (e *EventSink) Register(args *structs.EventSinkRegisterRequest, reply *structs.GenericResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.Register", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "register"}, time.Now())
+
+	// This action requires a management token, as sinks receive the events
+	// of every topic and namespace they subscribe to without the per-topic
+	// ACL checks of the event stream.
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Sink == nil {
+		return errors.New("missing event sink")
+	}
+	args.Sink.Canonicalize()
+	if err := args.Sink.Validate(); err != nil {
+		return fmt.Errorf("invalid event sink: %w", err)
+	}
+	if args.Sink.Type == structs.EventSinkFile {
+		if err := stream.ValidateFileSinkPath(e.srv.config.EventSinkFileDir, args.Sink.Path); err != nil {
+			return fmt.Errorf("invalid event sink: %w", err)
+		}
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventSinkRegisterRequestType, args)
+	if err != nil {
+		e.logger.Error("register event sink failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// Deregister deletes a set of event sinks.
+func (e *EventSink) Deregister(args *structs.EventSinkDeregisterRequest, reply *structs.GenericResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.Deregister", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "deregister"}, time.Now())
+
+	// This action requires operator write access.
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.IDs) == 0 {
+		return errors.New("must specify at least one event sink ID")
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventSinkDeregisterRequestType, args)
+	if err != nil {
+		e.logger.Error("deregister event sinks failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// Get returns a single event sink.
+func (e *EventSink) Get(args *structs.EventSinkSpecificRequest, reply *structs.EventSinkResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.Get", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "get"}, time.Now())
+
+	// This action requires operator read access.
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.ID == "" {
+		return errors.New("missing event sink ID")
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			sink, err := store.EventSinkByID(ws, args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Sink = sink.Copy()
+			return e.srv.replySetIndex(state.TableEventSinks, &reply.QueryMeta)
+		}}
+	return e.srv.blockingRPC(&opts)
+}
+
+// List returns all the event sinks.
+func (e *EventSink) List(args *structs.EventSinkListRequest, reply *structs.EventSinkListResponse) error {
+	authErr := e.srv.Authenticate(e.ctx, args)
+	if done, err := e.srv.forward("EventSink.List", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("event_sink", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "event_sink", "list"}, time.Now())
+
+	// This action requires operator read access.
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			iter, err := store.EventSinks(ws)
+			if err != nil {
+				return err
+			}
+
+			sinks := []*structs.EventSink{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				sinks = append(sinks, raw.(*structs.EventSink).Copy())
+			}
+			reply.Sinks = sinks
+			return e.srv.replySetIndex(state.TableEventSinks, &reply.QueryMeta)
+		}}
+	return e.srv.blockingRPC(&opts)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestEventSinkEndpoint_CRUD(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, rootToken, cleanupSrv := TestACLServer(t, nil)
+	t.Cleanup(cleanupSrv)
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+
+	sink := mock.EventSink()
+	sink.Topics = nil
+	sink.Namespace = ""
+	regReq := &structs.EventSinkRegisterRequest{
+		Sink:         sink,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var regResp structs.GenericResponse
+
+	// registering a sink requires a management token
+	readToken := mock.CreatePolicyAndToken(t, srv.State(), 100, "operator-read",
+		`operator { policy = "read" }`)
+	regReq.AuthToken = readToken.SecretID
+	err := msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	writeToken := mock.CreatePolicyAndToken(t, srv.State(), 101, "operator-write",
+		`operator { policy = "write" }`)
+	regReq.AuthToken = writeToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// invalid sinks are rejected
+	regReq.AuthToken = rootToken.SecretID
+	regReq.Sink.Address = "ftp://example.com"
+	err = msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp)
+	must.ErrorContains(t, err, "webhook address must be an http or https URL")
+
+	regReq.Sink.Address = "https://example.com/events"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp))
+	must.Positive(t, regResp.Index)
+
+	// the sink can be read with operator read access, with defaults set
+	getReq := &structs.EventSinkSpecificRequest{
+		ID: sink.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var getResp structs.EventSinkResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.Get", getReq, &getResp))
+	must.NotNil(t, getResp.Sink)
+	must.Eq(t, "*", getResp.Sink.Namespace)
+	must.Eq(t, map[structs.Topic][]string{structs.TopicAll: {"*"}}, getResp.Sink.Topics)
+	must.Eq(t, regResp.Index, getResp.Index)
+
+	getReq.AuthToken = ""
+	err = msgpackrpc.CallWithCodec(codec, "EventSink.Get", getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	listReq := &structs.EventSinkListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var listResp structs.EventSinkListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Sinks)
+
+	// deleting an unknown sink fails
+	delReq := &structs.EventSinkDeregisterRequest{
+		IDs: []string{"unknown"},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: rootToken.SecretID,
+		},
+	}
+	var delResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "EventSink.Deregister", delReq, &delResp)
+	must.ErrorContains(t, err, `event sink "unknown" not found`)
+
+	delReq.IDs = []string{sink.ID}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.Deregister", delReq, &delResp))
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.List", listReq, &listResp))
+	must.Len(t, 0, listResp.Sinks)
+}
+
+func TestEventSinkEndpoint_Deliver(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.EventSinkFileDir = dir
+	})
+	t.Cleanup(cleanupSrv)
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+
+	// file sinks outside of the event sink directory are rejected
+	regReq := &structs.EventSinkRegisterRequest{
+		Sink: &structs.EventSink{
+			ID:   "outside",
+			Type: structs.EventSinkFile,
+			Path: "/etc/events.json",
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var regResp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp)
+	must.ErrorContains(t, err, "must be below the event sink directory")
+
+	path := filepath.Join(dir, "events.json")
+	sink := &structs.EventSink{
+		ID:     "jobs",
+		Type:   structs.EventSinkFile,
+		Path:   path,
+		Topics: map[structs.Topic][]string{structs.TopicJob: {"*"}},
+	}
+	regReq.Sink = sink
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "EventSink.Register", regReq, &regResp))
+
+	job := mock.Job()
+	jobReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var jobResp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", jobReq, &jobResp))
+
+	// the job events are written to the file
+	readEvents := func() []structs.Events {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+
+		var out []structs.Events
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			var events structs.Events
+			must.NoError(t, json.Unmarshal(scanner.Bytes(), &events))
+			out = append(out, events)
+		}
+		return out
+	}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			for _, events := range readEvents() {
+				for _, event := range events.Events {
+					if event.Topic == structs.TopicJob && event.Key == job.ID {
+						return true
+					}
+				}
+			}
+			return false
+		}),
+		wait.Timeout(10*time.Second),
+		wait.Gap(100*time.Millisecond),
+	))
+	for _, events := range readEvents() {
+		must.Greater(t, regResp.Index, events.Index)
+		for _, event := range events.Events {
+			must.Eq(t, structs.TopicJob, event.Topic)
+		}
+	}
+
+	// the progress of the sink is eventually recorded in raft
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			out, err := srv.State().EventSinkByID(nil, sink.ID)
+			must.NoError(t, err)
+			return out.LatestIndex >= jobResp.JobModifyIndex
+		}),
+		wait.Timeout(2*eventSinkProgressInterval),
+		wait.Gap(100*time.Millisecond),
+	))
+}
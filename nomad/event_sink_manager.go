@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/stream"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// eventSinkProgressInterval is how often the leader records the progress
+	// of the event sinks in raft. Events delivered since the last update are
+	// delivered again after a leader election.
+	eventSinkProgressInterval = 5 * time.Second
+
+	// eventSinkRetryInterval is the delay before a sink restarts after it
+	// failed to subscribe to the event broker or to open its destination.
+	eventSinkRetryInterval = 10 * time.Second
+)
+
+// eventSinkManager runs the event sinks registered in the state store on the
+// leader. Each sink subscribes to the event broker from the index of the
+// last events it delivered, so delivery is at-least-once across leader
+// elections, for as long as the events remain in the broker buffer.
+type eventSinkManager struct {
+	srv    *Server
+	logger hclog.Logger
+
+	// runners is the set of running sinks, keyed by sink ID.
+	runners map[string]*eventSinkRunner
+}
+
+// eventSinkRunner delivers the events of a single sink.
+type eventSinkRunner struct {
+	sink   *structs.EventSink
+	logger hclog.Logger
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	// delivered is the index of the last events delivered by the sink.
+	delivered atomic.Uint64
+}
+
+// runEventSinks runs the event sinks until leadership is lost.
+func (s *Server) runEventSinks(stopCh chan struct{}) {
+	m := &eventSinkManager{
+		srv:     s,
+		logger:  s.logger.Named("event_sinks"),
+		runners: make(map[string]*eventSinkRunner),
+	}
+	m.run(stopCh)
+}
+
+func (m *eventSinkManager) run(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	defer m.stopAll()
+
+	ticker := time.NewTicker(eventSinkProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		ws := memdb.NewWatchSet()
+		sinks, err := m.sinks(ws)
+		if err != nil {
+			m.logger.Error("failed to lookup event sinks", "error", err)
+		} else {
+			m.reconcile(ctx, sinks)
+		}
+
+		watchCh := make(chan error, 1)
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		go func() { watchCh <- ws.WatchCtx(watchCtx) }()
+
+		select {
+		case <-ctx.Done():
+			watchCancel()
+			return
+		case <-ticker.C:
+			watchCancel()
+			m.flushProgress(sinks)
+		case <-watchCh:
+			watchCancel()
+		}
+	}
+}
+
+// sinks returns the registered event sinks keyed by ID.
+func (m *eventSinkManager) sinks(ws memdb.WatchSet) (map[string]*structs.EventSink, error) {
+	iter, err := m.srv.State().EventSinks(ws)
+	if err != nil {
+		return nil, err
+	}
+	sinks := make(map[string]*structs.EventSink)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sink := raw.(*structs.EventSink)
+		sinks[sink.ID] = sink
+	}
+	return sinks, nil
+}
+
+// reconcile starts the sinks which are not running, and stops the sinks
+// which were deleted or whose configuration changed.
+func (m *eventSinkManager) reconcile(ctx context.Context, sinks map[string]*structs.EventSink) {
+	for id, runner := range m.runners {
+		if sink, ok := sinks[id]; ok && runner.sink.EqualConfig(sink) {
+			continue
+		}
+		runner.stop()
+		delete(m.runners, id)
+	}
+
+	for id, sink := range sinks {
+		if _, ok := m.runners[id]; ok {
+			continue
+		}
+		runCtx, cancel := context.WithCancel(ctx)
+		runner := newEventSinkRunner(sink.Copy(), cancel, m.logger)
+		m.runners[id] = runner
+		go runner.run(runCtx, m.srv)
+	}
+}
+
+// flushProgress records the index of the last events delivered by each
+// running sink in raft, if it moved past the stored progress.
+func (m *eventSinkManager) flushProgress(sinks map[string]*structs.EventSink) {
+	progress := make(map[string]uint64)
+	for id, runner := range m.runners {
+		delivered := runner.delivered.Load()
+		if sink, ok := sinks[id]; ok && delivered > sink.LatestIndex {
+			progress[id] = delivered
+		}
+	}
+	if len(progress) == 0 {
+		return
+	}
+
+	req := structs.EventSinkProgressRequest{
+		Progress:     progress,
+		WriteRequest: structs.WriteRequest{Region: m.srv.Region()},
+	}
+	if _, _, err := m.srv.raftApply(structs.EventSinkProgressRequestType, &req); err != nil {
+		m.logger.Error("failed to update event sink progress", "error", err)
+	}
+}
+
+func (m *eventSinkManager) stopAll() {
+	for id, runner := range m.runners {
+		runner.stop()
+		delete(m.runners, id)
+	}
+}
+
+func newEventSinkRunner(sink *structs.EventSink, cancel context.CancelFunc, logger hclog.Logger) *eventSinkRunner {
+	r := &eventSinkRunner{
+		sink:   sink,
+		cancel: cancel,
+		logger: logger.With("sink_id", sink.ID, "sink_type", sink.Type),
+		doneCh: make(chan struct{}),
+	}
+	r.delivered.Store(max(sink.LatestIndex, sink.CreateIndex))
+	return r
+}
+
+// stop stops the sink and waits for it to exit.
+func (r *eventSinkRunner) stop() {
+	r.cancel()
+	<-r.doneCh
+}
+
+func (r *eventSinkRunner) run(ctx context.Context, srv *Server) {
+	defer close(r.doneCh)
+
+	for {
+		err := r.deliver(ctx, srv)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			r.logger.Error("event sink failed", "error", err)
+		}
+
+		timer, stop := helper.NewSafeTimer(eventSinkRetryInterval)
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-timer.C:
+		}
+		stop()
+	}
+}
+
+// deliver subscribes to the event broker and delivers events to the sink
+// until the context is canceled or an error occurs.
+func (r *eventSinkRunner) deliver(ctx context.Context, srv *Server) error {
+	writer, err := stream.NewSinkWriter(r.sink, srv.config.EventSinkFileDir, r.logger)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	broker, err := srv.State().EventBroker()
+	if err != nil {
+		return err
+	}
+
+	for {
+		// The subscription starts at the closest index held by the broker,
+		// which may include events that were already delivered.
+		sub, err := broker.Subscribe(&stream.SubscribeRequest{
+			Index:     r.delivered.Load() + 1,
+			Namespace: r.sink.Namespace,
+			Topics:    r.sink.Topics,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = r.deliverSubscription(ctx, sub, writer)
+		sub.Unsubscribe()
+		if errors.Is(err, stream.ErrSubscriptionClosed) {
+			continue
+		}
+		return err
+	}
+}
+
+func (r *eventSinkRunner) deliverSubscription(ctx context.Context, sub *stream.Subscription, writer stream.SinkWriter) error {
+	for {
+		events, err := sub.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if events.Index <= r.delivered.Load() {
+			continue
+		}
+		if err := writer.Send(ctx, &events); err != nil {
+			return err
+		}
+		r.delivered.Store(events.Index)
+	}
+}
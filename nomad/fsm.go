@@ -66,6 +66,8 @@ const (
 	ACLBindingRuleSnapshot               SnapshotType = 27
 	NodePoolSnapshot                     SnapshotType = 28
 	JobSubmissionSnapshot                SnapshotType = 29
+	HostVolumeSnapshot                   SnapshotType = 30
+	EventSinkStateSnapshot               SnapshotType = 31
 
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
@@ -237,6 +239,16 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyNodePoolUpsert(msgType, buf[1:], log.Index)
 	case structs.NodePoolDeleteRequestType:
 		return n.applyNodePoolDelete(msgType, buf[1:], log.Index)
+	case structs.HostVolumeRegisterRequestType:
+		return n.applyHostVolumeRegister(buf[1:], log.Index)
+	case structs.HostVolumeDeleteRequestType:
+		return n.applyHostVolumeDelete(buf[1:], log.Index)
+	case structs.EventSinkRegisterRequestType:
+		return n.applyEventSinkRegister(buf[1:], log.Index)
+	case structs.EventSinkDeregisterRequestType:
+		return n.applyEventSinkDeregister(buf[1:], log.Index)
+	case structs.EventSinkProgressRequestType:
+		return n.applyEventSinkProgress(buf[1:], log.Index)
 	case structs.JobRegisterRequestType:
 		return n.applyUpsertJob(msgType, buf[1:], log.Index)
 	case structs.JobDeregisterRequestType:
@@ -1331,6 +1343,94 @@ func (n *nomadFSM) applySchedulerConfigUpdate(buf []byte, index uint64) interfac
 	return n.state.SchedulerSetConfig(index, &req.Config)
 }
 
+func (n *nomadFSM) applyHostVolumeRegister(buf []byte, index uint64) interface{} {
+	var req structs.HostVolumeRegisterRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_host_volume_register"}, time.Now())
+
+	if err := n.state.UpsertHostVolume(index, req.Volume); err != nil {
+		n.logger.Error("UpsertHostVolume failed", "error", err)
+		return err
+	}
+
+	// A newly ready volume may make its node feasible for evals that were
+	// blocked waiting on it, so unblock the node's computed class.
+	if req.Volume.IsReady() {
+		node, err := n.state.NodeByID(nil, req.Volume.NodeID)
+		if err != nil {
+			n.logger.Error("looking up node for host volume failed", "error", err)
+			return err
+		}
+		if node != nil && node.Status == structs.NodeStatusReady {
+			n.blockedEvals.Unblock(node.ComputedClass, index)
+		}
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyHostVolumeDelete(buf []byte, index uint64) interface{} {
+	var req structs.HostVolumeDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_host_volume_delete"}, time.Now())
+
+	if err := n.state.DeleteHostVolume(index, req.RequestNamespace(), req.VolumeID); err != nil {
+		n.logger.Error("DeleteHostVolume failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyEventSinkRegister(buf []byte, index uint64) interface{} {
+	var req structs.EventSinkRegisterRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_sink_register"}, time.Now())
+
+	if err := n.state.UpsertEventSink(index, req.Sink); err != nil {
+		n.logger.Error("UpsertEventSink failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyEventSinkDeregister(buf []byte, index uint64) interface{} {
+	var req structs.EventSinkDeregisterRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_sink_deregister"}, time.Now())
+
+	if err := n.state.DeleteEventSinks(index, req.IDs); err != nil {
+		n.logger.Error("DeleteEventSinks failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyEventSinkProgress(buf []byte, index uint64) interface{} {
+	var req structs.EventSinkProgressRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_event_sink_progress"}, time.Now())
+
+	if err := n.state.UpdateEventSinkProgress(index, req.Progress); err != nil {
+		n.logger.Error("UpdateEventSinkProgress failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyCSIVolumeRegister(buf []byte, index uint64) interface{} {
 	var req structs.CSIVolumeRegisterRequest
 	if err := structs.Decode(buf, &req); err != nil {
@@ -1873,6 +1973,26 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case HostVolumeSnapshot:
+			vol := new(structs.HostVolume)
+			if err := dec.Decode(vol); err != nil {
+				return err
+			}
+			if filter.Include(vol) {
+				if err := restore.HostVolumeRestore(vol); err != nil {
+					return err
+				}
+			}
+
+		case EventSinkStateSnapshot:
+			sink := new(structs.EventSink)
+			if err := dec.Decode(sink); err != nil {
+				return err
+			}
+			if err := restore.EventSinkRestore(sink); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2448,6 +2568,14 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistHostVolumes(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistEventSinks(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -3185,6 +3313,48 @@ func (s *nomadSnapshot) persistJobSubmissions(sink raft.SnapshotSink, encoder *c
 	return nil
 }
 
+func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+
+	// Get all the dynamic host volumes.
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.HostVolumes(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vol := raw.(*structs.HostVolume)
+
+		// write the snapshot
+		sink.Write([]byte{byte(HostVolumeSnapshot)})
+		if err := encoder.Encode(vol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistEventSinks(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+
+	// Get all the event sinks.
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.EventSinks(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		eventSink := raw.(*structs.EventSink)
+
+		// write the snapshot
+		sink.Write([]byte{byte(EventSinkStateSnapshot)})
+		if err := encoder.Encode(eventSink); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
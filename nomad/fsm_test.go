@@ -2445,6 +2445,46 @@ func TestFSM_SnapshotRestore_NodePools(t *testing.T) {
 	must.Eq(t, pool, out)
 }
 
+func TestFSM_SnapshotRestore_HostVolumes(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	vol := mock.HostVolume()
+	vol.NodeID = node.ID
+	must.NoError(t, state.UpsertHostVolume(1001, vol))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.HostVolumeByID(nil, vol.Namespace, vol.ID)
+	must.NoError(t, err)
+	must.Eq(t, vol, out)
+}
+
+func TestFSM_SnapshotRestore_EventSinks(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	sink := mock.EventSink()
+	must.NoError(t, state.UpsertEventSink(1000, sink))
+	must.NoError(t, state.UpdateEventSinkProgress(1001, map[string]uint64{sink.ID: 900}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.EventSinkByID(nil, sink.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, 900, out.LatestIndex)
+	must.True(t, sink.EqualConfig(out))
+}
+
 func TestFSM_SnapshotRestore_Jobs(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
@@ -0,0 +1,460 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/state/paginator"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// HostVolume is the server RPC endpoint for dynamic host volumes
+type HostVolume struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger hclog.Logger
+}
+
+func NewHostVolumeEndpoint(srv *Server, ctx *RPCContext) *HostVolume {
+	return &HostVolume{srv: srv, ctx: ctx, logger: srv.logger.Named("host_volume")}
+}
+
+// Get returns a single dynamic host volume.
+func (v *HostVolume) Get(args *structs.HostVolumeGetRequest, reply *structs.HostVolumeGetResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Get", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "get"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	if !allowVolume(aclObj, ns) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.ID == "" {
+		return fmt.Errorf("missing volume ID")
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			vol, err := store.HostVolumeByID(ws, ns, args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Volume = vol.Copy()
+			return v.srv.replySetIndex(state.TableHostVolumes, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// List returns the dynamic host volumes in a namespace, optionally filtered by
+// node ID or node pool.
+func (v *HostVolume) List(args *structs.HostVolumeListRequest, reply *structs.HostVolumeListResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.List", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "list"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	if !allowVolume(aclObj, ns) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+
+			var iter memdb.ResultIterator
+			var err error
+
+			switch {
+			case args.NodeID != "":
+				iter, err = store.HostVolumesByNodeID(ws, args.NodeID)
+			case ns != structs.AllNamespacesSentinel:
+				iter, err = store.HostVolumesByNamespace(ws, ns)
+			default:
+				iter, err = store.HostVolumes(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			tokenizer := paginator.NewStructsTokenizer(
+				iter,
+				paginator.StructsTokenizerOptions{
+					WithNamespace: true,
+					WithID:        true,
+				},
+			)
+			volFilter := paginator.GenericFilter{
+				Allow: func(raw interface{}) (bool, error) {
+					vol := raw.(*structs.HostVolume)
+
+					// Remove by namespace, since HostVolumesByNodeID
+					// hasn't used the namespace yet, and by ACL access for
+					// wildcard namespace requests
+					if ns != structs.AllNamespacesSentinel && vol.Namespace != ns {
+						return false, nil
+					}
+					if !allowVolume(aclObj, vol.Namespace) {
+						return false, nil
+					}
+					if args.NodePool != "" && vol.NodePool != args.NodePool {
+						return false, nil
+					}
+					return true, nil
+				},
+			}
+			filters := []paginator.Filter{volFilter}
+
+			vols := []*structs.HostVolumeStub{}
+			paginator, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
+				func(raw interface{}) error {
+					vol := raw.(*structs.HostVolume)
+					vols = append(vols, vol.Stub())
+					return nil
+				})
+			if err != nil {
+				return structs.NewErrRPCCodedf(
+					http.StatusBadRequest, "failed to create result paginator: %v", err)
+			}
+
+			nextToken, err := paginator.Page()
+			if err != nil {
+				return structs.NewErrRPCCodedf(
+					http.StatusBadRequest, "failed to read result page: %v", err)
+			}
+
+			reply.QueryMeta.NextToken = nextToken
+			reply.Volumes = vols
+			return v.srv.replySetIndex(state.TableHostVolumes, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// Create places a dynamic host volume on a node, has the client create it
+// with the requested plugin, and then writes the volume to raft.
+func (v *HostVolume) Create(args *structs.HostVolumeCreateRequest, reply *structs.HostVolumeCreateResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Create", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "create"}, time.Now())
+
+	if args.Volume == nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "missing volume definition")
+	}
+
+	vol := args.Volume.Copy()
+	if vol.Namespace == "" {
+		vol.Namespace = args.RequestNamespace()
+	}
+	vol.Canonicalize()
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeCreate)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !allowVolume(aclObj, vol.Namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	if err := vol.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "volume validation failed: %v", err)
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	if vol.ID != "" {
+		// Creating a volume that already exists is allowed so that users can
+		// retry, but it can't be moved or renamed.
+		existing, err := snap.HostVolumeByID(nil, vol.Namespace, vol.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return structs.NewErrRPCCodedf(http.StatusNotFound,
+				"host volume %q does not exist", vol.ID)
+		}
+		if vol.NodeID != "" && vol.NodeID != existing.NodeID {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest,
+				"host volume %q cannot be moved to another node", vol.ID)
+		}
+		if vol.Name != existing.Name {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest,
+				"host volume %q cannot be renamed", vol.ID)
+		}
+		vol.NodeID = existing.NodeID
+		vol.CreateTime = existing.CreateTime
+	} else {
+		vol.ID = uuid.Generate()
+		vol.CreateTime = time.Now().UnixNano()
+	}
+
+	node, err := v.placeHostVolume(snap, vol)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"could not place volume %q: %v", vol.Name, err)
+	}
+	vol.NodeID = node.ID
+	vol.NodePool = node.NodePool
+
+	// NOTE: creating the volume on the client can't be made atomic with
+	// registration. The client persists the volume before responding, so a
+	// failed raft write can be retried with the same volume ID.
+	cReq := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        vol.ID,
+		Name:                      vol.Name,
+		PluginID:                  vol.PluginID,
+		Namespace:                 vol.Namespace,
+		NodeID:                    vol.NodeID,
+		RequestedCapacityMinBytes: vol.RequestedCapacityMinBytes,
+		RequestedCapacityMaxBytes: vol.RequestedCapacityMaxBytes,
+		Parameters:                vol.Parameters,
+	}
+	cResp := &cstructs.ClientHostVolumeCreateResponse{}
+	if err := v.srv.RPC("ClientHostVolume.Create", cReq, cResp); err != nil {
+		return err
+	}
+
+	vol.HostPath = cResp.HostPath
+	vol.CapacityBytes = cResp.CapacityBytes
+	vol.State = structs.HostVolumeStateReady
+	vol.ModifyTime = time.Now().UnixNano()
+
+	regArgs := &structs.HostVolumeRegisterRequest{
+		Volume:       vol,
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := v.srv.raftApply(structs.HostVolumeRegisterRequestType, regArgs)
+	if err != nil {
+		v.logger.Error("raft apply failed", "error", err, "method", "register")
+		return err
+	}
+
+	// Return the volume as written by the state store
+	vol, err = v.srv.State().HostVolumeByID(nil, vol.Namespace, vol.ID)
+	if err != nil {
+		return err
+	}
+
+	reply.Volume = vol.Copy()
+	reply.Index = index
+	return nil
+}
+
+// placeHostVolume returns the node the volume should be created on. If the
+// user requested a node it's checked for feasibility, otherwise a node is
+// picked at random from the volume's node pool among the nodes that meet the
+// volume's constraints.
+func (v *HostVolume) placeHostVolume(snap *state.StateSnapshot, vol *structs.HostVolume) (*structs.Node, error) {
+	if vol.NodeID != "" {
+		node, err := snap.NodeByID(nil, vol.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("no such node %s", vol.NodeID)
+		}
+		if err := hostVolumeNameAvailable(snap, node, vol); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+
+	var iter memdb.ResultIterator
+	var err error
+	if vol.NodePool == structs.NodePoolAll {
+		iter, err = snap.Nodes(nil)
+	} else {
+		iter, err = snap.NodesByNodePool(nil, vol.NodePool)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checker *scheduler.ConstraintChecker
+	if len(vol.Constraints) > 0 {
+		ctx := scheduler.NewEvalContext(nil, snap, nil, v.logger)
+		checker = scheduler.NewConstraintChecker(ctx, vol.Constraints)
+	}
+
+	var candidates []*structs.Node
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+		if hostVolumeNameAvailable(snap, node, vol) != nil {
+			continue
+		}
+		if checker != nil && !checker.Feasible(node) {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+
+	if len(candidates) == 0 {
+		return nil, errors.New("no node meets constraints")
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
+// hostVolumeNameAvailable returns an error if another volume with the same
+// name is already configured or created on the node.
+func hostVolumeNameAvailable(snap *state.StateSnapshot, node *structs.Node, vol *structs.HostVolume) error {
+	if existing, ok := node.HostVolumes[vol.Name]; ok && existing.ID != vol.ID {
+		return fmt.Errorf("node %s already has a host volume named %q", node.ID, vol.Name)
+	}
+
+	iter, err := snap.HostVolumesByNodeID(nil, node.ID)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		existing := raw.(*structs.HostVolume)
+		if existing.Name == vol.Name && existing.ID != vol.ID {
+			return fmt.Errorf("node %s already has a host volume named %q", node.ID, vol.Name)
+		}
+	}
+	return nil
+}
+
+// Delete has the client delete a dynamic host volume with the plugin that
+// created it, and then removes the volume from raft. Volumes that are in use
+// by running allocations can't be deleted.
+func (v *HostVolume) Delete(args *structs.HostVolumeDeleteRequest, reply *structs.HostVolumeDeleteResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Delete", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "delete"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeDelete)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	if !allowVolume(aclObj, ns) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.VolumeID == "" {
+		return fmt.Errorf("missing volume ID")
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	vol, err := snap.HostVolumeByID(nil, ns, args.VolumeID)
+	if err != nil {
+		return err
+	}
+	if vol == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound,
+			"host volume %q does not exist", args.VolumeID)
+	}
+
+	allocs, err := snap.AllocsByNode(nil, vol.NodeID)
+	if err != nil {
+		return err
+	}
+	for _, alloc := range allocs {
+		if alloc.ClientTerminalStatus() || alloc.Namespace != vol.Namespace {
+			continue
+		}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil {
+			continue
+		}
+		for _, req := range tg.Volumes {
+			if req.Type == structs.VolumeTypeHost && req.Source == vol.Name {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"volume %q is in use by allocation %s", vol.ID, alloc.ID)
+			}
+		}
+	}
+
+	// NOTE: deleting the volume on the client can't be made atomic with
+	// deregistration, so we delete it on the client first so that a failed
+	// delete can be retried.
+	cReq := &cstructs.ClientHostVolumeDeleteRequest{
+		ID:         vol.ID,
+		PluginID:   vol.PluginID,
+		NodeID:     vol.NodeID,
+		HostPath:   vol.HostPath,
+		Parameters: vol.Parameters,
+	}
+	if err := v.srv.RPC("ClientHostVolume.Delete", cReq,
+		&cstructs.ClientHostVolumeDeleteResponse{}); err != nil {
+		return err
+	}
+
+	_, index, err := v.srv.raftApply(structs.HostVolumeDeleteRequestType, args)
+	if err != nil {
+		v.logger.Error("raft apply failed", "error", err, "method", "delete")
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestHostVolumeEndpoint_CreateDeleteRoundTrip(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, nil)
+	t.Cleanup(cleanupSrv)
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+
+	volumesDir := t.TempDir()
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{srv.config.RPCAddr.String()}
+		c.HostVolumesDir = volumesDir
+	})
+	t.Cleanup(func() { cleanupC() })
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(srv.connectedNodes()) == 1 }),
+		wait.Timeout(10*time.Second),
+	))
+
+	// a volume with constraints no node can meet can't be placed
+	vol := mock.HostVolume()
+	vol.ID = ""
+	vol.NodeID = ""
+	vol.PluginID = ""
+	vol.Parameters = nil
+	createReq := &structs.HostVolumeCreateRequest{
+		Volume:       vol,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var createResp structs.HostVolumeCreateResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", createReq, &createResp)
+	must.ErrorContains(t, err, "no node meets constraints")
+
+	// the node is picked from the node pool when the constraints are met
+	vol.Constraints = []*structs.Constraint{{
+		LTarget: "${node.unique.id}",
+		RTarget: c.NodeID(),
+		Operand: "=",
+	}}
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", createReq, &createResp)
+	must.NoError(t, err)
+	must.NotNil(t, createResp.Volume)
+
+	created := createResp.Volume
+	must.UUIDv4(t, created.ID)
+	must.Eq(t, c.NodeID(), created.NodeID)
+	must.Eq(t, structs.HostVolumeStateReady, created.State)
+	must.Eq(t, structs.HostVolumePluginMkdir, created.PluginID)
+	must.DirExists(t, created.HostPath)
+
+	// the client adds the volume to its node
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			vol, ok := c.Node().HostVolumes[created.Name]
+			return ok && vol.ID == created.ID
+		}),
+		wait.Timeout(10*time.Second),
+	))
+
+	// another volume with the same name can't be placed on the node
+	dupe := created.Copy()
+	dupe.ID = ""
+	dupe.NodeID = c.NodeID()
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create",
+		&structs.HostVolumeCreateRequest{
+			Volume:       dupe,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}, &createResp)
+	must.ErrorContains(t, err, "already has a host volume named")
+
+	getReq := &structs.HostVolumeGetRequest{
+		ID: created.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: created.Namespace,
+		},
+	}
+	var getResp structs.HostVolumeGetResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp))
+	must.Eq(t, created, getResp.Volume)
+
+	listReq := &structs.HostVolumeListRequest{
+		NodeID: c.NodeID(),
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.AllNamespacesSentinel,
+		},
+	}
+	var listResp structs.HostVolumeListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Volumes)
+
+	listReq.NodePool = "prod"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", listReq, &listResp))
+	must.Len(t, 0, listResp.Volumes)
+
+	// a volume that's in use can't be deleted. This is checked before the
+	// client is contacted, so we use a volume on a node with no client.
+	node := mock.Node()
+	index, _ := srv.State().LatestIndex()
+	must.NoError(t, srv.State().UpsertNode(structs.MsgTypeTestSetup, index+1, node))
+	inUse := mock.HostVolume()
+	inUse.NodeID = node.ID
+	must.NoError(t, srv.State().UpsertHostVolume(index+2, inUse))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+		"example": {Type: structs.VolumeTypeHost, Source: inUse.Name},
+	}
+	must.NoError(t, srv.State().UpsertAllocs(structs.MsgTypeTestSetup, index+3,
+		[]*structs.Allocation{alloc}))
+
+	delReq := &structs.HostVolumeDeleteRequest{
+		VolumeID: inUse.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: inUse.Namespace,
+		},
+	}
+	var delResp structs.HostVolumeDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp)
+	must.ErrorContains(t, err, "is in use by allocation")
+
+	delReq.VolumeID = created.ID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp))
+	must.DirNotExists(t, created.HostPath)
+
+	getResp = structs.HostVolumeGetResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp))
+	must.Nil(t, getResp.Volume)
+}
@@ -88,6 +88,7 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 			&jobValidate{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
 			jobNumaHook{},
+			jobDependenciesHook{srv: s},
 		},
 	}
 }
@@ -151,6 +152,20 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return structs.ErrPermissionDenied
 	}
 
+	// Validate the job may depend on jobs in other namespaces
+	for _, d := range args.Job.DependsOn {
+		ns := d.NamespacedID(args.Job.Namespace).Namespace
+		if ns != args.RequestNamespace() && !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	// Validate the job may select the allocations of jobs in other namespaces
+	// for colocation
+	if !allocSelectorsAreAllowed(aclObj, args.Job) {
+		return structs.ErrPermissionDenied
+	}
+
 	// Validate Volume Permissions
 	for _, tg := range args.Job.TaskGroups {
 		for _, vol := range tg.Volumes {
@@ -357,17 +372,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 			evalPriority = args.EvalPriority
 		}
 
-		eval = &structs.Evaluation{
-			ID:          uuid.Generate(),
-			Namespace:   args.RequestNamespace(),
-			Priority:    evalPriority,
-			Type:        args.Job.Type,
-			TriggeredBy: structs.EvalTriggerJobRegister,
-			JobID:       args.Job.ID,
-			Status:      structs.EvalStatusPending,
-			CreateTime:  now,
-			ModifyTime:  now,
-		}
+		eval = newJobEval(args.Job, structs.EvalTriggerJobRegister, evalPriority, 0, now)
 		reply.EvalID = eval.ID
 	}
 
@@ -791,18 +796,7 @@ func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegis
 
 	// Create a new evaluation
 	now := time.Now().UnixNano()
-	eval := &structs.Evaluation{
-		ID:             uuid.Generate(),
-		Namespace:      args.RequestNamespace(),
-		Priority:       job.Priority,
-		Type:           job.Type,
-		TriggeredBy:    structs.EvalTriggerJobRegister,
-		JobID:          job.ID,
-		JobModifyIndex: job.ModifyIndex,
-		Status:         structs.EvalStatusPending,
-		CreateTime:     now,
-		ModifyTime:     now,
-	}
+	eval := newJobEval(job, structs.EvalTriggerJobRegister, job.Priority, job.ModifyIndex, now)
 
 	// Create a AllocUpdateDesiredTransitionRequest request with the eval and any forced rescheduled allocs
 	updateTransitionReq := &structs.AllocUpdateDesiredTransitionRequest{
@@ -1157,18 +1151,8 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 
 		// Create an eval for non-dispatch jobs
 		if !(job.IsPeriodic() || job.IsParameterized()) {
-			eval := &structs.Evaluation{
-				ID:             uuid.Generate(),
-				Namespace:      namespace,
-				Priority:       job.Priority, // Safe as nil check performed above.
-				Type:           job.Type,
-				TriggeredBy:    structs.EvalTriggerScaling,
-				JobID:          args.JobID,
-				JobModifyIndex: reply.JobModifyIndex,
-				Status:         structs.EvalStatusPending,
-				CreateTime:     now,
-				ModifyTime:     now,
-			}
+			// Safe as nil check performed above.
+			eval := newJobEval(job, structs.EvalTriggerScaling, job.Priority, reply.JobModifyIndex, now)
 
 			_, evalIndex, err := j.srv.raftApply(
 				structs.EvalUpdateRequestType,
@@ -1408,6 +1392,17 @@ func registrationsAreAllowed(aclObj *acl.ACL, state *state.StateStore) (bool, er
 	return false, nil
 }
 
+// allocSelectorsAreAllowed checks that the ACL object may read the jobs of
+// every namespace whose allocations the job selects for colocation.
+func allocSelectorsAreAllowed(aclObj *acl.ACL, job *structs.Job) bool {
+	for _, ns := range job.AllocSelectorNamespaces() {
+		if !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+			return false
+		}
+	}
+	return true
+}
+
 // List is used to list the jobs registered in the system
 func (j *Job) List(args *structs.JobListRequest, reply *structs.JobListResponse) error {
 	authErr := j.srv.Authenticate(j.ctx, args)
@@ -1817,6 +1812,11 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
 			return structs.ErrPermissionDenied
 		}
+		// Check the job may select the allocations of jobs in other
+		// namespaces, as the plan reveals where they are placed
+		if !allocSelectorsAreAllowed(aclObj, args.Job) {
+			return structs.ErrPermissionDenied
+		}
 		// Check if override is set and we do not have permissions
 		if args.PolicyOverride {
 			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
@@ -2108,6 +2108,14 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	dispatchJob.StatusDescription = ""
 	dispatchJob.DispatchIdempotencyToken = args.IdempotencyToken
 
+	// Run one allocation of each task group per index of a job array
+	if args.Array != nil {
+		dispatchJob.Array = args.Array.Copy()
+		for _, tg := range dispatchJob.TaskGroups {
+			tg.Count = dispatchJob.Array.Size()
+		}
+	}
+
 	// Merge in the meta data
 	for k, v := range args.Meta {
 		if dispatchJob.Meta == nil {
@@ -2139,18 +2147,7 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	if !dispatchJob.IsPeriodic() {
 		// Create a new evaluation
 		now := time.Now().UnixNano()
-		eval := &structs.Evaluation{
-			ID:             uuid.Generate(),
-			Namespace:      args.RequestNamespace(),
-			Priority:       dispatchJob.Priority,
-			Type:           dispatchJob.Type,
-			TriggeredBy:    structs.EvalTriggerJobRegister,
-			JobID:          dispatchJob.ID,
-			JobModifyIndex: jobCreateIndex,
-			Status:         structs.EvalStatusPending,
-			CreateTime:     now,
-			ModifyTime:     now,
-		}
+		eval := newJobEval(dispatchJob, structs.EvalTriggerJobRegister, dispatchJob.Priority, jobCreateIndex, now)
 		update := &structs.EvalUpdateRequest{
 			Evals:        []*structs.Evaluation{eval},
 			WriteRequest: structs.WriteRequest{Region: args.Region},
@@ -2188,6 +2185,16 @@ func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job)
 		return fmt.Errorf("Payload exceeds maximum size; %d > %d", l, DispatchPayloadSizeLimit)
 	}
 
+	// Check the job array is valid for the job
+	if req.Array != nil {
+		if job.Type != structs.JobTypeBatch {
+			return fmt.Errorf("Job arrays are only supported by batch jobs")
+		}
+		if err := req.Array.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Check if the metadata is a set
 	keys := make(map[string]struct{}, len(req.Meta))
 	for k := range req.Meta {
@@ -2425,3 +2432,23 @@ func (j *Job) GetServiceRegistrations(
 		},
 	})
 }
+
+// newJobEval returns a pending evaluation of the job, which is blocked on the
+// dependencies of the job if it has any. Every evaluation triggered by a
+// change to a job is created with it, so that none bypass the dependencies.
+func newJobEval(job *structs.Job, triggeredBy string, priority int, jobModifyIndex uint64, now int64) *structs.Evaluation {
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       priority,
+		Type:           job.Type,
+		TriggeredBy:    triggeredBy,
+		JobID:          job.ID,
+		JobModifyIndex: jobModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	eval.BlockOnDependencies(job)
+	return eval
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobDependenciesHook is an admission hook that ensures the dependencies of a
+// job don't form a cycle, which would keep its evaluations blocked forever.
+type jobDependenciesHook struct {
+	srv *Server
+}
+
+func (jobDependenciesHook) Name() string {
+	return "dependencies"
+}
+
+func (h jobDependenciesHook) Validate(job *structs.Job) ([]error, error) {
+	if len(job.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	snap, err := h.srv.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	self := job.NamespacedID()
+	var warnings []error
+	for _, d := range job.DependsOn {
+		id := d.NamespacedID(job.Namespace)
+		dependency, err := snap.JobByID(nil, id.Namespace, id.ID)
+		if err != nil {
+			return nil, err
+		}
+		if dependency == nil {
+			warnings = append(warnings, fmt.Errorf(
+				"Dependency job %q in namespace %q does not exist yet", id.ID, id.Namespace))
+			continue
+		}
+
+		// Walk the dependencies of the job depended on looking for the job
+		// being registered.
+		visited := map[structs.NamespacedID]struct{}{id: {}}
+		queue := []*structs.Job{dependency}
+		for len(queue) > 0 {
+			next := queue[0]
+			queue = queue[1:]
+
+			for _, nd := range next.DependsOn {
+				nid := nd.NamespacedID(next.Namespace)
+				if nid == self {
+					return nil, fmt.Errorf(
+						"dependency on job %q in namespace %q forms a cycle", id.ID, id.Namespace)
+				}
+				if _, ok := visited[nid]; ok {
+					continue
+				}
+				visited[nid] = struct{}{}
+
+				nj, err := snap.JobByID(nil, nid.Namespace, nid.ID)
+				if err != nil {
+					return nil, err
+				}
+				if nj != nil {
+					queue = append(queue, nj)
+				}
+			}
+		}
+	}
+
+	return warnings, nil
+}
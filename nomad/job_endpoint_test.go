@@ -387,6 +387,86 @@ func TestJobEndpoint_Register_EvalPriority(t *testing.T) {
 	requireAssert.Equal(99, out[0].Priority)
 }
 
+func TestJobEndpoint_Register_DependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	register := func(job *structs.Job) (*structs.JobRegisterResponse, error) {
+		var resp structs.JobRegisterResponse
+		err := msgpackrpc.CallWithCodec(codec, "Job.Register", &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}, &resp)
+		return &resp, err
+	}
+
+	extract := mock.BatchJob()
+	extractResp, err := register(extract)
+	must.NoError(t, err)
+
+	// The evaluation of a job with dependencies is blocked on them, and isn't
+	// tracked by the blocked evals tracker
+	report := mock.BatchJob()
+	report.DependsOn = []*structs.JobDependency{{JobID: extract.ID}}
+	reportResp, err := register(report)
+	must.NoError(t, err)
+
+	eval, err := state.EvalByID(nil, reportResp.EvalID)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalStatusBlocked, eval.Status)
+	must.True(t, eval.BlockedOnDependencies)
+	must.Eq(t, structs.EvalStatusDescBlockedOnDependencies, eval.StatusDescription)
+	must.Eq(t, 0, s1.blockedEvals.Stats().TotalBlocked)
+
+	// Dependencies may not form a cycle
+	cyclic := extract.Copy()
+	cyclic.DependsOn = []*structs.JobDependency{{JobID: report.ID}}
+	_, err = register(cyclic)
+	must.ErrorContains(t, err, "forms a cycle")
+
+	// Complete the dependency so that the evaluation is unblocked
+	extractEval, err := state.EvalByID(nil, extractResp.EvalID)
+	must.NoError(t, err)
+	extractEval = extractEval.Copy()
+	extractEval.Status = structs.EvalStatusComplete
+
+	extractJob, err := state.JobByID(nil, extract.Namespace, extract.ID)
+	must.NoError(t, err)
+	alloc := mock.Alloc()
+	alloc.Job = extractJob
+	alloc.JobID = extract.ID
+	alloc.TaskGroup = extract.TaskGroups[0].Name
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+
+	index, err := state.LatestIndex()
+	must.NoError(t, err)
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, index+1,
+		[]*structs.Evaluation{extractEval}))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, index+2,
+		[]*structs.Allocation{alloc}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		eval, err := state.EvalByID(nil, reportResp.EvalID)
+		if err != nil {
+			return false, err
+		}
+		if eval.Status != structs.EvalStatusPending || eval.BlockedOnDependencies {
+			return false, fmt.Errorf("expected unblocked eval, got status %q", eval.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
 func TestJobEndpoint_Register_Connect(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -2395,6 +2475,82 @@ func TestJobEndpoint_Register_ACL_Namespace(t *testing.T) {
 	assert.NotNil(out, "expected job")
 }
 
+func TestJobEndpoint_Register_ACL_AllocSelector(t *testing.T) {
+	ci.Parallel(t)
+	s1, _, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	prod, secret := mock.Namespace(), mock.Namespace()
+	prod.Name, secret.Name = "prod", "secret"
+	must.NoError(t, state.UpsertNamespaces(1000, []*structs.Namespace{prod, secret}))
+
+	token := mock.CreatePolicyAndToken(t, state, 1001, "alloc-selector",
+		mock.NamespacePolicy(structs.DefaultNamespace, "write", nil)+
+			mock.NamespacePolicy("prod", "read", nil))
+
+	register := func(job *structs.Job) error {
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+				AuthToken: token.SecretID,
+			},
+		}
+		return msgpackrpc.CallWithCodec(codec, "Job.Register", req, &structs.JobRegisterResponse{})
+	}
+	plan := func(job *structs.Job) error {
+		req := &structs.JobPlanRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+				AuthToken: token.SecretID,
+			},
+		}
+		return msgpackrpc.CallWithCodec(codec, "Job.Plan", req, &structs.JobPlanResponse{})
+	}
+
+	// Selecting the allocations of a readable namespace is allowed
+	job := mock.Job()
+	job.TaskGroups[0].Constraints = append(job.TaskGroups[0].Constraints, &structs.Constraint{
+		Operand: structs.ConstraintColocatedWith,
+		RTarget: "namespace=prod,job=api",
+	})
+	must.NoError(t, register(job))
+
+	// Selecting the allocations of any other namespace is denied, whether by
+	// a constraint or an affinity
+	job = mock.Job()
+	job.TaskGroups[0].Constraints = append(job.TaskGroups[0].Constraints, &structs.Constraint{
+		Operand: structs.ConstraintNotColocatedWith,
+		RTarget: "namespace=secret,job=api",
+	})
+	must.EqError(t, register(job), structs.ErrPermissionDenied.Error())
+
+	job = mock.Job()
+	job.TaskGroups[0].Tasks[0].Affinities = append(job.TaskGroups[0].Tasks[0].Affinities, &structs.Affinity{
+		Operand: structs.ConstraintColocatedWith,
+		RTarget: "namespace=secret,job=api",
+		Weight:  50,
+	})
+	must.EqError(t, register(job), structs.ErrPermissionDenied.Error())
+
+	// Planning the job is denied as well, as the plan reveals where the
+	// selected allocations are placed
+	job = mock.Job()
+	job.TaskGroups[0].Constraints = append(job.TaskGroups[0].Constraints, &structs.Constraint{
+		Operand: structs.ConstraintColocatedWith,
+		RTarget: "namespace=secret,job=api",
+	})
+	must.EqError(t, plan(job), structs.ErrPermissionDenied.Error())
+}
+
 func TestJobRegister_ACL_RejectedBySchedulerConfig(t *testing.T) {
 	ci.Parallel(t)
 	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
@@ -7448,6 +7604,64 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Dispatch_Array(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	batch := mock.BatchJob()
+	batch.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	sysbatch := mock.BatchJob()
+	sysbatch.Type = structs.JobTypeSysBatch
+	sysbatch.ParameterizedJob = &structs.ParameterizedJobConfig{}
+
+	for _, job := range []*structs.Job{batch, sysbatch} {
+		regReq := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+	}
+
+	dispatch := func(job *structs.Job, array *structs.JobArray) (*structs.JobDispatchResponse, error) {
+		req := &structs.JobDispatchRequest{
+			JobID: job.ID,
+			Array: array,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobDispatchResponse
+		err := msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp)
+		return &resp, err
+	}
+
+	// Each task group runs one allocation per array index
+	resp, err := dispatch(batch, &structs.JobArray{Start: 10, End: 14, MaxParallel: 2})
+	must.NoError(t, err)
+	out, err := s1.fsm.State().JobByID(nil, batch.Namespace, resp.DispatchedJobID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, &structs.JobArray{Start: 10, End: 14, MaxParallel: 2}, out.Array)
+	must.Eq(t, 5, out.TaskGroups[0].Count)
+
+	_, err = dispatch(sysbatch, &structs.JobArray{Start: 0, End: 4})
+	must.ErrorContains(t, err, "Job arrays are only supported by batch jobs")
+
+	_, err = dispatch(batch, &structs.JobArray{Start: 4, End: 0})
+	must.ErrorContains(t, err, "Array end 0 is lower than its start 4")
+}
+
 // TestJobEndpoint_Dispatch_JobChildrenSummary asserts that the job summary is updated
 // appropriately as its dispatched/children jobs status are updated.
 func TestJobEndpoint_Dispatch_JobChildrenSummary(t *testing.T) {
@@ -7695,6 +7909,45 @@ func TestJobEndpoint_Scale(t *testing.T) {
 	require.Equal(int64(originalCount), events[groupName][0].PreviousCount)
 }
 
+func TestJobEndpoint_Scale_DependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	dependency := mock.BatchJob()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, dependency))
+
+	job := mock.Job()
+	job.DependsOn = []*structs.JobDependency{{JobID: dependency.ID}}
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, job))
+
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count: pointer.Of(int64(job.TaskGroups[0].Count + 1)),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+	must.NotEq(t, "", resp.EvalID)
+
+	// The evaluation of the scaled job is blocked on its dependencies
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalTriggerScaling, eval.TriggeredBy)
+	must.Eq(t, structs.EvalStatusBlocked, eval.Status)
+	must.True(t, eval.BlockedOnDependencies)
+}
+
 func TestJobEndpoint_Scale_DeploymentBlocking(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -391,6 +391,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Enable the deployment watcher, since we are now the leader
 	s.deploymentWatcher.SetEnabled(true, s.State())
 
+	// Enable the dependency watcher, since we are now the leader
+	s.dependencyWatcher.SetEnabled(true, s.State())
+
 	// Enable the NodeDrainer
 	s.nodeDrainer.SetEnabled(true, s.State())
 
@@ -448,6 +451,14 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Deliver events to the registered event sinks
+	go s.runEventSinks(stopCh)
+
+	// Keep the namespace weights of the eval broker up to date
+	if s.config.EvalBrokerFairShare {
+		go s.watchNamespaceWeights(stopCh)
+	}
+
 	// Populate the variable lock TTL timers, so we can start tracking renewals
 	// and expirations.
 	if err := s.restoreLockTTLTimers(); err != nil {
@@ -831,6 +842,65 @@ func (s *Server) restoreEvals() error {
 	return nil
 }
 
+// watchNamespaceWeights keeps the namespace weights used by the eval broker
+// for fair share queueing in sync with the namespaces in the state store. The
+// broker reads the weights while holding its lock, so they are copied into it
+// rather than looked up in the state store on every dequeue.
+func (s *Server) watchNamespaceWeights(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var index uint64
+	for {
+		raw, newIndex, err := s.State().BlockingQuery(namespaceWeights, index, ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Error("failed to lookup namespace weights", "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		s.evalBroker.SetNamespaceWeights(raw.(map[string]int))
+		index = max(newIndex, 1)
+	}
+}
+
+// namespaceWeights returns the weights of the namespaces which don't have the
+// default weight.
+func namespaceWeights(ws memdb.WatchSet, store *state.StateStore) (interface{}, uint64, error) {
+	iter, err := store.Namespaces(ws)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	weights := make(map[string]int)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+		if ns.Weight > 0 {
+			weights[ns.Name] = ns.Weight
+		}
+	}
+
+	index, err := store.Index(state.TableNamespaces)
+	if err != nil {
+		return nil, 0, err
+	}
+	return weights, index, nil
+}
+
 // revokeVaultAccessorsOnRestore is used to restore Vault accessors that should be
 // revoked.
 func (s *Server) revokeVaultAccessorsOnRestore() error {
@@ -989,6 +1059,13 @@ func (s *Server) restorePeriodicDispatcher() error {
 			continue
 		}
 
+		// We skip the missed launches if the catch-up policy of the job is to
+		// ignore them.
+		if job.Periodic.Catchup == structs.PeriodicCatchupNone {
+			logger.Debug("skipping missed launches of periodic job", "job", job.NamespacedID())
+			continue
+		}
+
 		// We skip if the job doesn't allow overlap and there are already
 		// instances running
 		allowed, err := s.cronJobOverlapAllowed(job)
@@ -999,6 +1076,20 @@ func (s *Server) restorePeriodicDispatcher() error {
 			continue
 		}
 
+		// Run every missed launch within the catch-up window. Jobs which
+		// prohibit overlap only run a single launch, as the missed launches
+		// would otherwise run in parallel.
+		if job.Periodic.Catchup == structs.PeriodicCatchupAll && !job.Periodic.ProhibitOverlap {
+			evals, err := s.periodicDispatcher.CatchUp(job.Namespace, job.ID, launch.Launch)
+			if err != nil {
+				logger.Error("catch up of periodic job failed", "job", job.NamespacedID(), "error", err)
+				return fmt.Errorf("catch up of periodic job %q failed: %v", job.NamespacedID(), err)
+			}
+
+			logger.Debug("periodic job caught up during leadership establishment", "job", job.NamespacedID(), "launches", len(evals))
+			continue
+		}
+
 		if _, err := s.periodicDispatcher.ForceEval(job.Namespace, job.ID); err != nil {
 			logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
 			return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
@@ -1048,6 +1139,8 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer rootKeyGC.Stop()
 	variablesRekey := time.NewTicker(s.config.VariablesRekeyInterval)
 	defer variablesRekey.Stop()
+	rebalance := time.NewTicker(s.config.RebalanceInterval)
+	defer rebalance.Stop()
 
 	// Set up the expired ACL local token garbage collection timer.
 	localTokenExpiredGC, localTokenExpiredGCStop := helper.NewSafeTimer(s.config.ACLTokenExpirationGCInterval)
@@ -1101,6 +1194,14 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := s.getLatestIndex(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobVariablesRekey, index))
 			}
+		case <-rebalance.C:
+			if s.config.RebalanceMaxMigrations <= 0 {
+				continue
+			}
+
+			if index, ok := s.getLatestIndex(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobRebalance, index))
+			}
 		case <-stopCh:
 			return
 		}
@@ -1516,6 +1617,9 @@ func (s *Server) revokeLeadership() error {
 	// Disable the deployment watcher as it is only useful as a leader.
 	s.deploymentWatcher.SetEnabled(false, nil)
 
+	// Disable the dependency watcher as it is only useful as a leader.
+	s.dependencyWatcher.SetEnabled(false, nil)
+
 	// Disable the node drainer
 	s.nodeDrainer.SetEnabled(false, nil)
 
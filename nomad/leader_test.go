@@ -441,6 +441,55 @@ func TestLeader_PeriodicDispatcher_Restore_NoEvals(t *testing.T) {
 	}
 }
 
+func TestLeader_PeriodicDispatcher_Restore_CatchupNone(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Inject a periodic job that will be triggered soon and skips the
+	// launches missed without a leader.
+	launch := time.Now().Add(1 * time.Second)
+	job := testPeriodicJob(launch)
+	job.Periodic.Catchup = structs.PeriodicCatchupNone
+	req := structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	_, _, err := s1.raftApply(structs.JobRegisterRequestType, req)
+	must.NoError(t, err)
+
+	// Flush the periodic dispatcher, ensuring that no evals will be created.
+	s1.periodicDispatcher.SetEnabled(false)
+
+	// Sleep till after the job should have been launched.
+	time.Sleep(3 * time.Second)
+
+	// Restore the periodic dispatcher.
+	s1.periodicDispatcher.SetEnabled(true)
+	must.NoError(t, s1.restorePeriodicDispatcher())
+
+	// Ensure the job is tracked.
+	tuple := structs.NamespacedID{
+		ID:        job.ID,
+		Namespace: job.Namespace,
+	}
+	_, tracked := s1.periodicDispatcher.tracked[tuple]
+	must.True(t, tracked)
+
+	// Check that the missed launch was skipped.
+	ws := memdb.NewWatchSet()
+	last, err := s1.fsm.State().PeriodicLaunchByID(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, last)
+	must.True(t, last.Launch.Before(launch))
+}
+
 type mockJobEvalDispatcher struct {
 	forceEvalCalled, children bool
 	evalToReturn              *structs.Evaluation
@@ -676,6 +725,49 @@ func TestLeader_PeriodicDispatch(t *testing.T) {
 	})
 }
 
+func TestLeader_WatchNamespaceWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.EvalBrokerFairShare = true
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	weight := func(namespace string) int {
+		s1.evalBroker.l.Lock()
+		defer s1.evalBroker.l.Unlock()
+		return s1.evalBroker.namespaceWeight(namespace)
+	}
+	must.Eq(t, 1, weight("weighted"))
+
+	// The weight of an upserted namespace is copied into the broker
+	ns := mock.Namespace()
+	ns.Name = "weighted"
+	ns.Weight = 3
+	must.NoError(t, s1.fsm.State().UpsertNamespaces(1000, []*structs.Namespace{ns}))
+	testutil.WaitForResult(func() (bool, error) {
+		if w := weight("weighted"); w != 3 {
+			return false, fmt.Errorf("expected weight 3, got %d", w)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// Deleting the namespace restores the default weight
+	must.NoError(t, s1.fsm.State().DeleteNamespaces(1001, []string{"weighted"}))
+	testutil.WaitForResult(func() (bool, error) {
+		if w := weight("weighted"); w != 1 {
+			return false, fmt.Errorf("expected weight 1, got %d", w)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
+
 func TestLeader_ReapFailedEval(t *testing.T) {
 	ci.Parallel(t)
 
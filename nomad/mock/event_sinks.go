@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package mock
+
+import (
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// EventSink returns a webhook event sink subscribed to all job events.
+func EventSink() *structs.EventSink {
+	return &structs.EventSink{
+		ID:        "sink-" + uuid.Short(),
+		Type:      structs.EventSinkWebhook,
+		Topics:    map[structs.Topic][]string{structs.TopicJob: {"*"}},
+		Namespace: "*",
+		Address:   "http://127.0.0.1:8080/events",
+	}
+}
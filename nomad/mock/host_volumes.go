@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package mock
+
+import (
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolume returns a ready dynamic host volume. The caller is responsible
+// for setting the NodeID to a node that exists in the state store.
+func HostVolume() *structs.HostVolume {
+	volID := uuid.Generate()
+	return &structs.HostVolume{
+		Namespace: structs.DefaultNamespace,
+		ID:        volID,
+		Name:      "example",
+		PluginID:  structs.HostVolumePluginMkdir,
+		NodePool:  structs.NodePoolDefault,
+		NodeID:    uuid.Generate(),
+		Constraints: []*structs.Constraint{
+			{
+				LTarget: "${meta.rack}",
+				RTarget: "r1",
+				Operand: "=",
+			},
+		},
+		RequestedCapacityMinBytes: 100000,
+		RequestedCapacityMaxBytes: 200000,
+		CapacityBytes:             150000,
+		Parameters:                map[string]string{"foo": "bar"},
+		HostPath:                  "/var/data/nomad/alloc_mounts/" + volID,
+		State:                     structs.HostVolumeStateReady,
+	}
+}
@@ -1424,6 +1424,15 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 			}
 		}
 
+		// Allocations of a job array with a concurrency limit are placed as
+		// the allocations before them finish. A single queued evaluation
+		// places them for all the allocations which finished before it runs.
+		if evalTriggerBy == "" && job != nil && job.Array != nil && job.Array.MaxParallel > 0 &&
+			allocToUpdate.TerminalStatus() && !alloc.ClientTerminalStatus() &&
+			!n.hasQueuedEval(job, structs.EvalTriggerJobArray) {
+			evalTriggerBy = structs.EvalTriggerJobArray
+		}
+
 		var eval *structs.Evaluation
 		// If unknown, and not an orphan, set the trigger by.
 		if evalTriggerBy != structs.EvalTriggerJobDeregister &&
@@ -1494,6 +1503,26 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 	return nil
 }
 
+// hasQueuedEval returns whether the job has a pending evaluation triggered by
+// the given reason which hasn't been dequeued by a scheduler yet, and so will
+// see the allocation updates made before it is processed.
+func (n *Node) hasQueuedEval(job *structs.Job, triggeredBy string) bool {
+	evals, err := n.srv.State().EvalsByJob(nil, job.Namespace, job.ID)
+	if err != nil {
+		n.logger.Debug("UpdateAlloc unable to find evals", "job", job.ID, "error", err)
+		return false
+	}
+	for _, eval := range evals {
+		if eval.TriggeredBy != triggeredBy || eval.Status != structs.EvalStatusPending {
+			continue
+		}
+		if _, outstanding := n.srv.evalBroker.Outstanding(eval.ID); !outstanding {
+			return true
+		}
+	}
+	return false
+}
+
 // batchUpdate is used to update all the allocations
 func (n *Node) batchUpdate(future *structs.BatchFuture, updates []*structs.Allocation, evals []*structs.Evaluation) {
 	var mErr multierror.Error
@@ -4595,6 +4595,7 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 		missingJob         bool
 		missingAlloc       bool
 		invalidTaskGroup   bool
+		jobArray           *structs.JobArray
 	}
 
 	testCases := []testCase{
@@ -4661,6 +4662,26 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 			missingAlloc:       false,
 			invalidTaskGroup:   true,
 		},
+		{
+			name:               "job-array-complete-alloc",
+			clientStatus:       structs.AllocClientStatusComplete,
+			serverClientStatus: structs.AllocClientStatusRunning,
+			triggerBy:          structs.EvalTriggerJobArray,
+			missingJob:         false,
+			missingAlloc:       false,
+			invalidTaskGroup:   false,
+			jobArray:           &structs.JobArray{Start: 0, End: 9, MaxParallel: 2},
+		},
+		{
+			name:               "job-array-already-complete-alloc",
+			clientStatus:       structs.AllocClientStatusComplete,
+			serverClientStatus: structs.AllocClientStatusComplete,
+			triggerBy:          "",
+			missingJob:         false,
+			missingAlloc:       false,
+			invalidTaskGroup:   false,
+			jobArray:           &structs.JobArray{Start: 0, End: 9, MaxParallel: 2},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -4692,6 +4713,7 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 
 			job := mock.Job()
 			job.ID = tc.name + "-test-job"
+			job.Array = tc.jobArray
 
 			if !tc.missingJob {
 				err = fsmState.UpsertJob(structs.MsgTypeTestSetup, 101, nil, job)
@@ -4762,6 +4784,64 @@ func TestClientEndpoint_UpdateAlloc_Evals_ByTrigger(t *testing.T) {
 
 }
 
+func TestClientEndpoint_UpdateAlloc_JobArray_SingleEval(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var nodeResp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &nodeResp))
+
+	fsmState := s1.fsm.State()
+	job := mock.BatchJob()
+	job.Array = &structs.JobArray{Start: 0, End: 9, MaxParallel: 2}
+	must.NoError(t, fsmState.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, fsmState.UpsertAllocs(structs.MsgTypeTestSetup, 101, allocs))
+
+	// Complete the allocations one after the other
+	for _, alloc := range allocs {
+		clientAlloc := alloc.Copy()
+		clientAlloc.ClientStatus = structs.AllocClientStatusComplete
+		updateReq := &structs.AllocUpdateRequest{
+			Alloc: []*structs.Allocation{clientAlloc},
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: node.SecretID,
+			},
+		}
+		var resp structs.NodeAllocsResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateAlloc", updateReq, &resp))
+	}
+
+	// The evaluation created for the first allocation is still queued, so
+	// it places the allocations following both
+	evals, err := fsmState.EvalsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerJobArray, evals[0].TriggeredBy)
+}
+
 // TestNode_List_PaginationFiltering asserts that API pagination and filtering
 // works against the Node.List RPC.
 func TestNode_List_PaginationFiltering(t *testing.T) {
@@ -21,6 +21,7 @@ import (
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/snapshot"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 )
 
 // Operator endpoint is used to perform low-level operator tasks for Nomad.
@@ -555,6 +556,49 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// SchedulerRebalanceReport is used to report the allocations the rebalancer
+// would migrate in its next cycle, without migrating them.
+func (op *Operator) SchedulerRebalanceReport(args *structs.GenericRequest, reply *structs.SchedulerRebalanceReportResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.SchedulerRebalanceReport", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// This action requires operator read access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	moves, err := scheduler.Rebalance(snap, op.srv.config.RebalanceMaxMigrations, op.logger)
+	if err != nil {
+		return err
+	}
+
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return err
+	}
+
+	reply.Moves = moves
+	reply.QueryMeta.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
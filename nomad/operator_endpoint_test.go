@@ -842,6 +842,40 @@ func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 
 }
 
+func TestOperator_SchedulerRebalanceReport_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerRebalanceReportResponse
+
+	// Try with no token and expect permission denied
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalanceReport", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token and expect permission denied
+	arg.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalanceReport", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with root token, should succeed without moves
+	arg.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalanceReport", &arg, &reply))
+	must.NonZero(t, reply.Index)
+	must.Len(t, 0, reply.Moves)
+}
+
 func TestOperator_SnapshotSave(t *testing.T) {
 	ci.Parallel(t)
 
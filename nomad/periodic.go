@@ -15,7 +15,6 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 
-	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -50,17 +49,7 @@ type JobEvalDispatcher interface {
 // evaluation and the job to the raft log. It returns the eval.
 func (s *Server) DispatchJob(job *structs.Job) (*structs.Evaluation, error) {
 	now := time.Now().UTC().UnixNano()
-	eval := &structs.Evaluation{
-		ID:          uuid.Generate(),
-		Namespace:   job.Namespace,
-		Priority:    job.Priority,
-		Type:        job.Type,
-		TriggeredBy: structs.EvalTriggerPeriodicJob,
-		JobID:       job.ID,
-		Status:      structs.EvalStatusPending,
-		CreateTime:  now,
-		ModifyTime:  now,
-	}
+	eval := newJobEval(job, structs.EvalTriggerPeriodicJob, job.Priority, 0, now)
 
 	// Commit this update via Raft
 	job.SetSubmitTime()
@@ -302,6 +291,46 @@ func (p *PeriodicDispatch) ForceEval(namespace, jobID string) (*structs.Evaluati
 	return p.createEval(job, time.Now().In(job.Periodic.GetLocation()))
 }
 
+// CatchUp creates an evaluation for each launch of the periodic job that was
+// missed since the last launch, according to the catch-up window of the job.
+// The evaluations are created in the order of the missed launches.
+func (p *PeriodicDispatch) CatchUp(namespace, jobID string, lastLaunch time.Time) ([]*structs.Evaluation, error) {
+	p.l.Lock()
+
+	// Do nothing if not enabled
+	if !p.enabled {
+		p.l.Unlock()
+		return nil, fmt.Errorf("periodic dispatch disabled")
+	}
+
+	tuple := structs.NamespacedID{
+		ID:        jobID,
+		Namespace: namespace,
+	}
+	job, tracked := p.tracked[tuple]
+	if !tracked {
+		p.l.Unlock()
+		return nil, fmt.Errorf("can't catch up non-tracked job %q (%s)", jobID, namespace)
+	}
+
+	p.l.Unlock()
+	launches, err := job.Periodic.MissedLaunches(lastLaunch, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine missed launches: %v", err)
+	}
+
+	evals := make([]*structs.Evaluation, 0, len(launches))
+	for _, launch := range launches {
+		p.logger.Debug("launching missed periodic job", "job", job.NamespacedID(), "launch_time", launch)
+		eval, err := p.createEval(job, launch)
+		if err != nil {
+			return evals, err
+		}
+		evals = append(evals, eval)
+	}
+	return evals, nil
+}
+
 // shouldRun returns whether the long lived run function should run.
 func (p *PeriodicDispatch) shouldRun() bool {
 	p.l.RLock()
@@ -397,6 +397,43 @@ func TestPeriodicDispatch_ForceEval_Tracked(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_CatchUp_Untracked(t *testing.T) {
+	ci.Parallel(t)
+	p, _ := testPeriodicDispatcher(t)
+
+	_, err := p.CatchUp("ns", "foo", time.Now())
+	require.Error(t, err)
+}
+
+func TestPeriodicDispatch_CatchUp_Tracked(t *testing.T) {
+	ci.Parallel(t)
+	p, m := testPeriodicDispatcher(t)
+
+	// Create a job that missed three launches, one of which is outside of the
+	// catch-up window, and launches again in the future.
+	now := time.Now().Round(1 * time.Second)
+	outside := now.Add(-2 * time.Hour)
+	missed1 := now.Add(-30 * time.Minute)
+	missed2 := now.Add(-10 * time.Minute)
+	future := now.Add(1 * time.Hour)
+	job := testPeriodicJob(outside, missed1, missed2, future)
+	job.Periodic.Catchup = structs.PeriodicCatchupAll
+	job.Periodic.CatchupWindow = 1 * time.Hour
+
+	require.NoError(t, p.Add(job))
+
+	evals, err := p.CatchUp(job.Namespace, job.ID, outside.Add(-1*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, evals, 2)
+
+	// Check that the missed launches within the window were launched.
+	launches, err := m.LaunchTimes(p, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, launches, 2)
+	require.True(t, launches[0].Equal(missed1), "got %v; want %v", launches[0], missed1)
+	require.True(t, launches[1].Equal(missed2), "got %v; want %v", launches[1], missed2)
+}
+
 func TestPeriodicDispatch_Run_DisallowOverlaps(t *testing.T) {
 	ci.Parallel(t)
 	p, m := testPeriodicDispatcher(t)
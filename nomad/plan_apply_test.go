@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/hashicorp/raft"
 	"github.com/shoenig/test/must"
@@ -738,6 +739,59 @@ func TestPlanApply_EvalPlan_Partial_AllAtOnce(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalPlan_Partial_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create two nodes which fit a single allocation each of a gang task
+	// group, and have the scheduler plan the group
+	h := scheduler.NewHarness(t)
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Gang = true
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 5000
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(scheduler.NewBatchScheduler, eval))
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+	must.MapLen(t, 2, plan.NodeAllocation)
+
+	// One of the nodes goes down before the plan is applied
+	state := testStateStore(t)
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, nodes[0]))
+	down := nodes[1].Copy()
+	down.Status = structs.NodeStatusDown
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1001, down))
+	snap, err := state.Snapshot()
+	must.NoError(t, err)
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	// No allocation of the gang is committed
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.NotNil(t, result)
+	must.MapEmpty(t, result.NodeAllocation)
+	must.Eq(t, 1001, result.RefreshIndex)
+}
+
 func TestPlanApply_EvalNodePlan_Simple(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
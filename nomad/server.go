@@ -41,6 +41,7 @@ import (
 	"github.com/hashicorp/nomad/helper/tlsutil"
 	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/auth"
+	"github.com/hashicorp/nomad/nomad/dependencywatcher"
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
 	"github.com/hashicorp/nomad/nomad/drainer"
 	"github.com/hashicorp/nomad/nomad/lock"
@@ -218,6 +219,10 @@ type Server struct {
 	// make the required calls to continue to transition the deployment.
 	deploymentWatcher *deploymentwatcher.Watcher
 
+	// dependencyWatcher is used to unblock the evaluations of jobs once the
+	// jobs they depend on have reached a terminal status.
+	dependencyWatcher *dependencywatcher.Watcher
+
 	// nodeDrainer is used to drain allocations from nodes.
 	nodeDrainer *drainer.NodeDrainer
 
@@ -379,6 +384,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	if err != nil {
 		return nil, err
 	}
+	if config.EvalBrokerFairShare {
+		evalBroker.SetFairShare(config.EvalBrokerPriorityAging)
+	}
 	s.evalBroker = evalBroker
 
 	// Create the blocked evals
@@ -496,6 +504,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 		return nil, fmt.Errorf("failed to create deployment watcher: %v", err)
 	}
 
+	// Setup the dependency watcher.
+	s.setupDependencyWatcher()
+
 	// Setup the volume watcher
 	if err := s.setupVolumeWatcher(); err != nil {
 		s.logger.Error("failed to create volume watcher", "error", err)
@@ -1175,6 +1186,16 @@ func (s *Server) setupDeploymentWatcher() error {
 	return nil
 }
 
+// setupDependencyWatcher creates a dependency watcher that unblocks the
+// evaluations of jobs with dependencies via Raft.
+func (s *Server) setupDependencyWatcher() {
+	raftShim := &dependencyWatcherRaftShim{
+		apply: s.raftApply,
+	}
+	s.dependencyWatcher = dependencywatcher.NewDependencyWatcher(
+		s.logger, raftShim, dependencywatcher.LimitStateQueriesPerSecond)
+}
+
 // setupVolumeWatcher creates a volume watcher that sends CSI RPCs
 func (s *Server) setupVolumeWatcher() error {
 	s.volumeWatcher = volumewatcher.NewVolumesWatcher(
@@ -1337,6 +1358,9 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewAllocEndpoint(s, ctx))
 	_ = server.Register(NewClientCSIEndpoint(s, ctx))
 	_ = server.Register(NewCSIVolumeEndpoint(s, ctx))
+	_ = server.Register(NewClientHostVolumeEndpoint(s, ctx))
+	_ = server.Register(NewHostVolumeEndpoint(s, ctx))
+	_ = server.Register(NewEventSinkEndpoint(s, ctx))
 	_ = server.Register(NewCSIPluginEndpoint(s, ctx))
 	_ = server.Register(NewDeploymentEndpoint(s, ctx))
 	_ = server.Register(NewEvalEndpoint(s, ctx))
@@ -26,6 +26,8 @@ const (
 	TableACLBindingRules      = "acl_binding_rules"
 	TableAllocs               = "allocs"
 	TableJobSubmission        = "job_submission"
+	TableHostVolumes          = "host_volumes"
+	TableEventSinks           = "event_sinks"
 )
 
 const (
@@ -97,6 +99,8 @@ func init() {
 		aclRolesTableSchema,
 		aclAuthMethodsTableSchema,
 		bindingRulesTableSchema,
+		hostVolumeTableSchema,
+		eventSinkTableSchema,
 	}...)
 }
 
@@ -408,6 +412,18 @@ func jobIsPeriodic(obj interface{}) (bool, error) {
 	return false, nil
 }
 
+// evalIsBlockedOnDependencies satisfies the ConditionalIndexFunc interface and
+// creates an index on whether an evaluation is blocked on the dependencies of
+// its job.
+func evalIsBlockedOnDependencies(obj interface{}) (bool, error) {
+	e, ok := obj.(*structs.Evaluation)
+	if !ok {
+		return false, fmt.Errorf("Unexpected type: %v", obj)
+	}
+
+	return e.Status == structs.EvalStatusBlocked && e.BlockedOnDependencies, nil
+}
+
 // deploymentSchema returns the MemDB schema tracking a job's deployments
 func deploymentSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -605,6 +621,17 @@ func evalTableSchema() *memdb.TableSchema {
 				},
 			},
 
+			// blocked_on_dependencies is used to lookup the evaluations
+			// blocked on the dependencies of their job.
+			"blocked_on_dependencies": {
+				Name:         "blocked_on_dependencies",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: evalIsBlockedOnDependencies,
+				},
+			},
+
 			// namespace_create index is used to lookup evaluations by namespace
 			// in their original chronological order based on CreateIndex.
 			//
@@ -1629,3 +1656,54 @@ func bindingRulesTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// hostVolumeTableSchema returns the MemDB schema for dynamic host volumes.
+func hostVolumeTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableHostVolumes,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+			// The nodeID index allows the scheduler to look up all the
+			// dynamic host volumes placed on a candidate node.
+			indexNodeID: {
+				Name:         indexNodeID,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "NodeID",
+				},
+			},
+		},
+	}
+}
+
+// eventSinkTableSchema returns the MemDB schema for event stream sinks.
+func eventSinkTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableEventSinks,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
@@ -3395,7 +3395,11 @@ func (s *StateStore) nestedUpsertEval(txn *txn, index uint64, eval *structs.Eval
 			if raw == nil {
 				break
 			}
-			blocked = append(blocked, raw.(*structs.Evaluation))
+			// Evaluations blocked on the dependencies of the job are
+			// unblocked by the dependency watcher instead.
+			if blockedEval := raw.(*structs.Evaluation); !blockedEval.BlockedOnDependencies {
+				blocked = append(blocked, blockedEval)
+			}
 		}
 
 		// Go through and update the evals
@@ -3782,6 +3786,21 @@ func evalNamespaceFilter(namespace string) func(interface{}) bool {
 	}
 }
 
+// EvalsBlockedOnDependencies returns an iterator over the evaluations which
+// are blocked on the dependencies of their job.
+func (s *StateStore) EvalsBlockedOnDependencies(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("evals", "blocked_on_dependencies", true)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // EvalsByJob returns all the evaluations by job id
 func (s *StateStore) EvalsByJob(ws memdb.WatchSet, namespace, jobID string) ([]*structs.Evaluation, error) {
 	txn := s.db.ReadTxn()
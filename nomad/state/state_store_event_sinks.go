@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// EventSinkByID retrieves a specific event sink. It returns nil if the sink
+// does not exist.
+func (s *StateStore) EventSinkByID(ws memdb.WatchSet, id string) (*structs.EventSink, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, obj, err := txn.FirstWatch(TableEventSinks, indexID, id)
+	if err != nil {
+		return nil, fmt.Errorf("event sink lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if obj == nil {
+		return nil, nil
+	}
+	return obj.(*structs.EventSink), nil
+}
+
+// EventSinks returns an iterator over all the event sinks.
+func (s *StateStore) EventSinks(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableEventSinks, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("event sink lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// UpsertEventSink inserts or updates a single event sink. The delivery
+// progress of an existing sink is preserved, so that updating the
+// configuration of a sink does not redeliver events.
+func (s *StateStore) UpsertEventSink(index uint64, sink *structs.EventSink) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableEventSinks, indexID, sink.ID)
+	if err != nil {
+		return fmt.Errorf("event sink lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		exist := existing.(*structs.EventSink)
+		sink.CreateIndex = exist.CreateIndex
+		sink.LatestIndex = exist.LatestIndex
+	} else {
+		sink.CreateIndex = index
+		sink.LatestIndex = 0
+	}
+	sink.ModifyIndex = index
+
+	if err := txn.Insert(TableEventSinks, sink); err != nil {
+		return fmt.Errorf("event sink insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableEventSinks, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// DeleteEventSinks deletes the event sinks with the given IDs. It returns an
+// error if any of the sinks does not exist.
+func (s *StateStore) DeleteEventSinks(index uint64, ids []string) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		existing, err := txn.First(TableEventSinks, indexID, id)
+		if err != nil {
+			return fmt.Errorf("event sink lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("event sink %q not found", id)
+		}
+		if err := txn.Delete(TableEventSinks, existing); err != nil {
+			return fmt.Errorf("event sink delete failed: %v", err)
+		}
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableEventSinks, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// UpdateEventSinkProgress records the index of the last events delivered by
+// each sink. Sinks which no longer exist are ignored, as they may have been
+// deleted while the leader was delivering events, and progress never moves
+// backwards.
+func (s *StateStore) UpdateEventSinkProgress(index uint64, progress map[string]uint64) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	for id, latest := range progress {
+		existing, err := txn.First(TableEventSinks, indexID, id)
+		if err != nil {
+			return fmt.Errorf("event sink lookup failed: %v", err)
+		}
+		if existing == nil {
+			continue
+		}
+		exist := existing.(*structs.EventSink)
+		if latest <= exist.LatestIndex {
+			continue
+		}
+
+		sink := exist.Copy()
+		sink.LatestIndex = latest
+		sink.ModifyIndex = index
+		if err := txn.Insert(TableEventSinks, sink); err != nil {
+			return fmt.Errorf("event sink insert failed: %v", err)
+		}
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableEventSinks, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_EventSinks_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	sinks := []*structs.EventSink{mock.EventSink(), mock.EventSink()}
+	must.NoError(t, store.UpsertEventSink(10, sinks[0].Copy()))
+	must.NoError(t, store.UpsertEventSink(11, sinks[1].Copy()))
+
+	sink, err := store.EventSinkByID(nil, sinks[0].ID)
+	must.NoError(t, err)
+	must.NotNil(t, sink)
+	must.Eq(t, 10, sink.CreateIndex)
+	must.Eq(t, 10, sink.ModifyIndex)
+
+	iter, err := store.EventSinks(nil)
+	must.NoError(t, err)
+	must.Len(t, 2, eventSinksFromIter(iter))
+
+	// progress only moves forward and ignores unknown sinks
+	ws := memdb.NewWatchSet()
+	_, err = store.EventSinkByID(ws, sinks[0].ID)
+	must.NoError(t, err)
+	must.NoError(t, store.UpdateEventSinkProgress(12, map[string]uint64{
+		sinks[0].ID: 9,
+		"unknown":   9,
+	}))
+	must.True(t, watchFired(ws))
+
+	sink, err = store.EventSinkByID(nil, sinks[0].ID)
+	must.NoError(t, err)
+	must.Eq(t, 9, sink.LatestIndex)
+	must.Eq(t, 12, sink.ModifyIndex)
+
+	must.NoError(t, store.UpdateEventSinkProgress(13, map[string]uint64{sinks[0].ID: 5}))
+	sink, err = store.EventSinkByID(nil, sinks[0].ID)
+	must.NoError(t, err)
+	must.Eq(t, 9, sink.LatestIndex)
+
+	// updating a sink keeps its progress and create index
+	update := sinks[0].Copy()
+	update.Address = "https://example.com/events"
+	must.NoError(t, store.UpsertEventSink(14, update))
+	sink, err = store.EventSinkByID(nil, sinks[0].ID)
+	must.NoError(t, err)
+	must.Eq(t, "https://example.com/events", sink.Address)
+	must.Eq(t, 9, sink.LatestIndex)
+	must.Eq(t, 10, sink.CreateIndex)
+	must.Eq(t, 14, sink.ModifyIndex)
+
+	// deleting an unknown sink fails without deleting the others
+	err = store.DeleteEventSinks(15, []string{sinks[0].ID, "unknown"})
+	must.EqError(t, err, `event sink "unknown" not found`)
+
+	must.NoError(t, store.DeleteEventSinks(15, []string{sinks[0].ID}))
+	sink, err = store.EventSinkByID(nil, sinks[0].ID)
+	must.NoError(t, err)
+	must.Nil(t, sink)
+
+	iter, err = store.EventSinks(nil)
+	must.NoError(t, err)
+	must.Len(t, 1, eventSinksFromIter(iter))
+
+	index, err := store.Index(TableEventSinks)
+	must.NoError(t, err)
+	must.Eq(t, 15, index)
+}
+
+func eventSinksFromIter(iter memdb.ResultIterator) []*structs.EventSink {
+	var sinks []*structs.EventSink
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sinks = append(sinks, raw.(*structs.EventSink))
+	}
+	return sinks
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolumeByID retrieves a specific dynamic host volume. It returns nil if
+// the volume does not exist.
+func (s *StateStore) HostVolumeByID(ws memdb.WatchSet, namespace, id string) (*structs.HostVolume, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, obj, err := txn.FirstWatch(TableHostVolumes, indexID, namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if obj == nil {
+		return nil, nil
+	}
+	return obj.(*structs.HostVolume), nil
+}
+
+// UpsertHostVolume inserts or updates a single dynamic host volume.
+func (s *StateStore) UpsertHostVolume(index uint64, vol *structs.HostVolume) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	if err := s.upsertHostVolumeTxn(index, txn, vol); err != nil {
+		return err
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// upsertHostVolumeTxn inserts a single host volume into the state store using
+// the provided write transaction. It is the responsibility of the caller to
+// update the index table.
+func (s *StateStore) upsertHostVolumeTxn(index uint64, txn *txn, vol *structs.HostVolume) error {
+	existing, err := txn.First(TableHostVolumes, indexID, vol.Namespace, vol.ID)
+	if err != nil {
+		return fmt.Errorf("host volume lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		exist := existing.(*structs.HostVolume)
+		if exist.NodeID != vol.NodeID {
+			return fmt.Errorf("host volume %q cannot be moved to another node", vol.ID)
+		}
+		vol.CreateIndex = exist.CreateIndex
+		vol.CreateTime = exist.CreateTime
+	} else {
+		vol.CreateIndex = index
+	}
+	vol.ModifyIndex = index
+
+	// Denormalize the node pool from the node, so that listing by pool
+	// doesn't need to join against the node table.
+	if node, err := txn.First("nodes", "id", vol.NodeID); err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	} else if node == nil {
+		return fmt.Errorf("host volume %q placed on unknown node %q", vol.ID, vol.NodeID)
+	} else {
+		vol.NodePool = node.(*structs.Node).NodePool
+	}
+
+	if err := txn.Insert(TableHostVolumes, vol); err != nil {
+		return fmt.Errorf("host volume insert failed: %v", err)
+	}
+	return nil
+}
+
+// DeleteHostVolume deletes a single dynamic host volume. It returns an error
+// if the volume does not exist.
+func (s *StateStore) DeleteHostVolume(index uint64, namespace, id string) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableHostVolumes, indexID, namespace, id)
+	if err != nil {
+		return fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	if existing == nil {
+		return errors.New("host volume not found")
+	}
+	if err := txn.Delete(TableHostVolumes, existing); err != nil {
+		return fmt.Errorf("host volume delete failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// HostVolumes returns an iterator over all the dynamic host volumes in every
+// namespace. The caller is responsible for filtering by ACL.
+func (s *StateStore) HostVolumes(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// HostVolumesByNamespace returns an iterator over the dynamic host volumes in
+// a single namespace.
+func (s *StateStore) HostVolumesByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexID+"_prefix", namespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// HostVolumesByNodeID returns an iterator over the dynamic host volumes
+// placed on a single node, in every namespace.
+func (s *StateStore) HostVolumesByNodeID(ws memdb.WatchSet, nodeID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexNodeID, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
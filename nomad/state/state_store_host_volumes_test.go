@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_HostVolumes_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[1].NodePool = "prod"
+	index++
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, nodes[0]))
+	index++
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, nodes[1]))
+
+	ns := mock.Namespace()
+	must.NoError(t, store.UpsertNamespaces(index, []*structs.Namespace{ns}))
+
+	vols := []*structs.HostVolume{
+		mock.HostVolume(),
+		mock.HostVolume(),
+		mock.HostVolume(),
+	}
+	vols[0].NodeID = nodes[0].ID
+	vols[1].NodeID = nodes[1].ID
+	vols[1].Name = "another-example"
+	vols[2].NodeID = nodes[1].ID
+	vols[2].Namespace = ns.Name
+
+	// a volume can't be placed on a node that doesn't exist
+	unknownNode := mock.HostVolume()
+	index++
+	err = store.UpsertHostVolume(index, unknownNode)
+	must.EqError(t, err, `host volume "`+unknownNode.ID+`" placed on unknown node "`+unknownNode.NodeID+`"`)
+
+	for _, vol := range vols {
+		index++
+		must.NoError(t, store.UpsertHostVolume(index, vol.Copy()))
+	}
+
+	vol, err := store.HostVolumeByID(nil, vols[1].Namespace, vols[1].ID)
+	must.NoError(t, err)
+	must.NotNil(t, vol)
+	must.Eq(t, vols[1].ID, vol.ID)
+	must.Eq(t, "prod", vol.NodePool, must.Sprint("expected node pool from node"))
+	must.Eq(t, vol.CreateIndex, vol.ModifyIndex)
+
+	iter, err := store.HostVolumesByNamespace(nil, structs.DefaultNamespace)
+	must.NoError(t, err)
+	must.Len(t, 2, hostVolumesFromIter(iter))
+
+	iter, err = store.HostVolumesByNodeID(nil, nodes[1].ID)
+	must.NoError(t, err)
+	must.Len(t, 2, hostVolumesFromIter(iter))
+
+	iter, err = store.HostVolumes(nil)
+	must.NoError(t, err)
+	must.Len(t, 3, hostVolumesFromIter(iter))
+
+	// updating a volume keeps its create index but can't move it
+	ws := memdb.NewWatchSet()
+	_, err = store.HostVolumeByID(ws, vols[0].Namespace, vols[0].ID)
+	must.NoError(t, err)
+
+	update := vols[0].Copy()
+	update.CapacityBytes = 300000
+	index++
+	must.NoError(t, store.UpsertHostVolume(index, update))
+	must.True(t, watchFired(ws))
+
+	vol, err = store.HostVolumeByID(nil, vols[0].Namespace, vols[0].ID)
+	must.NoError(t, err)
+	must.Eq(t, 300000, vol.CapacityBytes)
+	must.True(t, vol.ModifyIndex > vol.CreateIndex)
+
+	update = vols[0].Copy()
+	update.NodeID = nodes[1].ID
+	index++
+	must.EqError(t, store.UpsertHostVolume(index, update),
+		`host volume "`+vols[0].ID+`" cannot be moved to another node`)
+
+	// delete a volume
+	index++
+	must.NoError(t, store.DeleteHostVolume(index, vols[2].Namespace, vols[2].ID))
+	vol, err = store.HostVolumeByID(nil, vols[2].Namespace, vols[2].ID)
+	must.NoError(t, err)
+	must.Nil(t, vol)
+
+	index++
+	must.EqError(t, store.DeleteHostVolume(index, vols[2].Namespace, uuid.Generate()),
+		"host volume not found")
+
+	tableIndex, err := store.Index(TableHostVolumes)
+	must.NoError(t, err)
+	must.Eq(t, index-1, tableIndex)
+}
+
+func hostVolumesFromIter(iter memdb.ResultIterator) []*structs.HostVolume {
+	got := []*structs.HostVolume{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		got = append(got, raw.(*structs.HostVolume))
+	}
+	return got
+}
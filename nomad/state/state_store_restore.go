@@ -284,3 +284,21 @@ func (r *StateRestore) JobSubmissionRestore(jobSubmission *structs.JobSubmission
 	}
 	return nil
 }
+
+// HostVolumeRestore is used to restore a single dynamic host volume into the
+// host_volumes table.
+func (r *StateRestore) HostVolumeRestore(vol *structs.HostVolume) error {
+	if err := r.txn.Insert(TableHostVolumes, vol); err != nil {
+		return fmt.Errorf("host volume insert failed: %v", err)
+	}
+	return nil
+}
+
+// EventSinkRestore is used to restore a single event sink into the
+// event_sinks table.
+func (r *StateRestore) EventSinkRestore(sink *structs.EventSink) error {
+	if err := r.txn.Insert(TableEventSinks, sink); err != nil {
+		return fmt.Errorf("event sink insert failed: %v", err)
+	}
+	return nil
+}
@@ -5274,6 +5274,49 @@ func TestStateStore_EvalsByJob(t *testing.T) {
 	}
 }
 
+func TestStateStore_EvalsBlockedOnDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	job := mock.BatchJob()
+	job.DependsOn = []*structs.JobDependency{{JobID: "extract"}}
+	blocked := mock.Eval()
+	blocked.BlockOnDependencies(job)
+	pending := mock.Eval()
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Evaluation{blocked, pending}))
+
+	ws := memdb.NewWatchSet()
+	iter, err := state.EvalsBlockedOnDependencies(ws)
+	must.NoError(t, err)
+
+	var out []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.Evaluation).ID)
+	}
+	must.Eq(t, []string{blocked.ID}, out)
+
+	// Updating an evaluation which isn't blocked doesn't fire the watch
+	pending = pending.Copy()
+	pending.Status = structs.EvalStatusComplete
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1001,
+		[]*structs.Evaluation{pending}))
+	must.False(t, watchFired(ws))
+
+	// Unblocking the evaluation removes it from the index
+	blocked = blocked.Copy()
+	blocked.Status = structs.EvalStatusPending
+	blocked.BlockedOnDependencies = false
+	must.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1002,
+		[]*structs.Evaluation{blocked}))
+	must.True(t, watchFired(ws))
+
+	iter, err = state.EvalsBlockedOnDependencies(nil)
+	must.NoError(t, err)
+	must.Nil(t, iter.Next())
+}
+
 func TestStateStore_Evals(t *testing.T) {
 	ci.Parallel(t)
 
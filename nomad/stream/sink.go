@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// webhookTimeout is the timeout of a single webhook request.
+	webhookTimeout = 30 * time.Second
+
+	// webhookBackoffBase and webhookBackoffLimit bound the delay between
+	// retries of a failed webhook request.
+	webhookBackoffBase  = 1 * time.Second
+	webhookBackoffLimit = 1 * time.Minute
+)
+
+// SinkWriter delivers batches of events to the destination of an event sink.
+type SinkWriter interface {
+	// Send delivers the events, blocking until they have been delivered or
+	// the context is canceled.
+	Send(ctx context.Context, events *structs.Events) error
+
+	// Close releases the resources held by the writer.
+	Close() error
+}
+
+// NewSinkWriter returns the SinkWriter for the event sink type. File sinks
+// may only write below fileDir.
+func NewSinkWriter(sink *structs.EventSink, fileDir string, logger hclog.Logger) (SinkWriter, error) {
+	switch sink.Type {
+	case structs.EventSinkWebhook:
+		return NewWebhookSink(sink.Address, logger), nil
+	case structs.EventSinkFile:
+		return NewFileSink(fileDir, sink.Path)
+	default:
+		return nil, fmt.Errorf("unknown event sink type %q", sink.Type)
+	}
+}
+
+// encodeEvents encodes events as a single line of JSON, using the same
+// format as the event stream API.
+func encodeEvents(events *structs.Events) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(&buf, structs.JsonHandleWithExtensions)
+	if err := enc.Encode(events); err != nil {
+		return nil, fmt.Errorf("error marshaling json for sink: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// WebhookSink POSTs each batch of events as JSON to an HTTP endpoint,
+// retrying with an exponential backoff until the endpoint responds with a
+// successful status code.
+type WebhookSink struct {
+	address string
+	client  *http.Client
+	logger  hclog.Logger
+}
+
+// NewWebhookSink returns a sink that delivers events to address.
+func NewWebhookSink(address string, logger hclog.Logger) *WebhookSink {
+	client := cleanhttp.DefaultClient()
+	client.Timeout = webhookTimeout
+	return &WebhookSink{
+		address: address,
+		client:  client,
+		logger:  logger,
+	}
+}
+
+func (w *WebhookSink) Send(ctx context.Context, events *structs.Events) error {
+	body, err := encodeEvents(events)
+	if err != nil {
+		return err
+	}
+
+	for attempt := uint64(0); ; attempt++ {
+		err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+
+		backoff := helper.Backoff(webhookBackoffBase, webhookBackoffLimit, attempt)
+		w.logger.Warn("failed to deliver events to webhook, retrying",
+			"index", events.Index, "error", err, "backoff", backoff)
+
+		timer, stop := helper.NewSafeTimer(backoff)
+		select {
+		case <-ctx.Done():
+			stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		stop()
+	}
+}
+
+func (w *WebhookSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *WebhookSink) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+// FileSink appends each batch of events as a line of JSON to a file.
+type FileSink struct {
+	f *os.File
+}
+
+// NewFileSink opens, or creates, the file at path for appending events. The
+// path must be below dir, including after resolving symlinks.
+func NewFileSink(dir, path string) (*FileSink, error) {
+	if err := ValidateFileSinkPath(dir, path); err != nil {
+		return nil, err
+	}
+	dir = filepath.Clean(dir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create event sink directory: %w", err)
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve event sink directory: %w", err)
+	}
+
+	// Check the directories which already exist before creating the missing
+	// ones, so that a symlink can't be used to create directories elsewhere.
+	parent := filepath.Dir(filepath.Clean(path))
+	existing := parent
+	for {
+		if _, err := os.Lstat(existing); err == nil || existing == dir {
+			break
+		}
+		existing = filepath.Dir(existing)
+	}
+	if err := checkSinkPathResolves(realDir, existing); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(parent, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create event sink directory: %w", err)
+	}
+	if err := checkSinkPathResolves(realDir, parent); err != nil {
+		return nil, err
+	}
+	if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+		return nil, fmt.Errorf("event sink file %q must not be a symlink", path)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event sink file: %w", err)
+	}
+	return &FileSink{f: f}, nil
+}
+
+// ValidateFileSinkPath returns an error if file sinks are disabled because
+// dir is empty, or if path is not below dir.
+func ValidateFileSinkPath(dir, path string) error {
+	if dir == "" {
+		return errors.New("file event sinks are disabled, event_sink_file_dir must be set in the server configuration")
+	}
+	if !filepath.IsAbs(path) {
+		return errors.New("file sink path must be absolute")
+	}
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("file sink path %q must be below the event sink directory %q", path, dir)
+	}
+	return nil
+}
+
+// checkSinkPathResolves returns an error if path resolves to a directory
+// outside of realDir.
+func checkSinkPathResolves(realDir, path string) error {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve event sink path: %w", err)
+	}
+	rel, err := filepath.Rel(realDir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("event sink path %q resolves outside of the event sink directory", path)
+	}
+	return nil
+}
+
+func (s *FileSink) Send(_ context.Context, events *structs.Events) error {
+	line, err := encodeEvents(events)
+	if err != nil {
+		return err
+	}
+	if _, err := s.f.Write(line); err != nil {
+		return fmt.Errorf("failed to write events to file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
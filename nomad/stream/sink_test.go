@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func testSinkEvents(index uint64) *structs.Events {
+	return &structs.Events{
+		Index: index,
+		Events: []structs.Event{{
+			Index:   index,
+			Topic:   structs.TopicJob,
+			Key:     "example",
+			Payload: map[string]string{"ID": "example"},
+		}},
+	}
+}
+
+func TestWebhookSink_Send(t *testing.T) {
+	ci.Parallel(t)
+
+	// Fail the first request, so the sink has to retry.
+	var requests atomic.Int32
+	received := make(chan []byte, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		must.Eq(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer ts.Close()
+
+	sink := NewWebhookSink(ts.URL, hclog.NewNullLogger())
+	defer sink.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	must.NoError(t, sink.Send(ctx, testSinkEvents(10)))
+	must.Eq(t, 2, requests.Load())
+
+	var events structs.Events
+	must.NoError(t, json.Unmarshal(<-received, &events))
+	must.Eq(t, 10, events.Index)
+	must.Len(t, 1, events.Events)
+	must.Eq(t, "example", events.Events[0].Key)
+}
+
+func TestWebhookSink_Send_Canceled(t *testing.T) {
+	ci.Parallel(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	sink := NewWebhookSink(ts.URL, hclog.NewNullLogger())
+	defer sink.Close()
+
+	// The sink retries until the context is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	must.ErrorIs(t, sink.Send(ctx, testSinkEvents(10)), context.DeadlineExceeded)
+}
+
+func TestFileSink_Send(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := filepath.Join(t.TempDir(), "sinks")
+	path := filepath.Join(dir, "events", "events.json")
+	sink, err := NewFileSink(dir, path)
+	must.NoError(t, err)
+	must.NoError(t, sink.Send(context.Background(), testSinkEvents(10)))
+	must.NoError(t, sink.Close())
+
+	// Reopening the sink appends to the file.
+	sink, err = NewFileSink(dir, path)
+	must.NoError(t, err)
+	must.NoError(t, sink.Send(context.Background(), testSinkEvents(11)))
+	must.NoError(t, sink.Close())
+
+	f, err := os.Open(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	var indexes []uint64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var events structs.Events
+		must.NoError(t, json.Unmarshal(scanner.Bytes(), &events))
+		indexes = append(indexes, events.Index)
+	}
+	must.NoError(t, scanner.Err())
+	must.Eq(t, []uint64{10, 11}, indexes)
+}
+
+func TestFileSink_Path(t *testing.T) {
+	ci.Parallel(t)
+
+	tmp := t.TempDir()
+	dir := filepath.Join(tmp, "sinks")
+	outside := filepath.Join(tmp, "outside")
+	must.NoError(t, os.MkdirAll(dir, 0o700))
+	must.NoError(t, os.MkdirAll(outside, 0o700))
+	must.NoError(t, os.Symlink(outside, filepath.Join(dir, "link")))
+	must.NoError(t, os.Symlink(filepath.Join(outside, "file"), filepath.Join(dir, "file-link")))
+
+	cases := []struct {
+		name string
+		dir  string
+		path string
+		err  string
+	}{
+		{
+			name: "disabled",
+			dir:  "",
+			path: filepath.Join(dir, "events.json"),
+			err:  "file event sinks are disabled",
+		},
+		{
+			name: "outside",
+			dir:  dir,
+			path: filepath.Join(outside, "events.json"),
+			err:  "must be below the event sink directory",
+		},
+		{
+			name: "relative escape",
+			dir:  dir,
+			path: filepath.Join(dir, "..", "outside", "events.json"),
+			err:  "must be below the event sink directory",
+		},
+		{
+			name: "dir itself",
+			dir:  dir,
+			path: dir,
+			err:  "must be below the event sink directory",
+		},
+		{
+			name: "symlinked dir",
+			dir:  dir,
+			path: filepath.Join(dir, "link", "nested", "events.json"),
+			err:  "resolves outside of the event sink directory",
+		},
+		{
+			name: "symlinked file",
+			dir:  dir,
+			path: filepath.Join(dir, "file-link"),
+			err:  "must not be a symlink",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewFileSink(tc.dir, tc.path)
+			must.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	// Nothing was created through the symlinks
+	entries, err := os.ReadDir(outside)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+}
@@ -129,6 +129,17 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Dependencies diff
+	dependsOnDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.DependsOn),
+		interfaceSlice(other.DependsOn),
+		nil,
+		"Dependency",
+		contextual)
+	if dependsOnDiff != nil {
+		diff.Objects = append(diff.Objects, dependsOnDiff...)
+	}
+
 	// Task groups diff
 	tgs, err := taskGroupDiffs(j.TaskGroups, other.TaskGroups, contextual)
 	if err != nil {
@@ -257,6 +268,20 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		}
 	}
 
+	// MaxRuntime diff
+	if oldPrimitiveFlat != nil && newPrimitiveFlat != nil {
+		if tg.MaxRuntime == nil {
+			oldPrimitiveFlat["MaxRuntime"] = ""
+		} else {
+			oldPrimitiveFlat["MaxRuntime"] = fmt.Sprintf("%d", *tg.MaxRuntime)
+		}
+		if other.MaxRuntime == nil {
+			newPrimitiveFlat["MaxRuntime"] = ""
+		} else {
+			newPrimitiveFlat["MaxRuntime"] = fmt.Sprintf("%d", *other.MaxRuntime)
+		}
+	}
+
 	// StopAfterClientDisconnect diff
 	if oldPrimitiveFlat != nil && newPrimitiveFlat != nil {
 		if tg.StopAfterClientDisconnect == nil {
@@ -339,6 +364,11 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
+	// DisruptionBudget diff
+	if dDiff := primitiveObjectDiff(tg.DisruptionBudget, other.DisruptionBudget, nil, "DisruptionBudget", contextual); dDiff != nil {
+		diff.Objects = append(diff.Objects, dDiff)
+	}
+
 	// Disconnect diff
 	if disconnectDiff := disconectStrategyDiffs(tg.Disconnect, other.Disconnect, contextual); disconnectDiff != nil {
 		diff.Objects = append(diff.Objects, disconnectDiff)
@@ -521,7 +551,7 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(t.LogConfig, other.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(t.LogConfig, other.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -1693,7 +1723,7 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(old.LogConfig, new.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(old.LogConfig, new.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -1701,6 +1731,36 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	return diff
 }
 
+// logConfigDiff returns the diff of two LogConfig objects, including the diff
+// of their sinks.
+// If contextual diff is enabled, all fields will be returned, even if no diff occurred.
+func logConfigDiff(old, new *LogConfig, contextual bool) *ObjectDiff {
+	diff := primitiveObjectDiff(old, new, nil, "LogConfig", contextual)
+
+	var oldSinks, newSinks []*LogSink
+	if old != nil {
+		oldSinks = old.Sinks
+	}
+	if new != nil {
+		newSinks = new.Sinks
+	}
+	sDiffs := primitiveObjectSetDiff(
+		interfaceSlice(oldSinks),
+		interfaceSlice(newSinks),
+		nil,
+		"Sink",
+		contextual)
+	if len(sDiffs) == 0 {
+		return diff
+	}
+
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "LogConfig"}
+	}
+	diff.Objects = append(diff.Objects, sDiffs...)
+	return diff
+}
+
 // consulProxyDiff returns the diff of two ConsulProxy objects.
 // If contextual diff is enabled, all fields will be returned, even if no diff occurred.
 func consulProxyDiff(old, new *ConsulProxy, contextual bool) *ObjectDiff {
@@ -3082,6 +3142,17 @@ func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, setDiff)
 	}
 
+	// Exclusions diff
+	excludeDiff := primitiveObjectSetDiff(
+		interfaceSlice(old.Exclude),
+		interfaceSlice(new.Exclude),
+		nil,
+		"Exclude",
+		contextual)
+	if excludeDiff != nil {
+		diff.Objects = append(diff.Objects, excludeDiff...)
+	}
+
 	sort.Sort(FieldDiffs(diff.Fields))
 	return diff
 }
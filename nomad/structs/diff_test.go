@@ -183,6 +183,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "10",
 						New:  "",
 					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "Rebalance",
+						Old:  "false",
+						New:  "",
+					},
 					{
 						Type: DiffTypeDeleted,
 						Name: "Region",
@@ -252,6 +258,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "",
 						New:  "10",
 					},
+					{
+						Type: DiffTypeAdded,
+						Name: "Rebalance",
+						Old:  "",
+						New:  "false",
+					},
 					{
 						Type: DiffTypeAdded,
 						Name: "Region",
@@ -528,6 +540,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "CatchupWindow",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Enabled",
@@ -582,6 +600,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "CatchupWindow",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Enabled",
@@ -650,6 +674,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "CatchupWindow",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Enabled",
@@ -1027,12 +1057,30 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Catchup",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "CatchupWindow",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "EndBefore",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "ProhibitOverlap",
@@ -1051,6 +1099,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "StartAfter",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "TimeZone",
@@ -1062,6 +1116,79 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Periodic exclusions and bounds edited
+			Old: &Job{
+				Periodic: &PeriodicConfig{
+					Enabled:  true,
+					Spec:     "0 9 * * *",
+					SpecType: "cron",
+					Exclude: []*PeriodicExclusion{
+						{Cron: "* * * * 0,6"},
+					},
+				},
+			},
+			New: &Job{
+				Periodic: &PeriodicConfig{
+					Enabled:  true,
+					Spec:     "0 9 * * *",
+					SpecType: "cron",
+					Exclude: []*PeriodicExclusion{
+						{Start: "2024-12-24", End: "2024-12-26"},
+					},
+					EndBefore: "2025-01-01",
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Periodic",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "EndBefore",
+								Old:  "",
+								New:  "2025-01-01",
+							},
+						},
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Exclude",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "End",
+										Old:  "",
+										New:  "2024-12-26",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Start",
+										Old:  "",
+										New:  "2024-12-24",
+									},
+								},
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Exclude",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeDeleted,
+										Name: "Cron",
+										Old:  "* * * * 0,6",
+										New:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Constraints edited
 			Old: &Job{
@@ -1246,6 +1373,44 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Dependencies edited
+			Old: &Job{
+				DependsOn: []*JobDependency{
+					{
+						JobID:     "extract",
+						Namespace: "default",
+						Status:    JobDependencyStatusComplete,
+					},
+				},
+			},
+			New: &Job{
+				DependsOn: []*JobDependency{
+					{
+						JobID:     "extract",
+						Namespace: "default",
+						Status:    JobDependencyStatusFailed,
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Dependency",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Status",
+								Old:  "complete",
+								New:  "failed",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Task groups edited
 			Old: &Job{
@@ -1299,6 +1464,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Gang",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "PreventRescheduleOnLost",
@@ -1333,6 +1504,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Gang",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "PreventRescheduleOnLost",
@@ -1882,6 +2059,63 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "Gang diff",
+			Old: &TaskGroup{
+				Name:  "foo",
+				Count: 8,
+			},
+			New: &TaskGroup{
+				Name:  "foo",
+				Count: 8,
+				Gang:  true,
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Name: "foo",
+				Fields: []*FieldDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Gang",
+						Old:  "false",
+						New:  "true",
+					},
+				},
+			},
+		},
+		{
+			TestCase: "DisruptionBudget edited",
+			Old: &TaskGroup{
+				Name: "foo",
+				DisruptionBudget: &DisruptionBudget{
+					MaxUnavailable: 1,
+				},
+			},
+			New: &TaskGroup{
+				Name: "foo",
+				DisruptionBudget: &DisruptionBudget{
+					MaxUnavailable: 2,
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Name: "foo",
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "DisruptionBudget",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxUnavailable",
+								Old:  "1",
+								New:  "2",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			TestCase: "Map diff",
 			Old: &TaskGroup{
@@ -4559,6 +4793,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxRuntime",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "ShutdownDelay",
@@ -4605,6 +4845,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxRuntime",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "ShutdownDelay",
@@ -5510,6 +5756,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "RotateInterval",
+								Old:  "",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Timestamps",
+								Old:  "",
+								New:  "false",
+							},
 						},
 					},
 				},
@@ -5550,6 +5808,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "RotateInterval",
+								Old:  "0",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Timestamps",
+								Old:  "false",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -5625,6 +5895,12 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Compression",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Disabled",
@@ -5643,6 +5919,137 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "RotateInterval",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Timestamps",
+								Old:  "false",
+								New:  "false",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "LogConfig sinks edited",
+			Old: &Task{
+				LogConfig: &LogConfig{
+					MaxFiles:      1,
+					MaxFileSizeMB: 10,
+					Sinks: []*LogSink{
+						{
+							Type:    LogSinkTypeSyslog,
+							Address: "udp://127.0.0.1:514",
+						},
+					},
+				},
+			},
+			New: &Task{
+				LogConfig: &LogConfig{
+					MaxFiles:      1,
+					MaxFileSizeMB: 10,
+					Sinks: []*LogSink{
+						{
+							Type:    LogSinkTypeHTTP,
+							Address: "http://127.0.0.1:8080",
+							Headers: map[string]string{
+								"Authorization": "Bearer secret",
+							},
+						},
+					},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "LogConfig",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Sink",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Address",
+										Old:  "",
+										New:  "http://127.0.0.1:8080",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "BatchInterval",
+										Old:  "",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "BatchSize",
+										Old:  "",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "BufferSize",
+										Old:  "",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Headers[Authorization]",
+										Old:  "",
+										New:  "Bearer secret",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Type",
+										Old:  "",
+										New:  "http",
+									},
+								},
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Sink",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeDeleted,
+										Name: "Address",
+										Old:  "udp://127.0.0.1:514",
+										New:  "",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "BatchInterval",
+										Old:  "0",
+										New:  "",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "BatchSize",
+										Old:  "0",
+										New:  "",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "BufferSize",
+										Old:  "0",
+										New:  "",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Type",
+										Old:  "syslog",
+										New:  "",
+									},
+								},
+							},
 						},
 					},
 				},
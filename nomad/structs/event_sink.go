@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// EventSinkWebhook is a sink which POSTs events to an HTTP endpoint.
+	EventSinkWebhook = "webhook"
+
+	// EventSinkFile is a sink which appends events as newline delimited JSON
+	// to a file local to the leader.
+	EventSinkFile = "file"
+)
+
+var (
+	// validEventSinkID is used to validate the ID of an event sink.
+	validEventSinkID = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+)
+
+// EventSink is a durable destination for events published to the event
+// stream. Sinks are run by the leader, which forwards the events matching the
+// sink topics and records the index of the last delivered events in raft, so
+// that delivery resumes where it left off after a leader election.
+type EventSink struct {
+	// ID is the unique, operator provided, identifier of the sink.
+	ID string
+
+	// Type is the type of sink, either webhook or file.
+	Type string
+
+	// Topics is the set of topics and keys to subscribe to, with the same
+	// semantics as the topics of the event stream API.
+	Topics map[Topic][]string
+
+	// Namespace restricts the sink to events of the namespace, or of all
+	// namespaces when set to the wildcard "*".
+	Namespace string
+
+	// Address is the URL events are sent to by webhook sinks.
+	Address string
+
+	// Path is the file events are written to by file sinks.
+	Path string
+
+	// LatestIndex is the raft index of the last events delivered by the sink.
+	LatestIndex uint64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Canonicalize sets default values on the sink.
+func (s *EventSink) Canonicalize() {
+	if len(s.Topics) == 0 {
+		s.Topics = map[Topic][]string{TopicAll: {"*"}}
+	}
+	if s.Namespace == "" {
+		s.Namespace = "*"
+	}
+}
+
+// Validate ensures the sink is well formed.
+func (s *EventSink) Validate() error {
+	var mErr *multierror.Error
+
+	if !validEventSinkID.MatchString(s.ID) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid ID %q", s.ID))
+	}
+
+	switch s.Type {
+	case EventSinkWebhook:
+		u, err := url.Parse(s.Address)
+		if err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("invalid webhook address: %v", err))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			mErr = multierror.Append(mErr, errors.New("webhook address must be an http or https URL"))
+		}
+		if s.Path != "" {
+			mErr = multierror.Append(mErr, errors.New("path may only be set for file sinks"))
+		}
+	case EventSinkFile:
+		if !filepath.IsAbs(s.Path) {
+			mErr = multierror.Append(mErr, errors.New("file sink path must be absolute"))
+		}
+		if s.Address != "" {
+			mErr = multierror.Append(mErr, errors.New("address may only be set for webhook sinks"))
+		}
+	default:
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid sink type %q, must be one of %q or %q",
+			s.Type, EventSinkWebhook, EventSinkFile))
+	}
+
+	for topic, keys := range s.Topics {
+		if topic == "" {
+			mErr = multierror.Append(mErr, errors.New("topic must not be empty"))
+		}
+		if len(keys) == 0 {
+			mErr = multierror.Append(mErr, fmt.Errorf("topic %q must have at least one key", topic))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the sink.
+func (s *EventSink) Copy() *EventSink {
+	if s == nil {
+		return nil
+	}
+	ns := *s
+	if s.Topics != nil {
+		ns.Topics = make(map[Topic][]string, len(s.Topics))
+		for topic, keys := range s.Topics {
+			ns.Topics[topic] = slices.Clone(keys)
+		}
+	}
+	return &ns
+}
+
+// EqualConfig returns whether the two sinks deliver the same events to the
+// same destination, ignoring their progress and raft indexes.
+func (s *EventSink) EqualConfig(o *EventSink) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	return s.ID == o.ID &&
+		s.Type == o.Type &&
+		s.Namespace == o.Namespace &&
+		s.Address == o.Address &&
+		s.Path == o.Path &&
+		maps.EqualFunc(s.Topics, o.Topics, slices.Equal[[]string])
+}
+
+// EventSinkRegisterRequest is used to create or update an event sink.
+type EventSinkRegisterRequest struct {
+	Sink *EventSink
+	WriteRequest
+}
+
+// EventSinkSpecificRequest is used to query a specific event sink.
+type EventSinkSpecificRequest struct {
+	ID string
+	QueryOptions
+}
+
+// EventSinkResponse is used to return a single event sink.
+type EventSinkResponse struct {
+	Sink *EventSink
+	QueryMeta
+}
+
+// EventSinkListRequest is used to list event sinks.
+type EventSinkListRequest struct {
+	QueryOptions
+}
+
+// EventSinkListResponse is used to return a list of event sinks.
+type EventSinkListResponse struct {
+	Sinks []*EventSink
+	QueryMeta
+}
+
+// EventSinkDeregisterRequest is used to delete event sinks.
+type EventSinkDeregisterRequest struct {
+	IDs []string
+	WriteRequest
+}
+
+// EventSinkProgressRequest is used by the leader to record the index of the
+// last events delivered by a set of sinks.
+type EventSinkProgressRequest struct {
+	// Progress maps sink IDs to the index of the last delivered events.
+	Progress map[string]uint64
+	WriteRequest
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestEventSink_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		sink   *EventSink
+		expErr string
+	}{
+		{
+			name: "valid webhook",
+			sink: &EventSink{ID: "hook", Type: EventSinkWebhook, Address: "https://example.com"},
+		},
+		{
+			name: "valid file",
+			sink: &EventSink{ID: "file", Type: EventSinkFile, Path: "/var/log/nomad/events.json"},
+		},
+		{
+			name:   "invalid id",
+			sink:   &EventSink{ID: "my sink", Type: EventSinkWebhook, Address: "https://example.com"},
+			expErr: `invalid ID "my sink"`,
+		},
+		{
+			name:   "invalid type",
+			sink:   &EventSink{ID: "sink", Type: "kafka"},
+			expErr: `invalid sink type "kafka"`,
+		},
+		{
+			name:   "webhook scheme",
+			sink:   &EventSink{ID: "hook", Type: EventSinkWebhook, Address: "tcp://example.com"},
+			expErr: "webhook address must be an http or https URL",
+		},
+		{
+			name:   "webhook with path",
+			sink:   &EventSink{ID: "hook", Type: EventSinkWebhook, Address: "https://example.com", Path: "/tmp/x"},
+			expErr: "path may only be set for file sinks",
+		},
+		{
+			name:   "relative file path",
+			sink:   &EventSink{ID: "file", Type: EventSinkFile, Path: "events.json"},
+			expErr: "file sink path must be absolute",
+		},
+		{
+			name: "topic without keys",
+			sink: &EventSink{ID: "hook", Type: EventSinkWebhook, Address: "https://example.com",
+				Topics: map[Topic][]string{TopicJob: {}}},
+			expErr: `topic "Job" must have at least one key`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.sink.Canonicalize()
+			err := tc.sink.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestEventSink_EqualConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	a := &EventSink{
+		ID:        "hook",
+		Type:      EventSinkWebhook,
+		Address:   "https://example.com",
+		Namespace: "*",
+		Topics:    map[Topic][]string{TopicJob: {"*"}},
+	}
+	b := a.Copy()
+	b.LatestIndex = 10
+	b.ModifyIndex = 11
+	must.True(t, a.EqualConfig(b))
+
+	b.Topics[TopicJob] = []string{"example"}
+	must.False(t, a.EqualConfig(b))
+	must.Eq(t, []string{"*"}, a.Topics[TopicJob])
+}
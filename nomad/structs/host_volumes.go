@@ -0,0 +1,271 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// HostVolumePluginMkdir is the ID of the built-in host volume plugin,
+	// which creates a directory on the client with no further provisioning.
+	HostVolumePluginMkdir = "mkdir"
+
+	// HostVolumeStatePending is the state of a host volume that has been
+	// placed on a node but has not yet been created by the client.
+	HostVolumeStatePending = "pending"
+
+	// HostVolumeStateReady is the state of a host volume that has been
+	// created by the client and is available for mounting.
+	HostVolumeStateReady = "ready"
+)
+
+var (
+	// validHostVolumeName is the rule used to validate a host volume name.
+	validHostVolumeName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+
+	// validHostVolumePluginID is the rule used to validate a host volume
+	// plugin ID. Plugin IDs map directly to executables on the client, so
+	// path separators are not allowed.
+	validHostVolumePluginID = regexp.MustCompile("^[a-zA-Z0-9-_.]{1,128}$")
+)
+
+// HostVolume is a host volume that is created on a client via the API rather
+// than being statically configured in the client agent configuration.
+type HostVolume struct {
+	// Namespace is the Nomad namespace for the volume. Only jobs in the same
+	// namespace can mount the volume.
+	Namespace string
+
+	// ID is a UUID-like string generated by the server.
+	ID string
+
+	// Name is the name that group.volume will use to identify the volume
+	// source. It must be unique per node.
+	Name string
+
+	// PluginID is the host volume plugin on the client that will be used
+	// for creating the volume. Defaults to the built-in "mkdir" plugin.
+	PluginID string
+
+	// NodePool is the node pool of the node where the volume is placed. If
+	// the user doesn't provide a node ID, a node will be selected from this
+	// pool using the constraints.
+	NodePool string
+
+	// NodeID is the node where the volume is placed. It's the responsibility
+	// of the server to select a node if the user doesn't provide one.
+	NodeID string
+
+	// Constraints are optional. If the NodeID is not provided, the NodePool
+	// and Constraints are used to select a node.
+	Constraints []*Constraint
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are the
+	// optional inputs to the plugin for the size of the volume.
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+
+	// CapacityBytes is the actual size of the volume as reported by the
+	// plugin.
+	CapacityBytes int64
+
+	// Parameters are an opaque map of parameters for the plugin.
+	Parameters map[string]string
+
+	// HostPath is the path on disk where the volume's mount point was
+	// created. We record this to make debugging easier.
+	HostPath string
+
+	// State represents the overall state of the volume.
+	State string
+
+	CreateIndex uint64
+	CreateTime  int64
+
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// GetNamespace implements the NamespaceGetter interface required for
+// pagination and filtering namespaces in endpoints that support glob
+// namespace requests using tokens with limited access.
+func (hv *HostVolume) GetNamespace() string {
+	return hv.Namespace
+}
+
+// GetID implements the IDGetter interface required for pagination.
+func (hv *HostVolume) GetID() string {
+	return hv.ID
+}
+
+// Copy returns a deep copy of the host volume.
+func (hv *HostVolume) Copy() *HostVolume {
+	if hv == nil {
+		return nil
+	}
+
+	nhv := *hv
+	nhv.Constraints = CopySliceConstraints(hv.Constraints)
+	nhv.Parameters = maps.Clone(hv.Parameters)
+	return &nhv
+}
+
+// Stub returns the list view of the host volume.
+func (hv *HostVolume) Stub() *HostVolumeStub {
+	if hv == nil {
+		return nil
+	}
+
+	return &HostVolumeStub{
+		Namespace:     hv.Namespace,
+		ID:            hv.ID,
+		Name:          hv.Name,
+		PluginID:      hv.PluginID,
+		NodePool:      hv.NodePool,
+		NodeID:        hv.NodeID,
+		CapacityBytes: hv.CapacityBytes,
+		State:         hv.State,
+		CreateIndex:   hv.CreateIndex,
+		CreateTime:    hv.CreateTime,
+		ModifyIndex:   hv.ModifyIndex,
+		ModifyTime:    hv.ModifyTime,
+	}
+}
+
+// Canonicalize sets the defaults for fields the user did not set.
+func (hv *HostVolume) Canonicalize() {
+	if hv.Namespace == "" {
+		hv.Namespace = DefaultNamespace
+	}
+	if hv.PluginID == "" {
+		hv.PluginID = HostVolumePluginMkdir
+	}
+	if hv.NodePool == "" && hv.NodeID == "" {
+		hv.NodePool = NodePoolDefault
+	}
+}
+
+// Validate verifies that the user-provided fields of the host volume are
+// acceptable.
+func (hv *HostVolume) Validate() error {
+	var mErr *multierror.Error
+
+	if !validHostVolumeName.MatchString(hv.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf(
+			"invalid name %q, must match regex %s", hv.Name, validHostVolumeName))
+	}
+	if hv.PluginID != "" && !validHostVolumePluginID.MatchString(hv.PluginID) {
+		mErr = multierror.Append(mErr, fmt.Errorf(
+			"invalid plugin ID %q, must match regex %s", hv.PluginID, validHostVolumePluginID))
+	}
+	if hv.NodePool != "" {
+		if err := ValidateNodePoolName(hv.NodePool); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+	if hv.RequestedCapacityMinBytes < 0 || hv.RequestedCapacityMaxBytes < 0 {
+		mErr = multierror.Append(mErr, errors.New("capacity cannot be negative"))
+	}
+	if hv.RequestedCapacityMaxBytes > 0 &&
+		hv.RequestedCapacityMaxBytes < hv.RequestedCapacityMinBytes {
+		mErr = multierror.Append(mErr, fmt.Errorf(
+			"capacity_max (%d) must be larger than capacity_min (%d)",
+			hv.RequestedCapacityMaxBytes, hv.RequestedCapacityMinBytes))
+	}
+	for idx, constr := range hv.Constraints {
+		if err := constr.Validate(); err != nil {
+			mErr = multierror.Append(mErr,
+				multierror.Prefix(err, fmt.Sprintf("Constraint %d validation failed:", idx+1)))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// IsReady returns whether the volume has been created by the client and can
+// be mounted by allocations.
+func (hv *HostVolume) IsReady() bool {
+	return hv != nil && hv.State == HostVolumeStateReady
+}
+
+// HostVolumeStub is used for responses for the list volumes endpoint
+type HostVolumeStub struct {
+	Namespace     string
+	ID            string
+	Name          string
+	PluginID      string
+	NodePool      string
+	NodeID        string
+	CapacityBytes int64
+	State         string
+
+	CreateIndex uint64
+	CreateTime  int64
+
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// HostVolumeCreateRequest is used to create a host volume on a client.
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+	WriteRequest
+}
+
+// HostVolumeCreateResponse is the response to a HostVolumeCreateRequest.
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+	WriteMeta
+}
+
+// HostVolumeRegisterRequest is the Raft request used to write a host volume
+// into the state store.
+type HostVolumeRegisterRequest struct {
+	Volume *HostVolume
+	WriteRequest
+}
+
+// HostVolumeDeleteRequest is used to delete a host volume from its client
+// and from the state store.
+type HostVolumeDeleteRequest struct {
+	VolumeID string
+	WriteRequest
+}
+
+// HostVolumeDeleteResponse is the response to a HostVolumeDeleteRequest.
+type HostVolumeDeleteResponse struct {
+	WriteMeta
+}
+
+// HostVolumeGetRequest is used to read a single host volume.
+type HostVolumeGetRequest struct {
+	ID string
+	QueryOptions
+}
+
+// HostVolumeGetResponse is the response to a HostVolumeGetRequest.
+type HostVolumeGetResponse struct {
+	Volume *HostVolume
+	QueryMeta
+}
+
+// HostVolumeListRequest is used to list host volumes, optionally filtered by
+// the node they are placed on or the node pool of that node.
+type HostVolumeListRequest struct {
+	NodeID   string
+	NodePool string
+	QueryOptions
+}
+
+// HostVolumeListResponse is the response to a HostVolumeListRequest.
+type HostVolumeListResponse struct {
+	Volumes []*HostVolumeStub
+	QueryMeta
+}
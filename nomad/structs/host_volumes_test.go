@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/shoenig/test/must"
+)
+
+func TestHostVolume_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	out := (*HostVolume)(nil).Copy()
+	must.Nil(t, out)
+
+	vol := &HostVolume{
+		Namespace:   DefaultNamespace,
+		ID:          uuid.Generate(),
+		Name:        "example",
+		PluginID:    "example-plugin",
+		NodePool:    NodePoolDefault,
+		NodeID:      uuid.Generate(),
+		Constraints: []*Constraint{{LTarget: "${meta.rack}", RTarget: "r1", Operand: "="}},
+		Parameters:  map[string]string{"foo": "bar"},
+	}
+
+	out = vol.Copy()
+	must.Eq(t, vol, out)
+
+	out.Constraints[0].RTarget = "r2"
+	out.Parameters["foo"] = "baz"
+	must.Eq(t, "r1", vol.Constraints[0].RTarget)
+	must.Eq(t, "bar", vol.Parameters["foo"])
+}
+
+func TestHostVolume_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	invalid := &HostVolume{
+		Name:                      "example volume",
+		PluginID:                  "../example",
+		NodePool:                  "<invalid>",
+		RequestedCapacityMinBytes: 200000,
+		RequestedCapacityMaxBytes: 100000,
+		Constraints:               []*Constraint{{Operand: "="}},
+	}
+	err := invalid.Validate()
+	must.ErrorContains(t, err, `invalid name "example volume"`)
+	must.ErrorContains(t, err, `invalid plugin ID "../example"`)
+	must.ErrorContains(t, err, `invalid name "<invalid>"`)
+	must.ErrorContains(t, err, "capacity_max (100000) must be larger than capacity_min (200000)")
+	must.ErrorContains(t, err, "Constraint 1 validation failed")
+
+	vol := &HostVolume{Name: "example"}
+	vol.Canonicalize()
+	must.NoError(t, vol.Validate())
+	must.Eq(t, DefaultNamespace, vol.Namespace)
+	must.Eq(t, HostVolumePluginMkdir, vol.PluginID)
+	must.Eq(t, NodePoolDefault, vol.NodePool)
+}
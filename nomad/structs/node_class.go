@@ -107,11 +107,13 @@ func (n NodeDeviceResource) HashIncludeMap(field string, k, v interface{}) (bool
 }
 
 // EscapedConstraints takes a set of constraints and returns the set that
-// escapes computed node classes.
+// escapes computed node classes. Constraints on the allocations running on a
+// node always escape, since they don't depend on the node attributes.
 func EscapedConstraints(constraints []*Constraint) []*Constraint {
 	var escaped []*Constraint
 	for _, c := range constraints {
-		if constraintTargetEscapes(c.LTarget) || constraintTargetEscapes(c.RTarget) {
+		if IsAllocOperand(c.Operand) ||
+			constraintTargetEscapes(c.LTarget) || constraintTargetEscapes(c.RTarget) {
 			escaped = append(escaped, c)
 		}
 	}
@@ -209,8 +209,69 @@ const (
 	// SchedulerAlgorithmSpread indicates that the scheduler should spread
 	// allocations as evenly as possible over the available hardware.
 	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
+
+	// SchedulerAlgorithmWeighted indicates that the scheduler should bin pack
+	// allocations, preferring nodes according to the weighted list of node
+	// attributes in the scheduler configuration.
+	SchedulerAlgorithmWeighted SchedulerAlgorithm = "weighted"
 )
 
+// SchedulerNodeWeight is a node attribute or metadata value that the weighted
+// scheduler algorithm uses to score nodes, such as the cost tier or hardware
+// generation of the node.
+type SchedulerNodeWeight struct {
+	// Attribute is the node attribute or metadata to match, such as
+	// "${meta.cost_tier}".
+	Attribute string `hcl:"attribute"`
+
+	// Value is the value the attribute is compared to.
+	Value string `hcl:"value"`
+
+	// Operand is the comparison operator, using the same operators as
+	// affinities. Defaults to "=".
+	Operand string `hcl:"operator"`
+
+	// Weight is the score in the range [-100, 100] given to the nodes which
+	// match. Negative weights make the scheduler avoid the nodes.
+	Weight int8 `hcl:"weight"`
+}
+
+// Copy returns a copy of the node weight.
+func (w *SchedulerNodeWeight) Copy() *SchedulerNodeWeight {
+	if w == nil {
+		return nil
+	}
+
+	nw := *w
+	return &nw
+}
+
+// Affinity returns the node weight as an affinity, so nodes are matched the
+// same way as for the affinities of jobs.
+func (w *SchedulerNodeWeight) Affinity() *Affinity {
+	operand := w.Operand
+	if operand == "" {
+		operand = "="
+	}
+	return &Affinity{
+		LTarget: w.Attribute,
+		RTarget: w.Value,
+		Operand: operand,
+		Weight:  w.Weight,
+	}
+}
+
+// Validate returns an error if the node weight is invalid.
+func (w *SchedulerNodeWeight) Validate() error {
+	if w == nil {
+		return errors.New("missing node weight")
+	}
+	if IsAllocOperand(w.Operand) {
+		return fmt.Errorf("node weights may not use operator %q", w.Operand)
+	}
+	return w.Affinity().Validate()
+}
+
 // SchedulerConfiguration is the config for controlling scheduler behavior
 type SchedulerConfiguration struct {
 	// SchedulerAlgorithm lets you select between available scheduling algorithms.
@@ -233,6 +294,10 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// NodeWeights are the weighted node attributes used to score nodes when
+	// the weighted scheduler algorithm is selected.
+	NodeWeights []*SchedulerNodeWeight `hcl:"node_weight"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -244,6 +309,12 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	if s.NodeWeights != nil {
+		ns.NodeWeights = make([]*SchedulerNodeWeight, len(s.NodeWeights))
+		for i, w := range s.NodeWeights {
+			ns.NodeWeights[i] = w.Copy()
+		}
+	}
 	return &ns
 }
 
@@ -288,10 +359,20 @@ func (s *SchedulerConfiguration) Validate() error {
 
 	switch s.SchedulerAlgorithm {
 	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	case SchedulerAlgorithmWeighted:
+		if len(s.NodeWeights) == 0 {
+			return fmt.Errorf("scheduler algorithm %q requires at least one node weight", s.SchedulerAlgorithm)
+		}
 	default:
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	for i, w := range s.NodeWeights {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("invalid node weight %d: %v", i, err)
+		}
+	}
+
 	return nil
 }
 
@@ -313,6 +394,33 @@ type SchedulerSetConfigurationResponse struct {
 	WriteMeta
 }
 
+// RebalanceMove is a running allocation which the rebalancer migrates because
+// its placement has become a poor fit for the scheduler algorithm, spread or
+// affinity goals of its job.
+type RebalanceMove struct {
+	AllocID   string
+	Namespace string
+	JobID     string
+	TaskGroup string
+	NodeID    string
+
+	// Reason describes which goal the current placement is a poor fit for.
+	Reason string
+
+	// Score is how much the placement is expected to improve by migrating
+	// the allocation. Moves with a higher score are made first.
+	Score float64
+}
+
+// SchedulerRebalanceReportResponse is the response object used to report the
+// allocations the rebalancer would migrate in its next cycle.
+type SchedulerRebalanceReportResponse struct {
+	// Moves are the allocations the rebalancer would migrate.
+	Moves []*RebalanceMove
+
+	QueryMeta
+}
+
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
 type PreemptionConfig struct {
 	// SystemSchedulerEnabled specifies if preemption is enabled for system jobs
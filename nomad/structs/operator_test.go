@@ -106,3 +106,88 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerConfiguration_Validate_NodeWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		schedConfig *SchedulerConfiguration
+		expectedErr string
+	}{
+		{
+			name: "weighted with node weights",
+			schedConfig: &SchedulerConfiguration{
+				SchedulerAlgorithm: SchedulerAlgorithmWeighted,
+				NodeWeights: []*SchedulerNodeWeight{
+					{Attribute: "${meta.cost_tier}", Value: "spot", Weight: 100},
+					{Attribute: "${attr.cpu.arch}", Value: "arm64", Operand: "!=", Weight: -20},
+				},
+			},
+		},
+		{
+			name: "weighted without node weights",
+			schedConfig: &SchedulerConfiguration{
+				SchedulerAlgorithm: SchedulerAlgorithmWeighted,
+			},
+			expectedErr: "requires at least one node weight",
+		},
+		{
+			name: "node weights with other algorithm",
+			schedConfig: &SchedulerConfiguration{
+				SchedulerAlgorithm: SchedulerAlgorithmSpread,
+				NodeWeights: []*SchedulerNodeWeight{
+					{Attribute: "${meta.cost_tier}", Value: "spot", Weight: 100},
+				},
+			},
+		},
+		{
+			name: "zero weight",
+			schedConfig: &SchedulerConfiguration{
+				SchedulerAlgorithm: SchedulerAlgorithmWeighted,
+				NodeWeights: []*SchedulerNodeWeight{
+					{Attribute: "${meta.cost_tier}", Value: "spot"},
+				},
+			},
+			expectedErr: "weight cannot be zero",
+		},
+		{
+			name: "unknown operator",
+			schedConfig: &SchedulerConfiguration{
+				SchedulerAlgorithm: SchedulerAlgorithmWeighted,
+				NodeWeights: []*SchedulerNodeWeight{
+					{Attribute: "${meta.cost_tier}", Value: "spot", Operand: "~", Weight: 10},
+				},
+			},
+			expectedErr: `Unknown affinity operator "~"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.schedConfig.Validate()
+			if tc.expectedErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSchedulerConfiguration_Copy_NodeWeights(t *testing.T) {
+	ci.Parallel(t)
+
+	schedConfig := &SchedulerConfiguration{
+		SchedulerAlgorithm: SchedulerAlgorithmWeighted,
+		NodeWeights: []*SchedulerNodeWeight{
+			{Attribute: "${meta.cost_tier}", Value: "spot", Weight: 100},
+		},
+	}
+
+	copied := schedConfig.Copy()
+	must.Eq(t, schedConfig, copied)
+
+	copied.NodeWeights[0].Weight = 50
+	must.Eq(t, 100, schedConfig.NodeWeights[0].Weight)
+}
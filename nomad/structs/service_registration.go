@@ -91,6 +91,11 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// ChecksFailing is set by the client when one or more of the Nomad checks
+	// defined on the service are failing. Such registrations are still listed
+	// by the API, but are excluded from DNS answers.
+	ChecksFailing bool
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -142,6 +147,9 @@ func (s *ServiceRegistration) Equal(o *ServiceRegistration) bool {
 	if s.Port != o.Port {
 		return false
 	}
+	if s.ChecksFailing != o.ChecksFailing {
+		return false
+	}
 	if !helper.SliceSetEq(s.Tags, o.Tags) {
 		return false
 	}
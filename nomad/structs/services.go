@@ -342,7 +342,7 @@ func (sc *ServiceCheck) validateCommon(allowableTypes []string) error {
 
 // validate a Service's ServiceCheck in the context of the Nomad provider.
 func (sc *ServiceCheck) validateNomad() error {
-	allowable := []string{ServiceCheckTCP, ServiceCheckHTTP}
+	allowable := []string{ServiceCheckTCP, ServiceCheckHTTP, ServiceCheckGRPC, ServiceCheckScript}
 	if err := sc.validateCommon(allowable); err != nil {
 		return err
 	}
@@ -393,12 +393,26 @@ func (sc *ServiceCheck) validateNomad() error {
 		return errors.New("failures_before_warning may only be set for Consul service checks")
 	}
 
-	// tls_server_name is consul only
+	if sc.Type == ServiceCheckGRPC {
+		// tls_server_name and tls_skip_verify only apply to grpc checks
+		// using tls
+		if !sc.GRPCUseTLS && (sc.TLSServerName != "" || sc.TLSSkipVerify) {
+			return errors.New("tls_server_name and tls_skip_verify may only be set for grpc checks with grpc_use_tls enabled")
+		}
+		return nil
+	}
+
+	// grpc_service and grpc_use_tls only apply to grpc checks
+	if sc.GRPCService != "" || sc.GRPCUseTLS {
+		return errors.New("grpc_service and grpc_use_tls may only be set for grpc checks")
+	}
+
+	// tls_server_name is consul only, outside of grpc checks
 	if sc.TLSServerName != "" {
 		return errors.New("tls_server_name may only be set for Consul service checks")
 	}
 
-	// tls_skip_verify is consul only
+	// tls_skip_verify is consul only, outside of grpc checks
 	if sc.TLSSkipVerify {
 		return errors.New("tls_skip_verify may only be set for Consul service checks")
 	}
@@ -254,8 +254,17 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 		sc   *ServiceCheck
 		exp  string
 	}{
-		{name: "grpc", sc: &ServiceCheck{Type: ServiceCheckGRPC}, exp: `invalid check type ("grpc"), must be one of tcp, http`},
-		{name: "script", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `invalid check type ("script"), must be one of tcp, http`},
+		{name: "unknown", sc: &ServiceCheck{Type: "docker"}, exp: `invalid check type ("docker"), must be one of tcp, http, grpc, script`},
+		{name: "script without command", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `script type must have a valid script path`},
+		{
+			name: "script",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckScript,
+				Command:  "/bin/true",
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+		},
 		{
 			name: "expose",
 			sc: &ServiceCheck{
@@ -393,6 +402,47 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 			},
 			exp: `tls_server_name may only be set for Consul service checks`,
 		},
+		{
+			name: "grpc",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckGRPC,
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+		},
+		{
+			name: "grpc with tls",
+			sc: &ServiceCheck{
+				Type:          ServiceCheckGRPC,
+				Interval:      3 * time.Second,
+				Timeout:       1 * time.Second,
+				GRPCService:   "foo.Bar",
+				GRPCUseTLS:    true,
+				TLSServerName: "foo",
+				TLSSkipVerify: true,
+			},
+		},
+		{
+			name: "grpc with tls_skip_verify but without tls",
+			sc: &ServiceCheck{
+				Type:          ServiceCheckGRPC,
+				Interval:      3 * time.Second,
+				Timeout:       1 * time.Second,
+				TLSSkipVerify: true,
+			},
+			exp: `tls_server_name and tls_skip_verify may only be set for grpc checks with grpc_use_tls enabled`,
+		},
+		{
+			name: "http with grpc_service",
+			sc: &ServiceCheck{
+				Type:        ServiceCheckHTTP,
+				Interval:    3 * time.Second,
+				Timeout:     1 * time.Second,
+				Path:        "/health",
+				GRPCService: "foo.Bar",
+			},
+			exp: `grpc_service and grpc_use_tls may only be set for grpc checks`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -2159,7 +2209,7 @@ func TestService_validateNomadService(t *testing.T) {
 			},
 			inputErr: &multierror.Error{},
 			expectedOutputErrors: []error{
-				errors.New(`invalid check type (""), must be one of tcp, http`),
+				errors.New(`invalid check type (""), must be one of tcp, http, grpc, script`),
 			},
 			name: "bad nomad check",
 		},
@@ -20,7 +20,9 @@ import (
 	"maps"
 	"math"
 	"net"
+	"net/url"
 	"os"
+	"path"
 	"reflect"
 	"regexp"
 	"slices"
@@ -128,10 +130,18 @@ const (
 	ACLBindingRulesDeleteRequestType             MessageType = 58
 	NodePoolUpsertRequestType                    MessageType = 59
 	NodePoolDeleteRequestType                    MessageType = 60
+	HostVolumeRegisterRequestType                MessageType = 61
+	HostVolumeDeleteRequestType                  MessageType = 62
+	EventSinkRegisterRequestType                 MessageType = 63
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
 	NamespaceDeleteRequestType MessageType = 65
+
+	// The original event sink types (41-43) were removed during the 1.0-beta
+	// series and are still ignored by the FSM, so event sinks use new types.
+	EventSinkDeregisterRequestType MessageType = 66
+	EventSinkProgressRequestType   MessageType = 67
 )
 
 const (
@@ -180,6 +190,10 @@ const (
 	// maxNamespaceDescriptionLength limits a namespace description length
 	maxNamespaceDescriptionLength = 256
 
+	// maxNamespaceWeight limits the weight of a namespace for fair share
+	// queueing of evaluations
+	maxNamespaceWeight = 1000
+
 	// JitterFraction is a the limit to the amount of jitter we apply
 	// to a user specified MaxQueryTime. We divide the specified time by
 	// the fraction. So 16 == 6.25% limit of jitter. This jitter is also
@@ -942,6 +956,9 @@ type JobDispatchRequest struct {
 	Meta    map[string]string
 	WriteRequest
 	IdPrefixTemplate string
+
+	// Array dispatches a single job array rather than a single work item.
+	Array *JobArray
 }
 
 // JobValidateRequest is used to validate a job
@@ -3105,7 +3122,7 @@ func (r *RequestedDevice) Validate() error {
 	for idx, constr := range r.Constraints {
 		// Ensure that the constraint doesn't use an operand we do not allow
 		switch constr.Operand {
-		case ConstraintDistinctHosts, ConstraintDistinctProperty:
+		case ConstraintDistinctHosts, ConstraintDistinctProperty, ConstraintColocatedWith, ConstraintNotColocatedWith:
 			outer := fmt.Errorf("Constraint %d validation failed: using unsupported operand %q", idx+1, constr.Operand)
 			_ = multierror.Append(&mErr, outer)
 		default:
@@ -3116,7 +3133,10 @@ func (r *RequestedDevice) Validate() error {
 		}
 	}
 	for idx, affinity := range r.Affinities {
-		if err := affinity.Validate(); err != nil {
+		if IsAllocOperand(affinity.Operand) {
+			outer := fmt.Errorf("Affinity %d validation failed: using unsupported operand %q", idx+1, affinity.Operand)
+			_ = multierror.Append(&mErr, outer)
+		} else if err := affinity.Validate(); err != nil {
 			outer := fmt.Errorf("Affinity %d validation failed: %s", idx+1, err)
 			_ = multierror.Append(&mErr, outer)
 		}
@@ -4383,6 +4403,11 @@ type Job struct {
 	// can slow down larger jobs if resources are not available.
 	AllAtOnce bool
 
+	// Rebalance opts the job into having its allocations migrated by the
+	// background rebalancer when their placement has become a poor fit for
+	// the scheduler algorithm, spread or affinity goals of the job.
+	Rebalance bool
+
 	// Datacenters contains all the datacenters this job is allowed to span
 	Datacenters []string
 
@@ -4406,6 +4431,10 @@ type Job struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// DependsOn is the set of jobs which must reach a terminal status before
+	// evaluations of this job are processed by the schedulers.
+	DependsOn []*JobDependency
+
 	// TaskGroups are the collections of task groups that this job needs
 	// to run. Each task group is an atomic unit of scheduling and placement.
 	TaskGroups []*TaskGroup
@@ -4431,6 +4460,10 @@ type Job struct {
 	// non-terminal siblings which have the same token value.
 	DispatchIdempotencyToken string
 
+	// Array is the array of work items of a job dispatched as a job array.
+	// Each task group has one allocation per array index.
+	Array *JobArray
+
 	// Payload is the payload supplied when the job was dispatched.
 	Payload []byte
 
@@ -4570,6 +4603,10 @@ func (j *Job) Canonicalize() {
 	if j.Periodic != nil {
 		j.Periodic.Canonicalize()
 	}
+
+	for _, d := range j.DependsOn {
+		d.Canonicalize(j)
+	}
 }
 
 // Copy returns a deep copy of the Job. It is expected that callers use recover.
@@ -4583,6 +4620,7 @@ func (j *Job) Copy() *Job {
 	nj.Datacenters = slices.Clone(nj.Datacenters)
 	nj.Constraints = CopySliceConstraints(nj.Constraints)
 	nj.Affinities = CopySliceAffinities(nj.Affinities)
+	nj.DependsOn = CopySliceJobDependencies(nj.DependsOn)
 	nj.Multiregion = nj.Multiregion.Copy()
 
 	if j.TaskGroups != nil {
@@ -4596,6 +4634,7 @@ func (j *Job) Copy() *Job {
 	nj.Periodic = nj.Periodic.Copy()
 	nj.Meta = maps.Clone(nj.Meta)
 	nj.ParameterizedJob = nj.ParameterizedJob.Copy()
+	nj.Array = nj.Array.Copy()
 	return nj
 }
 
@@ -4661,6 +4700,36 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if j.Rebalance && j.Type != JobTypeService {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow rebalance", j.Type))
+	}
+
+	if j.Array != nil {
+		if j.Type != JobTypeBatch {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow job arrays", j.Type))
+		}
+		if err := j.Array.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
+	dependencies := make(map[NamespacedID]struct{}, len(j.DependsOn))
+	for idx, d := range j.DependsOn {
+		if err := d.Validate(); err != nil {
+			outer := fmt.Errorf("Dependency %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+			continue
+		}
+		id := d.NamespacedID(j.Namespace)
+		if id.ID == j.ID && id.Namespace == j.Namespace {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job may not depend on itself"))
+		}
+		if _, ok := dependencies[id]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Duplicate dependency on job %q", d.JobID))
+		}
+		dependencies[id] = struct{}{}
+	}
+
 	if j.Type == JobTypeSystem {
 		if j.Spreads != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a spread block"))
@@ -4689,6 +4758,14 @@ func (j *Job) Validate() error {
 			mErr.Errors = append(mErr.Errors, errors.New("ShutdownDelay must be a positive value"))
 		}
 
+		if tg.MaxRuntime != nil && *tg.MaxRuntime != 0 {
+			if *tg.MaxRuntime < 0 {
+				mErr.Errors = append(mErr.Errors, errors.New("MaxRuntime must be a positive value"))
+			} else if j.Type != JobTypeBatch && j.Type != JobTypeSysBatch {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow max_runtime", j.Type))
+			}
+		}
+
 		if tg.StopAfterClientDisconnect != nil && *tg.StopAfterClientDisconnect != 0 {
 			if *tg.StopAfterClientDisconnect > 0 &&
 				!(j.Type == JobTypeBatch || j.Type == JobTypeService) {
@@ -4946,6 +5023,48 @@ func (j *Job) Vault() map[string]map[string]*Vault {
 	return blocks
 }
 
+// AllocSelectorNamespaces returns the namespaces, other than the namespace of
+// the job, whose allocations are selected by the colocated_with and
+// not_colocated_with constraints and affinities of the job.
+func (j *Job) AllocSelectorNamespaces() []string {
+	var constraints []*Constraint
+	var affinities []*Affinity
+	constraints = append(constraints, j.Constraints...)
+	affinities = append(affinities, j.Affinities...)
+	for _, tg := range j.TaskGroups {
+		constraints = append(constraints, tg.Constraints...)
+		affinities = append(affinities, tg.Affinities...)
+		for _, task := range tg.Tasks {
+			constraints = append(constraints, task.Constraints...)
+			affinities = append(affinities, task.Affinities...)
+		}
+	}
+
+	targets := make([]string, 0, len(constraints)+len(affinities))
+	for _, c := range constraints {
+		if IsAllocOperand(c.Operand) {
+			targets = append(targets, c.RTarget)
+		}
+	}
+	for _, a := range affinities {
+		if IsAllocOperand(a.Operand) {
+			targets = append(targets, a.RTarget)
+		}
+	}
+
+	var namespaces []string
+	for _, target := range targets {
+		selector, err := ParseAllocSelector(target)
+		if err != nil || selector.Namespace == "" || selector.Namespace == j.Namespace {
+			continue
+		}
+		if !slices.Contains(namespaces, selector.Namespace) {
+			namespaces = append(namespaces, selector.Namespace)
+		}
+	}
+	return namespaces
+}
+
 // ConnectTasks returns the set of Consul Connect enabled tasks defined on the
 // job that will require a Service Identity token in the case that Consul ACLs
 // are enabled. The TaskKind.Value is the name of the Consul service.
@@ -5050,6 +5169,185 @@ func (j *Job) SetSubmitTime() {
 	j.SubmitTime = time.Now().UTC().UnixNano()
 }
 
+// MaxJobArraySize is the maximum number of indexes of a job array.
+const MaxJobArraySize = 100_000
+
+// JobArray is the range of work items of a job dispatched as a job array.
+// Each task group of the job has one allocation per array index, which is
+// exposed to its tasks as NOMAD_ARRAY_INDEX.
+type JobArray struct {
+	// Start and End are the first and last array indexes, inclusive.
+	Start int
+	End   int
+
+	// MaxParallel is the maximum number of allocations of each task group
+	// which may be running at the same time. Zero means no limit.
+	MaxParallel int
+}
+
+func (a *JobArray) Copy() *JobArray {
+	if a == nil {
+		return nil
+	}
+	na := new(JobArray)
+	*na = *a
+	return na
+}
+
+// Size returns the number of indexes of the array.
+func (a *JobArray) Size() int {
+	return a.End - a.Start + 1
+}
+
+// Index returns the array index of the allocation with the given index.
+func (a *JobArray) Index(allocIndex uint) int {
+	return a.Start + int(allocIndex)
+}
+
+func (a *JobArray) Validate() error {
+	var mErr multierror.Error
+	if a.Start < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Array start must not be negative: %d", a.Start))
+	}
+	if a.End < a.Start {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Array end %d is lower than its start %d", a.End, a.Start))
+	} else if a.Size() > MaxJobArraySize {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Array size %d exceeds the maximum of %d", a.Size(), MaxJobArraySize))
+	}
+	if a.MaxParallel < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Array max parallel must not be negative: %d", a.MaxParallel))
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// JobDependencyStatusComplete requires every allocation of the current
+	// version of the dependency to have completed successfully.
+	JobDependencyStatusComplete = "complete"
+
+	// JobDependencyStatusFailed requires an allocation of the current version
+	// of the dependency to have failed or been lost.
+	JobDependencyStatusFailed = "failed"
+
+	// JobDependencyStatusDead only requires the dependency to be dead.
+	JobDependencyStatusDead = "dead"
+)
+
+// JobDependency is a job which must reach a terminal status before the
+// evaluations of the job depending on it are processed.
+type JobDependency struct {
+	// JobID is the ID of the job depended on.
+	JobID string
+
+	// Namespace is the namespace of the job depended on. It defaults to the
+	// namespace of the dependent job.
+	Namespace string
+
+	// Status is the terminal status the job depended on must reach.
+	Status string
+}
+
+// CopySliceJobDependencies returns a deep copy of the dependencies.
+func CopySliceJobDependencies(s []*JobDependency) []*JobDependency {
+	l := len(s)
+	if l == 0 {
+		return nil
+	}
+
+	c := make([]*JobDependency, l)
+	for i, v := range s {
+		c[i] = v.Copy()
+	}
+	return c
+}
+
+func (d *JobDependency) Copy() *JobDependency {
+	if d == nil {
+		return nil
+	}
+	nd := new(JobDependency)
+	*nd = *d
+	return nd
+}
+
+func (d *JobDependency) Canonicalize(job *Job) {
+	if d.Namespace == "" {
+		d.Namespace = job.Namespace
+	}
+	if d.Status == "" {
+		d.Status = JobDependencyStatusComplete
+	}
+}
+
+func (d *JobDependency) String() string {
+	return fmt.Sprintf("%s %s", d.JobID, d.Status)
+}
+
+// DiffID fulfills the DiffableWithID interface.
+func (d *JobDependency) DiffID() string {
+	return d.Namespace + "/" + d.JobID
+}
+
+func (d *JobDependency) Validate() error {
+	var mErr multierror.Error
+	if d.JobID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing dependency job ID"))
+	}
+	switch d.Status {
+	case JobDependencyStatusComplete, JobDependencyStatusFailed, JobDependencyStatusDead, "":
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid dependency status %q", d.Status))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NamespacedID returns the namespaced ID of the job depended on, defaulting
+// to the given namespace of the dependent job.
+func (d *JobDependency) NamespacedID(namespace string) NamespacedID {
+	if d.Namespace != "" {
+		namespace = d.Namespace
+	}
+	return NamespacedID{ID: d.JobID, Namespace: namespace}
+}
+
+// SatisfiedBy returns whether the job depended on and its allocations satisfy
+// the dependency. Only allocations of the current version of the job which
+// have not been replaced are considered.
+func (d *JobDependency) SatisfiedBy(job *Job, allocs []*Allocation) bool {
+	if job == nil || job.Status != JobStatusDead {
+		return false
+	}
+
+	status := d.Status
+	if status == "" {
+		status = JobDependencyStatusComplete
+	}
+	if status == JobDependencyStatusDead {
+		return true
+	}
+
+	var complete, failed int
+	for _, alloc := range allocs {
+		if alloc.NextAllocation != "" || alloc.Job == nil || alloc.Job.Version != job.Version {
+			continue
+		}
+		switch alloc.ClientStatus {
+		case AllocClientStatusComplete:
+			complete++
+		case AllocClientStatusFailed, AllocClientStatusLost:
+			failed++
+		}
+	}
+
+	switch status {
+	case JobDependencyStatusComplete:
+		return !job.Stop && complete > 0 && failed == 0
+	case JobDependencyStatusFailed:
+		return failed > 0
+	}
+	return false
+}
+
 // JobListStub is used to return a subset of job information
 // for the job list
 type JobListStub struct {
@@ -5369,6 +5667,11 @@ type Namespace struct {
 	// Meta is the set of metadata key/value pairs that attached to the namespace
 	Meta map[string]string
 
+	// Weight is the share of the evaluation broker the namespace receives
+	// relative to other namespaces when fair share queueing is enabled. Zero
+	// is treated as the default weight of 1.
+	Weight int
+
 	// Hash is the hash of the namespace which is used to efficiently replicate
 	// cross-regions.
 	Hash []byte
@@ -5419,6 +5722,10 @@ func (n *Namespace) Validate() error {
 		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
 		mErr.Errors = append(mErr.Errors, err)
 	}
+	if n.Weight < 0 || n.Weight > maxNamespaceWeight {
+		err := fmt.Errorf("weight must be between 0 and %d", maxNamespaceWeight)
+		mErr.Errors = append(mErr.Errors, err)
+	}
 
 	err := n.NodePoolConfiguration.Validate()
 	switch e := err.(type) {
@@ -5503,6 +5810,12 @@ func (n *Namespace) SetHash() []byte {
 		}
 	}
 
+	// only hash a weight that is set, so the hash of existing namespaces is
+	// unchanged
+	if n.Weight != 0 {
+		_, _ = hash.Write([]byte(strconv.Itoa(n.Weight)))
+	}
+
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
 	for k := range n.Meta {
@@ -5617,6 +5930,36 @@ const (
 	// PeriodicSpecTest is only used by unit tests. It is a sorted, comma
 	// separated list of unix timestamps at which to launch.
 	PeriodicSpecTest = "_internal_test"
+
+	// periodicDateFormat is the format of dates in periodic exclusions and
+	// bounds, which cover the whole day.
+	periodicDateFormat = "2006-01-02"
+
+	// periodicDateTimeFormat is the format of times in periodic exclusions and
+	// bounds without an explicit offset, interpreted in the job time zone.
+	periodicDateTimeFormat = "2006-01-02T15:04:05"
+
+	// PeriodicCatchupNone skips the launches missed while no leader was
+	// running the periodic dispatcher.
+	PeriodicCatchupNone = "none"
+
+	// PeriodicCatchupLatest runs a single launch if any launch was missed
+	// while no leader was running the periodic dispatcher.
+	PeriodicCatchupLatest = "latest"
+
+	// PeriodicCatchupAll runs every launch missed within the catch-up window
+	// while no leader was running the periodic dispatcher.
+	PeriodicCatchupAll = "all"
+
+	// periodicMaxExcludedLaunches bounds the number of excluded launches
+	// skipped while computing the next launch, so that exclusions matching
+	// every launch don't loop forever.
+	periodicMaxExcludedLaunches = 10_000
+
+	// periodicMaxCatchupLaunches bounds the number of missed launches run to
+	// catch up with the schedule, so that a frequent schedule doesn't flood
+	// the cluster with evaluations after a long outage.
+	periodicMaxCatchupLaunches = 100
 )
 
 // Periodic defines the interval a job should be run at.
@@ -5645,16 +5988,55 @@ type PeriodicConfig struct {
 	// Reference: https://www.iana.org/time-zones
 	TimeZone string
 
+	// Exclude is the list of exclusions, such as holidays or maintenance
+	// windows, during which launches are skipped.
+	Exclude []*PeriodicExclusion
+
+	// StartAfter and EndBefore bound the launches of the job. They are either
+	// a date, an RFC 3339 timestamp, or a timestamp without offset which is
+	// interpreted in the time zone of the job.
+	StartAfter string
+	EndBefore  string
+
+	// Catchup is the policy for the launches missed while no leader was
+	// running the periodic dispatcher. An empty policy is equivalent to
+	// PeriodicCatchupLatest.
+	Catchup string
+
+	// CatchupWindow is how far back missed launches are run when Catchup is
+	// PeriodicCatchupAll.
+	CatchupWindow time.Duration
+
 	// location is the time zone to evaluate the launch time against
 	location *time.Location
 }
 
+// PeriodicExclusion excludes launches of a periodic job, either within a
+// range of dates and times or matching a cron expression.
+type PeriodicExclusion struct {
+	// Cron excludes the launches matching the cron expression.
+	Cron string
+
+	// Start and End exclude the launches within the range, with the same
+	// format as the periodic bounds. A date for End includes the whole day.
+	Start string
+	End   string
+}
+
 func (p *PeriodicConfig) Copy() *PeriodicConfig {
 	if p == nil {
 		return nil
 	}
 	np := new(PeriodicConfig)
 	*np = *p
+	np.Specs = slices.Clone(p.Specs)
+	if p.Exclude != nil {
+		np.Exclude = make([]*PeriodicExclusion, len(p.Exclude))
+		for i, e := range p.Exclude {
+			ne := *e
+			np.Exclude[i] = &ne
+		}
+	}
 	return np
 }
 
@@ -5699,9 +6081,100 @@ func (p *PeriodicConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Unknown periodic specification type %q", p.SpecType))
 	}
 
+	for i, e := range p.Exclude {
+		if err := e.validate(); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Exclusion %d invalid: %v", i+1, err))
+		}
+	}
+
+	startAfter, _, err := parsePeriodicTime(p.StartAfter, time.UTC)
+	if err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid start_after: %v", err))
+	}
+	endBefore, _, err := parsePeriodicTime(p.EndBefore, time.UTC)
+	if err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid end_before: %v", err))
+	}
+	if !startAfter.IsZero() && !endBefore.IsZero() && !startAfter.Before(endBefore) {
+		_ = multierror.Append(&mErr, fmt.Errorf("start_after must be before end_before"))
+	}
+
+	switch p.Catchup {
+	case "", PeriodicCatchupNone, PeriodicCatchupLatest:
+		if p.CatchupWindow != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("catchup_window may only be set when catchup is %q", PeriodicCatchupAll))
+		}
+	case PeriodicCatchupAll:
+		if p.CatchupWindow <= 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("catchup_window must be positive when catchup is %q", PeriodicCatchupAll))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown catchup policy %q", p.Catchup))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
+func (e *PeriodicExclusion) validate() error {
+	if e.Cron != "" {
+		if e.Start != "" || e.End != "" {
+			return fmt.Errorf("cron may not be combined with start and end")
+		}
+		if _, err := cronexpr.Parse(e.Cron); err != nil {
+			return fmt.Errorf("invalid cron spec %q: %v", e.Cron, err)
+		}
+		return nil
+	}
+
+	if e.Start == "" || e.End == "" {
+		return fmt.Errorf("must specify either cron or both start and end")
+	}
+	start, end, err := e.rangeIn(time.UTC)
+	if err != nil {
+		return err
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("start must be before end")
+	}
+	return nil
+}
+
+// rangeIn returns the range of the exclusion in the location. A date for
+// the end of the range includes the whole day.
+func (e *PeriodicExclusion) rangeIn(loc *time.Location) (time.Time, time.Time, error) {
+	start, _, err := parsePeriodicTime(e.Start, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start: %v", err)
+	}
+	end, isDate, err := parsePeriodicTime(e.End, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end: %v", err)
+	}
+	if isDate {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end, nil
+}
+
+// parsePeriodicTime parses a date or timestamp in the location, returning
+// whether the value is a date. The zero time is returned for an empty value.
+func parsePeriodicTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	if t, err := time.ParseInLocation(periodicDateFormat, value, loc); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.ParseInLocation(periodicDateTimeFormat, value, loc); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%q must be a date (YYYY-MM-DD) or a timestamp (YYYY-MM-DDTHH:MM:SS, with an optional offset)", value)
+	}
+	return t, false, nil
+}
+
 func (p *PeriodicConfig) Canonicalize() {
 	// Load the location
 	l, err := time.LoadLocation(p.TimeZone)
@@ -5729,10 +6202,99 @@ func CronParseNext(fromTime time.Time, spec string) (t time.Time, err error) {
 }
 
 // Next returns the closest time instant matching the spec that is after the
-// passed time. If no matching instance exists, the zero value of time.Time is
-// returned. The `time.Location` of the returned value matches that of the
-// passed time.
+// passed time, within the bounds and not excluded. If no matching instance
+// exists, the zero value of time.Time is returned. The `time.Location` of the
+// returned value matches that of the passed time.
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
+	loc := p.GetLocation()
+	startAfter, _, err := parsePeriodicTime(p.StartAfter, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	endBefore, _, err := parsePeriodicTime(p.EndBefore, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !startAfter.IsZero() && fromTime.Before(startAfter) {
+		fromTime = startAfter.Add(-time.Nanosecond).In(fromTime.Location())
+	}
+
+	exclusions, err := p.compileExclusions(loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for i := 0; i < periodicMaxExcludedLaunches; i++ {
+		next, err := p.next(fromTime)
+		if err != nil || next.IsZero() {
+			return next, err
+		}
+		if !endBefore.IsZero() && !next.Before(endBefore) {
+			return time.Time{}, nil
+		}
+
+		resume, excluded := exclusions.excluded(next)
+		if !excluded {
+			return next, nil
+		}
+		fromTime = resume.In(fromTime.Location())
+	}
+
+	return time.Time{}, fmt.Errorf("failed to find a launch which is not excluded after %d attempts", periodicMaxExcludedLaunches)
+}
+
+// periodicExclusions is the compiled form of the exclusions of a periodic
+// job.
+type periodicExclusions struct {
+	crons  []*cronexpr.Expression
+	ranges [][2]time.Time
+}
+
+func (p *PeriodicConfig) compileExclusions(loc *time.Location) (*periodicExclusions, error) {
+	exclusions := &periodicExclusions{}
+	for _, e := range p.Exclude {
+		if e.Cron != "" {
+			exp, err := cronexpr.Parse(e.Cron)
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing cron expression: %s: %v", e.Cron, err)
+			}
+			exclusions.crons = append(exclusions.crons, exp)
+			continue
+		}
+
+		start, end, err := e.rangeIn(loc)
+		if err != nil {
+			return nil, err
+		}
+		exclusions.ranges = append(exclusions.ranges, [2]time.Time{start, end})
+	}
+	return exclusions, nil
+}
+
+// excluded returns whether the launch is excluded, and if so the time after
+// which to look for the next launch.
+func (e *periodicExclusions) excluded(launch time.Time) (time.Time, bool) {
+	resume := launch
+	excluded := false
+	for _, exp := range e.crons {
+		if exp.Next(launch.Add(-time.Second)).Equal(launch) {
+			excluded = true
+		}
+	}
+	for _, r := range e.ranges {
+		if !launch.Before(r[0]) && launch.Before(r[1]) {
+			excluded = true
+			if last := r[1].Add(-time.Nanosecond); last.After(resume) {
+				resume = last
+			}
+		}
+	}
+	return resume, excluded
+}
+
+// next returns the closest time instant matching the spec that is after the
+// passed time, regardless of the bounds and exclusions.
+func (p *PeriodicConfig) next(fromTime time.Time) (time.Time, error) {
 	switch p.SpecType {
 	case PeriodicSpecCron:
 		// Single spec parsing
@@ -5781,6 +6343,32 @@ func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// MissedLaunches returns the launches scheduled after lastLaunch and before
+// now which are within the catch-up window. Only the latest launches are
+// returned if more than periodicMaxCatchupLaunches were missed.
+func (p *PeriodicConfig) MissedLaunches(lastLaunch, now time.Time) ([]time.Time, error) {
+	from := lastLaunch.In(p.GetLocation())
+	if windowStart := now.Add(-p.CatchupWindow); windowStart.After(from) {
+		from = windowStart.Add(-time.Nanosecond).In(p.GetLocation())
+	}
+
+	var launches []time.Time
+	for {
+		next, err := p.Next(from)
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() || !next.Before(now) {
+			return launches, nil
+		}
+		if len(launches) == periodicMaxCatchupLaunches {
+			launches = launches[1:]
+		}
+		launches = append(launches, next)
+		from = next
+	}
+}
+
 // GetLocation returns the location to use for determining the time zone to run
 // the periodic job against.
 func (p *PeriodicConfig) GetLocation() *time.Location {
@@ -6609,6 +7197,39 @@ func (m *MigrateStrategy) Validate() error {
 	return mErr.ErrorOrNil()
 }
 
+// DisruptionBudget limits how many allocations of a task group can be
+// unavailable at once before the drainer, preemption and destructive updates
+// stop allocations of the group for voluntary reasons.
+type DisruptionBudget struct {
+	// MaxUnavailable is the maximum number of allocations of the task group
+	// that can be unavailable at once.
+	MaxUnavailable int
+}
+
+func (d *DisruptionBudget) Copy() *DisruptionBudget {
+	if d == nil {
+		return nil
+	}
+	nd := new(DisruptionBudget)
+	*nd = *d
+	return nd
+}
+
+func (d *DisruptionBudget) Validate() error {
+	if d.MaxUnavailable < 1 {
+		return fmt.Errorf("MaxUnavailable must be > 0 but found %d", d.MaxUnavailable)
+	}
+	return nil
+}
+
+// Remaining returns how many more allocations of a task group can be
+// disrupted, given its count and the number of its allocations which are
+// available.
+func (d *DisruptionBudget) Remaining(count, available int) int {
+	unavailable := max(count-available, 0)
+	return max(d.MaxUnavailable-unavailable, 0)
+}
+
 // TaskGroup is an atomic unit of placement. Each task group belongs to
 // a job and may contain any number of tasks. A task group support running
 // in many replicas using the same configuration..
@@ -6626,6 +7247,10 @@ type TaskGroup struct {
 	// Migrate is used to control the migration strategy for this task group
 	Migrate *MigrateStrategy
 
+	// DisruptionBudget limits how many allocations of this task group can be
+	// unavailable at once for voluntary reasons
+	DisruptionBudget *DisruptionBudget
+
 	// Constraints can be specified at a task group level and apply to
 	// all the tasks contained.
 	Constraints []*Constraint
@@ -6679,6 +7304,11 @@ type TaskGroup struct {
 	// group services in consul and stopping tasks.
 	ShutdownDelay *time.Duration
 
+	// MaxRuntime is the maximum amount of time each task of the group may
+	// run before it is killed and restarted as failed, unless the task sets
+	// its own.
+	MaxRuntime *time.Duration
+
 	// StopAfterClientDisconnect, if set, configures the client to stop the task group
 	// after this duration since the last known good heartbeat
 	// To be deprecated after 1.8.0 infavor of Disconnect.StopOnClientAfter
@@ -6694,6 +7324,11 @@ type TaskGroup struct {
 	// To be deprecated after 1.8.0
 	// To be deprecated after 1.8.0 infavor of Disconnect.Replace
 	PreventRescheduleOnLost bool
+
+	// Gang, if set, requires all the allocations of the task group to be
+	// placed at once. If any placement fails, none of the placements of the
+	// group are made and the evaluation is blocked until they all fit.
+	Gang bool
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6703,6 +7338,7 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg := new(TaskGroup)
 	*ntg = *tg
 	ntg.Update = ntg.Update.Copy()
+	ntg.DisruptionBudget = ntg.DisruptionBudget.Copy()
 	ntg.Constraints = CopySliceConstraints(ntg.Constraints)
 	ntg.RestartPolicy = ntg.RestartPolicy.Copy()
 	ntg.Disconnect = ntg.Disconnect.Copy()
@@ -6747,6 +7383,10 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 		ntg.ShutdownDelay = tg.ShutdownDelay
 	}
 
+	if tg.MaxRuntime != nil {
+		ntg.MaxRuntime = tg.MaxRuntime
+	}
+
 	if tg.StopAfterClientDisconnect != nil {
 		ntg.StopAfterClientDisconnect = tg.StopAfterClientDisconnect
 	}
@@ -6876,6 +7516,10 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr = multierror.Append(mErr, errors.New("Task group cannot be configured with both max_client_disconnect and stop_after_client_disconnect"))
 	}
 
+	if tg.Gang && j.Type != JobTypeBatch {
+		mErr = multierror.Append(mErr, fmt.Errorf("Gang scheduling is only supported for batch jobs, not %q", j.Type))
+	}
+
 	if tg.MaxClientDisconnect != nil && *tg.MaxClientDisconnect < 0 {
 		mErr = multierror.Append(mErr, errors.New("max_client_disconnect cannot be negative"))
 	}
@@ -6986,6 +7630,18 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	// Validate the disruption budget
+	if tg.DisruptionBudget != nil {
+		switch j.Type {
+		case JobTypeService, JobTypeBatch:
+			if err := tg.DisruptionBudget.Validate(); err != nil {
+				mErr = multierror.Append(mErr, fmt.Errorf("Disruption budget: %v", err))
+			}
+		default:
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow disruption_budget block", j.Type))
+		}
+	}
+
 	// Check that there is only one leader task if any
 	tasks := make(map[string]int)
 	leaderTasks := 0
@@ -7535,6 +8191,23 @@ type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
 	Disabled      bool
+
+	// Compression is the algorithm rotated log files are compressed with,
+	// either gzip or zstd. Rotated files are kept uncompressed if unset.
+	Compression string
+
+	// RotateInterval is the age at which the current log file is rotated,
+	// even if it has not reached MaxFileSizeMB. Zero disables time-based
+	// rotation.
+	RotateInterval time.Duration
+
+	// Timestamps records the time each log line was written in an index
+	// next to the log files, so logs can be read from or until a time.
+	Timestamps bool
+
+	// Sinks are external destinations the task logs are shipped to, in
+	// addition to being written to the rotated log files.
+	Sinks []*LogSink
 }
 
 func (l *LogConfig) Equal(o *LogConfig) bool {
@@ -7554,6 +8227,22 @@ func (l *LogConfig) Equal(o *LogConfig) bool {
 		return false
 	}
 
+	if l.Compression != o.Compression {
+		return false
+	}
+
+	if l.RotateInterval != o.RotateInterval {
+		return false
+	}
+
+	if l.Timestamps != o.Timestamps {
+		return false
+	}
+
+	if !slices.EqualFunc(l.Sinks, o.Sinks, func(a, b *LogSink) bool { return a.Equal(b) }) {
+		return false
+	}
+
 	return true
 }
 
@@ -7562,9 +8251,13 @@ func (l *LogConfig) Copy() *LogConfig {
 		return nil
 	}
 	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
-		Disabled:      l.Disabled,
+		MaxFiles:       l.MaxFiles,
+		MaxFileSizeMB:  l.MaxFileSizeMB,
+		Disabled:       l.Disabled,
+		Compression:    l.Compression,
+		RotateInterval: l.RotateInterval,
+		Timestamps:     l.Timestamps,
+		Sinks:          helper.CopySlice(l.Sinks),
 	}
 }
 
@@ -7596,6 +8289,156 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 					logUsage, disk.SizeMB))
 		}
 	}
+	switch l.Compression {
+	case "", LogCompressionGzip, LogCompressionZstd:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("unknown log compression %q", l.Compression))
+	}
+	if l.RotateInterval < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("rotate interval must not be negative; got %v", l.RotateInterval))
+	}
+	for i, sink := range l.Sinks {
+		if err := sink.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("sink %d: %v", i+1, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// LogCompressionGzip and LogCompressionZstd are the algorithms rotated
+	// log files can be compressed with.
+	LogCompressionGzip = "gzip"
+	LogCompressionZstd = "zstd"
+)
+
+const (
+	// LogSinkTypeSyslog ships each log line as an RFC5424 syslog message over
+	// UDP, TCP, or a unix datagram socket.
+	LogSinkTypeSyslog = "syslog"
+
+	// LogSinkTypeSocket writes newline delimited log lines to a local unix
+	// socket, such as the socket source of a log forwarder.
+	LogSinkTypeSocket = "socket"
+
+	// LogSinkTypeHTTP posts batches of log lines as JSON to an HTTP endpoint.
+	LogSinkTypeHTTP = "http"
+)
+
+// LogSink is an external destination task logs are shipped to by logmon. Log
+// lines are buffered in memory up to BufferSize and dropped once the buffer
+// is full, so a slow or unavailable sink never blocks the task.
+type LogSink struct {
+	// Type is the type of the sink: syslog, socket, or http.
+	Type string
+
+	// Address is where logs are shipped to. For syslog sinks it is a URL
+	// such as udp://host:514, tcp://host:514, or unix:///dev/log. For socket
+	// sinks it is the path of the unix socket, and for http sinks the URL
+	// log batches are posted to.
+	Address string
+
+	// Tag identifies the task in shipped logs, and is used as the syslog
+	// APP-NAME. Defaults to the task name.
+	Tag string
+
+	// Headers are added to the requests of http sinks.
+	Headers map[string]string
+
+	// BufferSize is the number of log lines buffered for the sink.
+	BufferSize int
+
+	// BatchSize is the maximum number of log lines posted at once to http
+	// sinks.
+	BatchSize int
+
+	// BatchInterval is the maximum time log lines are batched for before
+	// they are posted to http sinks.
+	BatchInterval time.Duration
+}
+
+func (s *LogSink) Copy() *LogSink {
+	if s == nil {
+		return nil
+	}
+	ns := new(LogSink)
+	*ns = *s
+	ns.Headers = maps.Clone(s.Headers)
+	return ns
+}
+
+func (s *LogSink) Equal(o *LogSink) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	switch {
+	case s.Type != o.Type:
+		return false
+	case s.Address != o.Address:
+		return false
+	case s.Tag != o.Tag:
+		return false
+	case !maps.Equal(s.Headers, o.Headers):
+		return false
+	case s.BufferSize != o.BufferSize:
+		return false
+	case s.BatchSize != o.BatchSize:
+		return false
+	case s.BatchInterval != o.BatchInterval:
+		return false
+	}
+	return true
+}
+
+func (s *LogSink) Validate() error {
+	var mErr multierror.Error
+	switch s.Type {
+	case LogSinkTypeSyslog:
+		u, err := url.Parse(s.Address)
+		switch {
+		case err != nil:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid syslog address %q: %v", s.Address, err))
+		case u.Scheme == "udp" || u.Scheme == "tcp":
+			if u.Host == "" {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("syslog address %q is missing a host", s.Address))
+			}
+		case u.Scheme == "unix":
+			if u.Path == "" {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("syslog address %q is missing a path", s.Address))
+			}
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("syslog address %q must use one of the udp, tcp, or unix schemes", s.Address))
+		}
+	case LogSinkTypeSocket:
+		if !path.IsAbs(s.Address) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("socket address %q must be an absolute path", s.Address))
+		}
+	case LogSinkTypeHTTP:
+		u, err := url.Parse(s.Address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("http address %q must be an http or https URL", s.Address))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("unknown sink type %q", s.Type))
+	}
+
+	if s.Type != LogSinkTypeHTTP {
+		if len(s.Headers) > 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("headers are only supported by http sinks"))
+		}
+		if s.BatchSize != 0 || s.BatchInterval != 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("batching is only supported by http sinks"))
+		}
+	}
+	if s.BufferSize < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("buffer size must not be negative"))
+	}
+	if s.BatchSize < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("batch size must not be negative"))
+	}
+	if s.BatchInterval < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("batch interval must not be negative"))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -7673,6 +8516,10 @@ type Task struct {
 	// task from Consul and sending it a signal to shutdown. See #2441
 	ShutdownDelay time.Duration
 
+	// MaxRuntime is the maximum amount of time the task may run before it is
+	// killed and restarted as failed. Zero means no limit.
+	MaxRuntime time.Duration
+
 	// VolumeMounts is a list of Volume name <-> mount configurations that will be
 	// attached to this task.
 	VolumeMounts []*VolumeMount
@@ -7706,6 +8553,15 @@ func (t *Task) UsesCores() bool {
 	return t.Resources.Cores > 0
 }
 
+// GetMaxRuntime returns the maximum amount of time the task may run, falling
+// back to the max runtime of its group. Zero means no limit.
+func (t *Task) GetMaxRuntime(tg *TaskGroup) time.Duration {
+	if t.MaxRuntime == 0 && tg != nil && tg.MaxRuntime != nil {
+		return *tg.MaxRuntime
+	}
+	return t.MaxRuntime
+}
+
 // UsesConnect is for conveniently detecting if the Task is able to make use
 // of Consul Connect features. This will be indicated in the TaskKind of the
 // Task, which exports known types of Tasks. UsesConnect will be true if the
@@ -7929,6 +8785,11 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 	if t.ShutdownDelay < 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("ShutdownDelay must be a positive value"))
 	}
+	if t.MaxRuntime < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("MaxRuntime must be a positive value"))
+	} else if t.MaxRuntime > 0 && jobType != JobTypeBatch && jobType != JobTypeSysBatch {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow max_runtime", jobType))
+	}
 
 	// Validate the resources.
 	if t.Resources == nil {
@@ -7952,7 +8813,7 @@ func (t *Task) Validate(jobType string, tg *TaskGroup) error {
 		}
 
 		switch constr.Operand {
-		case ConstraintDistinctHosts, ConstraintDistinctProperty:
+		case ConstraintDistinctHosts, ConstraintDistinctProperty, ConstraintColocatedWith, ConstraintNotColocatedWith:
 			outer := fmt.Errorf("Constraint %d has disallowed Operand at task level: %s", idx+1, constr.Operand)
 			mErr.Errors = append(mErr.Errors, outer)
 		}
@@ -8985,8 +9846,8 @@ const (
 	// TaskSetup indicates the task runner is setting up the task environment
 	TaskSetup = "Task Setup"
 
-	// TaskDiskExceeded indicates that one of the tasks in a taskgroup has
-	// exceeded the requested disk resources.
+	// TaskDiskExceeded indicates that the allocation of the task has exceeded
+	// the size of its ephemeral disk.
 	TaskDiskExceeded = "Disk Resources Exceeded"
 
 	// TaskSiblingFailed indicates that a sibling task in the task group has
@@ -9031,6 +9892,14 @@ const (
 	// TaskSkippingShutdownDelay indicates that the task operation was
 	// configured to ignore the shutdown delay value set for the tas.
 	TaskSkippingShutdownDelay = "Skipping shutdown delay"
+
+	// TaskMaxRuntimeExceeded indicates that the task has been killed because
+	// it ran for longer than its max runtime.
+	TaskMaxRuntimeExceeded = "Max Runtime Exceeded"
+
+	// TaskResourcesResized indicates that the cpu and memory limits of the
+	// running task have been changed without restarting it.
+	TaskResourcesResized = "Resources Resized"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -9211,6 +10080,14 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		} else {
 			desc = "Task exceeded restart policy"
 		}
+	case TaskDiskExceeded:
+		if e.KillReason != "" {
+			desc = e.KillReason
+		} else if e.DiskLimit != 0 {
+			desc = fmt.Sprintf("Allocation exceeded its ephemeral disk size of %d MB", e.DiskLimit)
+		} else {
+			desc = "Allocation exceeded its ephemeral disk size"
+		}
 	case TaskSiblingFailed:
 		if e.FailedSibling != "" {
 			desc = fmt.Sprintf("Task's sibling %q failed", e.FailedSibling)
@@ -9244,6 +10121,14 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		desc = "Main tasks in the group died"
 	case TaskClientReconnected:
 		desc = "Client reconnected"
+	case TaskMaxRuntimeExceeded:
+		if e.KillReason != "" {
+			desc = e.KillReason
+		} else {
+			desc = "Task exceeded its max runtime"
+		}
+	case TaskResourcesResized:
+		desc = "Task resources resized without restart"
 	default:
 		desc = e.Message
 	}
@@ -9650,8 +10535,76 @@ const (
 	ConstraintSetContainsAny    = "set_contains_any"
 	ConstraintAttributeIsSet    = "is_set"
 	ConstraintAttributeIsNotSet = "is_not_set"
+	ConstraintColocatedWith     = "colocated_with"
+	ConstraintNotColocatedWith  = "not_colocated_with"
 )
 
+// IsAllocOperand returns whether the constraint or affinity operand matches
+// the allocations running on a node, selected by an AllocSelector in the
+// RTarget, rather than the attributes of the node.
+func IsAllocOperand(operand string) bool {
+	return operand == ConstraintColocatedWith || operand == ConstraintNotColocatedWith
+}
+
+// AllocSelector selects the allocations a colocated_with or
+// not_colocated_with constraint or affinity refers to. It is written as a
+// comma separated list of key=value pairs, such as "job=api,group=cache".
+type AllocSelector struct {
+	// Namespace of the job of the allocations. Defaults to the namespace of
+	// the job the constraint or affinity belongs to.
+	Namespace string
+
+	// JobID of the allocations.
+	JobID string
+
+	// TaskGroup of the allocations. All task groups are selected if empty.
+	TaskGroup string
+}
+
+// ParseAllocSelector parses the target of a colocated_with or
+// not_colocated_with constraint or affinity.
+func ParseAllocSelector(target string) (*AllocSelector, error) {
+	selector := new(AllocSelector)
+	seen := make(map[string]struct{})
+	for _, pair := range strings.Split(target, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid allocation selector %q: expected key=value pairs", target)
+		}
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("invalid allocation selector %q: %q defined more than once", target, key)
+		}
+		seen[key] = struct{}{}
+
+		switch key {
+		case "job":
+			selector.JobID = value
+		case "group":
+			selector.TaskGroup = value
+		case "namespace":
+			selector.Namespace = value
+		default:
+			return nil, fmt.Errorf("invalid allocation selector %q: unknown key %q", target, key)
+		}
+	}
+	if selector.JobID == "" {
+		return nil, fmt.Errorf("invalid allocation selector %q: missing job", target)
+	}
+	return selector, nil
+}
+
+// Matches returns whether the selector selects the allocation. The namespace
+// is used when the selector doesn't specify one.
+func (s *AllocSelector) Matches(namespace string, alloc *Allocation) bool {
+	if s.Namespace != "" {
+		namespace = s.Namespace
+	}
+	return alloc.Namespace == namespace &&
+		alloc.JobID == s.JobID &&
+		(s.TaskGroup == "" || alloc.TaskGroup == s.TaskGroup)
+}
+
 // A Constraint is used to restrict placement options.
 type Constraint struct {
 	LTarget string // Left-hand target
@@ -9696,6 +10649,11 @@ func (c *Constraint) Validate() error {
 	switch c.Operand {
 	case ConstraintDistinctHosts:
 		requireLtarget = false
+	case ConstraintColocatedWith, ConstraintNotColocatedWith:
+		requireLtarget = false
+		if _, err := ParseAllocSelector(c.RTarget); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
 	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains:
 		if c.RTarget == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Set contains constraint requires an RTarget"))
@@ -9815,12 +10773,21 @@ func (a *Affinity) Validate() error {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing affinity operand"))
 	}
 
+	// requireLtarget specifies whether the affinity requires an LTarget to be
+	// provided.
+	requireLtarget := true
+
 	// Perform additional validation based on operand
 	switch a.Operand {
 	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains:
 		if a.RTarget == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Set contains operators require an RTarget"))
 		}
+	case ConstraintColocatedWith, ConstraintNotColocatedWith:
+		requireLtarget = false
+		if _, err := ParseAllocSelector(a.RTarget); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
 	case ConstraintRegex:
 		if _, err := regexp.Compile(a.RTarget); err != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Regular expression failed to compile: %v", err))
@@ -9841,8 +10808,8 @@ func (a *Affinity) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Unknown affinity operator %q", a.Operand))
 	}
 
-	// Ensure we have an LTarget
-	if a.LTarget == "" {
+	// Ensure we have an LTarget for the affinities that need one
+	if requireLtarget && a.LTarget == "" {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("No LTarget provided but is required"))
 	}
 
@@ -9870,6 +10837,15 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// MaxSkew is the maximum difference between the number of allocations of
+	// the attribute value with the most allocations and the one with the
+	// fewest. Zero means the skew is unbounded.
+	MaxSkew int
+
+	// Hard makes MaxSkew a placement requirement rather than a preference,
+	// so nodes which would exceed it are infeasible.
+	Hard bool
+
 	// Memoized string representation
 	str string
 }
@@ -9885,6 +10861,10 @@ func (s *Spread) Equal(o *Spread) bool {
 		return false
 	case !slices.EqualFunc(s.SpreadTarget, o.SpreadTarget, func(a, b *SpreadTarget) bool { return a.Equal(b) }):
 		return false
+	case s.MaxSkew != o.MaxSkew:
+		return false
+	case s.Hard != o.Hard:
+		return false
 	}
 	return true
 }
@@ -9930,9 +10910,18 @@ func (s *Spread) String() string {
 		return s.str
 	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
+	if s.MaxSkew > 0 {
+		s.str += fmt.Sprintf(" max_skew=%d hard=%v", s.MaxSkew, s.Hard)
+	}
 	return s.str
 }
 
+// SkewString returns a description of the maximum skew of the spread, used
+// to report nodes filtered because placing on them would exceed it.
+func (s *Spread) SkewString() string {
+	return fmt.Sprintf("spread %s max_skew = %d", s.Attribute, s.MaxSkew)
+}
+
 func (s *Spread) Validate() error {
 	var mErr multierror.Error
 	if s.Attribute == "" {
@@ -9960,6 +10949,15 @@ func (s *Spread) Validate() error {
 	if sumPercent > 100 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Sum of spread target percentages must not be greater than 100%%; got %d%%", sumPercent))
 	}
+	if s.MaxSkew < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread max_skew must not be negative; got %d", s.MaxSkew))
+	}
+	if s.MaxSkew > 0 && len(s.SpreadTarget) > 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Spread max_skew may not be combined with targets"))
+	}
+	if s.Hard && s.MaxSkew <= 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("Hard spread requires a positive max_skew"))
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -10998,6 +11996,15 @@ func (a *Allocation) ClientTerminalStatus() bool {
 	}
 }
 
+// DisruptionAvailable returns if the allocation counts as available against
+// the disruption budget of its task group. An allocation is available if it
+// is running and hasn't been marked unhealthy by a deployment.
+func (a *Allocation) DisruptionAvailable() bool {
+	return !a.TerminalStatus() &&
+		a.ClientStatus == AllocClientStatusRunning &&
+		!a.DeploymentStatus.IsUnhealthy()
+}
+
 // ShouldReschedule returns if the allocation is eligible to be rescheduled according
 // to its status and ReschedulePolicy given its failure time
 func (a *Allocation) ShouldReschedule(reschedulePolicy *ReschedulePolicy, failTime time.Time) bool {
@@ -11757,6 +12764,11 @@ type AllocMetric struct {
 	// This is to prevent creating many failed allocations for a
 	// single task group.
 	CoalescedFailures int
+
+	// GangPlacementsReverted is the number of placements of a gang task group
+	// which found a node, but were reverted because other placements of the
+	// group failed.
+	GangPlacementsReverted int
 }
 
 func (a *AllocMetric) Copy() *AllocMetric {
@@ -12088,6 +13100,12 @@ const (
 	EvalStatusCancelled = "canceled"
 )
 
+const (
+	// EvalStatusDescBlockedOnDependencies is the status description of
+	// evaluations blocked until the dependencies of their job are satisfied.
+	EvalStatusDescBlockedOnDependencies = "blocked on job dependencies"
+)
+
 const (
 	EvalTriggerJobRegister          = "job-register"
 	EvalTriggerJobDeregister        = "job-deregister"
@@ -12106,6 +13124,9 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerDisruptionBudget     = "disruption-budget"
+	EvalTriggerRebalance            = "rebalance"
+	EvalTriggerJobArray             = "job-array"
 )
 
 const (
@@ -12164,6 +13185,11 @@ const (
 	// active key
 	CoreJobVariablesRekey = "variables-rekey"
 
+	// CoreJobRebalance is used to migrate allocations of jobs which opted
+	// into rebalancing when their placement has become a poor fit, such as
+	// after new nodes joined or their spread became skewed.
+	CoreJobRebalance = "rebalance"
+
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
 )
@@ -12266,6 +13292,11 @@ type Evaluation struct {
 	// captured by computed node classes.
 	EscapedComputedClass bool
 
+	// BlockedOnDependencies marks a blocked evaluation as waiting for the
+	// dependencies of its job to be satisfied rather than for capacity. Such
+	// evaluations are unblocked by the dependency watcher.
+	BlockedOnDependencies bool
+
 	// AnnotatePlan triggers the scheduler to provide additional annotations
 	// during the evaluation. This should not be set during normal operations.
 	AnnotatePlan bool
@@ -12455,7 +13486,7 @@ func (e *Evaluation) ShouldEnqueue() bool {
 func (e *Evaluation) ShouldBlock() bool {
 	switch e.Status {
 	case EvalStatusBlocked:
-		return true
+		return !e.BlockedOnDependencies
 	case EvalStatusComplete, EvalStatusFailed, EvalStatusPending, EvalStatusCancelled:
 		return false
 	default:
@@ -12463,6 +13494,17 @@ func (e *Evaluation) ShouldBlock() bool {
 	}
 }
 
+// BlockOnDependencies marks a pending evaluation of a job with dependencies
+// as blocked until the dependency watcher finds them satisfied.
+func (e *Evaluation) BlockOnDependencies(job *Job) {
+	if job == nil || len(job.DependsOn) == 0 || e.Status != EvalStatusPending {
+		return
+	}
+	e.Status = EvalStatusBlocked
+	e.StatusDescription = EvalStatusDescBlockedOnDependencies
+	e.BlockedOnDependencies = true
+}
+
 // MakePlan is used to make a plan from the given evaluation
 // for a given Job
 func (e *Evaluation) MakePlan(j *Job) *Plan {
@@ -12772,6 +13814,46 @@ func (p *Plan) PopUpdate(alloc *Allocation) {
 	}
 }
 
+// RemoveAlloc removes the alloc from the plan allocations.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	removePlanAlloc(p.NodeAllocation, alloc.NodeID, func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+}
+
+// RemoveUpdate removes the alloc from the plan updates, regardless of its
+// position unlike PopUpdate.
+func (p *Plan) RemoveUpdate(alloc *Allocation) {
+	removePlanAlloc(p.NodeUpdate, alloc.NodeID, func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+}
+
+// RemovePreemptions removes the allocations preempted by the given alloc from
+// the plan.
+func (p *Plan) RemovePreemptions(preemptingAllocID string) {
+	for nodeID := range p.NodePreemptions {
+		removePlanAlloc(p.NodePreemptions, nodeID, func(a *Allocation) bool {
+			return a.PreemptedByAllocation == preemptingAllocID
+		})
+	}
+}
+
+// removePlanAlloc removes the allocations of the node matching fn from the
+// map of plan allocations, deleting the node once it has no allocations left.
+func removePlanAlloc(allocs map[string][]*Allocation, nodeID string, fn func(*Allocation) bool) {
+	existing, ok := allocs[nodeID]
+	if !ok {
+		return
+	}
+	existing = slices.DeleteFunc(existing, fn)
+	if len(existing) > 0 {
+		allocs[nodeID] = existing
+	} else {
+		delete(allocs, nodeID)
+	}
+}
+
 // AppendAlloc appends the alloc to the plan allocations.
 // Uses the passed job if explicitly passed, otherwise
 // it is assumed the alloc will use the plan Job version.
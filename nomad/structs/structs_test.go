@@ -63,6 +63,14 @@ func TestNamespace_Validate(t *testing.T) {
 			},
 			Expected: "description longer than",
 		},
+		{
+			Test: "negative weight",
+			Namespace: &Namespace{
+				Name:   "foo",
+				Weight: -1,
+			},
+			Expected: "weight must be between 0 and 1000",
+		},
 		{
 			Test: "valid",
 			Namespace: &Namespace{
@@ -388,6 +396,81 @@ func TestJob_Validate(t *testing.T) {
 				"Task Group web should have an ephemeral disk object",
 			},
 		},
+		{
+			name: "job rebalance for batch job",
+			job: &Job{
+				Type:      JobTypeBatch,
+				Rebalance: true,
+			},
+			expErr: []string{
+				`Job type "batch" does not allow rebalance`,
+			},
+		},
+		{
+			name: "job dependencies",
+			job: &Job{
+				ID:        "report",
+				Namespace: "default",
+				Type:      JobTypeBatch,
+				DependsOn: []*JobDependency{
+					{JobID: "report"},
+					{JobID: "extract"},
+					{JobID: "extract", Namespace: "default"},
+					{JobID: "load", Status: "running"},
+					{},
+				},
+			},
+			expErr: []string{
+				"Job may not depend on itself",
+				`Duplicate dependency on job "extract"`,
+				`Dependency 4 validation failed: 1 error occurred:`,
+				`Invalid dependency status "running"`,
+				`Dependency 5 validation failed: 1 error occurred:`,
+				"Missing dependency job ID",
+			},
+		},
+		{
+			name: "group max runtime for service job",
+			job: &Job{
+				Type: JobTypeService,
+				TaskGroups: []*TaskGroup{
+					{
+						Name:       "web",
+						MaxRuntime: pointer.Of(time.Hour),
+					},
+					{
+						Name:       "api",
+						MaxRuntime: pointer.Of(-time.Hour),
+					},
+				},
+			},
+			expErr: []string{
+				`Job type "service" does not allow max_runtime`,
+				"MaxRuntime must be a positive value",
+			},
+		},
+		{
+			name: "job array for service job",
+			job: &Job{
+				Type:  JobTypeService,
+				Array: &JobArray{Start: 0, End: 9},
+			},
+			expErr: []string{
+				`Job type "service" does not allow job arrays`,
+			},
+		},
+		{
+			name: "invalid job array",
+			job: &Job{
+				Type:  JobTypeBatch,
+				Array: &JobArray{Start: -1, End: -2, MaxParallel: -1},
+			},
+			expErr: []string{
+				"Array start must not be negative: -1",
+				"Array end -2 is lower than its start -1",
+				"Array max parallel must not be negative: -1",
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -398,6 +481,108 @@ func TestJob_Validate(t *testing.T) {
 
 }
 
+func TestJobArray_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	array := &JobArray{Start: 10, End: 19, MaxParallel: 2}
+	must.NoError(t, array.Validate())
+	must.Eq(t, 10, array.Size())
+	must.Eq(t, 10, array.Index(0))
+	must.Eq(t, 19, array.Index(9))
+
+	array = &JobArray{Start: 0, End: MaxJobArraySize}
+	must.ErrorContains(t, array.Validate(), "exceeds the maximum of 100000")
+}
+
+func TestJobDependency_SatisfiedBy(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{Status: JobStatusDead, Version: 2}
+	alloc := func(version uint64, status, next string) *Allocation {
+		return &Allocation{
+			Job:            &Job{Version: version},
+			ClientStatus:   status,
+			NextAllocation: next,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		status    string
+		job       *Job
+		allocs    []*Allocation
+		satisfied bool
+	}{
+		{
+			name:   "missing job",
+			status: JobDependencyStatusDead,
+		},
+		{
+			name:   "running job",
+			status: JobDependencyStatusDead,
+			job:    &Job{Status: JobStatusRunning},
+		},
+		{
+			name:      "dead",
+			status:    JobDependencyStatusDead,
+			job:       job,
+			satisfied: true,
+		},
+		{
+			name:   "complete",
+			status: JobDependencyStatusComplete,
+			job:    job,
+			allocs: []*Allocation{
+				alloc(2, AllocClientStatusComplete, ""),
+				alloc(2, AllocClientStatusFailed, "next"),
+				alloc(1, AllocClientStatusFailed, ""),
+			},
+			satisfied: true,
+		},
+		{
+			name:   "complete with failure",
+			status: JobDependencyStatusComplete,
+			job:    job,
+			allocs: []*Allocation{
+				alloc(2, AllocClientStatusComplete, ""),
+				alloc(2, AllocClientStatusLost, ""),
+			},
+		},
+		{
+			name:   "complete but stopped",
+			status: JobDependencyStatusComplete,
+			job:    &Job{Status: JobStatusDead, Stop: true},
+			allocs: []*Allocation{
+				alloc(0, AllocClientStatusComplete, ""),
+			},
+		},
+		{
+			name:   "failed",
+			status: JobDependencyStatusFailed,
+			job:    job,
+			allocs: []*Allocation{
+				alloc(2, AllocClientStatusComplete, ""),
+				alloc(2, AllocClientStatusFailed, ""),
+			},
+			satisfied: true,
+		},
+		{
+			name:   "failed without failure",
+			status: JobDependencyStatusFailed,
+			job:    job,
+			allocs: []*Allocation{
+				alloc(2, AllocClientStatusComplete, ""),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &JobDependency{JobID: "extract", Status: tc.status}
+			must.Eq(t, tc.satisfied, d.SatisfiedBy(tc.job, tc.allocs))
+		})
+	}
+}
+
 func TestJob_ValidateScaling(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1697,6 +1882,42 @@ func TestTaskGroup_Validate(t *testing.T) {
 			},
 			jobType: JobTypeService,
 		},
+		{
+			name: "gang scheduling for service job",
+			tg: &TaskGroup{
+				Name:  "web",
+				Gang:  true,
+				Tasks: []*Task{{Name: "task-a"}},
+			},
+			expErr: []string{
+				`Gang scheduling is only supported for batch jobs, not "service"`,
+			},
+			jobType: JobTypeService,
+		},
+		{
+			name: "invalid disruption budget",
+			tg: &TaskGroup{
+				Name:             "web",
+				DisruptionBudget: &DisruptionBudget{MaxUnavailable: 0},
+				Tasks:            []*Task{{Name: "task-a"}},
+			},
+			expErr: []string{
+				"Disruption budget: MaxUnavailable must be > 0 but found 0",
+			},
+			jobType: JobTypeService,
+		},
+		{
+			name: "disruption budget for system job",
+			tg: &TaskGroup{
+				Name:             "web",
+				DisruptionBudget: &DisruptionBudget{MaxUnavailable: 1},
+				Tasks:            []*Task{{Name: "task-a"}},
+			},
+			expErr: []string{
+				`Job type "system" does not allow disruption_budget block`,
+			},
+			jobType: JobTypeSystem,
+		},
 	}
 
 	for _, tc := range tests {
@@ -2042,6 +2263,34 @@ func TestTask_Validate(t *testing.T) {
 		"task level: distinct_hosts",
 		"task level: distinct_property",
 	)
+	task.Constraints = nil
+
+	task.MaxRuntime = time.Hour
+	must.NoError(t, task.Validate(JobTypeSysBatch, tg))
+	err = task.Validate(JobTypeService, tg)
+	requireErrors(t, err,
+		`Job type "service" does not allow max_runtime`,
+	)
+
+	task.MaxRuntime = -time.Hour
+	err = task.Validate(JobTypeBatch, tg)
+	requireErrors(t, err,
+		"MaxRuntime must be a positive value",
+	)
+}
+
+func TestTask_GetMaxRuntime(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &Task{}
+	must.Zero(t, task.GetMaxRuntime(nil))
+	must.Zero(t, task.GetMaxRuntime(&TaskGroup{}))
+
+	tg := &TaskGroup{MaxRuntime: pointer.Of(time.Hour)}
+	must.Eq(t, time.Hour, task.GetMaxRuntime(tg))
+
+	task.MaxRuntime = time.Minute
+	must.Eq(t, time.Minute, task.GetMaxRuntime(tg))
 }
 
 func TestTask_Validate_Resources(t *testing.T) {
@@ -2941,6 +3190,37 @@ func TestLogConfig_Equals(t *testing.T) {
 		require.False(t, a.Equal(b))
 	})
 
+	t.Run("compression", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Compression: LogCompressionGzip}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Compression: LogCompressionZstd}
+		require.False(t, a.Equal(b))
+	})
+
+	t.Run("rotate interval", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, RotateInterval: time.Hour}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		require.False(t, a.Equal(b))
+	})
+
+	t.Run("timestamps", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Timestamps: true}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		require.False(t, a.Equal(b))
+	})
+
+	t.Run("sinks", func(t *testing.T) {
+		sink := &LogSink{Type: LogSinkTypeSyslog, Address: "udp://127.0.0.1:514"}
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Sinks: []*LogSink{sink}}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		require.False(t, a.Equal(b))
+
+		b.Sinks = []*LogSink{sink.Copy()}
+		require.True(t, a.Equal(b))
+
+		b.Sinks[0].Tag = "web"
+		require.False(t, a.Equal(b))
+	})
+
 	t.Run("same", func(t *testing.T) {
 		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
@@ -2948,6 +3228,101 @@ func TestLogConfig_Equals(t *testing.T) {
 	})
 }
 
+func TestLogConfig_Validate_Rotation(t *testing.T) {
+	ci.Parallel(t)
+
+	config := DefaultLogConfig()
+	config.Compression = LogCompressionZstd
+	config.RotateInterval = time.Hour
+	must.NoError(t, config.Validate(nil))
+
+	config.Compression = "bzip2"
+	must.ErrorContains(t, config.Validate(nil), `unknown log compression "bzip2"`)
+
+	config.Compression = LogCompressionGzip
+	config.RotateInterval = -time.Minute
+	must.ErrorContains(t, config.Validate(nil), "rotate interval must not be negative")
+}
+
+func TestLogSink_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name string
+		sink *LogSink
+		err  string
+	}{
+		{
+			name: "syslog udp",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "udp://127.0.0.1:514", Tag: "web"},
+		},
+		{
+			name: "syslog unix",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "unix:///dev/log"},
+		},
+		{
+			name: "syslog bad scheme",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "http://127.0.0.1:514"},
+			err:  "must use one of the udp, tcp, or unix schemes",
+		},
+		{
+			name: "syslog missing host",
+			sink: &LogSink{Type: LogSinkTypeSyslog, Address: "tcp://"},
+			err:  "is missing a host",
+		},
+		{
+			name: "socket",
+			sink: &LogSink{Type: LogSinkTypeSocket, Address: "/run/vector.sock", BufferSize: 100},
+		},
+		{
+			name: "socket relative path",
+			sink: &LogSink{Type: LogSinkTypeSocket, Address: "vector.sock"},
+			err:  "must be an absolute path",
+		},
+		{
+			name: "socket headers",
+			sink: &LogSink{Type: LogSinkTypeSocket, Address: "/run/vector.sock", Headers: map[string]string{"a": "b"}},
+			err:  "headers are only supported by http sinks",
+		},
+		{
+			name: "http",
+			sink: &LogSink{
+				Type:          LogSinkTypeHTTP,
+				Address:       "https://logs.example.com/ingest",
+				Headers:       map[string]string{"Authorization": "Bearer secret"},
+				BatchSize:     50,
+				BatchInterval: time.Second,
+			},
+		},
+		{
+			name: "http bad address",
+			sink: &LogSink{Type: LogSinkTypeHTTP, Address: "logs.example.com"},
+			err:  "must be an http or https URL",
+		},
+		{
+			name: "negative buffer size",
+			sink: &LogSink{Type: LogSinkTypeHTTP, Address: "http://127.0.0.1", BufferSize: -1},
+			err:  "buffer size must not be negative",
+		},
+		{
+			name: "unknown type",
+			sink: &LogSink{Type: "kafka", Address: "127.0.0.1:9092"},
+			err:  `unknown sink type "kafka"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.sink.Validate()
+			if tc.err == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
 func TestTask_Validate_CSIPluginConfig(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3419,6 +3794,17 @@ func TestConstraint_Validate(t *testing.T) {
 		t.Fatalf("expected valid constraint: %v", err)
 	}
 
+	// Perform colocated_with and not_colocated_with validation
+	for _, o := range []string{ConstraintColocatedWith, ConstraintNotColocatedWith} {
+		c.Operand = o
+		c.RTarget = "group=cache"
+		err = c.Validate()
+		require.Error(t, err, "missing job")
+
+		c.RTarget = "job=api,group=cache"
+		require.NoError(t, c.Validate())
+	}
+
 	// Perform set_contains* validation
 	c.RTarget = ""
 	for _, o := range []string{ConstraintSetContains, ConstraintSetContainsAll, ConstraintSetContainsAny} {
@@ -3513,6 +3899,21 @@ func TestAffinity_Validate(t *testing.T) {
 			},
 			err: fmt.Errorf("Regular expression failed to compile"),
 		},
+		{
+			affinity: &Affinity{
+				Operand: ConstraintColocatedWith,
+				RTarget: "job",
+				Weight:  50,
+			},
+			err: fmt.Errorf("invalid allocation selector \"job\": expected key=value pairs"),
+		},
+		{
+			affinity: &Affinity{
+				Operand: ConstraintNotColocatedWith,
+				RTarget: "job=db",
+				Weight:  50,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3528,6 +3929,111 @@ func TestAffinity_Validate(t *testing.T) {
 	}
 }
 
+func TestParseAllocSelector(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		target   string
+		expected *AllocSelector
+		err      string
+	}{
+		{
+			name:     "job",
+			target:   "job=api",
+			expected: &AllocSelector{JobID: "api"},
+		},
+		{
+			name:   "all keys",
+			target: "namespace=prod, job=api, group=cache",
+			expected: &AllocSelector{
+				Namespace: "prod",
+				JobID:     "api",
+				TaskGroup: "cache",
+			},
+		},
+		{
+			name:   "missing job",
+			target: "group=cache",
+			err:    "missing job",
+		},
+		{
+			name:   "empty",
+			target: "",
+			err:    "expected key=value pairs",
+		},
+		{
+			name:   "unknown key",
+			target: "job=api,node=foo",
+			err:    `unknown key "node"`,
+		},
+		{
+			name:   "duplicate key",
+			target: "job=api,job=db",
+			err:    `"job" defined more than once`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := ParseAllocSelector(tc.target)
+			if tc.err != "" {
+				must.ErrorContains(t, err, tc.err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, selector)
+		})
+	}
+}
+
+func TestAllocSelector_Matches(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{
+		Namespace: "prod",
+		JobID:     "api",
+		TaskGroup: "cache",
+	}
+
+	must.True(t, (&AllocSelector{JobID: "api"}).Matches("prod", alloc))
+	must.False(t, (&AllocSelector{JobID: "api"}).Matches("default", alloc))
+	must.True(t, (&AllocSelector{Namespace: "prod", JobID: "api"}).Matches("default", alloc))
+	must.True(t, (&AllocSelector{JobID: "api", TaskGroup: "cache"}).Matches("prod", alloc))
+	must.False(t, (&AllocSelector{JobID: "api", TaskGroup: "web"}).Matches("prod", alloc))
+	must.False(t, (&AllocSelector{JobID: "db"}).Matches("prod", alloc))
+}
+
+func TestJob_AllocSelectorNamespaces(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Namespace = "default"
+	must.SliceEmpty(t, job.AllocSelectorNamespaces())
+
+	job.Constraints = append(job.Constraints, &Constraint{
+		Operand: ConstraintColocatedWith,
+		RTarget: "namespace=prod,job=api",
+	}, &Constraint{
+		Operand: ConstraintNotColocatedWith,
+		RTarget: "namespace=default,job=db",
+	})
+	job.TaskGroups[0].Affinities = append(job.TaskGroups[0].Affinities, &Affinity{
+		Operand: ConstraintColocatedWith,
+		RTarget: "namespace=prod,job=cache",
+		Weight:  50,
+	})
+	job.TaskGroups[0].Tasks[0].Constraints = append(job.TaskGroups[0].Tasks[0].Constraints, &Constraint{
+		Operand: ConstraintColocatedWith,
+		RTarget: "namespace=staging,job=api",
+	}, &Constraint{
+		LTarget: "${attr.kernel.name}",
+		Operand: "=",
+		RTarget: "namespace=other",
+	})
+	must.Eq(t, []string{"prod", "staging"}, job.AllocSelectorNamespaces())
+}
+
 func TestUpdateStrategy_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4335,6 +4841,256 @@ func TestPeriodicConfig_DST(t *testing.T) {
 	require.Equal(e2, n2.UTC())
 }
 
+func TestPeriodicConfig_Exclude_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name       string
+		exclude    []*PeriodicExclusion
+		startAfter string
+		endBefore  string
+		errorMsg   string
+	}{
+		{
+			name: "valid",
+			exclude: []*PeriodicExclusion{
+				{Start: "2024-12-24", End: "2024-12-26"},
+				{Start: "2024-06-01T22:00:00", End: "2024-06-02T02:00:00Z"},
+				{Cron: "* * * * 0,6"},
+			},
+			startAfter: "2024-01-01",
+			endBefore:  "2025-01-01T00:00:00+01:00",
+		},
+		{
+			name:     "cron and range",
+			exclude:  []*PeriodicExclusion{{Cron: "* * * * 0", Start: "2024-12-24"}},
+			errorMsg: "Exclusion 1 invalid: cron may not be combined with start and end",
+		},
+		{
+			name:     "invalid cron",
+			exclude:  []*PeriodicExclusion{{Cron: "bad"}},
+			errorMsg: `Exclusion 1 invalid: invalid cron spec "bad"`,
+		},
+		{
+			name:     "missing end",
+			exclude:  []*PeriodicExclusion{{Start: "2024-12-24"}},
+			errorMsg: "must specify either cron or both start and end",
+		},
+		{
+			name:     "invalid date",
+			exclude:  []*PeriodicExclusion{{Start: "24/12/2024", End: "2024-12-26"}},
+			errorMsg: `invalid start: "24/12/2024" must be a date`,
+		},
+		{
+			name:     "reversed range",
+			exclude:  []*PeriodicExclusion{{Start: "2024-12-26", End: "2024-12-24"}},
+			errorMsg: "start must be before end",
+		},
+		{
+			name:       "reversed bounds",
+			startAfter: "2025-01-01",
+			endBefore:  "2024-01-01",
+			errorMsg:   "start_after must be before end_before",
+		},
+		{
+			name:      "invalid bound",
+			endBefore: "tomorrow",
+			errorMsg:  `Invalid end_before: "tomorrow" must be a date`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &PeriodicConfig{
+				Enabled:    true,
+				SpecType:   PeriodicSpecCron,
+				Spec:       "0 9 * * *",
+				Exclude:    tc.exclude,
+				StartAfter: tc.startAfter,
+				EndBefore:  tc.endBefore,
+			}
+			p.Canonicalize()
+			err := p.Validate()
+			if tc.errorMsg == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
+func TestPeriodicConfig_Exclude_Next(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "0 9 * * *",
+		TimeZone: "America/New_York",
+		Exclude: []*PeriodicExclusion{
+			// Weekends
+			{Cron: "* * * * 0,6"},
+			// Christmas, in the job time zone, including the whole end day
+			{Start: "2024-12-24", End: "2024-12-25"},
+			// A maintenance window ending at the launch time
+			{Start: "2024-12-30T00:00:00", End: "2024-12-30T09:00:00"},
+		},
+		StartAfter: "2024-12-20",
+		EndBefore:  "2025-01-01",
+	}
+	p.Canonicalize()
+	must.NoError(t, p.Validate())
+
+	loc := p.GetLocation()
+	from := time.Date(2024, time.December, 1, 0, 0, 0, 0, loc)
+
+	var launches []string
+	for {
+		next, err := p.Next(from)
+		must.NoError(t, err)
+		if next.IsZero() {
+			break
+		}
+		launches = append(launches, next.Format("2006-01-02 15:04"))
+		from = next
+	}
+
+	must.Eq(t, []string{
+		"2024-12-20 09:00",
+		"2024-12-23 09:00",
+		"2024-12-26 09:00",
+		"2024-12-27 09:00",
+		"2024-12-30 09:00",
+		"2024-12-31 09:00",
+	}, launches)
+}
+
+func TestPeriodicConfig_Exclude_All(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "* * * * *",
+		Exclude:  []*PeriodicExclusion{{Cron: "* * * * *"}},
+	}
+	p.Canonicalize()
+
+	_, err := p.Next(time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC))
+	must.ErrorContains(t, err, "failed to find a launch which is not excluded")
+}
+
+func TestPeriodicConfig_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Specs:    []string{"0 9 * * *"},
+		Exclude:  []*PeriodicExclusion{{Cron: "* * * * 0"}},
+	}
+	c := p.Copy()
+	must.Eq(t, p, c)
+
+	c.Specs[0] = "0 10 * * *"
+	c.Exclude[0].Cron = "* * * * 6"
+	must.Eq(t, "0 9 * * *", p.Specs[0])
+	must.Eq(t, "* * * * 0", p.Exclude[0].Cron)
+}
+
+func TestPeriodicConfig_Catchup_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		catchup  string
+		window   time.Duration
+		errorMsg string
+	}{
+		{name: "default"},
+		{name: "none", catchup: PeriodicCatchupNone},
+		{name: "all", catchup: PeriodicCatchupAll, window: time.Hour},
+		{
+			name:     "all without window",
+			catchup:  PeriodicCatchupAll,
+			errorMsg: `catchup_window must be positive when catchup is "all"`,
+		},
+		{
+			name:     "window without all",
+			catchup:  PeriodicCatchupLatest,
+			window:   time.Hour,
+			errorMsg: `catchup_window may only be set when catchup is "all"`,
+		},
+		{
+			name:     "unknown",
+			catchup:  "some",
+			errorMsg: `Unknown catchup policy "some"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &PeriodicConfig{
+				Enabled:       true,
+				SpecType:      PeriodicSpecCron,
+				Spec:          "0 9 * * *",
+				Catchup:       tc.catchup,
+				CatchupWindow: tc.window,
+			}
+			err := p.Validate()
+			if tc.errorMsg == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.errorMsg)
+			}
+		})
+	}
+}
+
+func TestPeriodicConfig_MissedLaunches(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &PeriodicConfig{
+		Enabled:       true,
+		SpecType:      PeriodicSpecCron,
+		Spec:          "0 * * * *",
+		Catchup:       PeriodicCatchupAll,
+		CatchupWindow: 3 * time.Hour,
+	}
+	p.Canonicalize()
+
+	now := time.Date(2024, time.December, 2, 10, 30, 0, 0, time.UTC)
+
+	// Only the launches within the window are returned.
+	launches, err := p.MissedLaunches(now.Add(-24*time.Hour), now)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{
+		time.Date(2024, time.December, 2, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC),
+	}, launches)
+
+	// The launches before the last launch are not returned.
+	launches, err = p.MissedLaunches(time.Date(2024, time.December, 2, 9, 0, 0, 0, time.UTC), now)
+	must.NoError(t, err)
+	must.Eq(t, []time.Time{
+		time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC),
+	}, launches)
+
+	// Nothing was missed since the last launch.
+	launches, err = p.MissedLaunches(time.Date(2024, time.December, 2, 10, 0, 0, 0, time.UTC), now)
+	must.NoError(t, err)
+	must.SliceEmpty(t, launches)
+
+	// Only the latest launches are returned when too many were missed.
+	p.Spec = "* * * * *"
+	launches, err = p.MissedLaunches(now.Add(-24*time.Hour), now)
+	must.NoError(t, err)
+	must.Len(t, periodicMaxCatchupLaunches, launches)
+	must.Eq(t, time.Date(2024, time.December, 2, 10, 29, 0, 0, time.UTC), launches[len(launches)-1])
+}
+
 func TestTaskLifecycleConfig_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5055,6 +5811,40 @@ func TestPlan_AppendPreemptedAllocAppendsAllocWithUpdatedAttrs(t *testing.T) {
 	assert.Equal(t, expectedAlloc, appendedAlloc)
 }
 
+func TestPlan_RemoveAlloc(t *testing.T) {
+	ci.Parallel(t)
+	plan := &Plan{
+		NodeUpdate:      make(map[string][]*Allocation),
+		NodeAllocation:  make(map[string][]*Allocation),
+		NodePreemptions: make(map[string][]*Allocation),
+	}
+
+	alloc1 := MockAlloc()
+	alloc2 := MockAlloc()
+	alloc2.NodeID = alloc1.NodeID
+	plan.AppendAlloc(alloc1, nil)
+	plan.AppendAlloc(alloc2, nil)
+
+	stopped := MockAlloc()
+	plan.AppendStoppedAlloc(stopped, "replaced", "", "")
+
+	preempted := MockAlloc()
+	plan.AppendPreemptedAlloc(preempted, alloc1.ID)
+
+	plan.RemoveAlloc(alloc1)
+	must.Len(t, 1, plan.NodeAllocation[alloc1.NodeID])
+	must.Eq(t, alloc2.ID, plan.NodeAllocation[alloc1.NodeID][0].ID)
+
+	plan.RemoveAlloc(alloc2)
+	must.MapEmpty(t, plan.NodeAllocation)
+
+	plan.RemoveUpdate(stopped)
+	must.MapEmpty(t, plan.NodeUpdate)
+
+	plan.RemovePreemptions(alloc1.ID)
+	must.MapEmpty(t, plan.NodePreemptions)
+}
+
 func TestAllocation_MsgPackTags(t *testing.T) {
 	ci.Parallel(t)
 	planType := reflect.TypeOf(Allocation{})
@@ -5064,6 +5854,21 @@ func TestAllocation_MsgPackTags(t *testing.T) {
 	assert.Equal(t, msgPackTags.Tag, reflect.StructTag(`codec:",omitempty"`))
 }
 
+func TestEvaluation_BlockOnDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	eval := &Evaluation{Status: EvalStatusPending}
+	eval.BlockOnDependencies(&Job{})
+	must.Eq(t, EvalStatusPending, eval.Status)
+	must.True(t, eval.ShouldEnqueue())
+
+	eval.BlockOnDependencies(&Job{DependsOn: []*JobDependency{{JobID: "extract"}}})
+	must.Eq(t, EvalStatusBlocked, eval.Status)
+	must.True(t, eval.BlockedOnDependencies)
+	must.False(t, eval.ShouldEnqueue())
+	must.False(t, eval.ShouldBlock())
+}
+
 func TestEvaluation_MsgPackTags(t *testing.T) {
 	ci.Parallel(t)
 	planType := reflect.TypeOf(Evaluation{})
@@ -5113,6 +5918,43 @@ func TestAllocation_Terminated(t *testing.T) {
 	}
 }
 
+func TestAllocation_DisruptionAvailable(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{
+		DesiredStatus: AllocDesiredStatusRun,
+		ClientStatus:  AllocClientStatusRunning,
+	}
+	must.True(t, alloc.DisruptionAvailable())
+
+	alloc.DeploymentStatus = &AllocDeploymentStatus{Healthy: pointer.Of(true)}
+	must.True(t, alloc.DisruptionAvailable())
+
+	alloc.DeploymentStatus.Healthy = pointer.Of(false)
+	must.False(t, alloc.DisruptionAvailable())
+
+	alloc.DeploymentStatus = nil
+	alloc.ClientStatus = AllocClientStatusPending
+	must.False(t, alloc.DisruptionAvailable())
+
+	alloc.ClientStatus = AllocClientStatusRunning
+	alloc.DesiredStatus = AllocDesiredStatusStop
+	must.False(t, alloc.DisruptionAvailable())
+}
+
+func TestDisruptionBudget_Remaining(t *testing.T) {
+	ci.Parallel(t)
+
+	budget := &DisruptionBudget{MaxUnavailable: 2}
+	must.Eq(t, 2, budget.Remaining(5, 5))
+	must.Eq(t, 1, budget.Remaining(5, 4))
+	must.Eq(t, 0, budget.Remaining(5, 3))
+	must.Eq(t, 0, budget.Remaining(5, 1))
+
+	// Allocations above the count, such as canaries, don't add to the budget
+	must.Eq(t, 2, budget.Remaining(5, 7))
+}
+
 func TestAllocation_ShouldReschedule(t *testing.T) {
 	ci.Parallel(t)
 	type testCase struct {
@@ -6497,6 +7339,8 @@ func TestTaskEventPopulate(t *testing.T) {
 		{NewTaskEvent(TaskNotRestarting).SetRestartReason("Chaos Monkey did it"), "Chaos Monkey did it"},
 		{NewTaskEvent(TaskNotRestarting), "Task exceeded restart policy"},
 		{NewTaskEvent(TaskLeaderDead), "Leader Task in Group dead"},
+		{NewTaskEvent(TaskDiskExceeded), "Allocation exceeded its ephemeral disk size"},
+		{NewTaskEvent(TaskDiskExceeded).SetDiskLimit(300), "Allocation exceeded its ephemeral disk size of 300 MB"},
 		{NewTaskEvent(TaskSiblingFailed), "Task's sibling failed"},
 		{NewTaskEvent(TaskSiblingFailed).SetFailedSibling("patient zero"), "Task's sibling \"patient zero\" failed"},
 		{NewTaskEvent(TaskSignaling), "Task being sent a signal"},
@@ -6936,6 +7780,49 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   -1,
+			},
+			err:  fmt.Errorf("Spread max_skew must not be negative; got -1"),
+			name: "Negative max skew",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "dc1",
+						Percent: 50,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread max_skew may not be combined with targets"),
+			name: "Max skew with targets",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				Hard:      true,
+			},
+			err:  fmt.Errorf("Hard spread requires a positive max_skew"),
+			name: "Hard spread without max skew",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+				Hard:      true,
+			},
+			err:  nil,
+			name: "Valid hard spread",
+		},
 	}
 
 	for _, tc := range testCases {
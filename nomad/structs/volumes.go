@@ -31,6 +31,10 @@ type ClientHostVolumeConfig struct {
 	Name     string `hcl:",key"`
 	Path     string `hcl:"path"`
 	ReadOnly bool   `hcl:"read_only"`
+
+	// ID is set for dynamic host volumes only, which are created via the
+	// HostVolume API instead of the client configuration.
+	ID string `hcl:"-"`
 }
 
 func (p *ClientHostVolumeConfig) Copy() *ClientHostVolumeConfig {
@@ -82,6 +82,7 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
 		caps.DisableLogCollection = resp.Capabilities.DisableLogCollection
 		caps.DynamicWorkloadUsers = resp.Capabilities.DynamicWorkloadUsers
+		caps.ResizeResources = resp.Capabilities.ResizeResources
 	}
 
 	return caps, nil
@@ -183,6 +183,11 @@ type Capabilities struct {
 	// The allocation of a unique, not-in-use UID/GID is managed by Nomad client
 	// ensuring no overlap.
 	DynamicWorkloadUsers bool
+
+	// ResizeResources indicates this driver runs tasks in the cgroup managed
+	// by the Nomad client, so the client can change the cpu and memory limits
+	// of a running task instead of restarting it.
+	ResizeResources bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
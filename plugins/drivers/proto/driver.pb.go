@@ -1857,7 +1857,10 @@ type DriverCapabilities struct {
 	DisableLogCollection bool `protobuf:"varint,8,opt,name=disable_log_collection,json=disableLogCollection,proto3" json:"disable_log_collection,omitempty"`
 	// dynamic_workload_users indicates the task is capable of using UID/GID
 	// assigned from the Nomad client as user credentials for the task.
-	DynamicWorkloadUsers bool     `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	DynamicWorkloadUsers bool `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	// resize_resources indicates the driver runs tasks in the cgroup managed
+	// by the Nomad client, so their resource limits can be changed in place.
+	ResizeResources      bool     `protobuf:"varint,10,opt,name=resize_resources,json=resizeResources,proto3" json:"resize_resources,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1951,6 +1954,13 @@ func (m *DriverCapabilities) GetDynamicWorkloadUsers() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetResizeResources() bool {
+	if m != nil {
+		return m.ResizeResources
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -3768,254 +3778,254 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3945 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
-	0x76, 0x17, 0x08, 0x92, 0x22, 0x1f, 0x25, 0x0a, 0x6a, 0x49, 0x36, 0xcd, 0xd9, 0x64, 0xbc, 0xd8,
-	0x9a, 0x94, 0xb2, 0x3b, 0x43, 0xcf, 0x6a, 0x93, 0xf1, 0xd8, 0xeb, 0x59, 0x0f, 0x87, 0xa2, 0x2d,
-	0xda, 0x12, 0xa5, 0x34, 0xa9, 0x78, 0x1d, 0x27, 0x83, 0x40, 0x40, 0x9b, 0x82, 0x45, 0x02, 0x18,
-	0x34, 0x28, 0x4b, 0x9b, 0x4a, 0x25, 0xb5, 0xa9, 0x4a, 0x6d, 0xaa, 0x92, 0x4a, 0x2e, 0x93, 0xbd,
-	0xe4, 0xb4, 0x55, 0x39, 0xa5, 0x72, 0x4f, 0x6d, 0x6a, 0x4e, 0x39, 0xe4, 0x4b, 0xe4, 0x92, 0x5b,
-	0x6e, 0xa9, 0x7c, 0x82, 0x6c, 0xf5, 0x1f, 0x80, 0x80, 0x48, 0x8f, 0x41, 0xca, 0x27, 0xf2, 0xbd,
-	0xee, 0xfe, 0xf5, 0xc3, 0x7b, 0xaf, 0x5f, 0xbf, 0xee, 0x7e, 0xa0, 0xfb, 0xc3, 0xf1, 0xc0, 0x71,
-	0xe9, 0x1d, 0x3b, 0x70, 0xce, 0x49, 0x40, 0xef, 0xf8, 0x81, 0x17, 0x7a, 0x92, 0x6a, 0x70, 0x02,
-	0x7d, 0x70, 0x6a, 0xd2, 0x53, 0xc7, 0xf2, 0x02, 0xbf, 0xe1, 0x7a, 0x23, 0xd3, 0x6e, 0xc8, 0x31,
-	0x0d, 0x39, 0x46, 0x74, 0xab, 0xff, 0xf6, 0xc0, 0xf3, 0x06, 0x43, 0x22, 0x10, 0x4e, 0xc6, 0x2f,
-	0xef, 0xd8, 0xe3, 0xc0, 0x0c, 0x1d, 0xcf, 0x95, 0xed, 0xef, 0x5f, 0x6d, 0x0f, 0x9d, 0x11, 0xa1,
-	0xa1, 0x39, 0xf2, 0x65, 0x87, 0x0f, 0x22, 0x59, 0xe8, 0xa9, 0x19, 0x10, 0xfb, 0xce, 0xa9, 0x35,
-	0xa4, 0x3e, 0xb1, 0xd8, 0xaf, 0xc1, 0xfe, 0xc8, 0x6e, 0x1f, 0x5e, 0xe9, 0x46, 0xc3, 0x60, 0x6c,
-	0x85, 0x91, 0xe4, 0x66, 0x18, 0x06, 0xce, 0xc9, 0x38, 0x24, 0xa2, 0xb7, 0x7e, 0x0b, 0x6e, 0xf6,
-	0x4d, 0x7a, 0xd6, 0xf2, 0xdc, 0x97, 0xce, 0xa0, 0x67, 0x9d, 0x92, 0x91, 0x89, 0xc9, 0x57, 0x63,
-	0x42, 0x43, 0xfd, 0x8f, 0xa1, 0x36, 0xdd, 0x44, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x0e, 0x79, 0x36,
-	0x65, 0x4d, 0xb9, 0xad, 0x6c, 0x57, 0x76, 0x3e, 0x6c, 0xbc, 0x49, 0x05, 0x42, 0x86, 0x86, 0x14,
-	0xb5, 0xd1, 0xf3, 0x89, 0x85, 0xf9, 0x48, 0x7d, 0x0b, 0x36, 0x5a, 0xa6, 0x6f, 0x9e, 0x38, 0x43,
-	0x27, 0x74, 0x08, 0x8d, 0x26, 0x1d, 0xc3, 0x66, 0x9a, 0x2d, 0x27, 0xfc, 0x13, 0x58, 0xb1, 0x12,
-	0x7c, 0x39, 0xf1, 0xbd, 0x46, 0x26, 0xdd, 0x37, 0x76, 0x39, 0x95, 0x02, 0x4e, 0xc1, 0xe9, 0x9b,
-	0x80, 0x1e, 0x39, 0xee, 0x80, 0x04, 0x7e, 0xe0, 0xb8, 0x61, 0x24, 0xcc, 0x37, 0x2a, 0x6c, 0xa4,
-	0xd8, 0x52, 0x98, 0x57, 0x00, 0xb1, 0x1e, 0x99, 0x28, 0xea, 0x76, 0x65, 0xe7, 0x49, 0x46, 0x51,
-	0x66, 0xe0, 0x35, 0x9a, 0x31, 0x58, 0xdb, 0x0d, 0x83, 0x4b, 0x9c, 0x40, 0x47, 0x5f, 0x42, 0xf1,
-	0x94, 0x98, 0xc3, 0xf0, 0xb4, 0x96, 0xbb, 0xad, 0x6c, 0x57, 0x77, 0x1e, 0x5d, 0x63, 0x9e, 0x3d,
-	0x0e, 0xd4, 0x0b, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xfe, 0x19, 0x36, 0xa1, 0x56,
-	0xe0, 0xf8, 0xcc, 0x25, 0x6b, 0xea, 0x6d, 0x65, 0xbb, 0x8c, 0xd7, 0x45, 0xcb, 0xee, 0xa4, 0xa1,
-	0xee, 0xc3, 0xda, 0x15, 0x69, 0x91, 0x06, 0xea, 0x19, 0xb9, 0xe4, 0x16, 0x29, 0x63, 0xf6, 0x17,
-	0x3d, 0x86, 0xc2, 0xb9, 0x39, 0x1c, 0x13, 0x2e, 0x72, 0x65, 0xe7, 0x87, 0x6f, 0x73, 0x0f, 0xe9,
-	0xa2, 0x13, 0x3d, 0x60, 0x31, 0xfe, 0x7e, 0xee, 0x53, 0x45, 0xbf, 0x07, 0x95, 0x84, 0xdc, 0xa8,
-	0x0a, 0x70, 0xdc, 0xdd, 0x6d, 0xf7, 0xdb, 0xad, 0x7e, 0x7b, 0x57, 0x5b, 0x42, 0xab, 0x50, 0x3e,
-	0xee, 0xee, 0xb5, 0x9b, 0xfb, 0xfd, 0xbd, 0xe7, 0x9a, 0x82, 0x2a, 0xb0, 0x1c, 0x11, 0x39, 0xfd,
-	0x02, 0x10, 0x26, 0x96, 0x77, 0x4e, 0x02, 0xe6, 0xc8, 0xd2, 0xaa, 0xe8, 0x26, 0x2c, 0x87, 0x26,
-	0x3d, 0x33, 0x1c, 0x5b, 0xca, 0x5c, 0x64, 0x64, 0xc7, 0x46, 0x1d, 0x28, 0x9e, 0x9a, 0xae, 0x3d,
-	0x7c, 0xbb, 0xdc, 0x69, 0x55, 0x33, 0xf0, 0x3d, 0x3e, 0x10, 0x4b, 0x00, 0xe6, 0xdd, 0xa9, 0x99,
-	0x85, 0x01, 0xf4, 0xe7, 0xa0, 0xf5, 0x42, 0x33, 0x08, 0x93, 0xe2, 0xb4, 0x21, 0xcf, 0xe6, 0x97,
-	0x1e, 0x3d, 0xcf, 0x9c, 0x62, 0x65, 0x62, 0x3e, 0x5c, 0xff, 0xbf, 0x1c, 0xac, 0x27, 0xb0, 0xa5,
-	0xa7, 0x3e, 0x83, 0x62, 0x40, 0xe8, 0x78, 0x18, 0x72, 0xf8, 0xea, 0xce, 0xc3, 0x8c, 0xf0, 0x53,
-	0x48, 0x0d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x11, 0x06, 0x09, 0x02, 0x2f, 0x30,
-	0x46, 0x74, 0xc0, 0xb5, 0x56, 0xc6, 0x55, 0xc1, 0x6f, 0x33, 0xf6, 0x01, 0x1d, 0x24, 0xb4, 0xaa,
-	0x5e, 0x53, 0xab, 0xc8, 0x04, 0xcd, 0x25, 0xe1, 0x6b, 0x2f, 0x38, 0x33, 0x98, 0x6a, 0x03, 0xc7,
-	0x26, 0xb5, 0x3c, 0x07, 0xfd, 0x24, 0x23, 0x68, 0x57, 0x0c, 0x3f, 0x94, 0xa3, 0xf1, 0x9a, 0x9b,
-	0x66, 0xe8, 0x3f, 0x80, 0xa2, 0xf8, 0x52, 0xe6, 0x49, 0xbd, 0xe3, 0x56, 0xab, 0xdd, 0xeb, 0x69,
-	0x4b, 0xa8, 0x0c, 0x05, 0xdc, 0xee, 0x63, 0xe6, 0x61, 0x65, 0x28, 0x3c, 0x6a, 0xf6, 0x9b, 0xfb,
-	0x5a, 0x4e, 0xff, 0x3e, 0xac, 0x3d, 0x33, 0x9d, 0x30, 0x8b, 0x73, 0xe9, 0x1e, 0x68, 0x93, 0xbe,
-	0xd2, 0x3a, 0x9d, 0x94, 0x75, 0xb2, 0xab, 0xa6, 0x7d, 0xe1, 0x84, 0x57, 0xec, 0xa1, 0x81, 0x4a,
-	0x82, 0x40, 0x9a, 0x80, 0xfd, 0xd5, 0x5f, 0xc3, 0x5a, 0x2f, 0xf4, 0xfc, 0x4c, 0x9e, 0xff, 0x23,
-	0x58, 0x66, 0xbb, 0x8d, 0x37, 0x0e, 0xa5, 0xeb, 0xdf, 0x6a, 0x88, 0xdd, 0xa8, 0x11, 0xed, 0x46,
-	0x8d, 0x5d, 0xb9, 0x5b, 0xe1, 0xa8, 0x27, 0xba, 0x01, 0x45, 0xea, 0x0c, 0x5c, 0x73, 0x28, 0xa3,
-	0x85, 0xa4, 0x74, 0xc4, 0x9c, 0x3c, 0x9a, 0x58, 0x3a, 0x7e, 0x0b, 0xd0, 0x2e, 0xa1, 0x61, 0xe0,
-	0x5d, 0x66, 0x92, 0x67, 0x13, 0x0a, 0x2f, 0xbd, 0xc0, 0x12, 0x0b, 0xb1, 0x84, 0x05, 0xc1, 0x16,
-	0x55, 0x0a, 0x44, 0x62, 0x7f, 0x04, 0xa8, 0xe3, 0xb2, 0x3d, 0x25, 0x9b, 0x21, 0xfe, 0x21, 0x07,
-	0x1b, 0xa9, 0xfe, 0xd2, 0x18, 0x8b, 0xaf, 0x43, 0x16, 0x98, 0xc6, 0x54, 0xac, 0x43, 0x74, 0x08,
-	0x45, 0xd1, 0x43, 0x6a, 0xf2, 0xee, 0x1c, 0x40, 0x62, 0x9b, 0x92, 0x70, 0x12, 0x66, 0xa6, 0xd3,
-	0xab, 0xef, 0xd6, 0xe9, 0x5f, 0x83, 0x16, 0x7d, 0x07, 0x7d, 0xab, 0x6d, 0x9e, 0xc0, 0x86, 0xe5,
-	0x0d, 0x87, 0xc4, 0x62, 0xde, 0x60, 0x38, 0x6e, 0x48, 0x82, 0x73, 0x73, 0xf8, 0x76, 0xbf, 0x41,
-	0x93, 0x51, 0x1d, 0x39, 0x48, 0x7f, 0x01, 0xeb, 0x89, 0x89, 0xa5, 0x21, 0x1e, 0x41, 0x81, 0x32,
-	0x86, 0xb4, 0xc4, 0xc7, 0x73, 0x5a, 0x82, 0x62, 0x31, 0x5c, 0xdf, 0x10, 0xe0, 0xed, 0x73, 0xe2,
-	0xc6, 0x9f, 0xa5, 0xef, 0xc2, 0x7a, 0x8f, 0xbb, 0x69, 0x26, 0x3f, 0x9c, 0xb8, 0x78, 0x2e, 0xe5,
-	0xe2, 0x9b, 0x80, 0x92, 0x28, 0xd2, 0x11, 0x2f, 0x61, 0xad, 0x7d, 0x41, 0xac, 0x4c, 0xc8, 0x35,
-	0x58, 0xb6, 0xbc, 0xd1, 0xc8, 0x74, 0xed, 0x5a, 0xee, 0xb6, 0xba, 0x5d, 0xc6, 0x11, 0x99, 0x5c,
-	0x8b, 0x6a, 0xd6, 0xb5, 0xa8, 0xff, 0x9d, 0x02, 0xda, 0x64, 0x6e, 0xa9, 0x48, 0x26, 0x7d, 0x68,
-	0x33, 0x20, 0x36, 0xf7, 0x0a, 0x96, 0x94, 0xe4, 0x47, 0xe1, 0x42, 0xf0, 0x49, 0x10, 0x24, 0xc2,
-	0x91, 0x7a, 0xcd, 0x70, 0xa4, 0xef, 0xc1, 0x77, 0x22, 0x71, 0x7a, 0x61, 0x40, 0xcc, 0x91, 0xe3,
-	0x0e, 0x3a, 0x87, 0x87, 0x3e, 0x11, 0x82, 0x23, 0x04, 0x79, 0xdb, 0x0c, 0x4d, 0x29, 0x18, 0xff,
-	0xcf, 0x16, 0xbd, 0x35, 0xf4, 0x68, 0xbc, 0xe8, 0x39, 0xa1, 0xff, 0xa7, 0x0a, 0xb5, 0x29, 0xa8,
-	0x48, 0xbd, 0x2f, 0xa0, 0x40, 0x49, 0x38, 0xf6, 0xa5, 0xab, 0xb4, 0x33, 0x0b, 0x3c, 0x1b, 0xaf,
-	0xd1, 0x63, 0x60, 0x58, 0x60, 0xa2, 0x01, 0x94, 0xc2, 0xf0, 0xd2, 0xa0, 0xce, 0xcf, 0xa2, 0x84,
-	0x60, 0xff, 0xba, 0xf8, 0x7d, 0x12, 0x8c, 0x1c, 0xd7, 0x1c, 0xf6, 0x9c, 0x9f, 0x11, 0xbc, 0x1c,
-	0x86, 0x97, 0xec, 0x0f, 0x7a, 0xce, 0x1c, 0xde, 0x76, 0x5c, 0xa9, 0xf6, 0xd6, 0xa2, 0xb3, 0x24,
-	0x14, 0x8c, 0x05, 0x62, 0x7d, 0x1f, 0x0a, 0xfc, 0x9b, 0x16, 0x71, 0x44, 0x0d, 0xd4, 0x30, 0xbc,
-	0xe4, 0x42, 0x95, 0x30, 0xfb, 0x5b, 0x7f, 0x00, 0x2b, 0xc9, 0x2f, 0x60, 0x8e, 0x74, 0x4a, 0x9c,
-	0xc1, 0xa9, 0x70, 0xb0, 0x02, 0x96, 0x14, 0xb3, 0xe4, 0x6b, 0xc7, 0x96, 0x29, 0x6b, 0x01, 0x0b,
-	0x42, 0xff, 0xb7, 0x1c, 0xdc, 0x9a, 0xa1, 0x19, 0xe9, 0xac, 0x2f, 0x52, 0xce, 0xfa, 0x8e, 0xb4,
-	0x10, 0x79, 0xfc, 0x8b, 0x94, 0xc7, 0xbf, 0x43, 0x70, 0xb6, 0x6c, 0x6e, 0x40, 0x91, 0x5c, 0x38,
-	0x21, 0xb1, 0xa5, 0xaa, 0x24, 0x95, 0x58, 0x4e, 0xf9, 0xeb, 0x2e, 0xa7, 0x03, 0xd8, 0x6c, 0x05,
-	0xc4, 0x0c, 0x89, 0x0c, 0xe5, 0x91, 0xff, 0xdf, 0x82, 0x92, 0x39, 0x1c, 0x7a, 0xd6, 0xc4, 0xac,
-	0xcb, 0x9c, 0xee, 0xd8, 0xa8, 0x0e, 0xa5, 0x53, 0x8f, 0x86, 0xae, 0x39, 0x22, 0x32, 0x78, 0xc5,
-	0xb4, 0xfe, 0xb5, 0x02, 0x5b, 0x57, 0xf0, 0xa4, 0x15, 0x4e, 0xa0, 0xea, 0x50, 0x6f, 0xc8, 0x3f,
-	0xd0, 0x48, 0x9c, 0xf0, 0x7e, 0x3c, 0xdf, 0x56, 0xd3, 0x89, 0x30, 0xf8, 0x81, 0x6f, 0xd5, 0x49,
-	0x92, 0xdc, 0xe3, 0xf8, 0xe4, 0xb6, 0x5c, 0xe9, 0x11, 0xa9, 0xff, 0xa3, 0x02, 0x5b, 0x72, 0x87,
-	0xcf, 0xfe, 0xa1, 0xd3, 0x22, 0xe7, 0xde, 0xb5, 0xc8, 0x7a, 0x0d, 0x6e, 0x5c, 0x95, 0x4b, 0xc6,
-	0xfc, 0xff, 0x2d, 0x00, 0x9a, 0x3e, 0x5d, 0xa2, 0xef, 0xc2, 0x0a, 0x25, 0xae, 0x6d, 0x88, 0xfd,
-	0x42, 0x6c, 0x65, 0x25, 0x5c, 0x61, 0x3c, 0xb1, 0x71, 0x50, 0x16, 0x02, 0xc9, 0x85, 0x94, 0xb6,
-	0x84, 0xf9, 0x7f, 0x74, 0x0a, 0x2b, 0x2f, 0xa9, 0x11, 0xcf, 0xcd, 0x1d, 0xaa, 0x9a, 0x39, 0xac,
-	0x4d, 0xcb, 0xd1, 0x78, 0xd4, 0x8b, 0xbf, 0x0b, 0x57, 0x5e, 0xd2, 0x98, 0x40, 0xbf, 0x50, 0xe0,
-	0x66, 0x94, 0x56, 0x4c, 0xd4, 0x37, 0xf2, 0x6c, 0x42, 0x6b, 0xf9, 0xdb, 0xea, 0x76, 0x75, 0xe7,
-	0xe8, 0x1a, 0xfa, 0x9b, 0x62, 0x1e, 0x78, 0x36, 0xc1, 0x5b, 0xee, 0x0c, 0x2e, 0x45, 0x0d, 0xd8,
-	0x18, 0x8d, 0x69, 0x68, 0x08, 0x2f, 0x30, 0x64, 0xa7, 0x5a, 0x81, 0xeb, 0x65, 0x9d, 0x35, 0xa5,
-	0x7c, 0x15, 0x9d, 0xc1, 0xea, 0xc8, 0x1b, 0xbb, 0xa1, 0x61, 0xf1, 0xf3, 0x0f, 0xad, 0x15, 0xe7,
-	0x3a, 0x18, 0xcf, 0xd0, 0xd2, 0x01, 0x83, 0x13, 0xa7, 0x29, 0x8a, 0x57, 0x46, 0x09, 0x8a, 0x19,
-	0x32, 0x20, 0x23, 0x2f, 0x24, 0x06, 0x8b, 0x97, 0xb4, 0xb6, 0x2c, 0x0c, 0x29, 0x78, 0x2c, 0x34,
-	0x50, 0xf4, 0x7b, 0x70, 0xc3, 0x76, 0xa8, 0x79, 0x32, 0x24, 0xc6, 0xd0, 0x1b, 0x18, 0x93, 0x34,
-	0xa7, 0x56, 0xe2, 0x9d, 0x37, 0x65, 0xeb, 0xbe, 0x37, 0x68, 0xc5, 0x6d, 0x7c, 0xd4, 0xa5, 0x6b,
-	0x8e, 0x1c, 0xcb, 0x60, 0x5f, 0x35, 0xf4, 0x4c, 0xdb, 0x18, 0x53, 0x12, 0xd0, 0x5a, 0x59, 0x8e,
-	0x12, 0xad, 0xcf, 0x64, 0xe3, 0x31, 0x6b, 0xd3, 0xef, 0x43, 0x25, 0x61, 0x52, 0x54, 0x82, 0x7c,
-	0xf7, 0xb0, 0xdb, 0xd6, 0x96, 0x10, 0x40, 0xb1, 0xb5, 0x87, 0x0f, 0x0f, 0xfb, 0xe2, 0x84, 0xd2,
-	0x39, 0x68, 0x3e, 0x6e, 0x6b, 0x39, 0xc6, 0x3e, 0xee, 0xfe, 0x61, 0xbb, 0xb3, 0xaf, 0xa9, 0x7a,
-	0x1b, 0x56, 0x92, 0x1f, 0x8a, 0x10, 0x54, 0x8f, 0xbb, 0x4f, 0xbb, 0x87, 0xcf, 0xba, 0xc6, 0xc1,
-	0xe1, 0x71, 0xb7, 0xcf, 0xce, 0x39, 0x55, 0x80, 0x66, 0xf7, 0xf9, 0x84, 0x5e, 0x85, 0x72, 0xf7,
-	0x30, 0x22, 0x95, 0x7a, 0x4e, 0x53, 0xf4, 0xff, 0x50, 0x61, 0x73, 0x96, 0xcd, 0x91, 0x0d, 0x79,
-	0xe6, 0x3f, 0xf2, 0xa4, 0xf9, 0xee, 0xdd, 0x87, 0xa3, 0xb3, 0x65, 0xe3, 0x9b, 0x72, 0x6b, 0x29,
-	0x63, 0xfe, 0x1f, 0x19, 0x50, 0x1c, 0x9a, 0x27, 0x64, 0x48, 0x6b, 0x2a, 0xbf, 0x8b, 0x79, 0x7c,
-	0x9d, 0xb9, 0xf7, 0x39, 0x92, 0xb8, 0x88, 0x91, 0xb0, 0xa8, 0x0f, 0x15, 0x16, 0x3c, 0xa9, 0x50,
-	0x9d, 0x8c, 0xe7, 0x3b, 0x19, 0x67, 0xd9, 0x9b, 0x8c, 0xc4, 0x49, 0x98, 0xfa, 0x3d, 0xa8, 0x24,
-	0x26, 0x9b, 0x71, 0x8f, 0xb2, 0x99, 0xbc, 0x47, 0x29, 0x27, 0x2f, 0x45, 0x1e, 0x4e, 0xdb, 0x80,
-	0xe9, 0x88, 0x39, 0xc4, 0xde, 0x61, 0xaf, 0x2f, 0x4e, 0xac, 0x8f, 0xf1, 0xe1, 0xf1, 0x91, 0xa6,
-	0x30, 0x66, 0xbf, 0xd9, 0x7b, 0xaa, 0xe5, 0x62, 0x7f, 0x51, 0xf5, 0x16, 0x54, 0x12, 0x72, 0xa5,
-	0x76, 0x0b, 0x25, 0xbd, 0x5b, 0xb0, 0x78, 0x6d, 0xda, 0x76, 0x40, 0x28, 0x95, 0x72, 0x44, 0xa4,
-	0xfe, 0x02, 0xca, 0xbb, 0xdd, 0x9e, 0x84, 0xa8, 0xc1, 0x32, 0x25, 0x01, 0xfb, 0x6e, 0x7e, 0x23,
-	0x56, 0xc6, 0x11, 0xc9, 0xc0, 0x29, 0x31, 0x03, 0xeb, 0x94, 0x50, 0x99, 0x63, 0xc4, 0x34, 0x1b,
-	0xe5, 0xf1, 0x9b, 0x25, 0x61, 0xbb, 0x32, 0x8e, 0x48, 0xfd, 0xff, 0x4b, 0x00, 0x93, 0x5b, 0x0e,
-	0x54, 0x85, 0x5c, 0x1c, 0xfb, 0x73, 0x8e, 0xcd, 0xfc, 0x20, 0xb1, 0xb7, 0xf1, 0xff, 0x68, 0x07,
-	0xb6, 0x46, 0x74, 0xe0, 0x9b, 0xd6, 0x99, 0x21, 0x2f, 0x27, 0x44, 0x88, 0xe0, 0x71, 0x74, 0x05,
-	0x6f, 0xc8, 0x46, 0x19, 0x01, 0x04, 0xee, 0x3e, 0xa8, 0xc4, 0x3d, 0xe7, 0x31, 0xaf, 0xb2, 0x73,
-	0x7f, 0xee, 0xdb, 0x97, 0x46, 0xdb, 0x3d, 0x17, 0xbe, 0xc2, 0x60, 0x90, 0x01, 0x60, 0x93, 0x73,
-	0xc7, 0x22, 0x06, 0x03, 0x2d, 0x70, 0xd0, 0xcf, 0xe7, 0x07, 0xdd, 0xe5, 0x18, 0x31, 0x74, 0xd9,
-	0x8e, 0x68, 0xd4, 0x85, 0x72, 0x40, 0xa8, 0x37, 0x0e, 0x2c, 0x22, 0x02, 0x5f, 0xf6, 0x03, 0x12,
-	0x8e, 0xc6, 0xe1, 0x09, 0x04, 0xda, 0x85, 0x22, 0x8f, 0x77, 0x2c, 0xb2, 0xa9, 0xdf, 0x7a, 0x95,
-	0x9b, 0x06, 0xe3, 0x91, 0x04, 0xcb, 0xb1, 0xe8, 0x31, 0x2c, 0x0b, 0x11, 0x69, 0xad, 0xc4, 0x61,
-	0x3e, 0xca, 0x1a, 0x8c, 0xf9, 0x28, 0x1c, 0x8d, 0x66, 0x56, 0x65, 0x41, 0x90, 0xc7, 0xc0, 0x32,
-	0xe6, 0xff, 0xd1, 0x7b, 0x50, 0x16, 0x7b, 0xbf, 0xed, 0x04, 0x35, 0x10, 0xce, 0xc9, 0x19, 0xbb,
-	0x4e, 0x80, 0xde, 0x87, 0x8a, 0xc8, 0xf1, 0x0c, 0x1e, 0x15, 0x2a, 0xbc, 0x19, 0x04, 0xeb, 0x88,
-	0xc5, 0x06, 0xd1, 0x81, 0x04, 0x81, 0xe8, 0xb0, 0x12, 0x77, 0x20, 0x41, 0xc0, 0x3b, 0xfc, 0x0e,
-	0xac, 0xf1, 0xcc, 0x78, 0x10, 0x78, 0x63, 0xdf, 0xe0, 0x3e, 0xb5, 0xca, 0x3b, 0xad, 0x32, 0xf6,
-	0x63, 0xc6, 0xed, 0x32, 0xe7, 0xba, 0x05, 0xa5, 0x57, 0xde, 0x89, 0xe8, 0x50, 0x15, 0xeb, 0xe0,
-	0x95, 0x77, 0x12, 0x35, 0xc5, 0xd9, 0xc9, 0x5a, 0x3a, 0x3b, 0xf9, 0x0a, 0x6e, 0x4c, 0x6f, 0xb3,
-	0x3c, 0x4b, 0xd1, 0xae, 0x9f, 0xa5, 0x6c, 0xba, 0xb3, 0xe2, 0xf0, 0x17, 0xa0, 0xda, 0x2e, 0xad,
-	0xad, 0xcf, 0xe5, 0x1c, 0xf1, 0x3a, 0xc6, 0x6c, 0x30, 0xda, 0x82, 0x22, 0xfb, 0x58, 0xc7, 0xae,
-	0x21, 0x11, 0x7a, 0x5e, 0x79, 0x27, 0x1d, 0x1b, 0x7d, 0x07, 0xca, 0xec, 0xfb, 0xa9, 0x6f, 0x5a,
-	0xa4, 0xb6, 0xc1, 0x5b, 0x26, 0x0c, 0x66, 0x28, 0xd7, 0xb3, 0x89, 0x50, 0xd1, 0xa6, 0x30, 0x14,
-	0x63, 0x70, 0x1d, 0xdd, 0x84, 0x65, 0xde, 0xe8, 0xd8, 0xb5, 0x2d, 0x71, 0x00, 0x61, 0x64, 0xc7,
-	0x46, 0x3a, 0xac, 0xfa, 0x66, 0x40, 0xdc, 0xd0, 0x90, 0x33, 0xde, 0xe0, 0xcd, 0x15, 0xc1, 0x7c,
-	0xc2, 0xe6, 0xad, 0x7f, 0x02, 0xa5, 0x68, 0x31, 0xcc, 0x13, 0x26, 0xeb, 0x0f, 0xa0, 0x9a, 0x5e,
-	0x4a, 0x73, 0x05, 0xd9, 0x7f, 0xce, 0x41, 0x39, 0x5e, 0x34, 0xc8, 0x85, 0x0d, 0x6e, 0x54, 0x96,
-	0xa9, 0x1a, 0x93, 0x35, 0x28, 0xf2, 0xe3, 0xcf, 0x32, 0xaa, 0xb9, 0x19, 0x21, 0xc8, 0x83, 0xba,
-	0x5c, 0x90, 0x28, 0x46, 0x9e, 0xcc, 0xf7, 0x25, 0xac, 0x0d, 0x1d, 0x77, 0x7c, 0x91, 0x98, 0x4b,
-	0x24, 0xb6, 0xbf, 0x9f, 0x71, 0xae, 0x7d, 0x36, 0x7a, 0x32, 0x47, 0x75, 0x98, 0xa2, 0xd1, 0x1e,
-	0x14, 0x7c, 0x2f, 0x08, 0xa3, 0x3d, 0x33, 0xeb, 0x6e, 0x76, 0xe4, 0x05, 0xe1, 0x81, 0xe9, 0xfb,
-	0xec, 0xec, 0x26, 0x00, 0xf4, 0xaf, 0x73, 0x70, 0x63, 0xf6, 0x87, 0xa1, 0x2e, 0xa8, 0x96, 0x3f,
-	0x96, 0x4a, 0x7a, 0x30, 0xaf, 0x92, 0x5a, 0xfe, 0x78, 0x22, 0x3f, 0x03, 0x42, 0xcf, 0xa0, 0x38,
-	0x22, 0x23, 0x2f, 0xb8, 0x94, 0xba, 0x78, 0x38, 0x2f, 0xe4, 0x01, 0x1f, 0x3d, 0x41, 0x95, 0x70,
-	0x08, 0x43, 0x49, 0x2e, 0x26, 0x2a, 0xc3, 0xf6, 0x9c, 0xb7, 0x6b, 0x11, 0x24, 0x8e, 0x71, 0xf4,
-	0x4f, 0x60, 0x6b, 0xe6, 0xa7, 0xa0, 0xdf, 0x02, 0xb0, 0xfc, 0xb1, 0xc1, 0x5f, 0x3f, 0x84, 0x07,
-	0xa9, 0xb8, 0x6c, 0xf9, 0xe3, 0x1e, 0x67, 0xe8, 0x2f, 0xa0, 0xf6, 0x26, 0x79, 0xd9, 0x1a, 0x13,
-	0x12, 0x1b, 0xa3, 0x13, 0xae, 0x03, 0x15, 0x97, 0x04, 0xe3, 0xe0, 0x84, 0x2d, 0xa5, 0xa8, 0xd1,
-	0xbc, 0x60, 0x1d, 0x54, 0xde, 0xa1, 0x22, 0x3b, 0x98, 0x17, 0x07, 0x27, 0xfa, 0x2f, 0x73, 0xb0,
-	0x76, 0x45, 0x64, 0x76, 0x82, 0x15, 0x01, 0x38, 0xba, 0x1b, 0x10, 0x14, 0x8b, 0xc6, 0x96, 0x63,
-	0x47, 0xb7, 0xca, 0xfc, 0x3f, 0xdf, 0x87, 0x7d, 0x79, 0xe3, 0x9b, 0x73, 0x7c, 0xb6, 0x7c, 0x46,
-	0x27, 0x4e, 0x48, 0x79, 0x52, 0x54, 0xc0, 0x82, 0x40, 0xcf, 0xa1, 0x1a, 0x10, 0xbe, 0xff, 0xdb,
-	0x86, 0xf0, 0xb2, 0xc2, 0x5c, 0x5e, 0x26, 0x25, 0x64, 0xce, 0x86, 0x57, 0x23, 0x24, 0x46, 0x51,
-	0xf4, 0x0c, 0x56, 0xa3, 0xc4, 0x59, 0x20, 0x17, 0x17, 0x46, 0x5e, 0x91, 0x40, 0x1c, 0x58, 0xbf,
-	0x07, 0x95, 0x44, 0x23, 0xfb, 0x30, 0x9e, 0xfd, 0x49, 0x9d, 0x08, 0x22, 0x1d, 0x2d, 0x0a, 0x32,
-	0x5a, 0xe8, 0x27, 0x50, 0x49, 0xac, 0x8b, 0x79, 0x86, 0x32, 0x7d, 0x86, 0x1e, 0xd7, 0x67, 0x01,
-	0xe7, 0x42, 0x8f, 0xc5, 0x49, 0x96, 0x79, 0x19, 0x8e, 0xcf, 0x35, 0x5a, 0xc6, 0x45, 0x46, 0x76,
-	0x7c, 0xfd, 0xd7, 0x39, 0xa8, 0xa6, 0x97, 0x74, 0xe4, 0x47, 0x3e, 0x09, 0x1c, 0xcf, 0x4e, 0xf8,
-	0xd1, 0x11, 0x67, 0x30, 0x5f, 0x61, 0xcd, 0x5f, 0x8d, 0xbd, 0xd0, 0x8c, 0x7c, 0xc5, 0xf2, 0xc7,
-	0x7f, 0xc0, 0xe8, 0x2b, 0x3e, 0xa8, 0x5e, 0xf1, 0x41, 0xf4, 0x21, 0x20, 0xe9, 0x4a, 0x43, 0x67,
-	0xe4, 0x84, 0xc6, 0xc9, 0x65, 0x48, 0x84, 0x8d, 0x55, 0xac, 0x89, 0x96, 0x7d, 0xd6, 0xf0, 0x05,
-	0xe3, 0x33, 0xc7, 0xf3, 0xbc, 0x91, 0x41, 0x2d, 0x2f, 0x20, 0x86, 0x69, 0xbf, 0xe2, 0x87, 0x37,
-	0x15, 0x57, 0x3c, 0x6f, 0xd4, 0x63, 0xbc, 0xa6, 0xfd, 0x8a, 0x6d, 0xc4, 0x96, 0x3f, 0xa6, 0x24,
-	0x34, 0xd8, 0x0f, 0xcf, 0x5d, 0xca, 0x18, 0x04, 0xab, 0xe5, 0x8f, 0x29, 0xfa, 0x1e, 0xac, 0x46,
-	0x1d, 0xf8, 0x5e, 0x2c, 0x93, 0x80, 0x15, 0xd9, 0x85, 0xf3, 0x90, 0x0e, 0x2b, 0x47, 0x24, 0xb0,
-	0x88, 0x1b, 0xf6, 0x1d, 0xeb, 0x8c, 0xf2, 0x23, 0x96, 0x82, 0x53, 0xbc, 0x27, 0xf9, 0xd2, 0xb2,
-	0x56, 0xc2, 0xd1, 0x6c, 0x23, 0x32, 0xa2, 0xfa, 0xbf, 0x2a, 0x50, 0xe0, 0x29, 0x0b, 0x53, 0x0a,
-	0xdf, 0xee, 0x79, 0x36, 0x20, 0x53, 0x5d, 0xc6, 0xe0, 0xb9, 0xc0, 0x7b, 0x50, 0xe6, 0xca, 0x4f,
-	0x9c, 0x30, 0x78, 0x1e, 0xcc, 0x1b, 0xeb, 0x50, 0x0a, 0x88, 0x69, 0x7b, 0xee, 0x30, 0xba, 0x14,
-	0x8b, 0x69, 0xf4, 0xbb, 0xa0, 0xf9, 0x81, 0xe7, 0x9b, 0x83, 0xc9, 0x39, 0x5a, 0x9a, 0x6f, 0x2d,
-	0xc1, 0xe7, 0x29, 0xfa, 0xf7, 0x60, 0x95, 0x12, 0x11, 0xd9, 0x85, 0x93, 0x14, 0xc4, 0x67, 0x4a,
-	0x26, 0x3f, 0x11, 0xe8, 0x5f, 0x41, 0x51, 0x6c, 0x5c, 0xd7, 0x90, 0xf7, 0x23, 0x40, 0x42, 0x91,
-	0xcc, 0x41, 0x46, 0x0e, 0xa5, 0x32, 0xcb, 0xe6, 0x2f, 0xbb, 0xa2, 0xe5, 0x68, 0xd2, 0xa0, 0xff,
-	0x97, 0x22, 0xf2, 0x6d, 0xf1, 0xe6, 0xc6, 0x12, 0x73, 0xb6, 0x6a, 0xd8, 0x31, 0x56, 0x5c, 0xee,
-	0x45, 0x24, 0xea, 0x40, 0x51, 0xa6, 0xd5, 0xb9, 0x45, 0x9f, 0x2c, 0x25, 0x40, 0x74, 0xd5, 0x4f,
-	0xe4, 0x45, 0xc7, 0xbc, 0x57, 0xfd, 0x44, 0x5c, 0xf5, 0x13, 0x76, 0x4a, 0x97, 0x09, 0xbf, 0x80,
-	0xcb, 0xf3, 0x7c, 0xbf, 0x62, 0xc7, 0xef, 0x29, 0x44, 0xff, 0x1f, 0x25, 0x8e, 0x7b, 0xd1, 0xbb,
-	0x07, 0xfa, 0x12, 0x4a, 0x2c, 0x84, 0x18, 0x23, 0xd3, 0x97, 0xaf, 0xf8, 0xad, 0xc5, 0x9e, 0x54,
-	0xa2, 0x5d, 0x51, 0xa4, 0xeb, 0xcb, 0xbe, 0xa0, 0x58, 0xfc, 0x64, 0x47, 0xa5, 0x28, 0x7e, 0xb2,
-	0xff, 0xe8, 0x03, 0xa8, 0x9a, 0xe3, 0xd0, 0x33, 0x4c, 0xfb, 0x9c, 0x04, 0xa1, 0x43, 0x89, 0xf4,
-	0xa5, 0x55, 0xc6, 0x6d, 0x46, 0xcc, 0xfa, 0x7d, 0x58, 0x49, 0x62, 0xbe, 0x2d, 0x6f, 0x29, 0x24,
-	0xf3, 0x96, 0x3f, 0x05, 0x98, 0xdc, 0x21, 0x32, 0x1f, 0x21, 0x17, 0x4e, 0x68, 0x58, 0xd1, 0xd9,
-	0xbc, 0x80, 0x4b, 0x8c, 0xd1, 0x62, 0xce, 0x98, 0x7e, 0xe0, 0x28, 0x44, 0x0f, 0x1c, 0x2c, 0x3a,
-	0xb0, 0x05, 0x7d, 0xe6, 0x0c, 0x87, 0xf1, 0xbd, 0x66, 0xd9, 0xf3, 0x46, 0x4f, 0x39, 0x43, 0xff,
-	0x26, 0x27, 0x7c, 0x45, 0x3c, 0x55, 0x65, 0x3a, 0x9b, 0xbd, 0x2b, 0x53, 0xdf, 0x03, 0xa0, 0xa1,
-	0x19, 0xb0, 0x24, 0xcc, 0x8c, 0x6e, 0x56, 0xeb, 0x53, 0x2f, 0x24, 0xfd, 0xa8, 0x76, 0x06, 0x97,
-	0x65, 0xef, 0x66, 0x88, 0x3e, 0x83, 0x15, 0xcb, 0x1b, 0xf9, 0x43, 0x22, 0x07, 0x17, 0xde, 0x3a,
-	0xb8, 0x12, 0xf7, 0x6f, 0x86, 0x89, 0xfb, 0xdc, 0xe2, 0x75, 0xef, 0x73, 0x7f, 0xad, 0x88, 0x17,
-	0xb7, 0xe4, 0x83, 0x1f, 0x1a, 0xcc, 0xa8, 0x2a, 0x79, 0xbc, 0xe0, 0xeb, 0xe1, 0xb7, 0x95, 0x94,
-	0xd4, 0x3f, 0xcb, 0x52, 0xc3, 0xf1, 0xe6, 0xb4, 0xf8, 0xdf, 0x55, 0x28, 0xc7, 0x8f, 0x6d, 0x53,
-	0xb6, 0xff, 0x14, 0xca, 0x71, 0xe1, 0x92, 0x0c, 0x10, 0xdf, 0x6a, 0x9e, 0xb8, 0x33, 0x7a, 0x09,
-	0xc8, 0x1c, 0x0c, 0xe2, 0x74, 0xd7, 0x18, 0x53, 0x73, 0x10, 0x3d, 0x75, 0x7e, 0x3a, 0x87, 0x1e,
-	0xa2, 0xfd, 0xf1, 0x98, 0x8d, 0xc7, 0x9a, 0x39, 0x18, 0xa4, 0x38, 0xe8, 0xcf, 0x60, 0x2b, 0x3d,
-	0x87, 0x71, 0x72, 0x69, 0xf8, 0x8e, 0x2d, 0xef, 0x00, 0xf6, 0xe6, 0x7d, 0x6f, 0x6c, 0xa4, 0xe0,
-	0xbf, 0xb8, 0x3c, 0x72, 0x6c, 0xa1, 0x73, 0x14, 0x4c, 0x35, 0xd4, 0xff, 0x02, 0x6e, 0xbe, 0xa1,
-	0xfb, 0x0c, 0x1b, 0x74, 0xd3, 0x75, 0x34, 0x8b, 0x2b, 0x21, 0x61, 0xbd, 0x5f, 0x29, 0xe2, 0x59,
-	0x34, 0xad, 0x93, 0x66, 0x32, 0x4f, 0xbf, 0x93, 0x71, 0x9e, 0xd6, 0xd1, 0xb1, 0x80, 0xe7, 0xa9,
-	0xf9, 0x93, 0x2b, 0xa9, 0x79, 0xd6, 0x84, 0x4c, 0x64, 0xb8, 0x02, 0x48, 0x22, 0xe8, 0xff, 0xa2,
-	0x42, 0x29, 0x42, 0xe7, 0x27, 0xf8, 0x4b, 0x1a, 0x92, 0x91, 0x11, 0x5f, 0x2f, 0x2a, 0x18, 0x04,
-	0x8b, 0xef, 0xa8, 0xef, 0x41, 0x79, 0x4c, 0x49, 0x20, 0x9a, 0x73, 0xbc, 0xb9, 0xc4, 0x18, 0xbc,
-	0xf1, 0x7d, 0xa8, 0x84, 0x5e, 0x68, 0x0e, 0x8d, 0x90, 0xe7, 0x0b, 0xaa, 0x18, 0xcd, 0x59, 0x3c,
-	0x5b, 0x40, 0x3f, 0x80, 0xf5, 0xf0, 0x34, 0xf0, 0xc2, 0x70, 0xc8, 0x72, 0x55, 0x9e, 0x39, 0x89,
-	0x44, 0x27, 0x8f, 0xb5, 0xb8, 0x41, 0x64, 0x54, 0x94, 0x45, 0xef, 0x49, 0x67, 0xe6, 0xba, 0x3c,
-	0x88, 0xe4, 0xf1, 0x6a, 0xcc, 0x65, 0xae, 0xcd, 0x36, 0x4f, 0x5f, 0x64, 0x24, 0x3c, 0x56, 0x28,
-	0x38, 0x22, 0x91, 0x01, 0x6b, 0x23, 0x62, 0xd2, 0x71, 0x40, 0x6c, 0xe3, 0xa5, 0x43, 0x86, 0xb6,
-	0xb8, 0x78, 0xa9, 0x66, 0x3e, 0x6e, 0x44, 0x6a, 0x69, 0x3c, 0xe2, 0xa3, 0x71, 0x35, 0x82, 0x13,
-	0x34, 0xcb, 0x1c, 0xc4, 0x3f, 0xb4, 0x06, 0x95, 0xde, 0xf3, 0x5e, 0xbf, 0x7d, 0x60, 0x1c, 0x1c,
-	0xee, 0xb6, 0x65, 0xa9, 0x54, 0xaf, 0x8d, 0x05, 0xa9, 0xb0, 0xf6, 0xfe, 0x61, 0xbf, 0xb9, 0x6f,
-	0xf4, 0x3b, 0xad, 0xa7, 0x3d, 0x2d, 0x87, 0xb6, 0x60, 0xbd, 0xbf, 0x87, 0x0f, 0xfb, 0xfd, 0xfd,
-	0xf6, 0xae, 0x71, 0xd4, 0xc6, 0x9d, 0xc3, 0xdd, 0x9e, 0xa6, 0x22, 0x04, 0xd5, 0x09, 0xbb, 0xdf,
-	0x39, 0x68, 0x6b, 0x79, 0x54, 0x81, 0xe5, 0xa3, 0x36, 0x6e, 0xb5, 0xbb, 0x7d, 0xad, 0xa0, 0xff,
-	0x52, 0x85, 0x4a, 0xc2, 0x8a, 0xcc, 0x91, 0x03, 0x2a, 0xce, 0x35, 0x79, 0xcc, 0xfe, 0xf2, 0xa7,
-	0x5d, 0xd3, 0x3a, 0x15, 0xd6, 0xc9, 0x63, 0x41, 0xf0, 0xb3, 0x8c, 0x79, 0x91, 0x58, 0xe7, 0x79,
-	0x5c, 0x1a, 0x99, 0x17, 0x02, 0xe4, 0xbb, 0xb0, 0x72, 0x46, 0x02, 0x97, 0x0c, 0x65, 0xbb, 0xb0,
-	0x48, 0x45, 0xf0, 0x44, 0x97, 0x6d, 0xd0, 0x64, 0x97, 0x09, 0x8c, 0x30, 0x47, 0x55, 0xf0, 0x0f,
-	0x22, 0xb0, 0x4d, 0x28, 0x88, 0xe6, 0x65, 0x31, 0x3f, 0x27, 0xd8, 0x36, 0x45, 0x5f, 0x9b, 0x3e,
-	0xcf, 0x21, 0xf3, 0x98, 0xff, 0x47, 0x27, 0xd3, 0xf6, 0x29, 0x72, 0xfb, 0xdc, 0x9b, 0xdf, 0x9d,
-	0xdf, 0x64, 0xa2, 0xd3, 0xd8, 0x44, 0xcb, 0xa0, 0xe2, 0xa8, 0xbe, 0xa8, 0xd5, 0x6c, 0xed, 0x31,
-	0xb3, 0xac, 0x42, 0xf9, 0xa0, 0xf9, 0x53, 0xe3, 0xb8, 0x27, 0x6e, 0xf0, 0x35, 0x58, 0x79, 0xda,
-	0xc6, 0xdd, 0xf6, 0xbe, 0xe4, 0xa8, 0x68, 0x13, 0x34, 0xc9, 0x99, 0xf4, 0xcb, 0x33, 0x04, 0xf1,
-	0xb7, 0x80, 0x4a, 0x90, 0xef, 0x3d, 0x6b, 0x1e, 0x69, 0x45, 0xfd, 0xbf, 0x73, 0xb0, 0x26, 0xb6,
-	0x85, 0xb8, 0x12, 0xe2, 0xcd, 0x2f, 0xc1, 0xc9, 0x5b, 0xac, 0x5c, 0xfa, 0x16, 0x2b, 0x4a, 0x42,
-	0xf9, 0xae, 0xae, 0x4e, 0x92, 0x50, 0x7e, 0xb3, 0x93, 0x8a, 0xf8, 0xf9, 0x79, 0x22, 0x7e, 0x0d,
-	0x96, 0x47, 0x84, 0xc6, 0x76, 0x2b, 0xe3, 0x88, 0x44, 0x0e, 0x54, 0x4c, 0xd7, 0xf5, 0x42, 0x53,
-	0x5c, 0x0d, 0x17, 0xe7, 0xda, 0x0c, 0xaf, 0x7c, 0x71, 0xa3, 0x39, 0x41, 0x12, 0x81, 0x39, 0x89,
-	0x5d, 0xff, 0x09, 0x68, 0x57, 0x3b, 0xcc, 0xb3, 0x1d, 0x7e, 0xff, 0x87, 0x93, 0xdd, 0x90, 0xb0,
-	0x75, 0x21, 0xdf, 0x54, 0xb4, 0x25, 0x46, 0xe0, 0xe3, 0x6e, 0xb7, 0xd3, 0x7d, 0xac, 0x29, 0x08,
-	0xa0, 0xd8, 0xfe, 0x69, 0xa7, 0xdf, 0xde, 0xd5, 0x72, 0x3b, 0xbf, 0x5a, 0x87, 0xa2, 0x10, 0x12,
-	0x7d, 0x2d, 0x33, 0x81, 0x64, 0x95, 0x2d, 0xfa, 0xc9, 0xdc, 0x19, 0x75, 0xaa, 0x72, 0xb7, 0xfe,
-	0x70, 0xe1, 0xf1, 0xf2, 0x55, 0x73, 0x09, 0xfd, 0x8d, 0x02, 0x2b, 0xa9, 0x17, 0xcd, 0xac, 0x57,
-	0xe3, 0x33, 0x8a, 0x7a, 0xeb, 0x3f, 0x5e, 0x68, 0x6c, 0x2c, 0xcb, 0x2f, 0x14, 0xa8, 0x24, 0xca,
-	0x59, 0xd1, 0xbd, 0x45, 0x4a, 0x60, 0x85, 0x24, 0xf7, 0x17, 0xaf, 0x9e, 0xd5, 0x97, 0x3e, 0x56,
-	0xd0, 0x5f, 0x2b, 0x50, 0x49, 0x14, 0x76, 0x66, 0x16, 0x65, 0xba, 0x0c, 0x35, 0xb3, 0x28, 0xb3,
-	0xea, 0x48, 0x97, 0xd0, 0x5f, 0x2a, 0x50, 0x8e, 0x8b, 0x34, 0xd1, 0xdd, 0xf9, 0xcb, 0x3a, 0x85,
-	0x10, 0x9f, 0x2e, 0x5a, 0x0f, 0xaa, 0x2f, 0xa1, 0x3f, 0x87, 0x52, 0x54, 0xd1, 0x88, 0xb2, 0xee,
-	0x5e, 0x57, 0xca, 0x25, 0xeb, 0x77, 0xe7, 0x1e, 0x97, 0x9c, 0x3e, 0x2a, 0x33, 0xcc, 0x3c, 0xfd,
-	0x95, 0x82, 0xc8, 0xfa, 0xdd, 0xb9, 0xc7, 0xc5, 0xd3, 0x33, 0x4f, 0x48, 0x54, 0x23, 0x66, 0xf6,
-	0x84, 0xe9, 0x32, 0xc8, 0xcc, 0x9e, 0x30, 0xab, 0xf8, 0x51, 0x08, 0x92, 0xa8, 0x67, 0xcc, 0x2c,
-	0xc8, 0x74, 0xcd, 0x64, 0x66, 0x41, 0x66, 0x94, 0x4f, 0xea, 0x4b, 0xe8, 0xe7, 0x4a, 0xf2, 0x5c,
-	0x70, 0x77, 0xee, 0xb2, 0xbd, 0x39, 0x5d, 0x72, 0xaa, 0x70, 0x90, 0x2f, 0xd0, 0x9f, 0xcb, 0x5b,
-	0x0c, 0x51, 0xf5, 0x87, 0xe6, 0x01, 0x4b, 0x15, 0x0a, 0xd6, 0x3f, 0x59, 0x6c, 0xb3, 0xe1, 0x42,
-	0xfc, 0x95, 0x02, 0x30, 0xa9, 0x0f, 0xcc, 0x2c, 0xc4, 0x54, 0x61, 0x62, 0xfd, 0xde, 0x02, 0x23,
-	0x93, 0x0b, 0x24, 0xaa, 0x5f, 0xca, 0xbc, 0x40, 0xae, 0xd4, 0x2f, 0x66, 0x5e, 0x20, 0x57, 0x6b,
-	0x0f, 0xf5, 0x25, 0xf4, 0x4f, 0x0a, 0xac, 0x4f, 0xd5, 0x4f, 0xa1, 0x87, 0xd7, 0x2c, 0xa1, 0xab,
-	0x7f, 0xbe, 0x38, 0x40, 0x24, 0xda, 0xb6, 0xf2, 0xb1, 0x82, 0xfe, 0x56, 0x81, 0xd5, 0x74, 0x5d,
-	0x49, 0xe6, 0x5d, 0x6a, 0x46, 0x25, 0x56, 0xfd, 0xc1, 0x62, 0x83, 0x63, 0x6d, 0xfd, 0xbd, 0x02,
-	0xd5, 0x74, 0x89, 0x11, 0x7a, 0x30, 0x5f, 0x58, 0xb8, 0x22, 0xd0, 0x67, 0x0b, 0x8e, 0x8e, 0x24,
-	0xfa, 0x62, 0xf9, 0x8f, 0x0a, 0x22, 0x7b, 0x2b, 0xf2, 0x9f, 0x1f, 0xfd, 0x26, 0x00, 0x00, 0xff,
-	0xff, 0x5b, 0xc7, 0xff, 0xf4, 0x0c, 0x35, 0x00, 0x00,
+	// 3947 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x5f, 0x6f, 0x1b, 0x49,
+	0x72, 0xf7, 0xf0, 0x9f, 0xc8, 0xa2, 0x44, 0x8d, 0x5a, 0x92, 0x97, 0xe6, 0x5e, 0xb2, 0xbe, 0x39,
+	0x6c, 0xa0, 0xdc, 0xed, 0xd2, 0x7b, 0xba, 0x64, 0xbd, 0xf6, 0x79, 0xcf, 0xcb, 0xa5, 0x68, 0x8b,
+	0xb6, 0x44, 0x29, 0x4d, 0x2a, 0x3e, 0xc7, 0xc9, 0x4e, 0x46, 0x9c, 0x36, 0x35, 0x16, 0x39, 0x33,
+	0x3b, 0x3d, 0x94, 0xa5, 0x0b, 0x82, 0x04, 0x17, 0x20, 0xb8, 0x00, 0x09, 0x92, 0x97, 0xcd, 0x01,
+	0x41, 0x9e, 0x0e, 0xc8, 0x53, 0x90, 0xf7, 0xe0, 0x82, 0x7b, 0xca, 0x43, 0xbe, 0x44, 0x5e, 0xf2,
+	0x96, 0xd7, 0x7c, 0x82, 0x1c, 0xaa, 0xbb, 0x67, 0x38, 0x23, 0xca, 0x67, 0x92, 0xf2, 0x13, 0x59,
+	0xd5, 0xdd, 0xbf, 0xae, 0xa9, 0xaa, 0xae, 0xae, 0xee, 0x2e, 0x30, 0xfc, 0xe1, 0x78, 0xe0, 0xb8,
+	0xfc, 0x8e, 0x1d, 0x38, 0x67, 0x2c, 0xe0, 0x77, 0xfc, 0xc0, 0x0b, 0x3d, 0x45, 0xd5, 0x05, 0x41,
+	0x3e, 0x3c, 0xb1, 0xf8, 0x89, 0xd3, 0xf7, 0x02, 0xbf, 0xee, 0x7a, 0x23, 0xcb, 0xae, 0xab, 0x31,
+	0x75, 0x35, 0x46, 0x76, 0xab, 0xfd, 0xf6, 0xc0, 0xf3, 0x06, 0x43, 0x26, 0x11, 0x8e, 0xc7, 0x2f,
+	0xef, 0xd8, 0xe3, 0xc0, 0x0a, 0x1d, 0xcf, 0x55, 0xed, 0x1f, 0x5c, 0x6e, 0x0f, 0x9d, 0x11, 0xe3,
+	0xa1, 0x35, 0xf2, 0x55, 0x87, 0x0f, 0x23, 0x59, 0xf8, 0x89, 0x15, 0x30, 0xfb, 0xce, 0x49, 0x7f,
+	0xc8, 0x7d, 0xd6, 0xc7, 0x5f, 0x13, 0xff, 0xa8, 0x6e, 0x1f, 0x5d, 0xea, 0xc6, 0xc3, 0x60, 0xdc,
+	0x0f, 0x23, 0xc9, 0xad, 0x30, 0x0c, 0x9c, 0xe3, 0x71, 0xc8, 0x64, 0x6f, 0xe3, 0x16, 0xbc, 0xd7,
+	0xb3, 0xf8, 0x69, 0xd3, 0x73, 0x5f, 0x3a, 0x83, 0x6e, 0xff, 0x84, 0x8d, 0x2c, 0xca, 0xbe, 0x1e,
+	0x33, 0x1e, 0x1a, 0x7f, 0x0c, 0xd5, 0xe9, 0x26, 0xee, 0x7b, 0x2e, 0x67, 0xe4, 0x0b, 0xc8, 0xe1,
+	0x94, 0x55, 0xed, 0xb6, 0xb6, 0x55, 0xde, 0xfe, 0xa8, 0xfe, 0x26, 0x15, 0x48, 0x19, 0xea, 0x4a,
+	0xd4, 0x7a, 0xd7, 0x67, 0x7d, 0x2a, 0x46, 0x1a, 0x9b, 0xb0, 0xde, 0xb4, 0x7c, 0xeb, 0xd8, 0x19,
+	0x3a, 0xa1, 0xc3, 0x78, 0x34, 0xe9, 0x18, 0x36, 0xd2, 0x6c, 0x35, 0xe1, 0x9f, 0xc0, 0x72, 0x3f,
+	0xc1, 0x57, 0x13, 0xdf, 0xab, 0xcf, 0xa4, 0xfb, 0xfa, 0x8e, 0xa0, 0x52, 0xc0, 0x29, 0x38, 0x63,
+	0x03, 0xc8, 0x23, 0xc7, 0x1d, 0xb0, 0xc0, 0x0f, 0x1c, 0x37, 0x8c, 0x84, 0xf9, 0x55, 0x16, 0xd6,
+	0x53, 0x6c, 0x25, 0xcc, 0x2b, 0x80, 0x58, 0x8f, 0x28, 0x4a, 0x76, 0xab, 0xbc, 0xfd, 0x64, 0x46,
+	0x51, 0xae, 0xc0, 0xab, 0x37, 0x62, 0xb0, 0x96, 0x1b, 0x06, 0x17, 0x34, 0x81, 0x4e, 0xbe, 0x82,
+	0xc2, 0x09, 0xb3, 0x86, 0xe1, 0x49, 0x35, 0x73, 0x5b, 0xdb, 0xaa, 0x6c, 0x3f, 0xba, 0xc6, 0x3c,
+	0xbb, 0x02, 0xa8, 0x1b, 0x5a, 0x21, 0xa3, 0x0a, 0x95, 0x7c, 0x0c, 0x44, 0xfe, 0x33, 0x6d, 0xc6,
+	0xfb, 0x81, 0xe3, 0xa3, 0x4b, 0x56, 0xb3, 0xb7, 0xb5, 0xad, 0x12, 0x5d, 0x93, 0x2d, 0x3b, 0x93,
+	0x86, 0x9a, 0x0f, 0xab, 0x97, 0xa4, 0x25, 0x3a, 0x64, 0x4f, 0xd9, 0x85, 0xb0, 0x48, 0x89, 0xe2,
+	0x5f, 0xf2, 0x18, 0xf2, 0x67, 0xd6, 0x70, 0xcc, 0x84, 0xc8, 0xe5, 0xed, 0xef, 0xbf, 0xcd, 0x3d,
+	0x94, 0x8b, 0x4e, 0xf4, 0x40, 0xe5, 0xf8, 0xfb, 0x99, 0xcf, 0x34, 0xe3, 0x1e, 0x94, 0x13, 0x72,
+	0x93, 0x0a, 0xc0, 0x51, 0x67, 0xa7, 0xd5, 0x6b, 0x35, 0x7b, 0xad, 0x1d, 0xfd, 0x06, 0x59, 0x81,
+	0xd2, 0x51, 0x67, 0xb7, 0xd5, 0xd8, 0xeb, 0xed, 0x3e, 0xd7, 0x35, 0x52, 0x86, 0xa5, 0x88, 0xc8,
+	0x18, 0xe7, 0x40, 0x28, 0xeb, 0x7b, 0x67, 0x2c, 0x40, 0x47, 0x56, 0x56, 0x25, 0xef, 0xc1, 0x52,
+	0x68, 0xf1, 0x53, 0xd3, 0xb1, 0x95, 0xcc, 0x05, 0x24, 0xdb, 0x36, 0x69, 0x43, 0xe1, 0xc4, 0x72,
+	0xed, 0xe1, 0xdb, 0xe5, 0x4e, 0xab, 0x1a, 0xc1, 0x77, 0xc5, 0x40, 0xaa, 0x00, 0xd0, 0xbb, 0x53,
+	0x33, 0x4b, 0x03, 0x18, 0xcf, 0x41, 0xef, 0x86, 0x56, 0x10, 0x26, 0xc5, 0x69, 0x41, 0x0e, 0xe7,
+	0xaf, 0x6a, 0x73, 0xcf, 0x29, 0x57, 0x26, 0x15, 0xc3, 0x8d, 0xff, 0xcb, 0xc0, 0x5a, 0x02, 0x5b,
+	0x79, 0xea, 0x33, 0x28, 0x04, 0x8c, 0x8f, 0x87, 0xa1, 0x80, 0xaf, 0x6c, 0x3f, 0x9c, 0x11, 0x7e,
+	0x0a, 0xa9, 0x4e, 0x05, 0x0c, 0x55, 0x70, 0x64, 0x0b, 0x74, 0x39, 0xc2, 0x64, 0x41, 0xe0, 0x05,
+	0xe6, 0x88, 0x0f, 0x84, 0xd6, 0x4a, 0xb4, 0x22, 0xf9, 0x2d, 0x64, 0xef, 0xf3, 0x41, 0x42, 0xab,
+	0xd9, 0x6b, 0x6a, 0x95, 0x58, 0xa0, 0xbb, 0x2c, 0x7c, 0xed, 0x05, 0xa7, 0x26, 0xaa, 0x36, 0x70,
+	0x6c, 0x56, 0xcd, 0x09, 0xd0, 0x4f, 0x67, 0x04, 0xed, 0xc8, 0xe1, 0x07, 0x6a, 0x34, 0x5d, 0x75,
+	0xd3, 0x0c, 0xe3, 0x7b, 0x50, 0x90, 0x5f, 0x8a, 0x9e, 0xd4, 0x3d, 0x6a, 0x36, 0x5b, 0xdd, 0xae,
+	0x7e, 0x83, 0x94, 0x20, 0x4f, 0x5b, 0x3d, 0x8a, 0x1e, 0x56, 0x82, 0xfc, 0xa3, 0x46, 0xaf, 0xb1,
+	0xa7, 0x67, 0x8c, 0xef, 0xc2, 0xea, 0x33, 0xcb, 0x09, 0x67, 0x71, 0x2e, 0xc3, 0x03, 0x7d, 0xd2,
+	0x57, 0x59, 0xa7, 0x9d, 0xb2, 0xce, 0xec, 0xaa, 0x69, 0x9d, 0x3b, 0xe1, 0x25, 0x7b, 0xe8, 0x90,
+	0x65, 0x41, 0xa0, 0x4c, 0x80, 0x7f, 0x8d, 0xd7, 0xb0, 0xda, 0x0d, 0x3d, 0x7f, 0x26, 0xcf, 0xff,
+	0x01, 0x2c, 0xe1, 0x6e, 0xe3, 0x8d, 0x43, 0xe5, 0xfa, 0xb7, 0xea, 0x72, 0x37, 0xaa, 0x47, 0xbb,
+	0x51, 0x7d, 0x47, 0xed, 0x56, 0x34, 0xea, 0x49, 0x6e, 0x42, 0x81, 0x3b, 0x03, 0xd7, 0x1a, 0xaa,
+	0x68, 0xa1, 0x28, 0x83, 0x80, 0x3e, 0x99, 0x58, 0x39, 0x7e, 0x13, 0xc8, 0x0e, 0xe3, 0x61, 0xe0,
+	0x5d, 0xcc, 0x24, 0xcf, 0x06, 0xe4, 0x5f, 0x7a, 0x41, 0x5f, 0x2e, 0xc4, 0x22, 0x95, 0x04, 0x2e,
+	0xaa, 0x14, 0x88, 0xc2, 0xfe, 0x18, 0x48, 0xdb, 0xc5, 0x3d, 0x65, 0x36, 0x43, 0xfc, 0x43, 0x06,
+	0xd6, 0x53, 0xfd, 0x95, 0x31, 0x16, 0x5f, 0x87, 0x18, 0x98, 0xc6, 0x5c, 0xae, 0x43, 0x72, 0x00,
+	0x05, 0xd9, 0x43, 0x69, 0xf2, 0xee, 0x1c, 0x40, 0x72, 0x9b, 0x52, 0x70, 0x0a, 0xe6, 0x4a, 0xa7,
+	0xcf, 0xbe, 0x5b, 0xa7, 0x7f, 0x0d, 0x7a, 0xf4, 0x1d, 0xfc, 0xad, 0xb6, 0x79, 0x02, 0xeb, 0x7d,
+	0x6f, 0x38, 0x64, 0x7d, 0xf4, 0x06, 0xd3, 0x71, 0x43, 0x16, 0x9c, 0x59, 0xc3, 0xb7, 0xfb, 0x0d,
+	0x99, 0x8c, 0x6a, 0xab, 0x41, 0xc6, 0x0b, 0x58, 0x4b, 0x4c, 0xac, 0x0c, 0xf1, 0x08, 0xf2, 0x1c,
+	0x19, 0xca, 0x12, 0x9f, 0xcc, 0x69, 0x09, 0x4e, 0xe5, 0x70, 0x63, 0x5d, 0x82, 0xb7, 0xce, 0x98,
+	0x1b, 0x7f, 0x96, 0xb1, 0x03, 0x6b, 0x5d, 0xe1, 0xa6, 0x33, 0xf9, 0xe1, 0xc4, 0xc5, 0x33, 0x29,
+	0x17, 0xdf, 0x00, 0x92, 0x44, 0x51, 0x8e, 0x78, 0x01, 0xab, 0xad, 0x73, 0xd6, 0x9f, 0x09, 0xb9,
+	0x0a, 0x4b, 0x7d, 0x6f, 0x34, 0xb2, 0x5c, 0xbb, 0x9a, 0xb9, 0x9d, 0xdd, 0x2a, 0xd1, 0x88, 0x4c,
+	0xae, 0xc5, 0xec, 0xac, 0x6b, 0xd1, 0xf8, 0x3b, 0x0d, 0xf4, 0xc9, 0xdc, 0x4a, 0x91, 0x28, 0x7d,
+	0x68, 0x23, 0x10, 0xce, 0xbd, 0x4c, 0x15, 0xa5, 0xf8, 0x51, 0xb8, 0x90, 0x7c, 0x16, 0x04, 0x89,
+	0x70, 0x94, 0xbd, 0x66, 0x38, 0x32, 0x76, 0xe1, 0x5b, 0x91, 0x38, 0xdd, 0x30, 0x60, 0xd6, 0xc8,
+	0x71, 0x07, 0xed, 0x83, 0x03, 0x9f, 0x49, 0xc1, 0x09, 0x81, 0x9c, 0x6d, 0x85, 0x96, 0x12, 0x4c,
+	0xfc, 0xc7, 0x45, 0xdf, 0x1f, 0x7a, 0x3c, 0x5e, 0xf4, 0x82, 0x30, 0xfe, 0x2b, 0x0b, 0xd5, 0x29,
+	0xa8, 0x48, 0xbd, 0x2f, 0x20, 0xcf, 0x59, 0x38, 0xf6, 0x95, 0xab, 0xb4, 0x66, 0x16, 0xf8, 0x6a,
+	0xbc, 0x7a, 0x17, 0xc1, 0xa8, 0xc4, 0x24, 0x03, 0x28, 0x86, 0xe1, 0x85, 0xc9, 0x9d, 0x9f, 0x44,
+	0x09, 0xc1, 0xde, 0x75, 0xf1, 0x7b, 0x2c, 0x18, 0x39, 0xae, 0x35, 0xec, 0x3a, 0x3f, 0x61, 0x74,
+	0x29, 0x0c, 0x2f, 0xf0, 0x0f, 0x79, 0x8e, 0x0e, 0x6f, 0x3b, 0xae, 0x52, 0x7b, 0x73, 0xd1, 0x59,
+	0x12, 0x0a, 0xa6, 0x12, 0xb1, 0xb6, 0x07, 0x79, 0xf1, 0x4d, 0x8b, 0x38, 0xa2, 0x0e, 0xd9, 0x30,
+	0xbc, 0x10, 0x42, 0x15, 0x29, 0xfe, 0xad, 0x3d, 0x80, 0xe5, 0xe4, 0x17, 0xa0, 0x23, 0x9d, 0x30,
+	0x67, 0x70, 0x22, 0x1d, 0x2c, 0x4f, 0x15, 0x85, 0x96, 0x7c, 0xed, 0xd8, 0x2a, 0x65, 0xcd, 0x53,
+	0x49, 0x18, 0xff, 0x9e, 0x81, 0x5b, 0x57, 0x68, 0x46, 0x39, 0xeb, 0x8b, 0x94, 0xb3, 0xbe, 0x23,
+	0x2d, 0x44, 0x1e, 0xff, 0x22, 0xe5, 0xf1, 0xef, 0x10, 0x1c, 0x97, 0xcd, 0x4d, 0x28, 0xb0, 0x73,
+	0x27, 0x64, 0xb6, 0x52, 0x95, 0xa2, 0x12, 0xcb, 0x29, 0x77, 0xdd, 0xe5, 0xb4, 0x0f, 0x1b, 0xcd,
+	0x80, 0x59, 0x21, 0x53, 0xa1, 0x3c, 0xf2, 0xff, 0x5b, 0x50, 0xb4, 0x86, 0x43, 0xaf, 0x3f, 0x31,
+	0xeb, 0x92, 0xa0, 0xdb, 0x36, 0xa9, 0x41, 0xf1, 0xc4, 0xe3, 0xa1, 0x6b, 0x8d, 0x98, 0x0a, 0x5e,
+	0x31, 0x6d, 0x7c, 0xa3, 0xc1, 0xe6, 0x25, 0x3c, 0x65, 0x85, 0x63, 0xa8, 0x38, 0xdc, 0x1b, 0x8a,
+	0x0f, 0x34, 0x13, 0x27, 0xbc, 0x1f, 0xce, 0xb7, 0xd5, 0xb4, 0x23, 0x0c, 0x71, 0xe0, 0x5b, 0x71,
+	0x92, 0xa4, 0xf0, 0x38, 0x31, 0xb9, 0xad, 0x56, 0x7a, 0x44, 0x1a, 0xff, 0xa8, 0xc1, 0xa6, 0xda,
+	0xe1, 0x67, 0xff, 0xd0, 0x69, 0x91, 0x33, 0xef, 0x5a, 0x64, 0xa3, 0x0a, 0x37, 0x2f, 0xcb, 0xa5,
+	0x62, 0xfe, 0x3f, 0x15, 0x80, 0x4c, 0x9f, 0x2e, 0xc9, 0xb7, 0x61, 0x99, 0x33, 0xd7, 0x36, 0xe5,
+	0x7e, 0x21, 0xb7, 0xb2, 0x22, 0x2d, 0x23, 0x4f, 0x6e, 0x1c, 0x1c, 0x43, 0x20, 0x3b, 0x57, 0xd2,
+	0x16, 0xa9, 0xf8, 0x4f, 0x4e, 0x60, 0xf9, 0x25, 0x37, 0xe3, 0xb9, 0x85, 0x43, 0x55, 0x66, 0x0e,
+	0x6b, 0xd3, 0x72, 0xd4, 0x1f, 0x75, 0xe3, 0xef, 0xa2, 0xe5, 0x97, 0x3c, 0x26, 0xc8, 0xcf, 0x34,
+	0x78, 0x2f, 0x4a, 0x2b, 0x26, 0xea, 0x1b, 0x79, 0x36, 0xe3, 0xd5, 0xdc, 0xed, 0xec, 0x56, 0x65,
+	0xfb, 0xf0, 0x1a, 0xfa, 0x9b, 0x62, 0xee, 0x7b, 0x36, 0xa3, 0x9b, 0xee, 0x15, 0x5c, 0x4e, 0xea,
+	0xb0, 0x3e, 0x1a, 0xf3, 0xd0, 0x94, 0x5e, 0x60, 0xaa, 0x4e, 0xd5, 0xbc, 0xd0, 0xcb, 0x1a, 0x36,
+	0xa5, 0x7c, 0x95, 0x9c, 0xc2, 0xca, 0xc8, 0x1b, 0xbb, 0xa1, 0xd9, 0x17, 0xe7, 0x1f, 0x5e, 0x2d,
+	0xcc, 0x75, 0x30, 0xbe, 0x42, 0x4b, 0xfb, 0x08, 0x27, 0x4f, 0x53, 0x9c, 0x2e, 0x8f, 0x12, 0x14,
+	0x1a, 0x32, 0x60, 0x23, 0x2f, 0x64, 0x26, 0xc6, 0x4b, 0x5e, 0x5d, 0x92, 0x86, 0x94, 0x3c, 0x0c,
+	0x0d, 0x9c, 0xfc, 0x1e, 0xdc, 0xb4, 0x1d, 0x6e, 0x1d, 0x0f, 0x99, 0x39, 0xf4, 0x06, 0xe6, 0x24,
+	0xcd, 0xa9, 0x16, 0x45, 0xe7, 0x0d, 0xd5, 0xba, 0xe7, 0x0d, 0x9a, 0x71, 0x9b, 0x18, 0x75, 0xe1,
+	0x5a, 0x23, 0xa7, 0x6f, 0xe2, 0x57, 0x0d, 0x3d, 0xcb, 0x36, 0xc7, 0x9c, 0x05, 0xbc, 0x5a, 0x52,
+	0xa3, 0x64, 0xeb, 0x33, 0xd5, 0x78, 0x84, 0x6d, 0xe4, 0x77, 0x41, 0x0f, 0x18, 0xee, 0x48, 0x66,
+	0xc0, 0xb8, 0x37, 0x0e, 0xfa, 0x8c, 0x57, 0x41, 0xf4, 0x5f, 0x95, 0x7c, 0x1a, 0xb1, 0x8d, 0xfb,
+	0x50, 0x4e, 0x58, 0x9f, 0x14, 0x21, 0xd7, 0x39, 0xe8, 0xb4, 0xf4, 0x1b, 0x04, 0xa0, 0xd0, 0xdc,
+	0xa5, 0x07, 0x07, 0x3d, 0x79, 0x98, 0x69, 0xef, 0x37, 0x1e, 0xb7, 0xf4, 0x0c, 0xb2, 0x8f, 0x3a,
+	0x7f, 0xd8, 0x6a, 0xef, 0xe9, 0x59, 0xa3, 0x05, 0xcb, 0x49, 0x9d, 0x10, 0x02, 0x95, 0xa3, 0xce,
+	0xd3, 0xce, 0xc1, 0xb3, 0x8e, 0xb9, 0x7f, 0x70, 0xd4, 0xe9, 0xe1, 0x91, 0xa8, 0x02, 0xd0, 0xe8,
+	0x3c, 0x9f, 0xd0, 0x2b, 0x50, 0xea, 0x1c, 0x44, 0xa4, 0x56, 0xcb, 0xe8, 0x9a, 0xf1, 0x9f, 0x59,
+	0xd8, 0xb8, 0xca, 0x3d, 0x88, 0x0d, 0x39, 0x74, 0x35, 0x75, 0x28, 0x7d, 0xf7, 0x9e, 0x26, 0xd0,
+	0x71, 0x85, 0xf9, 0x96, 0xda, 0x85, 0x4a, 0x54, 0xfc, 0x27, 0x26, 0x14, 0x86, 0xd6, 0x31, 0x1b,
+	0xf2, 0x6a, 0x56, 0x5c, 0xdb, 0x3c, 0xbe, 0xce, 0xdc, 0x7b, 0x02, 0x49, 0xde, 0xd9, 0x28, 0x58,
+	0xd2, 0x83, 0x32, 0xc6, 0x59, 0x2e, 0x55, 0xa7, 0x42, 0xff, 0xf6, 0x8c, 0xb3, 0xec, 0x4e, 0x46,
+	0xd2, 0x24, 0x4c, 0xed, 0x1e, 0x94, 0x13, 0x93, 0x5d, 0x71, 0xe5, 0xb2, 0x91, 0xbc, 0x72, 0x29,
+	0x25, 0xef, 0x4f, 0x1e, 0xc2, 0xc6, 0x55, 0x3a, 0x42, 0x87, 0xd8, 0x3d, 0xe8, 0xf6, 0xe4, 0xe1,
+	0xf6, 0x31, 0x3d, 0x38, 0x3a, 0xd4, 0x35, 0x64, 0xf6, 0x1a, 0xdd, 0xa7, 0x7a, 0x26, 0xf6, 0x97,
+	0xac, 0xd1, 0x84, 0x72, 0x42, 0xae, 0xd4, 0xc6, 0xa2, 0xa5, 0x37, 0x16, 0x0c, 0xed, 0x96, 0x6d,
+	0x07, 0x8c, 0x73, 0x25, 0x47, 0x44, 0x1a, 0x2f, 0xa0, 0xb4, 0xd3, 0xe9, 0x2a, 0x88, 0x2a, 0x2c,
+	0x71, 0x16, 0xe0, 0x77, 0x8b, 0xcb, 0xb3, 0x12, 0x8d, 0x48, 0x04, 0xe7, 0xcc, 0x0a, 0xfa, 0x27,
+	0x8c, 0xab, 0x74, 0x24, 0xa6, 0x71, 0x94, 0x27, 0x2e, 0xa1, 0xa4, 0xed, 0x4a, 0x34, 0x22, 0x8d,
+	0xff, 0x2f, 0x02, 0x4c, 0x2e, 0x44, 0x48, 0x05, 0x32, 0xf1, 0x36, 0x91, 0x71, 0x6c, 0xf4, 0x83,
+	0xc4, 0x36, 0x28, 0xfe, 0x93, 0x6d, 0xd8, 0x1c, 0xf1, 0x81, 0x6f, 0xf5, 0x4f, 0x4d, 0x75, 0x8f,
+	0x21, 0xa3, 0x89, 0x08, 0xb9, 0xcb, 0x74, 0x5d, 0x35, 0xaa, 0x60, 0x21, 0x71, 0xf7, 0x20, 0xcb,
+	0xdc, 0x33, 0x11, 0x1e, 0xcb, 0xdb, 0xf7, 0xe7, 0xbe, 0xa8, 0xa9, 0xb7, 0xdc, 0x33, 0xe9, 0x2b,
+	0x08, 0x43, 0x4c, 0x00, 0x9b, 0x9d, 0x39, 0x7d, 0x66, 0x22, 0x68, 0x5e, 0x80, 0x7e, 0x31, 0x3f,
+	0xe8, 0x8e, 0xc0, 0x88, 0xa1, 0x4b, 0x76, 0x44, 0x93, 0x0e, 0x94, 0x26, 0x41, 0xa2, 0x30, 0xd7,
+	0x59, 0x2a, 0x8e, 0x22, 0x74, 0x02, 0x41, 0x76, 0xa0, 0x20, 0x42, 0x23, 0x06, 0xc1, 0xec, 0x6f,
+	0xbc, 0xf5, 0x4d, 0x83, 0x89, 0x48, 0x42, 0xd5, 0x58, 0xf2, 0x18, 0x96, 0xa4, 0x88, 0xbc, 0x5a,
+	0x14, 0x30, 0x1f, 0xcf, 0x1a, 0xb7, 0xc5, 0x28, 0x1a, 0x8d, 0x46, 0xab, 0x62, 0xbc, 0x14, 0xe1,
+	0xb2, 0x44, 0xc5, 0x7f, 0xf2, 0x3e, 0x94, 0x64, 0x9a, 0x60, 0x3b, 0x81, 0x88, 0x8b, 0x25, 0x2a,
+	0xf3, 0x86, 0x1d, 0x27, 0x20, 0x1f, 0x40, 0x59, 0xa6, 0x83, 0xa6, 0x88, 0x0a, 0x65, 0xd1, 0x0c,
+	0x92, 0x75, 0x88, 0xb1, 0x41, 0x76, 0x60, 0x41, 0x20, 0x3b, 0x2c, 0xc7, 0x1d, 0x58, 0x10, 0x88,
+	0x0e, 0xbf, 0x03, 0xab, 0x22, 0x89, 0x1e, 0x04, 0xde, 0xd8, 0x37, 0x85, 0x4f, 0xad, 0x88, 0x4e,
+	0x2b, 0xc8, 0x7e, 0x8c, 0xdc, 0x0e, 0x3a, 0xd7, 0x2d, 0x28, 0xbe, 0xf2, 0x8e, 0x65, 0x87, 0x8a,
+	0x5c, 0x07, 0xaf, 0xbc, 0xe3, 0xa8, 0x29, 0x4e, 0x64, 0x56, 0xd3, 0x89, 0xcc, 0xd7, 0x70, 0x73,
+	0x7a, 0x47, 0x16, 0x09, 0x8d, 0x7e, 0xfd, 0x84, 0x66, 0xc3, 0xbd, 0x82, 0x4b, 0xbe, 0x84, 0xac,
+	0xed, 0xf2, 0xea, 0xda, 0x5c, 0xce, 0x11, 0xaf, 0x63, 0x8a, 0x83, 0xc9, 0x26, 0x14, 0xf0, 0x63,
+	0x1d, 0xbb, 0x4a, 0x64, 0xe8, 0x79, 0xe5, 0x1d, 0xb7, 0x6d, 0xf2, 0x2d, 0x28, 0xe1, 0xf7, 0x73,
+	0xdf, 0xea, 0xb3, 0xea, 0xba, 0x68, 0x99, 0x30, 0xd0, 0x50, 0xae, 0x67, 0x33, 0xa9, 0xa2, 0x0d,
+	0x69, 0x28, 0x64, 0x08, 0x1d, 0xbd, 0x07, 0x4b, 0xa2, 0xd1, 0xb1, 0xab, 0x9b, 0xa2, 0xa9, 0x80,
+	0x64, 0xdb, 0x26, 0x06, 0xac, 0xf8, 0x56, 0xc0, 0xdc, 0xd0, 0x54, 0x33, 0xde, 0x14, 0xcd, 0x65,
+	0xc9, 0x7c, 0x82, 0xf3, 0xd6, 0x3e, 0x85, 0x62, 0xb4, 0x18, 0xe6, 0x09, 0x93, 0xb5, 0x07, 0x50,
+	0x49, 0x2f, 0xa5, 0xb9, 0x82, 0xec, 0xbf, 0x64, 0xa0, 0x14, 0x2f, 0x1a, 0xe2, 0xc2, 0xba, 0x30,
+	0xaa, 0x15, 0x32, 0x3b, 0xb1, 0x51, 0xcb, 0x54, 0xfa, 0xf3, 0x19, 0xd5, 0xdc, 0x88, 0x10, 0xd4,
+	0x99, 0x5e, 0x2d, 0x48, 0x12, 0x23, 0x4f, 0xe6, 0xfb, 0x0a, 0x56, 0x87, 0x8e, 0x3b, 0x3e, 0x4f,
+	0xcc, 0x25, 0x73, 0xe0, 0xdf, 0x9f, 0x71, 0xae, 0x3d, 0x1c, 0x3d, 0x99, 0xa3, 0x32, 0x4c, 0xd1,
+	0x64, 0x17, 0xf2, 0xbe, 0x17, 0x84, 0xd1, 0x9e, 0x39, 0xeb, 0x6e, 0x76, 0xe8, 0x05, 0xe1, 0xbe,
+	0xe5, 0xfb, 0x78, 0xcc, 0x93, 0x00, 0xc6, 0x37, 0x19, 0xb8, 0x79, 0xf5, 0x87, 0x91, 0x0e, 0x64,
+	0xfb, 0xfe, 0x58, 0x29, 0xe9, 0xc1, 0xbc, 0x4a, 0x6a, 0xfa, 0xe3, 0x89, 0xfc, 0x08, 0x84, 0x57,
+	0xdf, 0x23, 0x36, 0xf2, 0x82, 0x0b, 0xa5, 0x8b, 0x87, 0xf3, 0x42, 0xee, 0x8b, 0xd1, 0x13, 0x54,
+	0x05, 0x47, 0x28, 0x14, 0xd5, 0x62, 0xe2, 0x2a, 0x6c, 0xcf, 0x79, 0x11, 0x17, 0x41, 0xd2, 0x18,
+	0xc7, 0xf8, 0x14, 0x36, 0xaf, 0xfc, 0x14, 0xf2, 0x5b, 0x00, 0x7d, 0x7f, 0x6c, 0x8a, 0x87, 0x12,
+	0xe9, 0x41, 0x59, 0x5a, 0xea, 0xfb, 0xe3, 0xae, 0x60, 0x18, 0x2f, 0xa0, 0xfa, 0x26, 0x79, 0x71,
+	0x8d, 0x49, 0x89, 0xcd, 0xd1, 0xb1, 0xd0, 0x41, 0x96, 0x16, 0x25, 0x63, 0xff, 0x18, 0x97, 0x52,
+	0xd4, 0x68, 0x9d, 0x63, 0x87, 0xac, 0xe8, 0x50, 0x56, 0x1d, 0xac, 0xf3, 0xfd, 0x63, 0xe3, 0xe7,
+	0x19, 0x58, 0xbd, 0x24, 0x32, 0x1e, 0x76, 0x65, 0x00, 0x8e, 0xae, 0x11, 0x24, 0x85, 0xd1, 0xb8,
+	0xef, 0xd8, 0xd1, 0x05, 0xb4, 0xf8, 0x2f, 0xf6, 0x61, 0x5f, 0x5d, 0x0e, 0x67, 0x1c, 0x1f, 0x97,
+	0xcf, 0xe8, 0xd8, 0x09, 0xb9, 0x48, 0x8a, 0xf2, 0x54, 0x12, 0xe4, 0x39, 0x54, 0x02, 0x26, 0xf6,
+	0x7f, 0xdb, 0x94, 0x5e, 0x96, 0x9f, 0xcb, 0xcb, 0x94, 0x84, 0xe8, 0x6c, 0x74, 0x25, 0x42, 0x42,
+	0x8a, 0x93, 0x67, 0xb0, 0x12, 0xe5, 0xd8, 0x12, 0xb9, 0xb0, 0x30, 0xf2, 0xb2, 0x02, 0x12, 0xc0,
+	0xf8, 0x26, 0x95, 0x68, 0xc4, 0x0f, 0x13, 0xd9, 0x9f, 0xd2, 0x89, 0x24, 0xd2, 0xd1, 0x22, 0xaf,
+	0xa2, 0x85, 0x71, 0x0c, 0xe5, 0xc4, 0xba, 0x98, 0x67, 0x28, 0xea, 0x33, 0xf4, 0x84, 0x3e, 0xf3,
+	0x34, 0x13, 0x7a, 0x18, 0x27, 0x31, 0xf3, 0x32, 0x1d, 0x5f, 0x68, 0xb4, 0x44, 0x0b, 0x48, 0xb6,
+	0x7d, 0xe3, 0x97, 0x19, 0xa8, 0xa4, 0x97, 0x74, 0xe4, 0x47, 0x3e, 0x0b, 0x1c, 0xcf, 0x4e, 0xf8,
+	0xd1, 0xa1, 0x60, 0xa0, 0xaf, 0x60, 0xf3, 0xd7, 0x63, 0x2f, 0xb4, 0x22, 0x5f, 0xe9, 0xfb, 0xe3,
+	0x3f, 0x40, 0xfa, 0x92, 0x0f, 0x66, 0x2f, 0xf9, 0x20, 0xf9, 0x08, 0x88, 0x72, 0xa5, 0xa1, 0x33,
+	0x72, 0x42, 0xf3, 0xf8, 0x22, 0x64, 0xd2, 0xc6, 0x59, 0xaa, 0xcb, 0x96, 0x3d, 0x6c, 0xf8, 0x12,
+	0xf9, 0xe8, 0x78, 0x9e, 0x37, 0x32, 0x79, 0xdf, 0x0b, 0x98, 0x69, 0xd9, 0xaf, 0xc4, 0x39, 0x2f,
+	0x4b, 0xcb, 0x9e, 0x37, 0xea, 0x22, 0xaf, 0x61, 0xbf, 0xc2, 0x8d, 0xb8, 0xef, 0x8f, 0x39, 0x0b,
+	0x4d, 0xfc, 0x11, 0xb9, 0x4b, 0x89, 0x82, 0x64, 0x35, 0xfd, 0x31, 0x27, 0xdf, 0x81, 0x95, 0xa8,
+	0x83, 0xd8, 0x8b, 0x55, 0x12, 0xb0, 0xac, 0xba, 0x08, 0x1e, 0x31, 0x60, 0xf9, 0x90, 0x05, 0x7d,
+	0xe6, 0x86, 0x3d, 0xa7, 0x7f, 0xca, 0xc5, 0x69, 0x4c, 0xa3, 0x29, 0xde, 0x93, 0x5c, 0x71, 0x49,
+	0x2f, 0xd2, 0x68, 0xb6, 0x11, 0x1b, 0x71, 0xe3, 0xdf, 0x34, 0xc8, 0x8b, 0x94, 0x05, 0x95, 0x22,
+	0xb6, 0x7b, 0x91, 0x0d, 0xa8, 0x54, 0x17, 0x19, 0x22, 0x17, 0x78, 0x1f, 0x4a, 0x42, 0xf9, 0x89,
+	0x13, 0x86, 0xc8, 0x83, 0x45, 0x63, 0x0d, 0x8a, 0x01, 0xb3, 0x6c, 0xcf, 0x1d, 0x46, 0xf7, 0x67,
+	0x31, 0x8d, 0x47, 0x38, 0x3f, 0xf0, 0x7c, 0x6b, 0x30, 0x39, 0x72, 0x2b, 0xf3, 0xad, 0x26, 0xf8,
+	0x22, 0x45, 0xff, 0x0e, 0xac, 0x70, 0x26, 0x23, 0xbb, 0x74, 0x92, 0xbc, 0xfc, 0x4c, 0xc5, 0x14,
+	0x27, 0x02, 0xe3, 0x6b, 0x28, 0xc8, 0x8d, 0xeb, 0x1a, 0xf2, 0x7e, 0x0c, 0x44, 0x2a, 0x12, 0x1d,
+	0x64, 0xe4, 0x70, 0xae, 0xb2, 0x6c, 0xf1, 0x08, 0x2c, 0x5b, 0x0e, 0x27, 0x0d, 0xc6, 0x7f, 0x6b,
+	0x00, 0x93, 0xe7, 0x39, 0x4c, 0xcc, 0x71, 0xd5, 0xe0, 0x89, 0x57, 0xde, 0x03, 0x46, 0x24, 0x5e,
+	0x81, 0xa9, 0xb4, 0x3a, 0xb3, 0xe8, 0xeb, 0xa6, 0x02, 0x88, 0x5e, 0x05, 0x98, 0xba, 0x13, 0x99,
+	0xf7, 0x55, 0x80, 0xc9, 0x57, 0x01, 0x86, 0x07, 0x7a, 0x95, 0xf0, 0x4b, 0xb8, 0x9c, 0xc8, 0xf7,
+	0xcb, 0x76, 0xfc, 0xf4, 0xc2, 0x8c, 0xff, 0xd5, 0xe2, 0xb8, 0x17, 0x3d, 0x91, 0x90, 0xaf, 0xa0,
+	0x88, 0x21, 0xc4, 0x1c, 0x59, 0xbe, 0x7a, 0xf0, 0x6f, 0x2e, 0xf6, 0xfa, 0x12, 0xed, 0x8a, 0x32,
+	0x5d, 0x5f, 0xf2, 0x25, 0x85, 0xf1, 0x13, 0x8f, 0x4a, 0x51, 0xfc, 0xc4, 0xff, 0xe4, 0x43, 0xa8,
+	0x58, 0xe3, 0xd0, 0x33, 0x2d, 0xfb, 0x8c, 0x05, 0xa1, 0xc3, 0x99, 0xf2, 0xa5, 0x15, 0xe4, 0x36,
+	0x22, 0x66, 0xed, 0x3e, 0x2c, 0x27, 0x31, 0xdf, 0x96, 0xb7, 0xe4, 0x93, 0x79, 0xcb, 0x9f, 0x02,
+	0x4c, 0xae, 0x1b, 0xd1, 0x47, 0xf0, 0xee, 0xd2, 0xec, 0x47, 0x67, 0xf3, 0x3c, 0x2d, 0x22, 0xa3,
+	0x89, 0xce, 0x98, 0x7e, 0x0b, 0xc9, 0x47, 0x6f, 0x21, 0x18, 0x1d, 0x70, 0x41, 0x9f, 0x3a, 0xc3,
+	0x61, 0x7c, 0x05, 0x5a, 0xf2, 0xbc, 0xd1, 0x53, 0xc1, 0x30, 0x7e, 0x95, 0x91, 0xbe, 0x22, 0x5f,
+	0xb5, 0x66, 0x3a, 0x9b, 0xbd, 0x2b, 0x53, 0xdf, 0x03, 0xe0, 0xa1, 0x15, 0x60, 0x12, 0x66, 0x45,
+	0x97, 0xb0, 0xb5, 0xa9, 0xc7, 0x94, 0x5e, 0x54, 0x66, 0x43, 0x4b, 0xaa, 0x77, 0x23, 0x24, 0x9f,
+	0xc3, 0x72, 0xdf, 0x1b, 0xf9, 0x43, 0xa6, 0x06, 0xe7, 0xdf, 0x3a, 0xb8, 0x1c, 0xf7, 0x6f, 0x84,
+	0x89, 0xab, 0xdf, 0xc2, 0x75, 0xaf, 0x7e, 0x7f, 0xa9, 0xc9, 0xc7, 0xb9, 0xe4, 0xdb, 0x20, 0x19,
+	0x5c, 0x51, 0x80, 0xf2, 0x78, 0xc1, 0x87, 0xc6, 0xdf, 0x54, 0x7d, 0x52, 0xfb, 0x7c, 0x96, 0x72,
+	0x8f, 0x37, 0xa7, 0xc5, 0xff, 0x91, 0x85, 0x52, 0x64, 0x96, 0x69, 0xdb, 0x7f, 0x06, 0xa5, 0xb8,
+	0xc6, 0xa9, 0x9a, 0x79, 0xab, 0x86, 0x27, 0x9d, 0xc9, 0x4b, 0x20, 0xd6, 0x60, 0x10, 0xa7, 0xbb,
+	0xe6, 0x98, 0x5b, 0x83, 0xe8, 0x55, 0xf4, 0xb3, 0x39, 0xf4, 0x10, 0xed, 0x8f, 0x47, 0x38, 0x9e,
+	0xea, 0xd6, 0x60, 0x90, 0xe2, 0x90, 0x3f, 0x83, 0xcd, 0xf4, 0x1c, 0xe6, 0xf1, 0x85, 0xe9, 0x3b,
+	0xb6, 0xba, 0x03, 0xd8, 0x9d, 0xf7, 0x69, 0xb2, 0x9e, 0x82, 0xff, 0xf2, 0xe2, 0xd0, 0xb1, 0xa5,
+	0xce, 0x49, 0x30, 0xd5, 0x50, 0xfb, 0x0b, 0x78, 0xef, 0x0d, 0xdd, 0xaf, 0xb0, 0x41, 0x27, 0x5d,
+	0x72, 0xb3, 0xb8, 0x12, 0x12, 0xd6, 0xfb, 0x85, 0x06, 0x6b, 0x53, 0x1d, 0x48, 0x23, 0x99, 0xa7,
+	0xdf, 0x99, 0x71, 0x9e, 0xe6, 0xe1, 0x91, 0x84, 0xc7, 0xb1, 0xe4, 0xc9, 0xa5, 0xd4, 0x7c, 0xd6,
+	0x84, 0x4c, 0x66, 0xb8, 0x12, 0x48, 0x21, 0x18, 0xff, 0x9a, 0x85, 0x62, 0x84, 0x2e, 0x4e, 0xf0,
+	0x17, 0x3c, 0x64, 0x23, 0x33, 0xbe, 0x5e, 0xd4, 0x28, 0x48, 0x96, 0xd8, 0x51, 0xdf, 0x87, 0xd2,
+	0x98, 0xb3, 0x40, 0x36, 0x67, 0x44, 0x73, 0x11, 0x19, 0xa2, 0xf1, 0x03, 0x28, 0x87, 0x5e, 0x68,
+	0x0d, 0xcd, 0x50, 0xe4, 0x0b, 0x59, 0x39, 0x5a, 0xb0, 0x44, 0xb6, 0x40, 0xbe, 0x07, 0x6b, 0xe1,
+	0x49, 0xe0, 0x85, 0xe1, 0x10, 0x73, 0x55, 0x91, 0x39, 0xc9, 0x44, 0x27, 0x47, 0xf5, 0xb8, 0x41,
+	0x66, 0x54, 0x1c, 0xa3, 0xf7, 0xa4, 0x33, 0xba, 0xae, 0x08, 0x22, 0x39, 0xba, 0x12, 0x73, 0xd1,
+	0xb5, 0x71, 0xf3, 0xf4, 0x65, 0x46, 0x22, 0x62, 0x85, 0x46, 0x23, 0x92, 0x98, 0xb0, 0x3a, 0x62,
+	0x16, 0x1f, 0x07, 0xcc, 0x36, 0x5f, 0x3a, 0x6c, 0x68, 0xcb, 0x8b, 0x97, 0xca, 0xcc, 0xc7, 0x8d,
+	0x48, 0x2d, 0xf5, 0x47, 0x62, 0x34, 0xad, 0x44, 0x70, 0x92, 0xc6, 0xcc, 0x41, 0xfe, 0x23, 0xab,
+	0x50, 0xee, 0x3e, 0xef, 0xf6, 0x5a, 0xfb, 0xe6, 0xfe, 0xc1, 0x4e, 0x4b, 0x55, 0x55, 0x75, 0x5b,
+	0x54, 0x92, 0x1a, 0xb6, 0xf7, 0x0e, 0x7a, 0x8d, 0x3d, 0xb3, 0xd7, 0x6e, 0x3e, 0xed, 0xea, 0x19,
+	0xb2, 0x09, 0x6b, 0xbd, 0x5d, 0x7a, 0xd0, 0xeb, 0xed, 0xb5, 0x76, 0xcc, 0xc3, 0x16, 0x6d, 0x1f,
+	0xec, 0x74, 0xf5, 0x2c, 0xde, 0x13, 0x4f, 0xd8, 0xbd, 0xf6, 0x7e, 0x4b, 0xcf, 0x61, 0x1d, 0xcd,
+	0x61, 0x8b, 0x36, 0x5b, 0x9d, 0x9e, 0x9e, 0x37, 0x7e, 0x9e, 0x85, 0x72, 0xc2, 0x8a, 0xe8, 0xc8,
+	0x01, 0x97, 0xe7, 0x9a, 0x1c, 0xc5, 0xbf, 0xe2, 0x15, 0xd8, 0xea, 0x9f, 0x48, 0xeb, 0xe4, 0xa8,
+	0x24, 0xc4, 0x59, 0xc6, 0x3a, 0x4f, 0xac, 0xf3, 0x1c, 0x2d, 0x8e, 0xac, 0x73, 0x09, 0xf2, 0x6d,
+	0x58, 0x3e, 0x65, 0x81, 0xcb, 0x86, 0xaa, 0x5d, 0x5a, 0xa4, 0x2c, 0x79, 0xb2, 0xcb, 0x16, 0xe8,
+	0xaa, 0xcb, 0x04, 0x46, 0x9a, 0xa3, 0x22, 0xf9, 0xfb, 0x11, 0xd8, 0x06, 0xe4, 0x65, 0xf3, 0x92,
+	0x9c, 0x5f, 0x10, 0xb8, 0x4d, 0xf1, 0xd7, 0x96, 0x2f, 0x72, 0xc8, 0x1c, 0x15, 0xff, 0xc9, 0xf1,
+	0xb4, 0x7d, 0x0a, 0xc2, 0x3e, 0xf7, 0xe6, 0x77, 0xe7, 0x37, 0x99, 0xe8, 0x24, 0x36, 0xd1, 0x12,
+	0x64, 0x69, 0x54, 0x8a, 0xd4, 0x6c, 0x34, 0x77, 0xd1, 0x2c, 0x2b, 0x50, 0xda, 0x6f, 0xfc, 0xd8,
+	0x3c, 0xea, 0xca, 0x1b, 0x7c, 0x1d, 0x96, 0x9f, 0xb6, 0x68, 0xa7, 0xb5, 0xa7, 0x38, 0x59, 0xb2,
+	0x01, 0xba, 0xe2, 0x4c, 0xfa, 0xe5, 0x10, 0x41, 0xfe, 0xcd, 0xe3, 0x2d, 0x6f, 0xf7, 0x59, 0xe3,
+	0x50, 0x2f, 0x18, 0xff, 0x93, 0x81, 0x55, 0xb9, 0x2d, 0xc4, 0x45, 0x13, 0x6f, 0x7e, 0x34, 0x4e,
+	0xde, 0x62, 0x65, 0xd2, 0xb7, 0x58, 0x51, 0x12, 0x2a, 0x76, 0xf5, 0xec, 0x24, 0x09, 0x15, 0x37,
+	0x3b, 0xa9, 0x88, 0x9f, 0x9b, 0x27, 0xe2, 0x57, 0x61, 0x69, 0xc4, 0x78, 0x6c, 0xb7, 0x12, 0x8d,
+	0x48, 0xe2, 0x40, 0xd9, 0x72, 0x5d, 0x2f, 0xb4, 0xe4, 0xd5, 0x70, 0x61, 0xae, 0xcd, 0xf0, 0xd2,
+	0x17, 0xd7, 0x1b, 0x13, 0x24, 0x19, 0x98, 0x93, 0xd8, 0xb5, 0x1f, 0x81, 0x7e, 0xb9, 0xc3, 0x3c,
+	0xdb, 0xe1, 0x77, 0xbf, 0x3f, 0xd9, 0x0d, 0x19, 0xae, 0x0b, 0xf5, 0xa6, 0xa2, 0xdf, 0x40, 0x82,
+	0x1e, 0x75, 0x3a, 0xed, 0xce, 0x63, 0x5d, 0xc3, 0x97, 0x98, 0xd6, 0x8f, 0xdb, 0x58, 0xde, 0x98,
+	0xd9, 0xfe, 0xc5, 0x1a, 0x14, 0xa4, 0x90, 0xe4, 0x1b, 0x95, 0x09, 0x24, 0x0b, 0x72, 0xc9, 0x8f,
+	0xe6, 0xce, 0xa8, 0x53, 0x45, 0xbe, 0xb5, 0x87, 0x0b, 0x8f, 0x57, 0x0f, 0xa0, 0x37, 0xc8, 0xdf,
+	0x68, 0xb0, 0x9c, 0x7a, 0xfc, 0x9c, 0xf5, 0x6a, 0xfc, 0x8a, 0xfa, 0xdf, 0xda, 0x0f, 0x17, 0x1a,
+	0x1b, 0xcb, 0xf2, 0x33, 0x0d, 0xca, 0x89, 0xca, 0x57, 0x72, 0x6f, 0x91, 0x6a, 0x59, 0x29, 0xc9,
+	0xfd, 0xc5, 0x0b, 0x6d, 0x8d, 0x1b, 0x9f, 0x68, 0xe4, 0xaf, 0x35, 0x28, 0x27, 0x6a, 0x40, 0x67,
+	0x16, 0x65, 0xba, 0x62, 0xb5, 0x76, 0x7f, 0x91, 0xa1, 0xb1, 0x4e, 0xfe, 0x52, 0x83, 0x52, 0x5c,
+	0xcf, 0x49, 0xee, 0xce, 0x5f, 0x01, 0x2a, 0x85, 0xf8, 0x6c, 0xd1, 0xd2, 0x51, 0xe3, 0x06, 0xf9,
+	0x73, 0x28, 0x46, 0xc5, 0x8f, 0x64, 0xd6, 0xdd, 0xeb, 0x52, 0x65, 0x65, 0xed, 0xee, 0xdc, 0xe3,
+	0x92, 0xd3, 0x47, 0x15, 0x89, 0x33, 0x4f, 0x7f, 0xa9, 0x76, 0xb2, 0x76, 0x77, 0xee, 0x71, 0xf1,
+	0xf4, 0xe8, 0x09, 0x89, 0xc2, 0xc5, 0x99, 0x3d, 0x61, 0xba, 0x62, 0xb2, 0x76, 0x7f, 0x91, 0xa1,
+	0x29, 0x41, 0x12, 0xa5, 0x8f, 0x33, 0x0b, 0x32, 0x5d, 0x5e, 0x59, 0xbb, 0xbf, 0xc8, 0xd0, 0x58,
+	0x90, 0x9f, 0x6a, 0xc9, 0x73, 0xc1, 0xdd, 0xb9, 0x2b, 0xfc, 0xe6, 0x74, 0xc9, 0xa9, 0x1a, 0x43,
+	0xb1, 0x40, 0x7f, 0xaa, 0x6e, 0x31, 0x64, 0x81, 0x20, 0x99, 0x07, 0x2c, 0x55, 0x53, 0x58, 0xfb,
+	0x74, 0xb1, 0xcd, 0x46, 0x08, 0xf1, 0x57, 0x1a, 0xc0, 0xa4, 0x94, 0x70, 0x66, 0x21, 0xa6, 0x6a,
+	0x18, 0x6b, 0xf7, 0x16, 0x18, 0x99, 0x5c, 0x20, 0x51, 0xa9, 0xd3, 0xcc, 0x0b, 0xe4, 0x52, 0xa9,
+	0x63, 0xed, 0xee, 0xdc, 0xe3, 0xe2, 0xe9, 0xff, 0x59, 0x83, 0xb5, 0xa9, 0x52, 0x2b, 0xf2, 0xf0,
+	0x9a, 0xd5, 0x76, 0xb5, 0x2f, 0x16, 0x07, 0x88, 0x44, 0xdb, 0xd2, 0x3e, 0xd1, 0xc8, 0xdf, 0x6a,
+	0xb0, 0x92, 0x2e, 0x41, 0x99, 0x79, 0x97, 0xba, 0xa2, 0x68, 0xab, 0xf6, 0x60, 0xb1, 0xc1, 0xb1,
+	0xb6, 0xfe, 0x5e, 0x83, 0x8a, 0x5a, 0xdf, 0x91, 0x3c, 0x0f, 0xe6, 0x0b, 0x0b, 0x97, 0x04, 0xfa,
+	0x7c, 0xc1, 0xd1, 0x91, 0x44, 0x5f, 0x2e, 0xfd, 0x51, 0x5e, 0x66, 0x6f, 0x05, 0xf1, 0xf3, 0x83,
+	0x5f, 0x0f, 0x00, 0x19, 0xa4, 0x09, 0x92, 0x37, 0x35, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -51,6 +51,7 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
 			DynamicWorkloadUsers:  caps.DynamicWorkloadUsers,
+			ResizeResources:       caps.ResizeResources,
 		},
 	}
 
@@ -42,6 +42,9 @@ type Context interface {
 	// SemverConstraintCache is a cache of semver constraints
 	SemverConstraintCache() map[string]VerConstraints
 
+	// AllocSelectorCache is a cache of parsed allocation selectors
+	AllocSelectorCache() map[string]*structs.AllocSelector
+
 	// Eligibility returns a tracker for node eligibility in the context of the
 	// eval.
 	Eligibility() *EvalEligibility
@@ -53,9 +56,10 @@ type Context interface {
 
 // EvalCache is used to cache certain things during an evaluation
 type EvalCache struct {
-	reCache      map[string]*regexp.Regexp
-	versionCache map[string]VerConstraints
-	semverCache  map[string]VerConstraints
+	reCache       map[string]*regexp.Regexp
+	versionCache  map[string]VerConstraints
+	semverCache   map[string]VerConstraints
+	selectorCache map[string]*structs.AllocSelector
 }
 
 func (e *EvalCache) RegexpCache() map[string]*regexp.Regexp {
@@ -79,6 +83,13 @@ func (e *EvalCache) SemverConstraintCache() map[string]VerConstraints {
 	return e.semverCache
 }
 
+func (e *EvalCache) AllocSelectorCache() map[string]*structs.AllocSelector {
+	if e.selectorCache == nil {
+		e.selectorCache = make(map[string]*structs.AllocSelector)
+	}
+	return e.selectorCache
+}
+
 // PortCollisionEvent is an event that can happen during scheduling when
 // an unexpected port collision is detected.
 type PortCollisionEvent struct {
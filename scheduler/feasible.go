@@ -135,9 +135,12 @@ func NewRandomIterator(ctx Context, nodes []*structs.Node) *StaticIterator {
 }
 
 // HostVolumeChecker is a FeasibilityChecker which returns whether a node has
-// the host volumes necessary to schedule a task group.
+// the host volumes necessary to schedule a task group. Host volumes may be
+// either statically configured on the client or dynamic host volumes created
+// via the API, which can only be mounted by jobs in the same namespace.
 type HostVolumeChecker struct {
-	ctx Context
+	ctx       Context
+	namespace string
 
 	// volumes is a map[HostVolumeName][]RequestedVolume. The requested volumes are
 	// a slice because a single task group may request the same volume multiple times.
@@ -151,6 +154,12 @@ func NewHostVolumeChecker(ctx Context) *HostVolumeChecker {
 	}
 }
 
+// SetNamespace sets the namespace of the job, which must match the namespace
+// of any dynamic host volumes.
+func (h *HostVolumeChecker) SetNamespace(namespace string) {
+	h.namespace = namespace
+}
+
 // SetVolumes takes the volumes required by a task group and updates the checker.
 func (h *HostVolumeChecker) SetVolumes(allocName string, volumes map[string]*structs.VolumeRequest) {
 	lookupMap := make(map[string][]*structs.VolumeRequest)
@@ -183,23 +192,34 @@ func (h *HostVolumeChecker) Feasible(candidate *structs.Node) bool {
 }
 
 func (h *HostVolumeChecker) hasVolumes(n *structs.Node) bool {
-	rLen := len(h.volumes)
-	hLen := len(n.HostVolumes)
-
 	// Fast path: Requested no volumes. No need to check further.
-	if rLen == 0 {
+	if len(h.volumes) == 0 {
 		return true
 	}
 
-	// Fast path: Requesting more volumes than the node has, can't meet the criteria.
-	if rLen > hLen {
-		return false
-	}
+	// Dynamic host volumes are only looked up in the state store if the node
+	// has any, or if the node hasn't yet been updated with a volume that was
+	// just created.
+	var dynamicVols map[string]*structs.HostVolume
 
 	for source, requests := range h.volumes {
 		nodeVolume, ok := n.HostVolumes[source]
-		if !ok {
-			return false
+		if !ok || nodeVolume.ID != "" {
+			if dynamicVols == nil {
+				var err error
+				dynamicVols, err = h.dynamicVolumes(n.ID)
+				if err != nil {
+					h.ctx.Logger().Error("failed to lookup dynamic host volumes",
+						"node_id", n.ID, "error", err)
+					return false
+				}
+			}
+			vol, ok := dynamicVols[source]
+			if !ok || !vol.IsReady() {
+				return false
+			}
+			// Dynamic volumes can always be mounted ReadWrite.
+			continue
 		}
 
 		// If the volume supports being mounted as ReadWrite, we do not need to
@@ -220,6 +240,25 @@ func (h *HostVolumeChecker) hasVolumes(n *structs.Node) bool {
 	return true
 }
 
+// dynamicVolumes returns the dynamic host volumes on the node in the job's
+// namespace, by name.
+func (h *HostVolumeChecker) dynamicVolumes(nodeID string) (map[string]*structs.HostVolume, error) {
+	iter, err := h.ctx.State().HostVolumesByNodeID(nil, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	vols := map[string]*structs.HostVolume{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vol := raw.(*structs.HostVolume)
+		if vol.Namespace != h.namespace {
+			continue
+		}
+		vols[vol.Name] = vol
+	}
+	return vols, nil
+}
+
 type CSIVolumeChecker struct {
 	ctx       Context
 	namespace string
@@ -643,6 +682,124 @@ func (iter *DistinctHostsIterator) Reset() {
 	iter.source.Reset()
 }
 
+// AllocColocationIterator is a FeasibleIterator which returns nodes that pass
+// the colocated_with and not_colocated_with constraints. The constraints
+// require or forbid allocations selected by their target to be running, or
+// proposed to run, on the node.
+type AllocColocationIterator struct {
+	ctx    Context
+	source FeasibleIterator
+	job    *structs.Job
+
+	jobConstraints []*structs.Constraint
+	constraints    []*structs.Constraint
+}
+
+// NewAllocColocationIterator creates an AllocColocationIterator from a source.
+func NewAllocColocationIterator(ctx Context, source FeasibleIterator) *AllocColocationIterator {
+	return &AllocColocationIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *AllocColocationIterator) SetJob(job *structs.Job) {
+	iter.job = job
+	iter.jobConstraints = allocConstraints(job.Constraints)
+}
+
+func (iter *AllocColocationIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.constraints = append(allocConstraints(tg.Constraints), iter.jobConstraints...)
+}
+
+// allocConstraints returns the constraints which match allocations rather
+// than node attributes.
+func allocConstraints(constraints []*structs.Constraint) []*structs.Constraint {
+	var out []*structs.Constraint
+	for _, c := range constraints {
+		if structs.IsAllocOperand(c.Operand) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (iter *AllocColocationIterator) Next() *structs.Node {
+	for {
+		option := iter.source.Next()
+
+		// Hot-path if the option is nil or there are no constraints
+		if option == nil || len(iter.constraints) == 0 {
+			return option
+		}
+
+		if ok, failed := satisfiesAllocConstraints(iter.ctx, iter.job.Namespace, iter.constraints, option); !ok {
+			iter.ctx.Metrics().FilterNode(option, failed.String())
+			continue
+		}
+		return option
+	}
+}
+
+func (iter *AllocColocationIterator) Reset() {
+	iter.source.Reset()
+}
+
+// satisfiesAllocConstraints returns whether the node satisfies the
+// colocated_with and not_colocated_with constraints, along with the first
+// constraint it doesn't satisfy. The namespace is that of the job the
+// constraints belong to.
+func satisfiesAllocConstraints(ctx Context, namespace string, constraints []*structs.Constraint, option *structs.Node) (bool, *structs.Constraint) {
+	for _, c := range constraints {
+		if !structs.IsAllocOperand(c.Operand) {
+			continue
+		}
+		if !matchesAllocOperand(ctx, namespace, c.Operand, c.RTarget, option) {
+			return false, c
+		}
+	}
+	return true, nil
+}
+
+// matchesAllocOperand returns whether the allocations proposed to run on the
+// node satisfy the colocated_with or not_colocated_with operand for the
+// allocation selector in the target.
+func matchesAllocOperand(ctx Context, namespace, operand, target string, option *structs.Node) bool {
+	// Check the cache
+	cache := ctx.AllocSelectorCache()
+	selector := cache[target]
+
+	// Parse the selector
+	if selector == nil {
+		var err error
+		selector, err = structs.ParseAllocSelector(target)
+		if err != nil {
+			ctx.Logger().Named("alloc_colocation").Error("failed to parse allocation selector", "error", err)
+			return false
+		}
+		cache[target] = selector
+	}
+
+	proposed, err := ctx.ProposedAllocs(option.ID)
+	if err != nil {
+		ctx.Logger().Named("alloc_colocation").Error("failed to get proposed allocations", "error", err)
+		return false
+	}
+
+	colocated := false
+	for _, alloc := range proposed {
+		if !alloc.TerminalStatus() && selector.Matches(namespace, alloc) {
+			colocated = true
+			break
+		}
+	}
+
+	if operand == structs.ConstraintNotColocatedWith {
+		return !colocated
+	}
+	return colocated
+}
+
 // DistinctPropertyIterator is a FeasibleIterator which returns nodes that pass the
 // distinct_property constraint. The constraint ensures that multiple allocations
 // do not use the same value of the given property.
@@ -779,6 +936,17 @@ func (c *ConstraintChecker) Feasible(option *structs.Node) bool {
 	return true
 }
 
+// meetsConstraints is used to check if the node meets all the constraints
+// without recording the node as filtered.
+func (c *ConstraintChecker) meetsConstraints(option *structs.Node) bool {
+	for _, constraint := range c.constraints {
+		if !c.meetsConstraint(constraint, option) {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *ConstraintChecker) meetsConstraint(constraint *structs.Constraint, option *structs.Node) bool {
 	// Resolve the targets. Targets that are not present are treated as `nil`.
 	// This is to allow for matching constraints where a target is not present.
@@ -833,7 +1001,8 @@ func resolveTarget(target string, node *structs.Node) (string, bool) {
 func checkConstraint(ctx Context, operand string, lVal, rVal interface{}, lFound, rFound bool) bool {
 	// Check for constraints not handled by this checker.
 	switch operand {
-	case structs.ConstraintDistinctHosts, structs.ConstraintDistinctProperty:
+	case structs.ConstraintDistinctHosts, structs.ConstraintDistinctProperty,
+		structs.ConstraintColocatedWith, structs.ConstraintNotColocatedWith:
 		return true
 	default:
 		break
@@ -1427,7 +1596,8 @@ func resolveDeviceTarget(target string, d *structs.NodeDeviceResource) (*psstruc
 func checkAttributeConstraint(ctx Context, operand string, lVal, rVal *psstructs.Attribute, lFound, rFound bool) bool {
 	// Check for constraints not handled by this checker.
 	switch operand {
-	case structs.ConstraintDistinctHosts, structs.ConstraintDistinctProperty:
+	case structs.ConstraintDistinctHosts, structs.ConstraintDistinctProperty,
+		structs.ConstraintColocatedWith, structs.ConstraintNotColocatedWith:
 		return true
 	default:
 		break
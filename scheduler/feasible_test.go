@@ -184,6 +184,65 @@ func TestHostVolumeChecker(t *testing.T) {
 	}
 }
 
+func TestHostVolumeChecker_Dynamic(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	for i, node := range nodes {
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(1000+i), node))
+	}
+
+	ready := &structs.HostVolume{
+		Namespace: structs.DefaultNamespace,
+		ID:        uuid.Generate(),
+		Name:      "foo",
+		NodeID:    nodes[0].ID,
+		State:     structs.HostVolumeStateReady,
+	}
+	otherNamespace := ready.Copy()
+	otherNamespace.ID = uuid.Generate()
+	otherNamespace.Namespace = "other"
+	otherNamespace.NodeID = nodes[1].ID
+	pending := ready.Copy()
+	pending.ID = uuid.Generate()
+	pending.NodeID = nodes[2].ID
+	pending.State = structs.HostVolumeStatePending
+
+	for i, vol := range []*structs.HostVolume{ready, otherNamespace, pending} {
+		must.NoError(t, store.UpsertHostVolume(uint64(1010+i), vol))
+	}
+
+	// Only the first node has been updated with its dynamic volume, the
+	// others will be looked up in the state store.
+	nodes[0].HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"foo": {Name: "foo", ID: ready.ID, Path: "/var/nomad/host_volumes/" + ready.ID},
+	}
+
+	volumes := map[string]*structs.VolumeRequest{
+		"foo": {
+			Type:   "host",
+			Source: "foo",
+		},
+	}
+
+	checker := NewHostVolumeChecker(ctx)
+	checker.SetNamespace(structs.DefaultNamespace)
+	checker.SetVolumes("example.web[0]", volumes)
+
+	must.True(t, checker.Feasible(nodes[0]), must.Sprint("ready volume"))
+	must.False(t, checker.Feasible(nodes[1]), must.Sprint("volume in other namespace"))
+	must.False(t, checker.Feasible(nodes[2]), must.Sprint("pending volume"))
+
+	checker.SetNamespace("other")
+	must.False(t, checker.Feasible(nodes[0]), must.Sprint("ready volume in other namespace"))
+	must.True(t, checker.Feasible(nodes[1]), must.Sprint("volume not yet on node"))
+}
+
 func TestHostVolumeChecker_ReadOnly(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1693,6 +1752,116 @@ func TestDistinctHostsIterator_TaskGroupDistinctHosts(t *testing.T) {
 	}
 }
 
+func TestAllocColocationIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	for i, node := range nodes {
+		must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+	}
+
+	// The api job runs its web group on node1 and its cache group is
+	// proposed to run on node2, while a stopped web alloc remains on node3
+	api := mock.Job()
+	api.ID = "api"
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, api))
+
+	running := mock.Alloc()
+	running.Job = api
+	running.JobID = api.ID
+	running.TaskGroup = "web"
+	running.NodeID = nodes[0].ID
+
+	stopped := mock.Alloc()
+	stopped.Job = api
+	stopped.JobID = api.ID
+	stopped.TaskGroup = "web"
+	stopped.NodeID = nodes[2].ID
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.Allocation{running, stopped}))
+
+	ctx.Plan().NodeAllocation[nodes[1].ID] = []*structs.Allocation{{
+		ID:        uuid.Generate(),
+		Namespace: structs.DefaultNamespace,
+		JobID:     "api",
+		TaskGroup: "cache",
+		NodeID:    nodes[1].ID,
+	}}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+
+	testCases := []struct {
+		name        string
+		job         []*structs.Constraint
+		tg          []*structs.Constraint
+		expected    []*structs.Node
+		filteredFor string
+	}{
+		{
+			name:     "no constraints",
+			expected: nodes,
+		},
+		{
+			name: "colocated with job",
+			tg: []*structs.Constraint{
+				{Operand: structs.ConstraintColocatedWith, RTarget: "job=api"},
+			},
+			expected:    []*structs.Node{nodes[0], nodes[1]},
+			filteredFor: " colocated_with job=api",
+		},
+		{
+			name: "colocated with group",
+			job: []*structs.Constraint{
+				{Operand: structs.ConstraintColocatedWith, RTarget: "job=api,group=web"},
+			},
+			expected:    []*structs.Node{nodes[0]},
+			filteredFor: " colocated_with job=api,group=web",
+		},
+		{
+			name: "not colocated with group",
+			tg: []*structs.Constraint{
+				{Operand: structs.ConstraintNotColocatedWith, RTarget: "job=api,group=cache"},
+			},
+			expected:    []*structs.Node{nodes[0], nodes[2]},
+			filteredFor: " not_colocated_with job=api,group=cache",
+		},
+		{
+			name: "other namespace",
+			tg: []*structs.Constraint{
+				{Operand: structs.ConstraintNotColocatedWith, RTarget: "job=api,namespace=other"},
+			},
+			expected: nodes,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx.Reset()
+			job.Constraints = tc.job
+			tg.Constraints = tc.tg
+
+			iter := NewAllocColocationIterator(ctx, NewStaticIterator(ctx, nodes))
+			iter.SetJob(job)
+			iter.SetTaskGroup(tg)
+
+			out := collectFeasible(iter)
+			must.SliceContainsAll(t, tc.expected, out)
+			if tc.filteredFor != "" {
+				must.Eq(t, len(nodes)-len(tc.expected),
+					ctx.Metrics().ConstraintFiltered[tc.filteredFor])
+			}
+		})
+	}
+}
+
 // This test puts creates allocations across task groups that use a property
 // value to detect if the constraint at the job level properly considers all
 // task groups.
@@ -6,6 +6,7 @@ package scheduler
 import (
 	"fmt"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"time"
 
@@ -73,6 +74,10 @@ const (
 	// timeout has passed.
 	disconnectTimeoutFollowupEvalDesc = "created for delayed disconnect timeout"
 
+	// disruptionBudgetFollowupEvalDesc is the description used when creating
+	// follow up evals for destructive updates held back by a disruption budget.
+	disruptionBudgetFollowupEvalDesc = "created for updates delayed by disruption budget"
+
 	// maxPastRescheduleEvents is the maximum number of past reschedule event
 	// that we track when unlimited rescheduling is enabled
 	maxPastRescheduleEvents = 5
@@ -114,6 +119,10 @@ type GenericScheduler struct {
 	// before being rescheduled
 	followUpEvals []*structs.Evaluation
 
+	// arrayFollowup is whether placements of a job array were held back by
+	// the limit of placements per plan
+	arrayFollowup bool
+
 	deployment *structs.Deployment
 
 	blocked        *structs.Evaluation
@@ -170,7 +179,9 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerDisruptionBudget, structs.EvalTriggerRebalance,
+		structs.EvalTriggerJobArray:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -261,6 +272,7 @@ func (s *GenericScheduler) process() (bool, error) {
 	}
 	s.queuedAllocs = make(map[string]int, numTaskGroups)
 	s.followUpEvals = nil
+	s.arrayFollowup = false
 
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
@@ -355,10 +367,68 @@ func (s *GenericScheduler) process() (bool, error) {
 		return false, nil
 	}
 
+	// Place the rest of a job array once the placements of this plan are
+	// applied. Nothing was placed when the cluster is full, in which case the
+	// blocked evaluation places the rest instead.
+	if s.arrayFollowup && len(result.NodeAllocation) != 0 {
+		if err := s.createArrayFollowupEval(); err != nil {
+			s.logger.Error("failed to make job array followup eval", "error", err)
+			return false, err
+		}
+	}
+
 	// Success!
 	return true, nil
 }
 
+// createArrayFollowupEval creates an evaluation placing the allocations of a
+// job array held back by the limit of placements per plan, unless the job
+// already has one pending.
+func (s *GenericScheduler) createArrayFollowupEval() error {
+	pending, err := s.hasPendingEval(nil, structs.EvalTriggerJobArray)
+	if err != nil || pending {
+		return err
+	}
+
+	now := time.Now().UTC().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      s.job.Namespace,
+		Priority:       s.eval.Priority,
+		Type:           s.job.Type,
+		TriggeredBy:    structs.EvalTriggerJobArray,
+		JobID:          s.job.ID,
+		JobModifyIndex: s.job.ModifyIndex,
+		Status:         structs.EvalStatusPending,
+		PreviousEval:   s.eval.ID,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	if err := s.planner.CreateEval(eval); err != nil {
+		return err
+	}
+	s.logger.Debug("job array placements held back, followup eval created", "followup_eval_id", eval.ID)
+	return nil
+}
+
+// hasPendingEval returns whether the job has a pending evaluation, other than
+// the one being processed, triggered by the given reason.
+func (s *GenericScheduler) hasPendingEval(ws memdb.WatchSet, triggeredBy string) (bool, error) {
+	evals, err := s.state.EvalsByJob(ws, s.eval.Namespace, s.eval.JobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get evals for job '%s': %v",
+			s.eval.JobID, err)
+	}
+	for _, eval := range evals {
+		if eval.ID != s.eval.ID &&
+			eval.TriggeredBy == triggeredBy &&
+			eval.Status == structs.EvalStatusPending {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // computeJobAllocs is used to reconcile differences between the job,
 // existing allocations and node status to update the allocations.
 func (s *GenericScheduler) computeJobAllocs() error {
@@ -381,10 +451,18 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	// nodes to lost, but only if the scheduler has already marked them
 	updateNonTerminalAllocsToLost(s.plan, tainted, allocs)
 
+	// Reuse the pending follow up eval of updates held back by the disruption
+	// budget, rather than chaining another one on every evaluation
+	disruptionFollowup, err := s.hasPendingEval(ws, structs.EvalTriggerDisruptionBudget)
+	if err != nil {
+		return err
+	}
+
 	reconciler := NewAllocReconciler(s.logger,
 		genericAllocUpdateFn(s.ctx, s.stack, s.eval.ID),
 		s.batch, s.eval.JobID, s.job, s.deployment, allocs, tainted, s.eval.ID,
-		s.eval.Priority, s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true))
+		s.eval.Priority, s.planner.ServersMeetMinimumVersion(minVersionMaxClientDisconnect, true),
+		AllocReconcilerWithDisruptionFollowup(disruptionFollowup))
 
 	results := reconciler.Compute()
 	s.logger.Debug("reconciled current state with desired state", "results", log.Fmt("%#v", results))
@@ -405,6 +483,7 @@ func (s *GenericScheduler) computeJobAllocs() error {
 			s.followUpEvals = append(s.followUpEvals, evals...)
 		}
 	}
+	s.arrayFollowup = results.arrayFollowup
 
 	// Update the stored deployment
 	if results.deployment != nil {
@@ -524,10 +603,14 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements of gang task groups, so they can be reverted if
+	// any placement of the group fails.
+	var gangPlacements map[string][]gangPlacement
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
-	for _, results := range [][]placementResult{destructive, place} {
+	for i, results := range [][]placementResult{destructive, place} {
 		for _, missing := range results {
 			// Get the task group
 			tg := missing.TaskGroup()
@@ -707,6 +790,17 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				if tg.Gang {
+					if gangPlacements == nil {
+						gangPlacements = make(map[string][]gangPlacement)
+					}
+					placement := gangPlacement{alloc: alloc, destructive: i == 0}
+					if stopPrevAlloc {
+						placement.stoppedPrev = prevAllocation
+					}
+					gangPlacements[tg.Name] = append(gangPlacements[tg.Name], placement)
+				}
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -729,9 +823,87 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	// Gang task groups are placed all at once or not at all, so revert the
+	// placements of the groups which failed to place any allocation. The
+	// placements of the other groups must not be partially committed either,
+	// if the plan applier rejects some of their nodes.
+	for tgName, placements := range gangPlacements {
+		metric, ok := s.failedTGAllocs[tgName]
+		if !ok {
+			s.plan.AllAtOnce = true
+			continue
+		}
+		s.revertGangPlacements(tgName, placements)
+		metric.GangPlacementsReverted += len(placements)
+	}
+
 	return nil
 }
 
+// gangPlacement is a placement of a gang task group, which must be reverted
+// if any other placement of the group fails.
+type gangPlacement struct {
+	// alloc is the placed allocation.
+	alloc *structs.Allocation
+
+	// stoppedPrev is the previous allocation stopped by the placement, if any.
+	stoppedPrev *structs.Allocation
+
+	// destructive is whether the placement is a destructive update of an
+	// existing allocation rather than a new placement.
+	destructive bool
+}
+
+// revertGangPlacements removes the placements of a gang task group from the
+// plan, along with the stops and preemptions made for them, and backs them
+// out of the plan annotations.
+func (s *GenericScheduler) revertGangPlacements(tgName string, placements []gangPlacement) {
+	var desired *structs.DesiredUpdates
+	if s.eval.AnnotatePlan && s.plan.Annotations != nil && s.plan.Annotations.DesiredTGUpdates != nil {
+		desired = s.plan.Annotations.DesiredTGUpdates[tgName]
+	}
+
+	for _, placement := range placements {
+		s.plan.RemoveAlloc(placement.alloc)
+		if placement.stoppedPrev != nil {
+			s.plan.RemoveUpdate(placement.stoppedPrev)
+		}
+
+		if desired != nil {
+			if placement.destructive {
+				desired.DestructiveUpdate--
+			} else {
+				desired.Place--
+			}
+		}
+
+		preempted := placement.alloc.PreemptedAllocations
+		if len(preempted) == 0 {
+			continue
+		}
+		s.plan.RemovePreemptions(placement.alloc.ID)
+
+		if s.eval.AnnotatePlan && s.plan.Annotations != nil {
+			s.plan.Annotations.PreemptedAllocs = slices.DeleteFunc(s.plan.Annotations.PreemptedAllocs,
+				func(stub *structs.AllocListStub) bool {
+					return slices.Contains(preempted, stub.ID)
+				})
+		}
+		if desired != nil {
+			desired.Preemptions -= uint64(len(preempted))
+		}
+	}
+
+	// The reverted allocations are not part of the plan, so the deployment
+	// never counts them as placed. Back them out of the desired total of a
+	// deployment created by this plan, as they have not been placed yet.
+	if s.deployment != nil && s.plan.Deployment == s.deployment {
+		if dstate, ok := s.deployment.TaskGroups[tgName]; ok {
+			dstate.DesiredTotal -= len(placements)
+		}
+	}
+}
+
 // setJob updates the stack with the given job and job's node pool scheduler
 // configuration.
 func (s *GenericScheduler) setJob(job *structs.Job) error {
@@ -827,6 +999,12 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 				penaltyNodes[reschedEvent.PrevNodeID] = struct{}{}
 			}
 		}
+
+		// If alloc is migrated, penalize the node it is migrated from so
+		// that the rebalancer doesn't place it back where it came from.
+		if prevAllocation.DesiredTransition.ShouldMigrate() {
+			penaltyNodes[prevAllocation.NodeID] = struct{}{}
+		}
 		selectOptions.PenaltyNodeIDs = penaltyNodes
 	}
 	if preferredNode != nil {
@@ -170,7 +170,7 @@ func TestServiceSched_JobRegister_StickyAllocs(t *testing.T) {
 
 	// Update the job to force a rolling upgrade
 	updated := job.Copy()
-	updated.TaskGroups[0].Tasks[0].Resources.CPU += 10
+	updated.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
 	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, updated))
 
 	// Create a mock evaluation to handle the update
@@ -2547,6 +2547,79 @@ func TestServiceSched_JobModify_CountZero(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobModify_DisruptionBudgetFollowup(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, pending := range []bool{false, true} {
+		t.Run(fmt.Sprintf("pending=%v", pending), func(t *testing.T) {
+			h := NewHarness(t)
+
+			var nodes []*structs.Node
+			for i := 0; i < 3; i++ {
+				node := mock.Node()
+				nodes = append(nodes, node)
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			}
+
+			job := mock.Job()
+			job.TaskGroups[0].Count = 3
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			var allocs []*structs.Allocation
+			for i := 0; i < 3; i++ {
+				alloc := mock.Alloc()
+				alloc.Job = job
+				alloc.JobID = job.ID
+				alloc.NodeID = nodes[i].ID
+				alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+				alloc.ClientStatus = structs.AllocClientStatusRunning
+				allocs = append(allocs, alloc)
+			}
+			must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+			// Update the job destructively, with a disruption budget which
+			// holds back all but one of the updates
+			job2 := job.Copy()
+			job2.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{MaxUnavailable: 1}
+			job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+			evals := []*structs.Evaluation{{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    50,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}}
+			if pending {
+				followup := evals[0].Copy()
+				followup.ID = uuid.Generate()
+				followup.TriggeredBy = structs.EvalTriggerDisruptionBudget
+				followup.WaitUntil = time.Now().Add(time.Minute)
+				evals = append(evals, followup)
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), evals))
+			must.NoError(t, h.Process(NewServiceScheduler, evals[0]))
+
+			must.Len(t, 1, h.Plans)
+			var update []*structs.Allocation
+			for _, updateList := range h.Plans[0].NodeUpdate {
+				update = append(update, updateList...)
+			}
+			must.Len(t, 1, update)
+
+			// A follow up eval is only created if there isn't one pending
+			if pending {
+				must.SliceEmpty(t, h.CreateEvals)
+				return
+			}
+			must.Len(t, 1, h.CreateEvals)
+			must.Eq(t, structs.EvalTriggerDisruptionBudget, h.CreateEvals[0].TriggeredBy)
+		})
+	}
+}
+
 func TestServiceSched_JobModify_Rolling(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3048,6 +3121,118 @@ func TestServiceSched_JobModify_InPlace(t *testing.T) {
 	}
 }
 
+// TestServiceSched_JobModify_InPlaceResources asserts that changing only the
+// cpu and memory of a task is an in-place update when the node still fits the
+// new resources and its client can resize tasks, and a destructive update
+// otherwise.
+func TestServiceSched_JobModify_InPlaceResources(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		nodeVersion string
+		cpu         int
+		memory      int
+		destructive bool
+		replaced    bool
+	}{
+		{
+			name:        "fits",
+			nodeVersion: "1.7.7",
+			cpu:         1000,
+			memory:      512,
+		},
+		{
+			name:        "does not fit",
+			nodeVersion: "1.7.7",
+			cpu:         20000,
+			memory:      512,
+			destructive: true,
+		},
+		{
+			name:        "client cannot resize",
+			nodeVersion: "1.7.6",
+			cpu:         1000,
+			memory:      512,
+			replaced:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+
+			node := mock.Node()
+			node.Attributes["nomad.version"] = tc.nodeVersion
+			must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+			job := mock.Job()
+			job.TaskGroups[0].Count = 1
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			alloc := mock.AllocForNode(node)
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.Name = "my-job.web[0]"
+			must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+			// Update only the resources of the task
+			job2 := job.Copy()
+			job2.TaskGroups[0].Tasks[0].Resources.CPU = tc.cpu
+			job2.TaskGroups[0].Tasks[0].Resources.MemoryMB = tc.memory
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    50,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			// A destructive update cannot place the replacement on the
+			// only node, so the existing allocation is kept running
+			if tc.destructive {
+				must.Len(t, 0, h.Plans)
+				must.Len(t, 1, h.CreateEvals)
+				must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+				return
+			}
+
+			must.Len(t, 1, h.Plans)
+			plan := h.Plans[0]
+
+			var update []*structs.Allocation
+			for _, updateList := range plan.NodeUpdate {
+				update = append(update, updateList...)
+			}
+
+			var planned []*structs.Allocation
+			for _, allocList := range plan.NodeAllocation {
+				planned = append(planned, allocList...)
+			}
+			must.Len(t, 1, planned)
+
+			// The client cannot resize the task, so it is replaced
+			if tc.replaced {
+				must.Len(t, 1, update)
+				must.Eq(t, alloc.ID, update[0].ID)
+				must.NotEq(t, alloc.ID, planned[0].ID)
+				return
+			}
+			must.Len(t, 0, update)
+			must.Eq(t, alloc.ID, planned[0].ID)
+
+			resources := planned[0].AllocatedResources.Tasks[job2.TaskGroups[0].Tasks[0].Name]
+			must.Eq(t, int64(tc.cpu), resources.Cpu.CpuShares)
+			must.Eq(t, int64(tc.memory), resources.Memory.MemoryMB)
+		})
+	}
+}
+
 // TestServiceSched_JobModify_InPlace08 asserts that inplace updates of
 // allocations created with Nomad 0.8 do not cause panics.
 //
@@ -3912,6 +4097,56 @@ func TestServiceSched_NodeUpdate(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_Rebalance_PenalizeSourceNode(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// The source node runs another allocation, so bin packing would place
+	// the migrated allocation back on it
+	source := mock.Node()
+	target := mock.Node()
+	for _, node := range []*structs.Node{source, target} {
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+	filler := mock.Alloc()
+	filler.NodeID = source.ID
+	filler.ClientStatus = structs.AllocClientStatusRunning
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	job.Rebalance = true
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = source.ID
+	alloc.Name = "my-job.web[0]"
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.DesiredTransition.Migrate = pointer.Of(true)
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{filler, alloc}))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerRebalance,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The migrated allocation is placed away from the source node
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+	must.Len(t, 1, plan.NodeUpdate[source.ID])
+	must.MapNotContainsKey(t, plan.NodeAllocation, source.ID)
+	must.Len(t, 1, plan.NodeAllocation[target.ID])
+}
+
 func TestServiceSched_NodeDrain(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4935,6 +5170,165 @@ func TestBatchSched_Run_CompleteAlloc(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestBatchSched_JobArray_PlacementLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a job array without a concurrency limit which is larger than
+	// the number of placements of a single plan
+	job := mock.BatchJob()
+	job.Array = &structs.JobArray{Start: 0, End: maxArrayPlacementsPerPlan + 99}
+	job.TaskGroups[0].Count = job.Array.Size()
+	job.TaskGroups[0].Tasks[0].Resources = &structs.Resources{CPU: 1, MemoryMB: 1}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+	// Only the limit of placements is planned, and a single follow up eval
+	// places the rest
+	must.Len(t, 1, h.Plans)
+	var planned []*structs.Allocation
+	for _, allocs := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocs...)
+	}
+	must.Len(t, maxArrayPlacementsPerPlan, planned)
+	must.Len(t, 1, h.CreateEvals)
+	followup := h.CreateEvals[0]
+	must.Eq(t, structs.EvalTriggerJobArray, followup.TriggeredBy)
+	must.Eq(t, eval.ID, followup.PreviousEval)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+
+	// The follow up eval places the rest of the array
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{followup}))
+	must.NoError(t, h.Process(NewBatchScheduler, followup))
+	must.Len(t, 2, h.Plans)
+	planned = nil
+	for _, allocs := range h.Plans[1].NodeAllocation {
+		planned = append(planned, allocs...)
+	}
+	must.Len(t, 100, planned)
+	must.Len(t, 1, h.CreateEvals)
+}
+
+func TestBatchSched_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		gang     bool
+		count    int
+		placed   int
+		reverted int
+		blocked  bool
+	}{
+		{
+			name:   "gang fits",
+			gang:   true,
+			count:  2,
+			placed: 2,
+		},
+		{
+			name:     "gang does not fit",
+			gang:     true,
+			count:    3,
+			placed:   0,
+			reverted: 2,
+			blocked:  true,
+		},
+		{
+			name:    "no gang places what fits",
+			gang:    false,
+			count:   3,
+			placed:  2,
+			blocked: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+
+			// Create two nodes which fit a single allocation each
+			for i := 0; i < 2; i++ {
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), mock.Node()))
+			}
+
+			job := mock.BatchJob()
+			job.TaskGroups[0].Count = tc.count
+			job.TaskGroups[0].Gang = tc.gang
+			job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 5000
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			eval := &structs.Evaluation{
+				Namespace:    structs.DefaultNamespace,
+				ID:           uuid.Generate(),
+				Priority:     job.Priority,
+				TriggeredBy:  structs.EvalTriggerJobRegister,
+				JobID:        job.ID,
+				Status:       structs.EvalStatusPending,
+				AnnotatePlan: true,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			must.NoError(t, h.Process(NewBatchScheduler, eval))
+
+			var placed []*structs.Allocation
+			for _, plan := range h.Plans {
+				for _, allocs := range plan.NodeAllocation {
+					placed = append(placed, allocs...)
+				}
+				must.Nil(t, plan.Deployment)
+				must.SliceEmpty(t, plan.DeploymentUpdates)
+			}
+			must.Len(t, tc.placed, placed)
+
+			// The reverted placements are backed out of the annotations
+			must.Len(t, 1, h.Plans)
+			desired := h.Plans[0].Annotations.DesiredTGUpdates[job.TaskGroups[0].Name]
+
+			// The placements of a gang are committed all at once
+			must.Eq(t, tc.gang && tc.placed > 0, h.Plans[0].AllAtOnce)
+			must.NotNil(t, desired)
+			must.Eq(t, uint64(tc.count-tc.reverted), desired.Place)
+
+			must.Len(t, 1, h.Evals)
+			outEval := h.Evals[0]
+			must.Eq(t, tc.count-tc.placed, outEval.QueuedAllocations[job.TaskGroups[0].Name])
+
+			if !tc.blocked {
+				must.MapEmpty(t, outEval.FailedTGAllocs)
+				must.SliceEmpty(t, h.CreateEvals)
+				return
+			}
+
+			// A single blocked eval is created for the placements which
+			// failed, including the reverted ones. Only the placements which
+			// found no node are counted as failures.
+			must.Len(t, 1, h.CreateEvals)
+			must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+			metrics := outEval.FailedTGAllocs[job.TaskGroups[0].Name]
+			must.NotNil(t, metrics)
+			must.Eq(t, tc.count-tc.placed-tc.reverted-1, metrics.CoalescedFailures)
+			must.Eq(t, tc.reverted, metrics.GangPlacementsReverted)
+		})
+	}
+}
+
 func TestBatchSched_Run_FailedAlloc(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5,6 +5,7 @@ package scheduler
 
 import (
 	"math"
+	"slices"
 	"sort"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -120,6 +121,11 @@ type Preemptor struct {
 	// currentAllocs is the candidate set used to find preemptible allocations
 	currentAllocs []*structs.Allocation
 
+	// disruptionBudgets caches the number of allocations per job/taskgroup
+	// which can be disrupted before the disruption budget of the task group
+	// is exhausted. Task groups without a disruption budget are absent.
+	disruptionBudgets map[structs.NamespacedID]map[string]int
+
 	// ctx is the context from the scheduler stack
 	ctx Context
 }
@@ -130,6 +136,7 @@ func NewPreemptor(jobPriority int, ctx Context, jobID *structs.NamespacedID) *Pr
 		jobPriority:        jobPriority,
 		jobID:              jobID,
 		allocDetails:       make(map[string]*allocInfo),
+		disruptionBudgets:  make(map[structs.NamespacedID]map[string]int),
 		ctx:                ctx,
 	}
 }
@@ -156,6 +163,11 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 			continue
 		}
 
+		// Ignore any allocations whose task group can't be disrupted further
+		if remaining, ok := p.disruptionBudgetRemaining(alloc); ok && remaining <= 0 {
+			continue
+		}
+
 		maxParallel := 0
 		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
 		if tg != nil && tg.Migrate != nil {
@@ -195,6 +207,86 @@ func (p *Preemptor) getNumPreemptions(alloc *structs.Allocation) int {
 	return c
 }
 
+// disruptionBudgetRemaining returns the number of allocations of the job and
+// task group of the alloc which can still be preempted without exceeding its
+// disruption budget, accounting for the allocations already being preempted.
+// The boolean is false if the task group has no disruption budget.
+func (p *Preemptor) disruptionBudgetRemaining(alloc *structs.Allocation) (int, bool) {
+	id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+	budgets, ok := p.disruptionBudgets[id]
+	if !ok {
+		budgets = p.computeDisruptionBudgets(alloc.Job)
+		p.disruptionBudgets[id] = budgets
+	}
+
+	remaining, ok := budgets[alloc.TaskGroup]
+	if !ok {
+		return 0, false
+	}
+	return remaining - p.getNumPreemptions(alloc), true
+}
+
+// computeDisruptionBudgets returns the remaining disruption budget of each task
+// group of the job which has one, based on the allocations currently available.
+func (p *Preemptor) computeDisruptionBudgets(job *structs.Job) map[string]int {
+	budgets := make(map[string]int)
+	if job == nil {
+		return budgets
+	}
+
+	var groups []*structs.TaskGroup
+	for _, tg := range job.TaskGroups {
+		if tg.DisruptionBudget != nil {
+			groups = append(groups, tg)
+		}
+	}
+	if len(groups) == 0 {
+		return budgets
+	}
+
+	allocs, err := p.ctx.State().AllocsByJob(nil, job.Namespace, job.ID, false)
+	if err != nil {
+		// Don't disrupt a task group whose budget can't be determined
+		p.ctx.Logger().Error("failed to look up allocations for disruption budget",
+			"job_id", job.ID, "namespace", job.Namespace, "error", err)
+		for _, tg := range groups {
+			budgets[tg.Name] = 0
+		}
+		return budgets
+	}
+
+	available := make(map[string]int)
+	for _, alloc := range allocs {
+		if alloc.DisruptionAvailable() {
+			available[alloc.TaskGroup]++
+		}
+	}
+	for _, tg := range groups {
+		budgets[tg.Name] = tg.DisruptionBudget.Remaining(tg.Count, available[tg.Name])
+	}
+	return budgets
+}
+
+// exceedsDisruptionBudget returns whether preempting the alloc along with the
+// already selected allocs exceeds the disruption budget of its task group.
+// Candidates which do are skipped in favor of the next best candidate, rather
+// than giving up on preemption altogether.
+func (p *Preemptor) exceedsDisruptionBudget(selected []*structs.Allocation, alloc *structs.Allocation) bool {
+	remaining, ok := p.disruptionBudgetRemaining(alloc)
+	if !ok {
+		return false
+	}
+
+	count := 1
+	for _, other := range selected {
+		if other.JobID == alloc.JobID && other.Namespace == alloc.Namespace &&
+			other.TaskGroup == alloc.TaskGroup {
+			count++
+		}
+	}
+	return count > remaining
+}
+
 // PreemptForTaskGroup computes a list of allocations to preempt to accommodate
 // the resources asked for. Only allocs with a job priority < 10 of jobPriority are considered
 // This method is meant only for finding preemptible allocations based on CPU/Memory/Disk
@@ -224,6 +316,9 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 			bestDistance := math.MaxFloat64
 			// Find the alloc with the closest distance
 			for index, alloc := range allocGrp.allocs {
+				if p.exceedsDisruptionBudget(bestAllocs, alloc) {
+					continue
+				}
 				currentPreemptionCount := p.getNumPreemptions(alloc)
 				allocDetails := p.allocDetails[alloc.ID]
 				maxParallel := allocDetails.maxParallel
@@ -233,6 +328,10 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 					closestAllocIndex = index
 				}
 			}
+			if closestAllocIndex == -1 {
+				// Every remaining alloc is over its disruption budget
+				break
+			}
 			closestAlloc := allocGrp.allocs[closestAllocIndex]
 			closestResources := p.allocDetails[closestAlloc.ID].resources
 			availableResources.Add(closestResources)
@@ -378,7 +477,15 @@ OUTER:
 			// Look for allocs that are using reserved ports needed
 			for _, port := range reservedPortsNeeded {
 				alloc, ok := usedPortToAlloc[port.Value]
+				if ok && slices.Contains(allocsToPreempt, alloc) {
+					continue
+				}
 				if ok {
+					// The port can't be freed on this device if the alloc
+					// using it is over its disruption budget
+					if p.exceedsDisruptionBudget(allocsToPreempt, alloc) {
+						continue OUTER
+					}
 					allocResources := p.allocDetails[alloc.ID].resources
 					preemptedBandwidth += allocResources.Flattened.Networks[0].MBits
 					allocsToPreempt = append(allocsToPreempt, alloc)
@@ -415,6 +522,9 @@ OUTER:
 
 			// Iterate over allocs until end of if requirements have been met
 			for _, alloc := range allocs {
+				if p.exceedsDisruptionBudget(allocsToPreempt, alloc) {
+					continue
+				}
 				allocResources := p.allocDetails[alloc.ID].resources
 				preemptedBandwidth += allocResources.Flattened.Networks[0].MBits
 				allocsToPreempt = append(allocsToPreempt, alloc)
@@ -530,6 +640,10 @@ OUTER:
 
 		for _, grpAllocs := range allocsByPriority {
 			for _, alloc := range grpAllocs.allocs {
+				if p.exceedsDisruptionBudget(preemptedAllocs, alloc) {
+					continue
+				}
+
 				// Look up the device instance from the device allocator
 				devInst := devAlloc.Devices[deviceIDTuple]
 
@@ -1523,6 +1523,167 @@ func TestPreemptionMultiple(t *testing.T) {
 	require.Equal(t, allocIDs, preempted)
 }
 
+func TestPreemption_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	// The test setup:
+	//  * a node with 4 GPUs
+	//  * a low priority job with 4 allocs, each is using 1 GPU
+	//
+	// Then schedule a high priority job needing 1 alloc using 2 GPUs, which
+	// requires preempting 2 of the low priority allocs. This is only possible
+	// if the disruption budget of the low priority job allows it, or if some
+	// of the GPUs are used by the allocs of another job without a budget.
+	cases := []struct {
+		name           string
+		maxUnavailable int
+		otherAllocs    int
+		expectPreempt  bool
+		expectBudgeted int
+	}{
+		{
+			name:           "budget exceeded",
+			maxUnavailable: 1,
+			expectPreempt:  false,
+		},
+		{
+			name:           "within budget",
+			maxUnavailable: 2,
+			expectPreempt:  true,
+			expectBudgeted: 2,
+		},
+		{
+			name:           "budget exceeded falls back to next candidate",
+			maxUnavailable: 1,
+			otherAllocs:    2,
+			expectPreempt:  true,
+			expectBudgeted: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+
+			legacyCpuResources, processorResources := cpuResources(4000)
+
+			node := mock.Node()
+			node.NodeResources = &structs.NodeResources{
+				Processors: processorResources,
+				Cpu:        legacyCpuResources,
+				Memory: structs.NodeMemoryResources{
+					MemoryMB: 8192,
+				},
+				Disk: structs.NodeDiskResources{
+					DiskMB: 100 * 1024,
+				},
+				Networks: []*structs.NetworkResource{
+					{
+						Device: "eth0",
+						CIDR:   "192.168.0.100/32",
+						MBits:  1000,
+					},
+				},
+				Devices: []*structs.NodeDeviceResource{
+					{
+						Type:   "gpu",
+						Vendor: "nvidia",
+						Name:   "1080ti",
+						Instances: []*structs.NodeDevice{
+							{ID: "dev0", Healthy: true},
+							{ID: "dev1", Healthy: true},
+							{ID: "dev2", Healthy: true},
+							{ID: "dev3", Healthy: true},
+						},
+					},
+				},
+			}
+			require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+			lowPrioJob := mock.Job()
+			lowPrioJob.Priority = 5
+			lowPrioJob.TaskGroups[0].Count = 4 - tc.otherAllocs
+			lowPrioJob.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{
+				MaxUnavailable: tc.maxUnavailable,
+			}
+			lowPrioJob.TaskGroups[0].Networks = nil
+			lowPrioJob.TaskGroups[0].Tasks[0].Services = nil
+			lowPrioJob.TaskGroups[0].Tasks[0].Resources.Networks = nil
+			lowPrioJob.TaskGroups[0].Tasks[0].Resources.Devices = structs.ResourceDevices{{
+				Name:  "gpu",
+				Count: 1,
+			}}
+			require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, lowPrioJob))
+
+			otherJob := lowPrioJob.Copy()
+			otherJob.ID = uuid.Generate()
+			otherJob.Priority = 10
+			otherJob.TaskGroups[0].Count = tc.otherAllocs
+			otherJob.TaskGroups[0].DisruptionBudget = nil
+			require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, otherJob))
+
+			allocs := []*structs.Allocation{}
+			for i := 0; i < 4; i++ {
+				job := lowPrioJob
+				if i >= 4-tc.otherAllocs {
+					job = otherJob
+				}
+				alloc := createAllocWithDevice(uuid.Generate(), job, job.TaskGroups[0].Tasks[0].Resources, &structs.AllocatedDeviceResource{
+					Type:      "gpu",
+					Vendor:    "nvidia",
+					Name:      "1080ti",
+					DeviceIDs: []string{fmt.Sprintf("dev%d", i)},
+				})
+				alloc.NodeID = node.ID
+				allocs = append(allocs, alloc)
+			}
+			require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+			highPrioJob := mock.Job()
+			highPrioJob.Priority = 100
+			highPrioJob.TaskGroups[0].Count = 1
+			highPrioJob.TaskGroups[0].Networks = nil
+			highPrioJob.TaskGroups[0].Tasks[0].Services = nil
+			highPrioJob.TaskGroups[0].Tasks[0].Resources.Networks = nil
+			highPrioJob.TaskGroups[0].Tasks[0].Resources.Devices = structs.ResourceDevices{{
+				Name:  "gpu",
+				Count: 2,
+			}}
+			require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, highPrioJob))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    highPrioJob.Priority,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       highPrioJob.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+			require.NoError(t, h.Process(NewServiceScheduler, eval))
+			if !tc.expectPreempt {
+				require.Len(t, h.Plans, 0)
+				require.Len(t, h.Evals, 1)
+				require.Len(t, h.Evals[0].FailedTGAllocs, 1)
+				return
+			}
+
+			require.Len(t, h.Plans, 1)
+			preempted := h.Plans[0].NodePreemptions[node.ID]
+			require.Len(t, preempted, 2)
+
+			budgeted := 0
+			for _, alloc := range preempted {
+				if alloc.JobID == lowPrioJob.ID {
+					budgeted++
+				}
+			}
+			require.Equal(t, tc.expectBudgeted, budgeted)
+		})
+	}
+}
+
 // helper method to create allocations with given jobs and resources
 func createAlloc(id string, job *structs.Job, resource *structs.Resources) *structs.Allocation {
 	return createAllocInner(id, job, resource, nil, nil)
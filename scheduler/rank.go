@@ -710,6 +710,7 @@ func (iter *NodeReschedulingPenaltyIterator) Reset() {
 type NodeAffinityIterator struct {
 	ctx           Context
 	source        RankIterator
+	namespace     string
 	jobAffinities []*structs.Affinity
 	affinities    []*structs.Affinity
 }
@@ -725,6 +726,7 @@ func NewNodeAffinityIterator(ctx Context, source RankIterator) *NodeAffinityIter
 }
 
 func (iter *NodeAffinityIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
 	iter.jobAffinities = job.Affinities
 }
 
@@ -772,7 +774,7 @@ func (iter *NodeAffinityIterator) Next() *RankedNode {
 
 	totalAffinityScore := 0.0
 	for _, affinity := range iter.affinities {
-		if matchesAffinity(iter.ctx, affinity, option.Node) {
+		if matchesJobAffinity(iter.ctx, iter.namespace, affinity, option.Node) {
 			totalAffinityScore += float64(affinity.Weight)
 		}
 	}
@@ -784,6 +786,15 @@ func (iter *NodeAffinityIterator) Next() *RankedNode {
 	return option
 }
 
+// matchesJobAffinity returns whether the node matches an affinity of a job in
+// the namespace, which may match the allocations running on the node.
+func matchesJobAffinity(ctx Context, namespace string, affinity *structs.Affinity, option *structs.Node) bool {
+	if structs.IsAllocOperand(affinity.Operand) {
+		return matchesAllocOperand(ctx, namespace, affinity.Operand, affinity.RTarget, option)
+	}
+	return matchesAffinity(ctx, affinity, option)
+}
+
 func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.Node) bool {
 	//TODO(preetha): Add a step here that filters based on computed node class for potential speedup
 	// Resolve the targets
@@ -794,6 +805,70 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// NodeWeightIterator is used to score nodes according to the weighted node
+// attributes of the scheduler configuration, when the weighted scheduler
+// algorithm is selected.
+type NodeWeightIterator struct {
+	ctx       Context
+	source    RankIterator
+	weights   []*structs.Affinity
+	sumWeight float64
+}
+
+// NewNodeWeightIterator is used to create a NodeWeightIterator that applies a
+// weighted score according to whether nodes match the node weights of the
+// scheduler configuration.
+func NewNodeWeightIterator(ctx Context, source RankIterator) *NodeWeightIterator {
+	return &NodeWeightIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *NodeWeightIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
+	iter.weights = nil
+	iter.sumWeight = 0
+	if schedConfig.EffectiveSchedulerAlgorithm() != structs.SchedulerAlgorithmWeighted {
+		return
+	}
+
+	for _, weight := range schedConfig.NodeWeights {
+		iter.weights = append(iter.weights, weight.Affinity())
+		iter.sumWeight += math.Abs(float64(weight.Weight))
+	}
+}
+
+func (iter *NodeWeightIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *NodeWeightIterator) hasWeights() bool {
+	return len(iter.weights) > 0
+}
+
+func (iter *NodeWeightIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+	if !iter.hasWeights() || iter.sumWeight == 0 {
+		return option
+	}
+
+	totalWeightScore := 0.0
+	for _, weight := range iter.weights {
+		if matchesAffinity(iter.ctx, weight, option.Node) {
+			totalWeightScore += float64(weight.Weight)
+		}
+	}
+	if totalWeightScore != 0.0 {
+		normScore := totalWeightScore / iter.sumWeight
+		option.Scores = append(option.Scores, normScore)
+		iter.ctx.Metrics().ScoreNode(option.Node, "node-weight", normScore)
+	}
+	return option
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
@@ -2191,6 +2191,77 @@ func TestNodeAntiAffinity_PenaltyNodes(t *testing.T) {
 
 }
 
+func TestNodeWeightIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	nodes[0].Node.Meta["cost_tier"] = "spot"
+	nodes[1].Node.Meta["cost_tier"] = "on-demand"
+	nodes[1].Node.Meta["hw_generation"] = "5"
+
+	schedConfig := &structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmWeighted,
+		NodeWeights: []*structs.SchedulerNodeWeight{
+			{
+				Attribute: "${meta.cost_tier}",
+				Value:     "spot",
+				Weight:    100,
+			},
+			{
+				Attribute: "${meta.cost_tier}",
+				Value:     "on-demand",
+				Weight:    -50,
+			},
+			{
+				Attribute: "${meta.hw_generation}",
+				Value:     "4",
+				Operand:   ">=",
+				Weight:    50,
+			},
+		},
+	}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	nodeWeight := NewNodeWeightIterator(ctx, static)
+	nodeWeight.SetSchedulerConfiguration(schedConfig)
+	scoreNorm := NewScoreNormalizationIterator(ctx, nodeWeight)
+
+	// Total weight = 200
+	expectedScores := map[string]float64{
+		// Node 0 matches the spot weight
+		nodes[0].Node.ID: 0.5,
+		// Node 1 matches the on-demand and hardware generation weights
+		nodes[1].Node.ID: 0,
+		// Node 2 matches no weight
+		nodes[2].Node.ID: 0,
+	}
+
+	out := collectRanked(scoreNorm)
+	require.Len(t, out, 3)
+	for _, n := range out {
+		require.Equal(t, expectedScores[n.Node.ID], n.FinalScore)
+	}
+
+	// The node weights are ignored by the other algorithms.
+	for _, n := range nodes {
+		n.Scores = nil
+		n.FinalScore = 0
+	}
+	schedConfig.SchedulerAlgorithm = structs.SchedulerAlgorithmBinpack
+	nodeWeight.SetSchedulerConfiguration(schedConfig)
+	scoreNorm.Reset()
+
+	out = collectRanked(scoreNorm)
+	require.Len(t, out, 3)
+	for _, n := range out {
+		require.Zero(t, n.FinalScore)
+	}
+}
+
 func TestScoreNormalizationIterator(t *testing.T) {
 	// Test normalized scores when there is more than one scorer
 	_, ctx := testContext(t)
@@ -2330,3 +2401,57 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+func TestNodeAffinityIterator_Allocs(t *testing.T) {
+	state, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	// The api job runs on node1 and the db job is proposed to run on node2
+	api := mock.Alloc()
+	api.JobID = "api"
+	api.NodeID = nodes[0].Node.ID
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{api}))
+
+	ctx.Plan().NodeAllocation[nodes[1].Node.ID] = []*structs.Allocation{{
+		ID:        uuid.Generate(),
+		Namespace: structs.DefaultNamespace,
+		JobID:     "db",
+		TaskGroup: "db",
+		NodeID:    nodes[1].Node.ID,
+	}}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Affinities = []*structs.Affinity{
+		{
+			Operand: structs.ConstraintColocatedWith,
+			RTarget: "job=api",
+			Weight:  100,
+		},
+		{
+			Operand: structs.ConstraintColocatedWith,
+			RTarget: "job=db",
+			Weight:  -100,
+		},
+	}
+
+	nodeAffinity := NewNodeAffinityIterator(ctx, NewStaticRankIterator(ctx, nodes))
+	nodeAffinity.SetJob(job)
+	nodeAffinity.SetTaskGroup(tg)
+
+	out := collectRanked(NewScoreNormalizationIterator(ctx, nodeAffinity))
+
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: 0.5,
+		nodes[1].Node.ID: -0.5,
+		nodes[2].Node.ID: 0,
+	}
+	require.Len(t, out, 3)
+	for _, n := range out {
+		require.Equal(t, expectedScores[n.Node.ID], n.FinalScore)
+	}
+}
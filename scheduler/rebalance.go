@@ -0,0 +1,527 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// rebalanceMinImprovement is the minimum improvement of the normalized node
+// score of an allocation for the rebalancer to migrate it, so that allocations
+// don't move back and forth between nodes with similar scores.
+const rebalanceMinImprovement = 0.25
+
+// rebalanceCandidate is a running allocation which the rebalancer could
+// migrate.
+type rebalanceCandidate struct {
+	alloc  *structs.Allocation
+	group  string
+	reason string
+	score  float64
+}
+
+// rebalancer scores the current placements of the running allocations of the
+// jobs which opted into rebalancing against the scheduler algorithm and the
+// spread and affinity goals of their jobs.
+type rebalancer struct {
+	ctx         *EvalContext
+	snap        *state.StateSnapshot
+	schedConfig *structs.SchedulerConfiguration
+
+	// nodes are the nodes allocations can be migrated to, by ID.
+	nodes map[string]*structs.Node
+
+	// nodeAllocs are the non-terminal allocations of each node, by node ID.
+	nodeAllocs map[string][]*structs.Allocation
+
+	// nodeUtil is the utilization of each node, by node ID.
+	nodeUtil map[string]*structs.ComparableResources
+
+	// groupLimits is the number of allocations of each task group which can
+	// be migrated at once, keyed by rebalanceGroupKey.
+	groupLimits map[string]int
+}
+
+// Rebalance returns up to limit running allocations of the jobs which opted
+// into rebalancing whose placement has become a poor fit, such as after new
+// nodes joined the cluster or the spread of a job became skewed. Migrating
+// the allocations is expected to improve their placement the most for the
+// moves listed first.
+func Rebalance(snap *state.StateSnapshot, limit int, logger log.Logger) ([]*structs.RebalanceMove, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	_, schedConfig, err := snap.SchedulerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler configuration: %v", err)
+	}
+
+	r := &rebalancer{
+		ctx:         NewEvalContext(nil, snap, &structs.Plan{}, logger),
+		snap:        snap,
+		schedConfig: schedConfig,
+		nodes:       make(map[string]*structs.Node),
+		nodeAllocs:  make(map[string][]*structs.Allocation),
+		nodeUtil:    make(map[string]*structs.ComparableResources),
+		groupLimits: make(map[string]int),
+	}
+	if err := r.setNodes(); err != nil {
+		return nil, err
+	}
+
+	iter, err := snap.Jobs(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %v", err)
+	}
+
+	var candidates []*rebalanceCandidate
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if !job.Rebalance || job.Type != structs.JobTypeService || job.Stopped() {
+			continue
+		}
+
+		jobCandidates, err := r.jobCandidates(job)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, jobCandidates...)
+	}
+
+	return r.selectMoves(candidates, limit), nil
+}
+
+// setNodes collects the nodes allocations can be migrated to along with their
+// allocations and utilization.
+func (r *rebalancer) setNodes() error {
+	iter, err := r.snap.Nodes(nil)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		allocs, err := r.snap.AllocsByNodeTerminal(nil, node.ID, false)
+		if err != nil {
+			return fmt.Errorf("failed to list allocations of node %q: %v", node.ID, err)
+		}
+
+		util := new(structs.ComparableResources)
+		for _, alloc := range allocs {
+			if !alloc.ClientTerminalStatus() {
+				util.Add(alloc.AllocatedResources.Comparable())
+			}
+		}
+
+		r.nodes[node.ID] = node
+		r.nodeAllocs[node.ID] = allocs
+		r.nodeUtil[node.ID] = util
+	}
+	return nil
+}
+
+// jobCandidates returns the allocations of the job which could be migrated.
+func (r *rebalancer) jobCandidates(job *structs.Job) ([]*rebalanceCandidate, error) {
+	// Leave jobs which are being deployed to the deployment
+	deployment, err := r.snap.LatestDeploymentByJobID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment of job %q: %v", job.ID, err)
+	}
+	if deployment != nil && deployment.Active() {
+		return nil, nil
+	}
+
+	allocs, err := r.snap.AllocsByJob(nil, job.Namespace, job.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list allocations of job %q: %v", job.ID, err)
+	}
+
+	pool, err := r.snap.NodePoolByName(nil, job.NodePool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node pool %q: %v", job.NodePool, err)
+	}
+	schedConfig := r.schedConfig.WithNodePool(pool)
+
+	var candidates []*rebalanceCandidate
+	for _, tg := range job.TaskGroups {
+		running, ok := r.stableAllocs(job, tg, allocs)
+		if !ok || len(running) == 0 {
+			continue
+		}
+
+		group := rebalanceGroupKey(job, tg)
+		r.groupLimits[group] = r.groupLimit(tg, len(running))
+
+		nodes := r.feasibleNodes(job, tg, allocs)
+		spreads := append([]*structs.Spread{}, job.Spreads...)
+		spreads = append(spreads, tg.Spreads...)
+		for _, spread := range spreads {
+			for _, c := range r.spreadCandidates(spread, running, nodes) {
+				c.group = group
+				candidates = append(candidates, c)
+			}
+		}
+		for _, c := range r.scoreCandidates(job, tg, schedConfig, running, nodes) {
+			c.group = group
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates, nil
+}
+
+// stableAllocs returns the running allocations of the task group. The boolean
+// is false if the task group isn't stable, because it doesn't run the desired
+// number of healthy allocations of the current job version, and so shouldn't
+// be disrupted further.
+func (r *rebalancer) stableAllocs(job *structs.Job, tg *structs.TaskGroup, allocs []*structs.Allocation) ([]*structs.Allocation, bool) {
+	var running []*structs.Allocation
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != tg.Name || alloc.TerminalStatus() {
+			continue
+		}
+		if !alloc.DisruptionAvailable() ||
+			alloc.DesiredTransition.ShouldMigrate() ||
+			alloc.Job == nil || alloc.Job.Version != job.Version {
+			return nil, false
+		}
+		if _, ok := r.nodes[alloc.NodeID]; !ok {
+			return nil, false
+		}
+		running = append(running, alloc)
+	}
+	if len(running) != tg.Count {
+		return nil, false
+	}
+
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].ID < running[j].ID
+	})
+	return running, true
+}
+
+// groupLimit returns how many allocations of the task group can be migrated
+// at once, which is limited by its migrate strategy and disruption budget.
+func (r *rebalancer) groupLimit(tg *structs.TaskGroup, available int) int {
+	limit := 1
+	if tg.Migrate != nil {
+		limit = tg.Migrate.MaxParallel
+	}
+	if tg.DisruptionBudget != nil {
+		limit = min(limit, tg.DisruptionBudget.Remaining(tg.Count, available))
+	}
+	return limit
+}
+
+// feasibleNodes returns the nodes the allocations of the task group could be
+// migrated to, based on the datacenters, node pool, constraints and drivers of
+// the task group.
+func (r *rebalancer) feasibleNodes(job *structs.Job, tg *structs.TaskGroup, allocs []*structs.Allocation) []*structs.Node {
+	constraints := append([]*structs.Constraint{}, job.Constraints...)
+	constraints = append(constraints, tg.Constraints...)
+	drivers := make(map[string]struct{})
+	for _, task := range tg.Tasks {
+		constraints = append(constraints, task.Constraints...)
+		drivers[task.Driver] = struct{}{}
+	}
+	constraintChecker := NewConstraintChecker(r.ctx, constraints)
+	driverChecker := NewDriverChecker(r.ctx, drivers)
+
+	// Nodes running the job are excluded when the job requires distinct hosts
+	excluded := make(map[string]struct{})
+	for _, c := range constraints {
+		if c.Operand == structs.ConstraintDistinctHosts {
+			for _, alloc := range allocs {
+				if !alloc.TerminalStatus() {
+					excluded[alloc.NodeID] = struct{}{}
+				}
+			}
+			break
+		}
+	}
+
+	var nodes []*structs.Node
+	for _, node := range r.nodes {
+		if _, ok := excluded[node.ID]; ok {
+			continue
+		}
+		if !node.IsInPool(job.NodePool) || !node.IsInAnyDC(job.Datacenters) {
+			continue
+		}
+		if !constraintChecker.Feasible(node) || !driverChecker.Feasible(node) {
+			continue
+		}
+		if ok, _ := satisfiesAllocConstraints(r.ctx, job.Namespace, constraints, node); !ok {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// fits returns whether the allocation fits on the node along with the
+// utilization of the node if it were placed there.
+func (r *rebalancer) fits(node *structs.Node, alloc *structs.Allocation) (bool, *structs.ComparableResources) {
+	allocs := make([]*structs.Allocation, 0, len(r.nodeAllocs[node.ID])+1)
+	allocs = append(allocs, r.nodeAllocs[node.ID]...)
+	allocs = append(allocs, alloc)
+
+	fit, _, util, err := structs.AllocsFit(node, allocs, nil, false)
+	if err != nil {
+		return false, nil
+	}
+	return fit, util
+}
+
+// spreadCandidates returns the allocations to migrate away from attribute
+// values which have more allocations than the spread desires, as long as the
+// allocations fit on a node with an attribute value which has too few.
+func (r *rebalancer) spreadCandidates(spread *structs.Spread, running []*structs.Allocation, nodes []*structs.Node) []*rebalanceCandidate {
+	counts := make(map[string][]*structs.Allocation)
+	for _, alloc := range running {
+		if value, ok := getProperty(r.nodes[alloc.NodeID], spread.Attribute); ok {
+			counts[value] = append(counts[value], alloc)
+		}
+	}
+
+	nodesByValue := make(map[string][]*structs.Node)
+	for _, node := range nodes {
+		if value, ok := getProperty(node, spread.Attribute); ok {
+			nodesByValue[value] = append(nodesByValue[value], node)
+		}
+	}
+
+	// Compute the desired number of allocations of each attribute value
+	total := float64(len(running))
+	desired := make(map[string]float64)
+	if len(spread.SpreadTarget) > 0 {
+		sumPercent := 0
+		for _, target := range spread.SpreadTarget {
+			desired[target.Value] = float64(target.Percent) / 100 * total
+			sumPercent += int(target.Percent)
+		}
+
+		var others []string
+		for value := range nodesByValue {
+			if _, ok := desired[value]; !ok {
+				others = append(others, value)
+			}
+		}
+		for _, value := range others {
+			desired[value] = float64(100-sumPercent) / 100 * total / float64(len(others))
+		}
+	} else {
+		for value := range nodesByValue {
+			desired[value] = total / float64(len(nodesByValue))
+		}
+	}
+
+	// Collect the nodes of the values with too few allocations
+	deficit := 0
+	var underNodes []*structs.Node
+	for value, nodes := range nodesByValue {
+		if missing := int(desired[value] - float64(len(counts[value]))); missing > 0 {
+			deficit += missing
+			underNodes = append(underNodes, nodes...)
+		}
+	}
+	if deficit == 0 {
+		return nil
+	}
+	sort.Slice(underNodes, func(i, j int) bool {
+		return underNodes[i].ID < underNodes[j].ID
+	})
+
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	var candidates []*rebalanceCandidate
+	for _, value := range values {
+		allocs := counts[value]
+		excess := int(float64(len(allocs)) - desired[value])
+		if excess <= 0 {
+			continue
+		}
+
+		score := float64(spread.Weight) / 100 * math.Min(1, float64(excess)/math.Max(desired[value], 1))
+		reason := fmt.Sprintf("spread on %s: %q has %d allocations but %.1f desired",
+			spread.Attribute, value, len(allocs), desired[value])
+
+		for _, alloc := range allocs {
+			if excess == 0 || deficit == 0 {
+				break
+			}
+			for _, node := range underNodes {
+				if fit, _ := r.fits(node, alloc); fit {
+					candidates = append(candidates, &rebalanceCandidate{
+						alloc:  alloc,
+						reason: reason,
+						score:  score,
+					})
+					excess--
+					deficit--
+					break
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// scoreCandidates returns the allocations which fit on a node with a
+// considerably better score for the scheduler algorithm, including its node
+// weights, or for the affinities of the task group.
+func (r *rebalancer) scoreCandidates(job *structs.Job, tg *structs.TaskGroup,
+	schedConfig *structs.SchedulerConfiguration, running []*structs.Allocation, nodes []*structs.Node) []*rebalanceCandidate {
+
+	algorithm := schedConfig.EffectiveSchedulerAlgorithm()
+	var weights []*structs.Affinity
+	if algorithm == structs.SchedulerAlgorithmWeighted {
+		for _, weight := range schedConfig.NodeWeights {
+			weights = append(weights, weight.Affinity())
+		}
+	}
+
+	affinities := append([]*structs.Affinity{}, job.Affinities...)
+	affinities = append(affinities, tg.Affinities...)
+	for _, task := range tg.Tasks {
+		affinities = append(affinities, task.Affinities...)
+	}
+
+	var candidates []*rebalanceCandidate
+	for _, alloc := range running {
+		current := r.nodes[alloc.NodeID]
+		currentScore := r.algorithmScore(algorithm, weights, current, r.nodeUtil[current.ID])
+		currentAffinity := r.affinityScore(job.Namespace, affinities, current)
+
+		bestScore, bestAffinity := currentScore, currentAffinity
+		for _, node := range nodes {
+			if node.ID == current.ID {
+				continue
+			}
+			fit, util := r.fits(node, alloc)
+			if !fit {
+				continue
+			}
+			bestScore = math.Max(bestScore, r.algorithmScore(algorithm, weights, node, util))
+			bestAffinity = math.Max(bestAffinity, r.affinityScore(job.Namespace, affinities, node))
+		}
+
+		if improvement := bestScore - currentScore; improvement >= rebalanceMinImprovement {
+			candidates = append(candidates, &rebalanceCandidate{
+				alloc: alloc,
+				reason: fmt.Sprintf("%s score %.2f can improve to %.2f",
+					algorithm, currentScore, bestScore),
+				score: improvement,
+			})
+		}
+		if improvement := bestAffinity - currentAffinity; improvement >= rebalanceMinImprovement {
+			candidates = append(candidates, &rebalanceCandidate{
+				alloc: alloc,
+				reason: fmt.Sprintf("affinity score %.2f can improve to %.2f",
+					currentAffinity, bestAffinity),
+				score: improvement,
+			})
+		}
+	}
+	return candidates
+}
+
+// algorithmScore returns the normalized score the scheduler algorithm gives
+// the node with the given utilization, combined with the node weights when
+// the weighted algorithm is used.
+func (r *rebalancer) algorithmScore(algorithm structs.SchedulerAlgorithm,
+	weights []*structs.Affinity, node *structs.Node, util *structs.ComparableResources) float64 {
+
+	var fitness float64
+	if algorithm == structs.SchedulerAlgorithmSpread {
+		fitness = structs.ScoreFitSpread(node, util)
+	} else {
+		fitness = structs.ScoreFitBinPack(node, util)
+	}
+	score := fitness / binPackingMaxFitScore
+
+	if len(weights) == 0 {
+		return score
+	}
+	return (score + r.affinityScore("", weights, node)) / 2
+}
+
+// affinityScore returns the normalized score of the node for the affinities
+// of a job in the namespace.
+func (r *rebalancer) affinityScore(namespace string, affinities []*structs.Affinity, node *structs.Node) float64 {
+	sumWeight, total := 0.0, 0.0
+	for _, affinity := range affinities {
+		sumWeight += math.Abs(float64(affinity.Weight))
+		if matchesJobAffinity(r.ctx, namespace, affinity, node) {
+			total += float64(affinity.Weight)
+		}
+	}
+	if sumWeight == 0 {
+		return 0
+	}
+	return total / sumWeight
+}
+
+// selectMoves returns up to limit of the candidates with the highest scores,
+// migrating each allocation at most once and no more allocations of a task
+// group than it allows to be migrated at once.
+func (r *rebalancer) selectMoves(candidates []*rebalanceCandidate, limit int) []*structs.RebalanceMove {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].alloc.ID < candidates[j].alloc.ID
+	})
+
+	selected := make(map[string]struct{})
+	groupMoves := make(map[string]int)
+	var moves []*structs.RebalanceMove
+	for _, c := range candidates {
+		if len(moves) >= limit {
+			break
+		}
+		if _, ok := selected[c.alloc.ID]; ok {
+			continue
+		}
+		if groupMoves[c.group] >= r.groupLimits[c.group] {
+			continue
+		}
+
+		selected[c.alloc.ID] = struct{}{}
+		groupMoves[c.group]++
+		moves = append(moves, &structs.RebalanceMove{
+			AllocID:   c.alloc.ID,
+			Namespace: c.alloc.Namespace,
+			JobID:     c.alloc.JobID,
+			TaskGroup: c.alloc.TaskGroup,
+			NodeID:    c.alloc.NodeID,
+			Reason:    c.reason,
+			Score:     c.score,
+		})
+	}
+	return moves
+}
+
+// rebalanceGroupKey returns the key of the task group of the job.
+func rebalanceGroupKey(job *structs.Job, tg *structs.TaskGroup) string {
+	return fmt.Sprintf("%s\x00%s\x00%s", job.Namespace, job.ID, tg.Name)
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// rebalanceSpreadHarness returns a harness with two nodes in each of two
+// datacenters and a job spread across both datacenters whose allocations all
+// run in the first one.
+func rebalanceSpreadHarness(t *testing.T, rebalance bool) (*Harness, *structs.Job) {
+	h := NewHarness(t)
+
+	var nodes []*structs.Node
+	for _, dc := range []string{"dc1", "dc1", "dc2", "dc2"} {
+		node := mock.Node()
+		node.Datacenter = dc
+		must.NoError(t, node.ComputeClass())
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.Job()
+	job.Rebalance = rebalance
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.Spreads = []*structs.Spread{{
+		Attribute: "${node.datacenter}",
+		Weight:    100,
+	}}
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Migrate.MaxParallel = 2
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 4; i++ {
+		alloc := mock.AllocForNode(nodes[i%2])
+		alloc.ID = uuid.Generate()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	return h, job
+}
+
+func TestRebalance_Spread(t *testing.T) {
+	ci.Parallel(t)
+
+	h, job := rebalanceSpreadHarness(t, true)
+	snap, err := h.State.Snapshot()
+	must.NoError(t, err)
+
+	moves, err := Rebalance(snap, 10, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Len(t, 2, moves)
+	for _, move := range moves {
+		must.Eq(t, job.ID, move.JobID)
+		must.StrContains(t, move.Reason, "spread on ${node.datacenter}")
+	}
+	must.NotEq(t, moves[0].AllocID, moves[1].AllocID)
+
+	// The limit bounds the number of moves
+	moves, err = Rebalance(snap, 1, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Len(t, 1, moves)
+
+	// A zero limit disables rebalancing
+	moves, err = Rebalance(snap, 0, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Len(t, 0, moves)
+}
+
+func TestRebalance_OptIn(t *testing.T) {
+	ci.Parallel(t)
+
+	h, _ := rebalanceSpreadHarness(t, false)
+	snap, err := h.State.Snapshot()
+	must.NoError(t, err)
+
+	moves, err := Rebalance(snap, 10, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Len(t, 0, moves)
+}
+
+func TestRebalance_UnstableGroup(t *testing.T) {
+	ci.Parallel(t)
+
+	h, job := rebalanceSpreadHarness(t, true)
+
+	// A task group with allocations being migrated isn't disrupted further
+	allocs, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	alloc := allocs[0].Copy()
+	alloc.DesiredTransition.Migrate = pointer.Of(true)
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	snap, err := h.State.Snapshot()
+	must.NoError(t, err)
+
+	moves, err := Rebalance(snap, 10, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Len(t, 0, moves)
+}
@@ -31,6 +31,15 @@ const (
 	// current time within which reschedulable allocations are placed.
 	// This helps protect against small clock drifts between servers
 	rescheduleWindowSize = 1 * time.Second
+
+	// disruptionBudgetFollowupDelay is how long to wait before reevaluating
+	// a task group whose destructive updates were held back by its disruption
+	// budget, when there is no deployment to trigger the next evaluation.
+	disruptionBudgetFollowupDelay = 30 * time.Second
+
+	// maxArrayPlacementsPerPlan is the maximum number of new allocations of a
+	// job array task group placed by a single plan.
+	maxArrayPlacementsPerPlan = 1000
 )
 
 type ReconnectingPicker interface {
@@ -53,6 +62,15 @@ func AllocRenconcilerWithNow(now time.Time) AllocReconcilerOption {
 	}
 }
 
+// AllocReconcilerWithDisruptionFollowup sets whether the job already has a
+// pending evaluation following up on updates held back by the disruption
+// budget, in which case no other is created.
+func AllocReconcilerWithDisruptionFollowup(pending bool) AllocReconcilerOption {
+	return func(ar *allocReconciler) {
+		ar.disruptionFollowup = pending
+	}
+}
+
 // allocReconciler is used to determine the set of allocations that require
 // placement, inplace updating or stopping given the job specification and
 // existing cluster state. The reconciler should only be used for batch and
@@ -107,6 +125,12 @@ type allocReconciler struct {
 	// defaults to time.Now, and overridden in unit tests
 	now time.Time
 
+	// disruptionFollowup is whether an evaluation following up on the updates
+	// held back by the disruption budget is pending or has been created, so
+	// that a single one is created for the job across task groups and
+	// evaluations.
+	disruptionFollowup bool
+
 	reconnectingPicker ReconnectingPicker
 
 	// result is the results of the reconcile. During computation it can be
@@ -157,6 +181,11 @@ type reconcileResults struct {
 	// This is used to create a delayed evaluation for rescheduling failed allocations.
 	desiredFollowupEvals map[string][]*structs.Evaluation
 
+	// arrayFollowup is whether placements of a job array were held back by
+	// the limit of placements per plan, and an evaluation should follow up on
+	// them once the plan is applied.
+	arrayFollowup bool
+
 	// taskGroupAllocNameIndexes is a tracking of the allocation name index,
 	// keyed by the task group name. This is stored within the results, so the
 	// generic scheduler can use this to perform duplicate alloc index checks
@@ -591,6 +620,7 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	var place []allocPlaceResult
 	if len(lostLater) == 0 {
 		place = a.computePlacements(tg, nameIndex, untainted, migrate, rescheduleNow, lost, isCanarying)
+		place = a.limitArrayPlacements(place, untainted, migrate)
 		if !existingDeployment {
 			dstate.DesiredTotal += len(place)
 		}
@@ -603,7 +633,7 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	underProvisionedBy = a.computeReplacements(deploymentPlaceReady, desiredChanges, place, rescheduleNow, lost, underProvisionedBy)
 
 	if deploymentPlaceReady {
-		a.computeDestructiveUpdates(destructive, underProvisionedBy, desiredChanges, tg)
+		a.computeDestructiveUpdates(destructive, untainted, underProvisionedBy, desiredChanges, tg)
 	} else {
 		desiredChanges.Ignore += uint64(len(destructive))
 	}
@@ -853,6 +883,50 @@ func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
 	return place
 }
 
+// limitArrayPlacements limits the placements of a job array so that no more
+// allocations of the group are running than the concurrency limit of the
+// array allows, and no more than maxArrayPlacementsPerPlan new allocations are
+// placed by a single plan. Placements replacing allocations are always kept.
+// The rest of the array is placed by the evaluations created as allocations
+// finish, or by a follow up evaluation when the plan limit was reached.
+func (a *allocReconciler) limitArrayPlacements(place []allocPlaceResult, untainted, migrate allocSet) []allocPlaceResult {
+	if a.job.Array == nil {
+		return place
+	}
+
+	running := 0
+	for _, alloc := range untainted.union(migrate) {
+		if !alloc.TerminalStatus() {
+			running++
+		}
+	}
+
+	limited := make([]allocPlaceResult, 0, len(place))
+	for _, p := range place {
+		if p.PreviousAllocation() != nil {
+			limited = append(limited, p)
+			running++
+		}
+	}
+	placed := 0
+	for _, p := range place {
+		if p.PreviousAllocation() != nil {
+			continue
+		}
+		if a.job.Array.MaxParallel > 0 && running >= a.job.Array.MaxParallel {
+			break
+		}
+		if placed >= maxArrayPlacementsPerPlan {
+			a.result.arrayFollowup = true
+			break
+		}
+		limited = append(limited, p)
+		running++
+		placed++
+	}
+	return limited
+}
+
 // computeReplacements either applies the placements calculated by computePlacements,
 // or computes more placements based on whether the deployment is ready for placement
 // and if the placement is already rescheduling or part of a failed deployment.
@@ -931,14 +1005,29 @@ func (a *allocReconciler) computeReplacements(deploymentPlaceReady bool, desired
 	return underProvisionedBy
 }
 
-func (a *allocReconciler) computeDestructiveUpdates(destructive allocSet, underProvisionedBy int,
+func (a *allocReconciler) computeDestructiveUpdates(destructive, untainted allocSet, underProvisionedBy int,
 	desiredChanges *structs.DesiredUpdates, tg *structs.TaskGroup) {
 
-	// Do all destructive updates
-	minimum := min(len(destructive), underProvisionedBy)
-	desiredChanges.DestructiveUpdate += uint64(minimum)
-	desiredChanges.Ignore += uint64(len(destructive) - minimum)
-	for _, alloc := range destructive.nameOrder()[:minimum] {
+	// Do all destructive updates, without stopping more available allocations
+	// than the disruption budget of the group allows
+	budget, hasBudget := disruptionBudgetRemaining(tg, untainted)
+	var updates []*structs.Allocation
+	for _, alloc := range destructive.nameOrder() {
+		if len(updates) >= underProvisionedBy {
+			break
+		}
+		if hasBudget && alloc.DisruptionAvailable() {
+			if budget <= 0 {
+				continue
+			}
+			budget--
+		}
+		updates = append(updates, alloc)
+	}
+
+	desiredChanges.DestructiveUpdate += uint64(len(updates))
+	desiredChanges.Ignore += uint64(len(destructive) - len(updates))
+	for _, alloc := range updates {
 		a.result.destructiveUpdate = append(a.result.destructiveUpdate, allocDestructiveResult{
 			placeName:             alloc.Name,
 			placeTaskGroup:        tg,
@@ -946,6 +1035,43 @@ func (a *allocReconciler) computeDestructiveUpdates(destructive allocSet, underP
 			stopStatusDescription: allocUpdating,
 		})
 	}
+
+	// Without a deployment nothing triggers another evaluation once the
+	// replacements become available, so follow up on the updates held back
+	// by the disruption budget.
+	heldBack := len(updates) < min(len(destructive), underProvisionedBy)
+	if heldBack && tg.Update.IsEmpty() && !a.disruptionFollowup {
+		a.disruptionFollowup = true
+		a.appendFollowupEvals(tg.Name, []*structs.Evaluation{{
+			ID:                uuid.Generate(),
+			Namespace:         a.job.Namespace,
+			Priority:          a.evalPriority,
+			Type:              a.job.Type,
+			TriggeredBy:       structs.EvalTriggerDisruptionBudget,
+			JobID:             a.job.ID,
+			JobModifyIndex:    a.job.ModifyIndex,
+			Status:            structs.EvalStatusPending,
+			StatusDescription: disruptionBudgetFollowupEvalDesc,
+			WaitUntil:         a.now.Add(disruptionBudgetFollowupDelay),
+		}})
+	}
+}
+
+// disruptionBudgetRemaining returns how many more available allocations of the
+// task group can be disrupted given its untainted allocations. The boolean is
+// false if the task group has no disruption budget.
+func disruptionBudgetRemaining(tg *structs.TaskGroup, untainted allocSet) (int, bool) {
+	if tg.DisruptionBudget == nil {
+		return 0, false
+	}
+
+	available := 0
+	for _, alloc := range untainted {
+		if alloc.DisruptionAvailable() {
+			available++
+		}
+	}
+	return tg.DisruptionBudget.Remaining(tg.Count, available), true
 }
 
 func (a *allocReconciler) computeMigrations(desiredChanges *structs.DesiredUpdates, migrate allocSet, tg *structs.TaskGroup, isCanarying bool) {
@@ -413,6 +413,52 @@ func TestReconciler_Place_Existing(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(5, 9), placeResultsToNames(r.place))
 }
 
+// Tests the reconciler only places as many allocations of a job array as its
+// concurrency limit allows
+func TestReconciler_Place_JobArrayMaxParallel(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 10
+	job.Array = &structs.JobArray{Start: 0, End: 9, MaxParallel: 3}
+
+	// Create 2 complete allocations and 1 running allocation
+	var allocs []*structs.Allocation
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		if i < 2 {
+			alloc.ClientStatus = structs.AllocClientStatusComplete
+		}
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, true, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             2,
+		inplace:           0,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place:  2,
+				Ignore: 3,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(3, 4), placeResultsToNames(r.place))
+}
+
 // Tests the reconciler properly handles stopping allocations for a job that has
 // scaled down
 func TestReconciler_ScaleDown_Partial(t *testing.T) {
@@ -768,6 +814,64 @@ func TestReconciler_Destructive(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 9), destructiveResultsToNames(r.destructiveUpdate))
 }
 
+// Tests the reconciler only destructively updates as many running allocations
+// as the disruption budget allows and follows up on the rest
+func TestReconciler_Destructive_DisruptionBudget(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].DisruptionBudget = &structs.DisruptionBudget{
+		MaxUnavailable: 3,
+	}
+
+	// Create 10 existing allocations, one of which is still pending
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		if i == 9 {
+			alloc.ClientStatus = structs.AllocClientStatusPending
+		}
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50, true)
+	r := reconciler.Compute()
+
+	// The pending allocation uses one unit of the budget and can be updated
+	// without using any more of it
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		destructive:       3,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				DestructiveUpdate: 3,
+				Ignore:            7,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, []int{0, 1, 9}, destructiveResultsToNames(r.destructiveUpdate))
+
+	evals := r.desiredFollowupEvals[job.TaskGroups[0].Name]
+	must.Len(t, 1, evals)
+	must.Eq(t, structs.EvalTriggerDisruptionBudget, evals[0].TriggeredBy)
+	must.False(t, evals[0].WaitUntil.IsZero())
+
+	// The follow up eval already pending is reused
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job,
+		nil, allocs, nil, "", 50, true, AllocReconcilerWithDisruptionFollowup(true))
+	r = reconciler.Compute()
+	must.Len(t, 3, r.destructiveUpdate)
+	must.MapEmpty(t, r.desiredFollowupEvals)
+}
+
 // Tests the reconciler properly handles destructive upgrading allocations when max_parallel=0
 func TestReconciler_DestructiveMaxParallel(t *testing.T) {
 	ci.Parallel(t)
@@ -248,6 +248,63 @@ func TestSysBatch_JobRegister_AddNode_Dead(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestSysBatch_JobModify_StickyEphemeralDisk(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes
+	nodes := createNodes(t, h, 2)
+
+	// Generate a fake job with a sticky ephemeral disk which completed on the
+	// first node and is still running on the second node
+	job := mock.SystemBatchJob()
+	job.TaskGroups[0].EphemeralDisk.Sticky = true
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	complete := mock.SysBatchAlloc()
+	complete.Job = job
+	complete.JobID = job.ID
+	complete.NodeID = nodes[0].ID
+	complete.Name = "my-sysbatch.pinger[0]"
+	complete.ClientStatus = structs.AllocClientStatusComplete
+
+	running := mock.SysBatchAlloc()
+	running.Job = job
+	running.JobID = job.ID
+	running.NodeID = nodes[1].ID
+	running.Name = "my-sysbatch.pinger[0]"
+	running.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(),
+		[]*structs.Allocation{complete, running}))
+
+	// Update the task, such that it cannot be done in-place
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewSysBatchScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+
+	// Both replacements are chained to the allocation they replace on the
+	// same node, including the completed one.
+	must.Len(t, 1, plan.NodeAllocation[nodes[0].ID])
+	must.Eq(t, complete.ID, plan.NodeAllocation[nodes[0].ID][0].PreviousAllocation)
+	must.Len(t, 1, plan.NodeAllocation[nodes[1].ID])
+	must.Eq(t, running.ID, plan.NodeAllocation[nodes[1].ID][0].PreviousAllocation)
+}
+
 func TestSysBatch_JobModify(t *testing.T) {
 	ci.Parallel(t)
 
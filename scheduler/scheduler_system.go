@@ -481,9 +481,12 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 			},
 		}
 
-		// If the new allocation is replacing an older allocation then we record the
-		// older allocation id so that they are chained
-		if missing.Alloc != nil {
+		// If the new allocation is replacing an older allocation on the same
+		// node then we record the older allocation id so that they are
+		// chained, and the client can keep or migrate the sticky ephemeral
+		// disk of the older allocation. Placements on nodes without an older
+		// allocation only carry the node ID.
+		if missing.Alloc != nil && missing.Alloc.ID != "" {
 			alloc.PreviousAllocation = missing.Alloc.ID
 		}
 
@@ -623,6 +623,77 @@ func TestSystemSched_JobModify(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestSystemSched_JobModify_StickyEphemeralDisk(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes
+	nodes := createNodes(t, h, 3)
+
+	// Generate a fake job with a sticky ephemeral disk and allocations on all
+	// but the last node
+	job := mock.SystemJob()
+	job.TaskGroups[0].EphemeralDisk.Sticky = true
+	job.TaskGroups[0].EphemeralDisk.Migrate = true
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	prevAllocs := make(map[string]string)
+	var allocs []*structs.Allocation
+	for _, node := range nodes[:2] {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = "my-job.web[0]"
+		allocs = append(allocs, alloc)
+		prevAllocs[node.ID] = alloc.ID
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Update the task, such that it cannot be done in-place
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewSystemScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	plan := h.Plans[0]
+
+	// The replacements are placed on the same node as the allocations they
+	// replace and are chained to them, so the client migrates the sticky
+	// ephemeral disk. The new placement has no previous allocation.
+	var planned []*structs.Allocation
+	for nodeID, allocList := range plan.NodeAllocation {
+		for _, alloc := range allocList {
+			must.Eq(t, nodeID, alloc.NodeID)
+			must.Eq(t, prevAllocs[nodeID], alloc.PreviousAllocation)
+			planned = append(planned, alloc)
+		}
+	}
+	must.Len(t, 3, planned)
+
+	// The previous allocations are linked to their replacements.
+	for _, alloc := range planned {
+		if alloc.PreviousAllocation == "" {
+			continue
+		}
+		prev, err := h.State.AllocByID(nil, alloc.PreviousAllocation)
+		must.NoError(t, err)
+		must.Eq(t, alloc.ID, prev.NextAllocation)
+	}
+}
+
 func TestSystemSched_JobModify_Rolling(t *testing.T) {
 	ci.Parallel(t)
 
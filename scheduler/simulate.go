@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// SimulationPlanner is a Planner which applies the plans of the scheduler to a
+// local state store instead of submitting them to the leader. It is used to
+// run the schedulers against a copy of the cluster state, such as a state
+// store restored from a snapshot, without side effects on the cluster.
+type SimulationPlanner struct {
+	State *state.StateStore
+
+	Plans       []*structs.Plan
+	Evals       []*structs.Evaluation
+	CreateEvals []*structs.Evaluation
+
+	nextIndex uint64
+	l         sync.Mutex
+}
+
+// NewSimulationPlanner returns a planner which applies plans to the state
+// store, after its latest index.
+func NewSimulationPlanner(store *state.StateStore) (*SimulationPlanner, error) {
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, err
+	}
+	return &SimulationPlanner{
+		State:     store,
+		nextIndex: index + 1,
+	}, nil
+}
+
+// NextIndex returns the next index to write to the state store.
+func (p *SimulationPlanner) NextIndex() uint64 {
+	p.l.Lock()
+	defer p.l.Unlock()
+	return p.nextIndexLocked()
+}
+
+func (p *SimulationPlanner) nextIndexLocked() uint64 {
+	index := p.nextIndex
+	p.nextIndex++
+	return index
+}
+
+// SubmitPlan applies the full plan to the state store.
+func (p *SimulationPlanner) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, State, error) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	p.Plans = append(p.Plans, plan)
+	index := p.nextIndexLocked()
+
+	result := &structs.PlanResult{
+		NodeUpdate:      plan.NodeUpdate,
+		NodeAllocation:  plan.NodeAllocation,
+		NodePreemptions: plan.NodePreemptions,
+		AllocIndex:      index,
+	}
+
+	now := time.Now().UTC().UnixNano()
+	var allocs []*structs.Allocation
+	for _, updateList := range plan.NodeUpdate {
+		allocs = append(allocs, updateList...)
+	}
+	for _, allocList := range plan.NodeAllocation {
+		allocs = append(allocs, allocList...)
+	}
+	updateCreateTimestamp(allocs, now)
+
+	var preempted []*structs.Allocation
+	for _, preemptions := range plan.NodePreemptions {
+		for _, alloc := range preemptions {
+			alloc.ModifyTime = now
+			preempted = append(preempted, alloc)
+		}
+	}
+
+	req := structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			Job:   plan.Job,
+			Alloc: allocs,
+		},
+		Deployment:        plan.Deployment,
+		DeploymentUpdates: plan.DeploymentUpdates,
+		EvalID:            plan.EvalID,
+		NodePreemptions:   preempted,
+	}
+	err := p.State.UpsertPlanResults(structs.ApplyPlanResultsRequestType, index, &req)
+	return result, nil, err
+}
+
+// UpdateEval records the evaluation updated by the scheduler.
+func (p *SimulationPlanner) UpdateEval(eval *structs.Evaluation) error {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.Evals = append(p.Evals, eval)
+	return nil
+}
+
+// CreateEval records the evaluations created by the scheduler, such as
+// blocked evaluations for the allocations which failed to be placed.
+func (p *SimulationPlanner) CreateEval(eval *structs.Evaluation) error {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.CreateEvals = append(p.CreateEvals, eval)
+	return nil
+}
+
+// ReblockEval is a no-op, since a simulation only processes new evaluations.
+func (p *SimulationPlanner) ReblockEval(*structs.Evaluation) error {
+	return nil
+}
+
+// ServersMeetMinimumVersion always returns true, so that the simulation uses
+// the features of the current version.
+func (p *SimulationPlanner) ServersMeetMinimumVersion(*version.Version, bool) bool {
+	return true
+}
+
+// SimulationResult is the outcome of a scheduling simulation.
+type SimulationResult struct {
+	// Eval is the evaluation processed by the scheduler, which holds the
+	// placement failures of each task group.
+	Eval *structs.Evaluation
+
+	// Placed are the allocations placed for the job, including the existing
+	// allocations updated in-place.
+	Placed []*structs.Allocation
+
+	// Stopped are the allocations of the job which were stopped.
+	Stopped []*structs.Allocation
+
+	// Preempted are the allocations of other jobs which were preempted to
+	// make room for the placed allocations.
+	Preempted []*structs.Allocation
+
+	// CreatedEvals are the evaluations created by the scheduler, such as the
+	// blocked evaluation for the allocations which failed to be placed.
+	CreatedEvals []*structs.Evaluation
+}
+
+// Simulate registers the job in the state store and processes an evaluation
+// for it with the scheduler of the job type, applying the resulting plans to
+// the state store. The state store must be a copy of the cluster state, such
+// as one restored from a snapshot, since it is modified by the simulation.
+func Simulate(logger log.Logger, store *state.StateStore, job *structs.Job) (*SimulationResult, error) {
+	planner, err := NewSimulationPlanner(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest index: %v", err)
+	}
+
+	index := planner.NextIndex()
+	if err := store.UpsertJob(structs.JobRegisterRequestType, index, nil, job); err != nil {
+		return nil, fmt.Errorf("failed to register job: %v", err)
+	}
+
+	// Lookup the job to get the version set by the state store.
+	job, err = store.JobByID(memdb.NewWatchSet(), job.Namespace, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup job: %v", err)
+	}
+
+	now := time.Now().UTC().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: job.JobModifyIndex,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	if err := store.UpsertEvals(structs.EvalUpdateRequestType, index, []*structs.Evaluation{eval}); err != nil {
+		return nil, fmt.Errorf("failed to create evaluation: %v", err)
+	}
+
+	snap, err := store.Snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot state: %v", err)
+	}
+	sched, err := NewScheduler(job.Type, logger, nil, snap, planner)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, fmt.Errorf("failed to process evaluation: %v", err)
+	}
+
+	result := &SimulationResult{
+		Eval:         eval,
+		CreatedEvals: planner.CreateEvals,
+	}
+	if n := len(planner.Evals); n > 0 {
+		result.Eval = planner.Evals[n-1]
+	}
+	for _, plan := range planner.Plans {
+		for _, allocs := range plan.NodeAllocation {
+			result.Placed = append(result.Placed, allocs...)
+		}
+		for _, allocs := range plan.NodeUpdate {
+			result.Stopped = append(result.Stopped, allocs...)
+		}
+		for _, allocs := range plan.NodePreemptions {
+			for _, stub := range allocs {
+				// The plan only holds a stub of the preempted allocations, so
+				// lookup the full allocation from the state before the plans
+				// were applied.
+				alloc, err := snap.AllocByID(nil, stub.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to lookup preempted allocation: %v", err)
+				}
+				if alloc == nil {
+					alloc = stub
+				}
+				result.Preempted = append(result.Preempted, alloc)
+			}
+		}
+	}
+	return result, nil
+}
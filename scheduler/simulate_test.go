@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSimulate_Service(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	for i := 0; i < 3; i++ {
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), mock.Node()))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 5
+
+	result, err := Simulate(testlog.HCLogger(t), store, job)
+	must.NoError(t, err)
+	must.Eq(t, structs.EvalStatusComplete, result.Eval.Status)
+	must.MapEmpty(t, result.Eval.FailedTGAllocs)
+	must.Len(t, 5, result.Placed)
+	must.SliceEmpty(t, result.Preempted)
+	must.SliceEmpty(t, result.CreatedEvals)
+
+	// The plan was applied to the state store.
+	allocs, err := store.AllocsByJob(nil, job.Namespace, job.ID, false)
+	must.NoError(t, err)
+	must.Len(t, 5, allocs)
+}
+
+func TestSimulate_Failures(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, mock.Node()))
+
+	// The job requires more memory than the node has.
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 1024 * 1024
+
+	result, err := Simulate(testlog.HCLogger(t), store, job)
+	must.NoError(t, err)
+	must.SliceEmpty(t, result.Placed)
+	must.MapContainsKey(t, result.Eval.FailedTGAllocs, "web")
+	must.Eq(t, 1, result.Eval.FailedTGAllocs["web"].CoalescedFailures)
+	must.Len(t, 1, result.CreatedEvals)
+	must.Eq(t, structs.EvalStatusBlocked, result.CreatedEvals[0].Status)
+}
+
+func TestSimulate_System(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	for i := 0; i < 3; i++ {
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), mock.Node()))
+	}
+
+	result, err := Simulate(testlog.HCLogger(t), store, mock.SystemJob())
+	must.NoError(t, err)
+	must.Len(t, 3, result.Placed)
+}
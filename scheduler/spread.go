@@ -45,6 +45,18 @@ type SpreadIterator struct {
 	// existing allocs are computed once, and allocs from the plan are updated
 	// when Reset is called
 	groupPropertySets map[string][]*propertySet
+
+	// nodes are the nodes being considered for placement. The values their
+	// attributes take are the domain the skew of a spread is computed over.
+	nodes []*structs.Node
+
+	// domainFilter excludes the nodes the task group can never be placed on
+	// from the skew domain, if set
+	domainFilter func(*structs.Node) bool
+
+	// skewDomains is a memoized map from task group to attribute to the
+	// values the nodes take for it
+	skewDomains map[string]map[string][]string
 }
 
 type spreadAttributeMap map[string]*spreadInfo
@@ -52,6 +64,16 @@ type spreadAttributeMap map[string]*spreadInfo
 type spreadInfo struct {
 	weight        int8
 	desiredCounts map[string]float64
+
+	// maxSkew is the maximum skew of the spread, or zero if unbounded
+	maxSkew int
+
+	// hard is whether nodes exceeding maxSkew are infeasible rather than
+	// penalized
+	hard bool
+
+	// skewFilter is the reason nodes exceeding maxSkew are filtered for
+	skewFilter string
 }
 
 func NewSpreadIterator(ctx Context, source RankIterator) *SpreadIterator {
@@ -61,10 +83,27 @@ func NewSpreadIterator(ctx Context, source RankIterator) *SpreadIterator {
 		groupPropertySets: make(map[string][]*propertySet),
 		tgSpreadInfo:      make(map[string]spreadAttributeMap),
 		lowestSpreadBoost: -1.0,
+		skewDomains:       make(map[string]map[string][]string),
 	}
 	return iter
 }
 
+// SetNodes sets the nodes being considered for placement, which define the
+// attribute values the skew of a spread is computed over.
+func (iter *SpreadIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	iter.skewDomains = make(map[string]map[string][]string)
+}
+
+// SetDomainFilter sets the filter of the nodes whose attribute values are
+// part of the skew domain of the current task group. Values only taken by
+// nodes the task group can't be placed on would otherwise never receive an
+// allocation, and block placements once the maximum skew is reached.
+func (iter *SpreadIterator) SetDomainFilter(filter func(*structs.Node) bool) {
+	iter.domainFilter = filter
+	iter.skewDomains = make(map[string]map[string][]string)
+}
+
 func (iter *SpreadIterator) Reset() {
 	iter.source.Reset()
 	for _, sets := range iter.groupPropertySets {
@@ -85,6 +124,7 @@ func (iter *SpreadIterator) SetJob(job *structs.Job) {
 	// versions of spread/properties to the new job version
 	iter.tgSpreadInfo = make(map[string]spreadAttributeMap)
 	iter.groupPropertySets = make(map[string][]*propertySet)
+	iter.skewDomains = make(map[string]map[string][]string)
 }
 
 func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
@@ -127,6 +167,7 @@ func (iter *SpreadIterator) hasSpreads() bool {
 
 func (iter *SpreadIterator) Next() *RankedNode {
 
+OUTER:
 	for {
 		option := iter.source.Next()
 
@@ -141,17 +182,21 @@ func (iter *SpreadIterator) Next() *RankedNode {
 		totalSpreadScore := 0.0
 		for _, pset := range propertySets {
 			nValue, errorMsg, usedCount := pset.UsedCount(option.Node, tgName)
+			spreadAttributeMap := iter.tgSpreadInfo[tgName]
+			spreadDetails := spreadAttributeMap[pset.targetAttribute]
 
 			// Add one to include placement on this node in the scoring calculation
 			usedCount += 1
 			// Set score to -1 if there were errors in building this attribute
 			if errorMsg != "" {
 				iter.ctx.Logger().Named("spread").Debug("error building spread attributes for task group", "task_group", tgName, "error", errorMsg)
+				if spreadDetails != nil && spreadDetails.hard {
+					iter.ctx.Metrics().FilterNode(option.Node, spreadDetails.skewFilter)
+					continue OUTER
+				}
 				totalSpreadScore -= 1.0
 				continue
 			}
-			spreadAttributeMap := iter.tgSpreadInfo[tgName]
-			spreadDetails := spreadAttributeMap[pset.targetAttribute]
 
 			if spreadDetails == nil {
 				iter.ctx.Logger().Named("spread").Error(
@@ -162,6 +207,18 @@ func (iter *SpreadIterator) Next() *RankedNode {
 				continue
 			}
 
+			// Placing on a node which would exceed the maximum skew is
+			// infeasible for a hard spread and gets the maximum penalty
+			// otherwise
+			if spreadDetails.maxSkew > 0 && iter.skew(pset, nValue) > spreadDetails.maxSkew {
+				if spreadDetails.hard {
+					iter.ctx.Metrics().FilterNode(option.Node, spreadDetails.skewFilter)
+					continue OUTER
+				}
+				totalSpreadScore -= 1.0
+				continue
+			}
+
 			if len(spreadDetails.desiredCounts) == 0 {
 				// When desired counts map is empty the user didn't specify any targets
 				// Use even spreading scoring algorithm for this scenario
@@ -209,6 +266,61 @@ func (iter *SpreadIterator) Next() *RankedNode {
 	}
 }
 
+// skew returns the difference between the number of allocations of the
+// attribute value with the fewest allocations and those of the given value
+// after placing an allocation on it.
+func (iter *SpreadIterator) skew(pset *propertySet, value string) int {
+	combinedUseMap := pset.GetCombinedUseMap()
+
+	// Without nodes the domain is made up of the values in use
+	domain := iter.skewDomain(pset.targetAttribute)
+	if iter.nodes == nil {
+		domain = []string{value}
+		for v := range combinedUseMap {
+			domain = append(domain, v)
+		}
+	}
+
+	minCount := uint64(0)
+	for i, v := range domain {
+		if count := combinedUseMap[v]; i == 0 || count < minCount {
+			minCount = count
+		}
+	}
+	return int(combinedUseMap[value]+1) - int(minCount)
+}
+
+// skewDomain returns the values the nodes the task group can be placed on
+// take for the attribute.
+func (iter *SpreadIterator) skewDomain(attribute string) []string {
+	domains, ok := iter.skewDomains[iter.tg.Name]
+	if !ok {
+		domains = make(map[string][]string)
+		iter.skewDomains[iter.tg.Name] = domains
+	}
+	if domain, ok := domains[attribute]; ok {
+		return domain
+	}
+
+	seen := make(map[string]struct{})
+	var domain []string
+	for _, node := range iter.nodes {
+		if iter.domainFilter != nil && !iter.domainFilter(node) {
+			continue
+		}
+		value, ok := getProperty(node, attribute)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[value]; !ok {
+			seen[value] = struct{}{}
+			domain = append(domain, value)
+		}
+	}
+	domains[attribute] = domain
+	return domain
+}
+
 // evenSpreadScoreBoost is a scoring helper that calculates the score
 // for the option when even spread is desired (all attribute values get equal preference)
 func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
@@ -274,7 +386,13 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	combinedSpreads = append(combinedSpreads, tg.Spreads...)
 	combinedSpreads = append(combinedSpreads, iter.jobSpreads...)
 	for _, spread := range combinedSpreads {
-		si := &spreadInfo{weight: spread.Weight, desiredCounts: make(map[string]float64)}
+		si := &spreadInfo{
+			weight:        spread.Weight,
+			desiredCounts: make(map[string]float64),
+			maxSkew:       spread.MaxSkew,
+			hard:          spread.Hard,
+			skewFilter:    spread.SkewString(),
+		}
 		sumDesiredCounts := 0.0
 		for _, st := range spread.SpreadTarget {
 			desiredCount := (float64(st.Percent) / float64(100)) * float64(totalCount)
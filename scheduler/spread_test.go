@@ -1155,3 +1155,191 @@ func TestSpread_ImplicitTargets(t *testing.T) {
 		})
 	}
 }
+
+func TestSpreadIterator_MaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name          string
+		hard          bool
+		expectedNodes int
+		expectedDCs   []string
+	}{
+		{
+			name:          "hard",
+			hard:          true,
+			expectedNodes: 1,
+			expectedDCs:   []string{"dc3"},
+		},
+		{
+			name:          "soft",
+			hard:          false,
+			expectedNodes: 4,
+			expectedDCs:   []string{"dc1", "dc1", "dc2", "dc3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+
+			var nodes []*structs.Node
+			var ranked []*RankedNode
+			for i, dc := range []string{"dc1", "dc1", "dc2", "dc3"} {
+				node := mock.Node()
+				node.Datacenter = dc
+				must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+				nodes = append(nodes, node)
+				ranked = append(ranked, &RankedNode{Node: node})
+			}
+
+			job := mock.Job()
+			tg := job.TaskGroups[0]
+			tg.Count = 6
+			tg.Spreads = []*structs.Spread{{
+				Attribute: "${node.datacenter}",
+				Weight:    100,
+				MaxSkew:   1,
+				Hard:      tc.hard,
+			}}
+
+			// dc1 has two allocations, dc2 has one and dc3 has none
+			var allocs []*structs.Allocation
+			for _, node := range []*structs.Node{nodes[0], nodes[1], nodes[2]} {
+				alloc := mock.AllocForNode(node)
+				alloc.Job = job
+				alloc.JobID = job.ID
+				alloc.TaskGroup = tg.Name
+				allocs = append(allocs, alloc)
+			}
+			must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+			spreadIter := NewSpreadIterator(ctx, NewStaticRankIterator(ctx, ranked))
+			spreadIter.SetNodes(nodes)
+			spreadIter.SetJob(job)
+			spreadIter.SetTaskGroup(tg)
+
+			out := collectRanked(spreadIter)
+			must.Len(t, tc.expectedNodes, out)
+
+			var dcs []string
+			for _, rn := range out {
+				dcs = append(dcs, rn.Node.Datacenter)
+			}
+			sort.Strings(dcs)
+			must.Eq(t, tc.expectedDCs, dcs)
+
+			filtered := ctx.Metrics().ConstraintFiltered[tg.Spreads[0].SkewString()]
+			if tc.hard {
+				must.Eq(t, 3, filtered)
+			} else {
+				must.Zero(t, filtered)
+			}
+		})
+	}
+}
+
+func TestSpread_MaxSkew_PlacementFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// The node in dc2 is too small to run any allocation, so only one
+	// allocation can be placed in dc1 without exceeding the maximum skew
+	for _, dc := range []string{"dc1", "dc1", "dc2"} {
+		node := mock.Node()
+		node.Datacenter = dc
+		if dc == "dc2" {
+			node.NodeResources.Memory.MemoryMB = 10
+		}
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.Datacenters = []string{"dc1", "dc2"}
+	job.TaskGroups[0].Count = 3
+	job.TaskGroups[0].Spreads = []*structs.Spread{{
+		Attribute: "${node.datacenter}",
+		Weight:    100,
+		MaxSkew:   1,
+		Hard:      true,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	var placed []*structs.Allocation
+	for _, allocs := range h.Plans[0].NodeAllocation {
+		placed = append(placed, allocs...)
+	}
+	must.Len(t, 1, placed)
+
+	must.Len(t, 1, h.Evals)
+	metrics := h.Evals[0].FailedTGAllocs[job.TaskGroups[0].Name]
+	must.NotNil(t, metrics)
+	must.Eq(t, 2, metrics.CoalescedFailures+1)
+	must.Positive(t, metrics.ConstraintFiltered[job.TaskGroups[0].Spreads[0].SkewString()])
+}
+
+func TestSpread_MaxSkew_ConstrainedDomain(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// The nodes in zone c are excluded by a constraint, so they never receive
+	// an allocation and must not count towards the skew
+	for _, zone := range []string{"a", "a", "b", "b", "c", "c"} {
+		node := mock.Node()
+		node.Meta["zone"] = zone
+		node.ComputeClass()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Constraints = append(job.TaskGroups[0].Constraints, &structs.Constraint{
+		LTarget: "${meta.zone}",
+		RTarget: "c",
+		Operand: "!=",
+	})
+	job.TaskGroups[0].Spreads = []*structs.Spread{{
+		Attribute: "${meta.zone}",
+		Weight:    100,
+		MaxSkew:   1,
+		Hard:      true,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	must.Len(t, 1, h.Plans)
+	zoneCounts := map[string]int{}
+	for nodeID, allocs := range h.Plans[0].NodeAllocation {
+		node, err := h.State.NodeByID(nil, nodeID)
+		must.NoError(t, err)
+		zoneCounts[node.Meta["zone"]] += len(allocs)
+	}
+	must.Eq(t, map[string]int{"a": 2, "b": 2}, zoneCounts)
+
+	must.Len(t, 1, h.Evals)
+	must.MapEmpty(t, h.Evals[0].FailedTGAllocs)
+}
@@ -13,6 +13,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-set/v2"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -335,6 +336,11 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 			return difference("task log disabled", at.LogConfig.Disabled, bt.LogConfig.Disabled)
 		}
 
+		// Log sinks are configured when logmon starts with the task
+		if !slices.EqualFunc(at.LogConfig.Sinks, bt.LogConfig.Sinks, func(a, b *structs.LogSink) bool { return a.Equal(b) }) {
+			return difference("task log sinks", at.LogConfig.Sinks, bt.LogConfig.Sinks)
+		}
+
 		// Check volume mount updates
 		if c := volumeMountsUpdated(at.VolumeMounts, bt.VolumeMounts); c.modified {
 			return c
@@ -353,17 +359,20 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 	return same
 }
 
+// minVersionResizeResources is the minimum client version which applies
+// changes to the cpu and memory of an in-place update to the running task.
+var minVersionResizeResources = version.Must(version.NewVersion("1.7.7-dev"))
+
+// nonNetworkResourcesUpdated returns whether the non-network resources of a
+// task have been changed in a way that requires a destructive update.
+//
+// Changes to cpu, memory, and memory_max are checked by resourcesResized
+// instead, as they are only destructive on nodes which cannot resize tasks.
 func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 	// Inspect the non-network resources
 	switch {
-	case a.CPU != b.CPU:
-		return difference("task cpu", a.CPU, b.CPU)
 	case a.Cores != b.Cores:
 		return difference("task cores", a.Cores, b.Cores)
-	case a.MemoryMB != b.MemoryMB:
-		return difference("task memory", a.MemoryMB, b.MemoryMB)
-	case a.MemoryMaxMB != b.MemoryMaxMB:
-		return difference("task memory max", a.MemoryMaxMB, b.MemoryMaxMB)
 	case !a.Devices.Equal(&b.Devices):
 		return difference("task devices", a.Devices, b.Devices)
 	case !a.NUMA.Equal(b.NUMA):
@@ -372,6 +381,42 @@ func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 	return same
 }
 
+// resourcesResized returns whether the cpu, memory, or memory_max of a task of
+// the task group has been changed.
+func resourcesResized(jobA, jobB *structs.Job, taskGroup string) comparison {
+	a := jobA.LookupTaskGroup(taskGroup)
+	b := jobB.LookupTaskGroup(taskGroup)
+
+	for _, at := range a.Tasks {
+		bt := b.LookupTask(at.Name)
+		if bt == nil {
+			return difference("task deleted", at.Name, "(nil)")
+		}
+		ar, br := at.Resources, bt.Resources
+		switch {
+		case ar.CPU != br.CPU:
+			return difference("task cpu", ar.CPU, br.CPU)
+		case ar.MemoryMB != br.MemoryMB:
+			return difference("task memory", ar.MemoryMB, br.MemoryMB)
+		case ar.MemoryMaxMB != br.MemoryMaxMB:
+			return difference("task memory max", ar.MemoryMaxMB, br.MemoryMaxMB)
+		}
+	}
+	return same
+}
+
+// nodeResizesResources returns whether the client of the node applies changes
+// to the cpu and memory of an in-place update to the running tasks, resizing
+// them if their driver supports it and restarting them otherwise. Older
+// clients keep running the tasks with their previous resources.
+func nodeResizesResources(node *structs.Node) bool {
+	v, err := version.NewVersion(node.Attributes["nomad.version"])
+	if err != nil {
+		return false
+	}
+	return v.GreaterThanOrEqual(minVersionResizeResources)
+}
+
 // consulUpdated returns true if the Consul namespace or cluster in the task
 // group has been changed.
 //
@@ -724,6 +769,12 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 			continue
 		}
 
+		// Changes to cpu and memory require a destructive update on nodes
+		// which cannot apply them in-place
+		if c := resourcesResized(job, existing, update.TaskGroup.Name); c.modified && !nodeResizesResources(node) {
+			continue
+		}
+
 		// The alloc is on a node that's now in an ineligible DC
 		if !node.IsInAnyDC(job.Datacenters) {
 			continue
@@ -972,6 +1023,12 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 			return false, true, nil
 		}
 
+		// Changes to cpu and memory require a destructive update on nodes
+		// which cannot apply them in-place
+		if c := resourcesResized(newJob, existing.Job, newTG.Name); c.modified && !nodeResizesResources(node) {
+			return false, true, nil
+		}
+
 		// The alloc is on a node that's now in an ineligible DC
 		if !node.IsInAnyDC(newJob.Datacenters) {
 			return false, true, nil
@@ -419,9 +419,25 @@ func TestTasksUpdated(t *testing.T) {
 	j10.TaskGroups[0].Tasks[0].Meta["baz"] = "boom"
 	must.True(t, tasksUpdated(j1, j10, name).modified)
 
+	j10s := mock.Job()
+	j10s.TaskGroups[0].Tasks[0].LogConfig.Sinks = []*structs.LogSink{{
+		Type:    structs.LogSinkTypeSyslog,
+		Address: "udp://127.0.0.1:514",
+	}}
+	must.True(t, tasksUpdated(j1, j10s, name).modified)
+
+	// Changing cpu or memory resources is an in-place update
 	j11 := mock.Job()
 	j11.TaskGroups[0].Tasks[0].Resources.CPU = 1337
-	must.True(t, tasksUpdated(j1, j11, name).modified)
+	must.False(t, tasksUpdated(j1, j11, name).modified)
+
+	j11m1 := mock.Job()
+	j11m1.TaskGroups[0].Tasks[0].Resources.MemoryMB = 1337
+	must.False(t, tasksUpdated(j1, j11m1, name).modified)
+
+	j11m2 := mock.Job()
+	j11m2.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 1337
+	must.False(t, tasksUpdated(j1, j11m2, name).modified)
 
 	j11d1 := mock.Job()
 	j11d1.TaskGroups[0].Tasks[0].Resources.Devices = structs.ResourceDevices{
@@ -1245,6 +1261,26 @@ func TestTasksUpdated_NUMA(t *testing.T) {
 	must.True(t, tasksUpdated(j1, j2, name).modified)
 }
 
+func TestResourcesResized(t *testing.T) {
+	ci.Parallel(t)
+
+	j1 := mock.Job()
+	name := j1.TaskGroups[0].Name
+
+	j2 := j1.Copy()
+	must.False(t, resourcesResized(j1, j2, name).modified)
+
+	j2.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 1337
+	must.True(t, resourcesResized(j1, j2, name).modified)
+
+	node := mock.Node()
+	must.False(t, nodeResizesResources(node))
+	node.Attributes["nomad.version"] = "1.7.7-dev"
+	must.True(t, nodeResizesResources(node))
+	node.Attributes["nomad.version"] = "1.8.0"
+	must.True(t, nodeResizesResources(node))
+}
+
 func TestTaskGroupConstraints(t *testing.T) {
 	ci.Parallel(t)
 